@@ -0,0 +1,200 @@
+// Package analyze runs ffmpeg-based analysis over a match video, such as
+// suggesting half boundaries from audio silence and scene changes.
+package analyze
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+
+	"github.com/user/tagging-rugby-cli/deps"
+)
+
+// Interval is a span of the video in seconds.
+type Interval struct {
+	Start float64
+	End   float64
+}
+
+// Halves holds suggested boundaries for the two halves of a match.
+type Halves struct {
+	FirstStart  float64
+	FirstEnd    float64
+	SecondStart float64
+	SecondEnd   float64
+	// Method describes which detector produced the half-time break ("silence" or "scene")
+	Method string
+}
+
+// halfTimeWindow bounds where the half-time break midpoint may fall, as fractions of the duration.
+const (
+	halfTimeWindowStart = 0.3
+	halfTimeWindowEnd   = 0.7
+)
+
+var (
+	durationRe     = regexp.MustCompile(`Duration: (\d+):(\d+):(\d+(?:\.\d+)?)`)
+	silenceStartRe = regexp.MustCompile(`silence_start: (-?\d+(?:\.\d+)?)`)
+	silenceEndRe   = regexp.MustCompile(`silence_end: (\d+(?:\.\d+)?)`)
+	ptsTimeRe      = regexp.MustCompile(`pts_time:(\d+(?:\.\d+)?)`)
+)
+
+// DetectSilences runs ffmpeg's silencedetect filter over the audio track and returns the
+// silent intervals of at least minSilence seconds below noiseDB, plus the video duration.
+func DetectSilences(ctx context.Context, videoPath string, noiseDB, minSilence float64) ([]Interval, float64, error) {
+	filter := fmt.Sprintf("silencedetect=noise=%gdB:d=%g", noiseDB, minSilence)
+	out, err := runFfmpeg(ctx, "-hide_banner", "-nostats", "-i", videoPath, "-vn", "-af", filter, "-f", "null", "-")
+	if err != nil {
+		return nil, 0, err
+	}
+	duration := parseDuration(out)
+
+	var silences []Interval
+	var open *Interval
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := silenceStartRe.FindStringSubmatch(line); m != nil {
+			start, _ := strconv.ParseFloat(m[1], 64)
+			if start < 0 {
+				start = 0
+			}
+			open = &Interval{Start: start}
+		} else if m := silenceEndRe.FindStringSubmatch(line); m != nil && open != nil {
+			open.End, _ = strconv.ParseFloat(m[1], 64)
+			silences = append(silences, *open)
+			open = nil
+		}
+	}
+	// Silence running to the end of the file has no silence_end line
+	if open != nil {
+		open.End = duration
+		silences = append(silences, *open)
+	}
+	return silences, duration, nil
+}
+
+// DetectSceneChanges runs ffmpeg's scene detection and returns the timestamps of frames whose
+// scene score exceeds threshold (0-1), plus the video duration. This decodes every video
+// frame and is much slower than DetectSilences.
+func DetectSceneChanges(ctx context.Context, videoPath string, threshold float64) ([]float64, float64, error) {
+	filter := fmt.Sprintf("select='gt(scene,%g)',showinfo", threshold)
+	out, err := runFfmpeg(ctx, "-hide_banner", "-nostats", "-i", videoPath, "-an", "-vf", filter, "-f", "null", "-")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var changes []float64
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if m := ptsTimeRe.FindStringSubmatch(scanner.Text()); m != nil {
+			t, _ := strconv.ParseFloat(m[1], 64)
+			changes = append(changes, t)
+		}
+	}
+	return changes, parseDuration(out), nil
+}
+
+// SuggestHalvesFromSilences picks the longest silence in the middle of the video as the
+// half-time break. Leading and trailing silences trim the start of the first half and
+// the end of the second. ok is false when no suitable silence is found.
+func SuggestHalvesFromSilences(silences []Interval, duration float64) (Halves, bool) {
+	if duration <= 0 {
+		return Halves{}, false
+	}
+	h := Halves{FirstStart: 0, SecondEnd: duration, Method: "silence"}
+
+	var best *Interval
+	for i := range silences {
+		s := silences[i]
+		mid := (s.Start + s.End) / 2
+		if mid < duration*halfTimeWindowStart || mid > duration*halfTimeWindowEnd {
+			// Pre-match and post-match quiet trims the halves
+			if s.Start <= 1 && s.End < duration*halfTimeWindowStart {
+				h.FirstStart = s.End
+			}
+			if s.End >= duration-1 && s.Start > duration*halfTimeWindowEnd {
+				h.SecondEnd = s.Start
+			}
+			continue
+		}
+		if best == nil || s.End-s.Start > best.End-best.Start {
+			best = &silences[i]
+		}
+	}
+	if best == nil {
+		return Halves{}, false
+	}
+	h.FirstEnd = best.Start
+	h.SecondStart = best.End
+	return h, true
+}
+
+// SuggestHalvesFromScenes picks the longest gap between scene changes in the middle of
+// the video as the half-time break (a static shot of an empty pitch). ok is false when
+// there are too few scene changes to decide.
+func SuggestHalvesFromScenes(changes []float64, duration float64) (Halves, bool) {
+	if duration <= 0 || len(changes) < 2 {
+		return Halves{}, false
+	}
+	var best *Interval
+	for i := 1; i < len(changes); i++ {
+		gap := Interval{Start: changes[i-1], End: changes[i]}
+		mid := (gap.Start + gap.End) / 2
+		if mid < duration*halfTimeWindowStart || mid > duration*halfTimeWindowEnd {
+			continue
+		}
+		if best == nil || gap.End-gap.Start > best.End-best.Start {
+			g := gap
+			best = &g
+		}
+	}
+	if best == nil {
+		return Halves{}, false
+	}
+	return Halves{
+		FirstStart:  0,
+		FirstEnd:    best.Start,
+		SecondStart: best.End,
+		SecondEnd:   duration,
+		Method:      "scene",
+	}, true
+}
+
+// runFfmpeg runs ffmpeg with the given arguments and returns its combined output
+// (filters log to stderr).
+func runFfmpeg(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, deps.Ffmpeg(), args...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg: %w\n%s", err, lastLines(out.Bytes(), 5))
+	}
+	return out.Bytes(), nil
+}
+
+// parseDuration extracts the input duration from ffmpeg's banner output.
+func parseDuration(out []byte) float64 {
+	m := durationRe.FindSubmatch(out)
+	if m == nil {
+		return 0
+	}
+	hours, _ := strconv.Atoi(string(m[1]))
+	minutes, _ := strconv.Atoi(string(m[2]))
+	seconds, _ := strconv.ParseFloat(string(m[3]), 64)
+	return float64(hours*3600+minutes*60) + seconds
+}
+
+// lastLines returns the last n lines of out, for error messages.
+func lastLines(out []byte, n int) string {
+	lines := bytes.Split(bytes.TrimSpace(out), []byte("\n"))
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return string(bytes.Join(lines, []byte("\n")))
+}
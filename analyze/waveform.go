@@ -0,0 +1,99 @@
+package analyze
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/user/tagging-rugby-cli/deps"
+)
+
+// waveformSampleRate is the rate the audio is decoded at for peak detection.
+// It is high enough to keep a referee's whistle (around 3-4 kHz).
+const waveformSampleRate = 16000
+
+// ComputePeaks decodes the audio track to mono and returns one peak level per
+// bucket of secondsPerPeak, scaled so the loudest bucket is 255. Whistle blasts
+// and crowd noise stand out as spikes. The audio is streamed, not buffered.
+func ComputePeaks(ctx context.Context, videoPath string, secondsPerPeak float64) ([]byte, error) {
+	if secondsPerPeak <= 0 {
+		return nil, fmt.Errorf("invalid peak interval: %g", secondsPerPeak)
+	}
+	cmd := exec.CommandContext(ctx, deps.Ffmpeg(), "-hide_banner", "-nostats", "-i", videoPath,
+		"-vn", "-ac", "1", "-ar", fmt.Sprint(waveformSampleRate), "-f", "s16le", "-")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("ffmpeg: %w", err)
+	}
+
+	peaks, readErr := readPeaks(bufio.NewReaderSize(stdout, 64*1024), int(secondsPerPeak*waveformSampleRate))
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("ffmpeg: %w\n%s", err, lastLines(stderr.Bytes(), 5))
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	return scalePeaks(peaks), nil
+}
+
+// readPeaks reads 16-bit little-endian mono samples and returns the largest
+// absolute sample in each bucket of samplesPerPeak.
+func readPeaks(r io.Reader, samplesPerPeak int) ([]int, error) {
+	if samplesPerPeak < 1 {
+		samplesPerPeak = 1
+	}
+	var peaks []int
+	peak, n := 0, 0
+	var buf [2]byte
+	for {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return nil, err
+		}
+		v := int(int16(binary.LittleEndian.Uint16(buf[:])))
+		if v < 0 {
+			v = -v
+		}
+		if v > peak {
+			peak = v
+		}
+		n++
+		if n == samplesPerPeak {
+			peaks = append(peaks, peak)
+			peak, n = 0, 0
+		}
+	}
+	if n > 0 {
+		peaks = append(peaks, peak)
+	}
+	return peaks, nil
+}
+
+// scalePeaks scales peaks to 0-255 relative to the loudest.
+func scalePeaks(peaks []int) []byte {
+	max := 0
+	for _, p := range peaks {
+		if p > max {
+			max = p
+		}
+	}
+	out := make([]byte, len(peaks))
+	if max == 0 {
+		return out
+	}
+	for i, p := range peaks {
+		out[i] = byte(p * 255 / max)
+	}
+	return out
+}
@@ -0,0 +1,93 @@
+package clip
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Manifest file names written next to a batch of exported clips.
+const (
+	ManifestCSV  = "manifest.csv"
+	ManifestJSON = "manifest.json"
+)
+
+// ManifestEntry describes one exported clip file.
+type ManifestEntry struct {
+	// File is the clip's path relative to the export directory
+	File   string `json:"file"`
+	NoteID int64  `json:"note_id"`
+	Video  string `json:"video"`
+	// Angle is the camera angle label, empty for the main video
+	Angle string `json:"angle,omitempty"`
+	// Start and End are the event's timing in the video, in seconds
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	// ClipStart and ClipEnd are the cut actually exported, after padding
+	ClipStart float64 `json:"clip_start"`
+	ClipEnd   float64 `json:"clip_end"`
+	Category  string  `json:"category"`
+	Player    string  `json:"player,omitempty"`
+	Team      string  `json:"team,omitempty"`
+	Outcome   string  `json:"outcome,omitempty"`
+	Text      string  `json:"text,omitempty"`
+	Starred   bool    `json:"starred"`
+}
+
+// WriteManifest writes manifest.csv and manifest.json describing entries into dir.
+func WriteManifest(dir string, entries []ManifestEntry) error {
+	if err := writeManifestCSV(filepath.Join(dir, ManifestCSV), entries); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ManifestCSV, err)
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestJSON), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ManifestJSON, err)
+	}
+	return nil
+}
+
+func writeManifestCSV(path string, entries []ManifestEntry) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"file", "note_id", "video", "angle", "start", "end", "clip_start", "clip_end",
+		"category", "player", "team", "outcome", "text", "starred"})
+	for _, e := range entries {
+		w.Write([]string{
+			e.File,
+			strconv.FormatInt(e.NoteID, 10),
+			e.Video,
+			e.Angle,
+			formatSeconds(e.Start),
+			formatSeconds(e.End),
+			formatSeconds(e.ClipStart),
+			formatSeconds(e.ClipEnd),
+			e.Category,
+			e.Player,
+			e.Team,
+			e.Outcome,
+			e.Text,
+			strconv.FormatBool(e.Starred),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// formatSeconds formats a time in seconds with millisecond precision.
+func formatSeconds(s float64) string {
+	return strconv.FormatFloat(s, 'f', 3, 64)
+}
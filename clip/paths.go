@@ -10,18 +10,38 @@ import (
 // Folder is derived from the video directory: <videoDir>/clips/<category>/<player>
 // Filename format: {HHMMSS}-{player}-{category}-{outcome}-{attempt}.mp4
 func ClipPaths(videoPath, category, player string, attempt int, outcome string, startSeconds float64) (folder, filename string) {
-	categorySlug := strings.ToLower(strings.ReplaceAll(category, " ", "_"))
-	playerSlug := strings.ToLower(strings.ReplaceAll(player, " ", "_"))
-	outcomeSlug := strings.ToLower(strings.ReplaceAll(outcome, " ", "_"))
+	categorySlug := Slug(category)
+	playerSlug := Slug(player)
+	outcomeSlug := Slug(outcome)
 
 	folder = filepath.Join(filepath.Dir(videoPath), "clips", categorySlug, playerSlug)
 
+	filename = fmt.Sprintf("%s-%s-%s-%s-%d.mp4", clipTimestamp(startSeconds), playerSlug, categorySlug, outcomeSlug, attempt)
+	return folder, filename
+}
+
+// EventClipPaths computes the output folder and filename for the clip of an
+// event without a tackle, which has no player or outcome to name it by.
+// Folder: <videoDir>/clips/<category>
+// Filename format: {HHMMSS}-{category}-{noteID}.mp4
+func EventClipPaths(videoPath, category string, noteID int64, startSeconds float64) (folder, filename string) {
+	categorySlug := Slug(category)
+	folder = filepath.Join(filepath.Dir(videoPath), "clips", categorySlug)
+	filename = fmt.Sprintf("%s-%s-%d.mp4", clipTimestamp(startSeconds), categorySlug, noteID)
+	return folder, filename
+}
+
+// clipTimestamp formats a position as HHMMSS, so clip files sort by time.
+func clipTimestamp(startSeconds float64) string {
 	totalSecs := int(startSeconds)
 	hours := totalSecs / 3600
 	minutes := (totalSecs % 3600) / 60
 	seconds := totalSecs % 60
-	timestamp := fmt.Sprintf("%02d%02d%02d", hours, minutes, seconds)
+	return fmt.Sprintf("%02d%02d%02d", hours, minutes, seconds)
+}
 
-	filename = fmt.Sprintf("%s-%s-%s-%s-%d.mp4", timestamp, playerSlug, categorySlug, outcomeSlug, attempt)
-	return folder, filename
+// Slug turns a label into a file or folder name: lower case, with spaces and
+// path separators replaced by underscores.
+func Slug(label string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "_", "/", "_", "\\", "_").Replace(label))
 }
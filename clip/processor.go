@@ -9,69 +9,152 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/deps"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
 )
 
-// Processor manages the background clip generation worker.
+// defaultPoll is how long an idle worker waits before looking for pending
+// clips again.
+const defaultPoll = 2 * time.Second
+
+// staleAfter is how long a clip may stay processing before an idle worker
+// takes its worker for killed mid-render and queues it again. Rendering a
+// clip takes seconds.
+const staleAfter = 15 * time.Minute
+
+// Processor renders pending note_clips rows with ffmpeg, in the background of
+// the TUI or in 'clip worker'. Several processors, even in separate processes,
+// can share the queue: each clip is claimed by one before it is rendered.
 type Processor struct {
 	DB *sql.DB
+	// Workers is how many clips are rendered at once; 0 means 1
+	Workers int
+	// Poll is how long an idle worker waits for new clips; 0 means 2 seconds
+	Poll time.Duration
+	// Once makes workers exit when no pending clip is left, instead of waiting
+	// for more
+	Once bool
+	// Done, when set, is called after each clip is rendered or fails. It may be
+	// called from several workers at once.
+	Done func(c *db.PendingClip, size int64, err error)
+
+	wg sync.WaitGroup
 }
 
-// Start launches a goroutine that continuously polls for pending clips and processes them.
-// The goroutine exits when ctx is cancelled.
+// Start launches the workers, which continuously poll for pending clips and
+// process them. They exit when ctx is cancelled, stopping any ffmpeg still
+// running and returning its clip to the queue.
 func (p *Processor) Start(ctx context.Context) {
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
+	p.Run(ctx, ctx)
+}
+
+// Run launches the workers, which stop taking new clips when stop is
+// cancelled and abandon the ones they are rendering when abort is. An
+// abandoned clip goes back to the queue and its partial file is removed.
+// Wait returns once every worker has exited.
+func (p *Processor) Run(stop, abort context.Context) {
+	workers := max(p.Workers, 1)
+	p.wg.Add(workers)
+	for range workers {
+		go func() {
+			defer p.wg.Done()
+			p.work(stop, abort)
+		}()
+	}
+}
 
-			clip, err := db.SelectNextPendingClip(p.DB)
-			if err != nil {
-				// On DB error, wait and retry
-				select {
-				case <-ctx.Done():
-					return
-				case <-time.After(2 * time.Second):
-				}
+// Wait blocks until every worker has exited.
+func (p *Processor) Wait() {
+	p.wg.Wait()
+}
+
+// work is one worker's loop: claim the next pending clip and render it.
+func (p *Processor) work(stop, abort context.Context) {
+	poll := p.Poll
+	if poll <= 0 {
+		poll = defaultPoll
+	}
+	// wait sleeps for poll, reporting false when stop is cancelled first
+	wait := func() bool {
+		select {
+		case <-stop.Done():
+			return false
+		case <-time.After(poll):
+			return true
+		}
+	}
+	for {
+		select {
+		case <-stop.Done():
+			return
+		default:
+		}
+
+		clip, err := db.SelectNextPendingClip(p.DB)
+		if err == nil && clip == nil {
+			// Clips left processing by a killed worker go back in the queue
+			if n, _ := db.RequeueStaleClips(p.DB, time.Now().Add(-staleAfter)); n > 0 {
 				continue
 			}
-			if clip == nil {
-				// No pending clips; sleep and retry
-				select {
-				case <-ctx.Done():
-					return
-				case <-time.After(2 * time.Second):
-				}
-				continue
+			if p.Once {
+				return
+			}
+		}
+		if err != nil || clip == nil {
+			// On DB error or with no pending clips, wait and retry
+			if !wait() {
+				return
 			}
+			continue
+		}
 
-			p.processClip(ctx, clip)
+		// Another worker may have taken it since it was selected
+		claimed, err := db.ClaimPendingClip(p.DB, clip.ClipID, time.Now())
+		if err != nil {
+			if !wait() {
+				return
+			}
+			continue
+		}
+		if !claimed {
+			continue
 		}
-	}()
+		size, err := p.processClip(abort, clip)
+		if p.Done != nil && abort.Err() == nil {
+			p.Done(clip, size, err)
+		}
+	}
 }
 
-// processClip handles the full lifecycle of generating a single clip.
-func (p *Processor) processClip(ctx context.Context, c *db.PendingClip) {
-	// Check ffmpeg is available
-	if _, err := exec.LookPath("ffmpeg"); err != nil {
-		_ = db.MarkClipError(p.DB, c.ClipID, time.Now(), "ffmpeg not found in PATH")
-		return
+// processClip renders a claimed clip, recording the outcome on its row. It
+// returns the file's size, or the error the row was marked with.
+func (p *Processor) processClip(ctx context.Context, c *db.PendingClip) (int64, error) {
+	fail := func(err error) (int64, error) {
+		_ = db.MarkClipError(p.DB, c.ClipID, time.Now(), err.Error())
+		return 0, err
+	}
+
+	// Streamed videos cannot be cut until a local copy is relinked
+	if videosrc.IsURL(c.VideoPath) {
+		return fail(fmt.Errorf("video is a stream URL; relink a local copy with 'video relink'"))
 	}
 
-	if err := db.MarkClipProcessing(p.DB, c.ClipID, time.Now()); err != nil {
-		return
+	// Check ffmpeg is available and can draw the overlay text
+	if err := deps.CheckFfmpeg(); err != nil {
+		return fail(err)
+	}
+	if err := deps.CheckFfmpegFilters("clip overlays", "drawtext"); err != nil {
+		return fail(err)
 	}
 
 	// Create output directory
 	outDir := c.Folder
 	if err := os.MkdirAll(outDir, 0755); err != nil {
-		_ = db.MarkClipError(p.DB, c.ClipID, time.Now(), fmt.Sprintf("mkdir: %v", err))
-		return
+		return fail(fmt.Errorf("mkdir: %v", err))
 	}
 
 	outPath := filepath.Join(outDir, c.Filename)
@@ -93,20 +176,26 @@ func (p *Processor) processClip(ctx context.Context, c *db.PendingClip) {
 	seconds := totalSecs % 60
 	timestamp := fmt.Sprintf("%02d\\\\:%02d\\\\:%02d", hours, minutes, seconds)
 
-	// Escape colons in free-text fields using the same double-escape.
-	outcome := strings.ReplaceAll(c.Outcome, ":", "\\\\:")
+	// Escape colons in free-text fields using the same double-escape. Events
+	// without a tackle are labelled with their category instead.
+	label := c.Outcome
+	if label == "" {
+		label = c.Category
+	}
+	label = strings.ReplaceAll(label, ":", "\\\\:")
 
 	// Build drawtext filter chain. No single quotes used — only backslash escaping.
 	// The comma in lt(t,3) is escaped as '\,' so it is not treated as a filter
 	// separator at the filtergraph level.
 	drawtext := fmt.Sprintf(
 		"drawtext=text=%s:x=10:y=h-th:fontsize=28:fontcolor=white:enable=lt(t\\,3),"+
-			"drawtext=text=%s:x=10:y=h-th-36:fontsize=28:fontcolor=white:enable=lt(t\\,3),"+
-			"drawtext=text=Attempt %d:x=10:y=h-th-72:fontsize=28:fontcolor=white:enable=lt(t\\,3)",
+			"drawtext=text=%s:x=10:y=h-th-36:fontsize=28:fontcolor=white:enable=lt(t\\,3)",
 		timestamp,
-		outcome,
-		c.Attempt,
+		label,
 	)
+	if c.Attempt > 0 {
+		drawtext += fmt.Sprintf(",drawtext=text=Attempt %d:x=10:y=h-th-72:fontsize=28:fontcolor=white:enable=lt(t\\,3)", c.Attempt)
+	}
 
 	args := []string{
 		"-y",
@@ -117,23 +206,31 @@ func (p *Processor) processClip(ctx context.Context, c *db.PendingClip) {
 		outPath,
 	}
 
-	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	cmd := exec.CommandContext(ctx, deps.Ffmpeg(), args...)
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &out
 
 	runErr := cmd.Run()
+	if ctx.Err() != nil {
+		// Stopped, not failed: leave no partial file and render it next time
+		os.Remove(outPath)
+		_ = db.RequeueClip(p.DB, c.ClipID)
+		return 0, ctx.Err()
+	}
 	if runErr != nil {
 		_ = db.MarkClipError(p.DB, c.ClipID, time.Now(), out.String())
-		return
+		// ffmpeg ends its output with the reason it failed
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		return 0, fmt.Errorf("ffmpeg: %v: %s", runErr, lines[len(lines)-1])
 	}
 
 	// Stat the output file for filesize
 	info, err := os.Stat(outPath)
 	if err != nil {
-		_ = db.MarkClipError(p.DB, c.ClipID, time.Now(), fmt.Sprintf("stat output: %v", err))
-		return
+		return fail(fmt.Errorf("stat output: %v", err))
 	}
 
 	_ = db.MarkClipComplete(p.DB, c.ClipID, time.Now(), info.Size())
+	return info.Size(), nil
 }
@@ -0,0 +1,200 @@
+package clip
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/deps"
+)
+
+// reelFrameRate is the frame rate every reel segment is encoded at, so they
+// can be joined without re-encoding.
+const reelFrameRate = 25
+
+// ReelSegment is one event cut into a highlight reel.
+type ReelSegment struct {
+	VideoPath  string
+	Start, End float64
+	// Title is shown on a card before the segment; empty for none
+	Title string
+}
+
+// Reel stitches segments of one or more videos into a single highlight video.
+// Each segment, and each title card, is encoded to the same size, frame rate
+// and codecs (H.264 and AAC), then joined with ffmpeg's concat demuxer.
+type Reel struct {
+	Segments []ReelSegment
+	// Width and Height are the reel's frame size; footage of another shape is
+	// letterboxed
+	Width, Height int
+	// TitleDuration is the seconds each title card is shown; 0 means no cards
+	TitleDuration float64
+	// Progress, when set, is called before each segment is cut
+	Progress func(i int, s ReelSegment)
+}
+
+// CheckReelDeps returns an error when ffmpeg is missing, or lacks the encoders
+// or, with title cards, the drawtext filter a reel needs.
+func CheckReelDeps(titles bool) error {
+	if err := deps.CheckFfmpeg(); err != nil {
+		return err
+	}
+	if err := deps.CheckFfmpegEncoders("highlight reels", "libx264", "aac"); err != nil {
+		return err
+	}
+	if titles {
+		return deps.CheckFfmpegFilters("reel title cards", "drawtext")
+	}
+	return nil
+}
+
+// Build writes the reel to output, through a .part file renamed once
+// finished. The segments are encoded in a temporary directory, removed
+// afterwards.
+func (r *Reel) Build(ctx context.Context, output string) error {
+	if len(r.Segments) == 0 {
+		return fmt.Errorf("no segments to build a reel from")
+	}
+	tmp, err := os.MkdirTemp("", "reel-")
+	if err != nil {
+		return fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	var parts []string
+	for i, s := range r.Segments {
+		if r.Progress != nil {
+			r.Progress(i, s)
+		}
+		if r.TitleDuration > 0 && s.Title != "" {
+			card := filepath.Join(tmp, fmt.Sprintf("card-%03d.mp4", i))
+			if err := r.renderCard(ctx, s.Title, filepath.Join(tmp, fmt.Sprintf("card-%03d.txt", i)), card); err != nil {
+				return fmt.Errorf("title card %d: %w", i+1, err)
+			}
+			parts = append(parts, card)
+		}
+		segment := filepath.Join(tmp, fmt.Sprintf("segment-%03d.mp4", i))
+		if err := r.cutSegment(ctx, s, segment); err != nil {
+			return fmt.Errorf("segment %d: %w", i+1, err)
+		}
+		parts = append(parts, segment)
+	}
+
+	// The concat demuxer reads the parts from a list file, one per line
+	var list strings.Builder
+	for _, p := range parts {
+		fmt.Fprintf(&list, "file '%s'\n", strings.ReplaceAll(p, "'", `'\''`))
+	}
+	listPath := filepath.Join(tmp, "list.txt")
+	if err := os.WriteFile(listPath, []byte(list.String()), 0644); err != nil {
+		return fmt.Errorf("write concat list: %w", err)
+	}
+
+	part := PartPath(output)
+	if err := runFfmpeg(ctx, "-y", "-f", "concat", "-safe", "0", "-i", listPath, "-c", "copy", "-movflags", "+faststart", part); err != nil {
+		os.Remove(part)
+		return fmt.Errorf("join segments: %w", err)
+	}
+	if err := os.Rename(part, output); err != nil {
+		return fmt.Errorf("save reel: %w", err)
+	}
+	return nil
+}
+
+// cutSegment encodes one event to the reel's size and codecs. Footage without
+// an audio track is given silence, so every part has the same streams.
+func (r *Reel) cutSegment(ctx context.Context, s ReelSegment, output string) error {
+	duration := s.End - s.Start
+	args := []string{
+		"-y",
+		"-ss", fmt.Sprintf("%.3f", s.Start),
+		"-t", fmt.Sprintf("%.3f", duration),
+		"-i", s.VideoPath,
+	}
+	audio := "0:a:0"
+	if !hasAudio(s.VideoPath) {
+		args = append(args, "-f", "lavfi", "-t", fmt.Sprintf("%.3f", duration), "-i", "anullsrc=r=48000:cl=stereo")
+		audio = "1:a:0"
+	}
+	args = append(args,
+		"-map", "0:v:0", "-map", audio,
+		"-vf", r.scaleFilter(),
+	)
+	args = append(args, reelCodecArgs()...)
+	args = append(args, output)
+	return runFfmpeg(ctx, args...)
+}
+
+// renderCard encodes a black title card showing title, read by drawtext from
+// textPath so it needs no filtergraph escaping.
+func (r *Reel) renderCard(ctx context.Context, title, textPath, output string) error {
+	if err := os.WriteFile(textPath, []byte(title), 0644); err != nil {
+		return fmt.Errorf("write title: %w", err)
+	}
+	duration := fmt.Sprintf("%.3f", r.TitleDuration)
+	// Colons in the path are escaped twice, as in the clip overlays
+	file := strings.ReplaceAll(filepath.ToSlash(textPath), ":", "\\\\:")
+	drawtext := fmt.Sprintf("drawtext=textfile=%s:expansion=none:x=(w-tw)/2:y=(h-th)/2:fontsize=%d:fontcolor=white",
+		file, max(r.Height/15, 12))
+	args := []string{
+		"-y",
+		"-f", "lavfi", "-i", fmt.Sprintf("color=c=black:s=%dx%d:r=%d:d=%s", r.Width, r.Height, reelFrameRate, duration),
+		"-f", "lavfi", "-t", duration, "-i", "anullsrc=r=48000:cl=stereo",
+		"-map", "0:v:0", "-map", "1:a:0",
+		"-vf", drawtext,
+	}
+	args = append(args, reelCodecArgs()...)
+	args = append(args, output)
+	return runFfmpeg(ctx, args...)
+}
+
+// scaleFilter fits footage into the reel's frame, letterboxed, at its frame
+// rate.
+func (r *Reel) scaleFilter() string {
+	return fmt.Sprintf("scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2,setsar=1,fps=%d",
+		r.Width, r.Height, r.Width, r.Height, reelFrameRate)
+}
+
+// reelCodecArgs are the encoder settings every part shares.
+func reelCodecArgs() []string {
+	return []string{
+		"-c:v", "libx264", "-preset", "veryfast", "-pix_fmt", "yuv420p",
+		"-c:a", "aac", "-ar", "48000", "-ac", "2",
+	}
+}
+
+// hasAudio reports whether the video has an audio track. Without ffprobe it
+// is assumed to.
+func hasAudio(path string) bool {
+	if _, err := exec.LookPath(deps.Ffprobe()); err != nil {
+		return true
+	}
+	out, err := exec.Command(deps.Ffprobe(), "-v", "error", "-select_streams", "a",
+		"-show_entries", "stream=index", "-of", "csv=p=0", path).Output()
+	if err != nil {
+		return true
+	}
+	return strings.TrimSpace(string(out)) != ""
+}
+
+// runFfmpeg runs ffmpeg, returning the last line of its output on failure:
+// where it says why.
+func runFfmpeg(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, deps.Ffmpeg(), append([]string{"-hide_banner", "-loglevel", "error"}, args...)...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		return fmt.Errorf("ffmpeg: %v: %s", err, lines[len(lines)-1])
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package clip
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/deps"
+)
+
+// durationSlack is how much shorter than asked a clip may be and still count
+// as complete. Stream copy cuts on keyframes, so lengths are never exact.
+const durationSlack = 1.0
+
+// PartPath returns the temporary path a clip is written to before being
+// renamed to path, e.g. clip-3.part.mp4, so an interrupted export never leaves
+// a file that looks finished.
+func PartPath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + ".part" + ext
+}
+
+// Complete reports whether path already holds a finished clip of about
+// seconds long. The length is checked with ffprobe when it is installed;
+// without it any non-empty file counts.
+func Complete(path string, seconds float64) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Size() == 0 {
+		return false
+	}
+	if _, err := exec.LookPath(deps.Ffprobe()); err != nil {
+		return true
+	}
+	out, err := exec.Command(deps.Ffprobe(), "-v", "error", "-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1", path).Output()
+	if err != nil {
+		return false
+	}
+	duration, err := strconv.ParseFloat(strings.TrimSpace(string(out)), 64)
+	if err != nil {
+		return false
+	}
+	return duration >= seconds-durationSlack
+}
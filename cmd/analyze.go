@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/analyze"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/deps"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Analyze a match video with ffmpeg",
+	Long:  `Run ffmpeg-based analysis over a match video to suggest structure such as halves.`,
+}
+
+var analyzeHalvesCmd = &cobra.Command{
+	Use:   "halves <video-file>",
+	Short: "Suggest kickoff and half-time boundaries",
+	Long: `Suggest the boundaries of the two halves of a match using ffmpeg audio silence
+detection: the longest quiet stretch in the middle of the video is taken as half-time,
+and quiet stretches at the very start and end trim the halves.
+
+With --scenes, scene-change detection is used instead when no suitable silence is
+found (slower: every frame is decoded).
+
+The suggestion is printed and, once confirmed, replaces the match periods stored
+for the video.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		noise, _ := cmd.Flags().GetFloat64("noise")
+		minSilence, _ := cmd.Flags().GetFloat64("min-silence")
+		useScenes, _ := cmd.Flags().GetBool("scenes")
+		sceneThreshold, _ := cmd.Flags().GetFloat64("scene-threshold")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		if err := deps.CheckFfmpeg(); err != nil {
+			return err
+		}
+
+		absPath, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to resolve path: %w", err)
+		}
+		info, err := os.Stat(absPath)
+		if os.IsNotExist(err) {
+			return notFoundErrorf("video file not found: %s", absPath)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to access video file: %w", err)
+		}
+
+		filters := []string{"silencedetect"}
+		if useScenes {
+			filters = append(filters, "select", "showinfo")
+		}
+		if err := deps.CheckFfmpegFilters("half detection", filters...); err != nil {
+			return err
+		}
+
+		infoln("Detecting silence (this reads the whole audio track)...")
+		ctx := context.Background()
+		silences, duration, err := analyze.DetectSilences(ctx, absPath, noise, minSilence)
+		if err != nil {
+			return fmt.Errorf("failed to detect silence: %w", err)
+		}
+		halves, ok := analyze.SuggestHalvesFromSilences(silences, duration)
+		if !ok && useScenes {
+			infoln("No half-time silence found, detecting scene changes...")
+			changes, sceneDuration, err := analyze.DetectSceneChanges(ctx, absPath, sceneThreshold)
+			if err != nil {
+				return fmt.Errorf("failed to detect scene changes: %w", err)
+			}
+			halves, ok = analyze.SuggestHalvesFromScenes(changes, sceneDuration)
+		}
+		if !ok {
+			return notFoundErrorf("could not find a half-time break: try a higher --noise or lower --min-silence, or --scenes")
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Period\tStart\tEnd\tLength")
+		fmt.Fprintln(w, "------\t-----\t---\t------")
+		fmt.Fprintf(w, "1st half\t%s\t%s\t%s\n", timeutil.FormatTime(halves.FirstStart), timeutil.FormatTime(halves.FirstEnd), timeutil.FormatTime(halves.FirstEnd-halves.FirstStart))
+		fmt.Fprintf(w, "2nd half\t%s\t%s\t%s\n", timeutil.FormatTime(halves.SecondStart), timeutil.FormatTime(halves.SecondEnd), timeutil.FormatTime(halves.SecondEnd-halves.SecondStart))
+		w.Flush()
+		infof("Half-time break detected by %s: %s\n", halves.Method, timeutil.FormatTime(halves.SecondStart-halves.FirstEnd))
+
+		if !yes {
+			fmt.Print("Save these periods for this video? [y/N] ")
+			var response string
+			fmt.Scanln(&response)
+			if response != "y" && response != "Y" {
+				infoln("Periods not saved.")
+				return nil
+			}
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := db.EnsureVideo(database, absPath, info.Size(), "")
+		if err != nil {
+			return fmt.Errorf("failed to register video: %w", err)
+		}
+
+		periods := []db.Period{
+			{Name: "1st half", Start: halves.FirstStart, End: halves.FirstEnd},
+			{Name: "2nd half", Start: halves.SecondStart, End: halves.SecondEnd},
+		}
+		if err := db.ReplacePeriods(database, videoID, periods); err != nil {
+			return fmt.Errorf("failed to save periods: %w", err)
+		}
+
+		infoln("Periods saved.")
+		return nil
+	},
+}
+
+var analyzeWaveformCmd = &cobra.Command{
+	Use:   "waveform <video-file>",
+	Short: "Compute the audio waveform shown under the TUI timeline",
+	Long: `Decode the audio track with ffmpeg and store its peak levels, which the TUI
+draws as a strip under the timeline. Whistle blasts and crowd noise show up as
+spikes, which helps find restarts and scores quickly.
+
+Run it again to recompute with a different --resolution.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolution, _ := cmd.Flags().GetFloat64("resolution")
+		if resolution <= 0 {
+			return usageErrorf("invalid --resolution: %g (want seconds > 0)", resolution)
+		}
+
+		if err := deps.CheckFfmpeg(); err != nil {
+			return err
+		}
+
+		absPath, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to resolve path: %w", err)
+		}
+		info, err := os.Stat(absPath)
+		if os.IsNotExist(err) {
+			return notFoundErrorf("video file not found: %s", absPath)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to access video file: %w", err)
+		}
+
+		infoln("Reading audio peaks (this reads the whole audio track)...")
+		peaks, err := analyze.ComputePeaks(context.Background(), absPath, resolution)
+		if err != nil {
+			return fmt.Errorf("failed to compute waveform: %w", err)
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := db.EnsureVideo(database, absPath, info.Size(), "")
+		if err != nil {
+			return fmt.Errorf("failed to register video: %w", err)
+		}
+		if err := db.UpsertWaveform(database, db.Waveform{VideoID: videoID, SecondsPerPeak: resolution, Peaks: peaks}); err != nil {
+			return fmt.Errorf("failed to save waveform: %w", err)
+		}
+
+		infof("Saved %d peaks (%gs each, %s of audio).\n", len(peaks), resolution, timeutil.FormatTime(float64(len(peaks))*resolution))
+		return nil
+	},
+}
+
+func init() {
+	analyzeHalvesCmd.Flags().Float64("noise", -35, "Silence threshold in dB")
+	analyzeHalvesCmd.Flags().Float64("min-silence", 20, "Minimum silence length in seconds")
+	analyzeHalvesCmd.Flags().Bool("scenes", false, "Fall back to scene-change detection when no silence is found")
+	analyzeHalvesCmd.Flags().Float64("scene-threshold", 0.4, "Scene-change score threshold (0-1)")
+	analyzeHalvesCmd.Flags().BoolP("yes", "y", false, "Save the suggestion without prompting")
+
+	analyzeWaveformCmd.Flags().Float64("resolution", 0.5, "Seconds of audio per peak")
+
+	analyzeCmd.AddCommand(analyzeHalvesCmd)
+	analyzeCmd.AddCommand(analyzeWaveformCmd)
+	rootCmd.AddCommand(analyzeCmd)
+}
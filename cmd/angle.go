@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+)
+
+var videoAngleCmd = &cobra.Command{
+	Use:   "angle",
+	Short: "Link secondary camera angles to a video",
+	Long: `Link secondary camera angles (e.g. an end-on or tight camera) to a registered
+video. Notes stay on the main video; in the TUI, a switches playback between
+angles at the same moment of the match, and 'clip export --all-angles' cuts an
+event from every angle.
+
+--offset is how far ahead the angle's recording is: the angle's time at any
+moment is the main video's time plus the offset. Use a negative offset for an
+angle that started recording later than the main video.`,
+}
+
+var videoAngleAddCmd = &cobra.Command{
+	Use:   "add <video> <angle-file>",
+	Short: "Link a camera angle to a video",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		label, _ := cmd.Flags().GetString("label")
+		offset, _ := cmd.Flags().GetFloat64("offset")
+
+		videoPath, err := resolveVideoPath(args[0])
+		if err != nil {
+			return err
+		}
+		anglePath := args[1]
+		if !videosrc.IsURL(anglePath) {
+			anglePath, err = filepath.Abs(anglePath)
+			if err != nil {
+				return fmt.Errorf("failed to resolve path: %w", err)
+			}
+			if _, err := os.Stat(anglePath); err != nil {
+				return notFoundErrorf("angle file not found: %s", anglePath)
+			}
+		}
+		if label == "" {
+			base := videosrc.Base(anglePath)
+			label = strings.TrimSuffix(base, filepath.Ext(base))
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := lookupVideoID(database, videoPath)
+		if err != nil {
+			return err
+		}
+		if _, err := db.InsertVideoAngle(database, db.VideoAngle{VideoID: videoID, Path: anglePath, Label: label, Offset: offset}); err != nil {
+			return fmt.Errorf("failed to link angle: %w", err)
+		}
+		infof("Linked angle %q to %s (offset %+.2fs)\n", label, videosrc.Base(videoPath), offset)
+		return nil
+	},
+}
+
+var videoAngleListCmd = &cobra.Command{
+	Use:   "list <video>",
+	Short: "List the camera angles linked to a video",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoPath, err := resolveVideoPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := lookupVideoID(database, videoPath)
+		if err != nil {
+			return err
+		}
+		angles, err := db.SelectVideoAngles(database, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to query angles: %w", err)
+		}
+		if len(angles) == 0 {
+			infoln("No angles linked.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Label\tOffset\tPath")
+		fmt.Fprintln(w, "-----\t------\t----")
+		for _, a := range angles {
+			fmt.Fprintf(w, "%s\t%+.2fs\t%s\n", a.Label, a.Offset, a.Path)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var videoAngleRemoveCmd = &cobra.Command{
+	Use:   "remove <video> <label>",
+	Short: "Unlink a camera angle from a video",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoPath, err := resolveVideoPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := lookupVideoID(database, videoPath)
+		if err != nil {
+			return err
+		}
+		if err := db.DeleteVideoAngle(database, videoID, args[1]); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return notFoundErrorf("no angle %q linked to %s", args[1], videosrc.Base(videoPath))
+			}
+			return fmt.Errorf("failed to unlink angle: %w", err)
+		}
+		infof("Unlinked angle %q\n", args[1])
+		return nil
+	},
+}
+
+// lookupVideoID returns the ID of a registered video, with a friendly error
+// when it has not been opened yet.
+func lookupVideoID(database *sql.DB, videoPath string) (int64, error) {
+	videoID, err := db.SelectVideoIDByPath(database, videoPath)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, notFoundErrorf("video not found in database: %s", videoPath)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up video: %w", err)
+	}
+	return videoID, nil
+}
+
+func init() {
+	videoAngleAddCmd.Flags().String("label", "", "Angle name (default: the file name)")
+	videoAngleAddCmd.Flags().Float64("offset", 0, "Seconds to add to the main video's time to get the angle's time")
+
+	videoAngleCmd.AddCommand(videoAngleAddCmd)
+	videoAngleCmd.AddCommand(videoAngleListCmd)
+	videoAngleCmd.AddCommand(videoAngleRemoveCmd)
+	videoCmd.AddCommand(videoAngleCmd)
+}
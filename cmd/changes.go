@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+)
+
+var changesCmd = &cobra.Command{
+	Use:   "changes",
+	Short: "Show the events added or edited since the last session",
+	Long: `List a video's events added or edited since its last tagging session started,
+to catch up when several people work on the same match. The TUI shows the same
+summary when it opens.
+
+Uses the video open in mpv unless --video is given; --session compares with an
+earlier session instead (see 'session list'). Deleted events are not listed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoFlag, _ := cmd.Flags().GetString("video")
+		sessionID, _ := cmd.Flags().GetInt64("session")
+
+		videoPath, err := resolveVideoPath(videoFlag)
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := db.SelectVideoIDByPath(database, videoPath)
+		if err != nil {
+			return notFoundErrorf("video not found: %s", videoPath)
+		}
+
+		var session db.Session
+		if sessionID != 0 {
+			session, err = db.SelectSession(database, sessionID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return notFoundErrorf("session not found: %d", sessionID)
+			}
+		} else {
+			session, err = db.SelectPreviousSession(database, videoID)
+			if errors.Is(err, sql.ErrNoRows) {
+				infoln("No earlier session on this video.")
+				return nil
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to query sessions: %w", err)
+		}
+		since := fmt.Sprintf("session %d (started %s)", session.ID, session.StartedAt.Local().Format("2006-01-02 15:04"))
+
+		changes, err := db.SelectNoteChanges(database, videoID, session.ID)
+		if err != nil {
+			return fmt.Errorf("failed to query changes: %w", err)
+		}
+		if len(changes) == 0 {
+			infof("No events added or edited since %s.\n", since)
+			return nil
+		}
+
+		added := 0
+		for _, c := range changes {
+			if c.Added {
+				added++
+			}
+		}
+		infof("Since %s: %d added, %d edited\n\n", since, added, len(changes)-added)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTime\tChange\tWhen\tCategory\tPlayer\tText")
+		fmt.Fprintln(w, "--\t----\t------\t----\t--------\t------\t----")
+		for _, c := range changes {
+			change, when := "added", c.CreatedAt
+			if !c.Added {
+				change, when = "edited", c.UpdatedAt.Time
+			}
+
+			// Truncate text if too long
+			text := c.Text
+			if len(text) > 40 {
+				text = text[:37] + "..."
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				c.NoteID, timeutil.FormatTime(c.Time), change, when.Local().Format("2006-01-02 15:04"),
+				c.Category, c.Player, text)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+func init() {
+	changesCmd.Flags().String("video", "", "Video to show changes for (default: the video open in mpv)")
+	changesCmd.Flags().Int64("session", 0, "Show changes since this session started instead of the last one")
+	rootCmd.AddCommand(changesCmd)
+}
@@ -1,20 +1,30 @@
 package cmd
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/clip"
+	"github.com/user/tagging-rugby-cli/config"
 	"github.com/user/tagging-rugby-cli/db"
 	"github.com/user/tagging-rugby-cli/deps"
 	"github.com/user/tagging-rugby-cli/mpv"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+	"github.com/user/tagging-rugby-cli/pkg/season"
 	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
 )
 
 // clipStartState holds the temporary clip start timestamp.
@@ -67,8 +77,8 @@ var clipStartCmd = &cobra.Command{
 		clipStartState.isSet = true
 		clipStartState.mu.Unlock()
 
-		fmt.Printf("Clip start marked at %s\n", timeutil.FormatTime(timestamp))
-		fmt.Println("Use 'clip end <name>' to complete the clip.")
+		infof("Clip start marked at %s\n", timeutil.FormatTime(timestamp))
+		infoln("Use 'clip end <name>' to complete the clip.")
 		return nil
 	},
 }
@@ -122,7 +132,7 @@ var clipEndCmd = &cobra.Command{
 
 		// Validate start < end
 		if startTimestamp >= endTimestamp {
-			return fmt.Errorf("clip end time (%s) must be after start time (%s)",
+			return usageErrorf("clip end time (%s) must be after start time (%s)",
 				timeutil.FormatTime(endTimestamp),
 				timeutil.FormatTime(startTimestamp))
 		}
@@ -162,7 +172,7 @@ var clipEndCmd = &cobra.Command{
 			return fmt.Errorf("failed to insert clip: %w", err)
 		}
 
-		fmt.Printf("Clip saved: Note ID %d (%s - %s, %.1fs)\n", noteID, timeutil.FormatTime(startTimestamp), timeutil.FormatTime(endTimestamp), duration)
+		infof("Clip saved: Note ID %d (%s - %s, %.1fs)\n", noteID, timeutil.FormatTime(startTimestamp), timeutil.FormatTime(endTimestamp), duration)
 		return nil
 	},
 }
@@ -245,9 +255,9 @@ var clipListCmd = &cobra.Command{
 		w.Flush()
 
 		if count == 0 {
-			fmt.Println("\nNo clips found for this video.")
+			infoln("\nNo clips found for this video.")
 		} else {
-			fmt.Printf("\n%d clip(s) found.\n", count)
+			infof("\n%d clip(s) found.\n", count)
 		}
 
 		return nil
@@ -262,7 +272,7 @@ var clipPlayCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var noteID int64
 		if _, err := fmt.Sscanf(args[0], "%d", &noteID); err != nil {
-			return fmt.Errorf("invalid note ID: %s", args[0])
+			return usageErrorf("invalid note ID: %s", args[0])
 		}
 
 		// Open database
@@ -313,9 +323,9 @@ var clipPlayCmd = &cobra.Command{
 
 		duration := endSec - startSec
 
-		fmt.Printf("Playing clip (note %d): %s\n", noteID, clipName)
-		fmt.Printf("Looping %s - %s (%.1fs)\n", timeutil.FormatTime(startSec), timeutil.FormatTime(endSec), duration)
-		fmt.Println("Use 'clip stop' to clear the loop.")
+		infof("Playing clip (note %d): %s\n", noteID, clipName)
+		infof("Looping %s - %s (%.1fs)\n", timeutil.FormatTime(startSec), timeutil.FormatTime(endSec), duration)
+		infoln("Use 'clip stop' to clear the loop.")
 		return nil
 	},
 }
@@ -337,36 +347,131 @@ var clipStopCmd = &cobra.Command{
 			return fmt.Errorf("failed to clear A-B loop: %w", err)
 		}
 
-		fmt.Println("A-B loop cleared.")
+		infoln("A-B loop cleared.")
 		return nil
 	},
 }
 
 var clipExportCmd = &cobra.Command{
-	Use:   "export <note-id>",
-	Short: "Export a clip as a video file using ffmpeg",
-	Long:  `Export a clip as a video file using ffmpeg. By default uses stream copy (-c copy) for fast export.`,
-	Args:  cobra.ExactArgs(1),
+	Use:   "export [note-id...]",
+	Short: "Export clips as video files using ffmpeg",
+	Long: `Export the clips of notes as video files using ffmpeg. By default uses stream copy (-c copy) for fast export.
+With --upload, each exported file is then uploaded (see 'upload login').
+
+Give note IDs, or select notes of a video (--video, or the one open in mpv) with
+--all, --category (with its subcategories), --player and --starred; selectors
+combine:
+
+  tagging-rugby-cli clip export 12 15
+  tagging-rugby-cli clip export --video match.mp4 --player Smith --starred --dir clips/
+
+A single clip is written to --output (default clip-<id>.<format> in --dir).
+Several are written under --dir (default the current directory) in <category>/<player>
+folders, or straight into it with --flat, together with manifest.csv and
+manifest.json describing each file: its note, video, timings and labels.
+
+Batches resume: clips already exported in full (checked by length with
+ffprobe when installed) are skipped, and a clip that fails doesn't stop the
+rest, so re-running after an interruption or error redoes only what is
+missing. --force exports everything again.
+
+--all-videos selects from every video instead, so stars (the "cut these"
+markers) can be exported in one go:
+
+  tagging-rugby-cli clip export --starred --all-videos --dir highlights/
+
+--match and --season narrow that to the videos of one match (see 'match list')
+or one season's matches:
+
+  tagging-rugby-cli clip export --category lineout --season 2025 --dir lineouts/
+
+Clips are widened by "clip_padding" in config.json, e.g.
+{"clip_padding": {"before": 3, "after": 2}}. "clip_dir" and "clip_format" in
+config.json change the defaults of --dir and --format (see 'config list').
+
+With --all-angles, the same event is also cut from every camera angle linked to
+the video (see 'video angle'), written next to the main clip with the angle's
+label appended, e.g. clip-12-endon.mp4.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check ffmpeg is installed
 		if err := deps.CheckFfmpeg(); err != nil {
 			return err
 		}
 
-		var noteID int64
-		if _, err := fmt.Sscanf(args[0], "%d", &noteID); err != nil {
-			return fmt.Errorf("invalid note ID: %s", args[0])
-		}
-
 		// Get flags
 		outputPath, _ := cmd.Flags().GetString("output")
+		dir, _ := cmd.Flags().GetString("dir")
 		format, _ := cmd.Flags().GetString("format")
 		reencode, _ := cmd.Flags().GetBool("reencode")
+		uploadTarget, _ := cmd.Flags().GetString("upload")
+		title, _ := cmd.Flags().GetString("title")
+		allAngles, _ := cmd.Flags().GetBool("all-angles")
+		videoFlag, _ := cmd.Flags().GetString("video")
+		category, _ := cmd.Flags().GetString("category")
+		player, _ := cmd.Flags().GetString("player")
+		starred, _ := cmd.Flags().GetBool("starred")
+		all, _ := cmd.Flags().GetBool("all")
+		allVideos, _ := cmd.Flags().GetBool("all-videos")
+		matchID, _ := cmd.Flags().GetInt64("match")
+		seasonFlag, _ := cmd.Flags().GetString("season")
+		seasonStart := seasonStartFlag(cmd)
+		flat, _ := cmd.Flags().GetBool("flat")
+		force, _ := cmd.Flags().GetBool("force")
+		selecting := all || category != "" || player != "" || starred
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if !cmd.Flags().Changed("dir") && cfg.ClipDir != "" {
+			dir = cfg.ClipDir
+		}
+		if !cmd.Flags().Changed("format") && cfg.ClipFormat != "" {
+			format = cfg.ClipFormat
+		}
 
 		// Validate format
 		validFormats := map[string]bool{"mp4": true, "webm": true, "mkv": true}
 		if !validFormats[format] {
-			return fmt.Errorf("invalid format: %s (supported: mp4, webm, mkv)", format)
+			return usageErrorf("invalid format: %s (supported: mp4, webm, mkv)", format)
+		}
+		if reencode {
+			video, audio := reencodeCodecs(format)
+			if err := deps.CheckFfmpegEncoders("--reencode to "+format, video, audio); err != nil {
+				return err
+			}
+		}
+		if uploadTarget != "" && !isUploadTarget(uploadTarget) {
+			return usageErrorf("invalid upload target: %s (supported: %s)", uploadTarget, uploadTargetsHelp())
+		}
+		if len(args) > 0 && selecting {
+			return usageErrorf("give note IDs or --all/--category/--player/--starred, not both")
+		}
+		if len(args) == 0 && !selecting {
+			return fmt.Errorf("give note IDs or select notes with --all, --category, --player or --starred")
+		}
+		if allVideos && (!selecting || videoFlag != "") {
+			return usageErrorf("--all-videos selects with --all, --category, --player or --starred, instead of --video")
+		}
+		if (matchID != 0 || seasonFlag != "") && (!selecting || videoFlag != "" || allVideos) {
+			return usageErrorf("--match and --season select with --all, --category, --player or --starred, instead of --video or --all-videos")
+		}
+		if matchID != 0 && seasonFlag != "" {
+			return usageErrorf("give --match or --season, not both")
+		}
+
+		var padding config.ClipPadding
+		if cfg.ClipPadding != nil {
+			padding = *cfg.ClipPadding
+		}
+
+		var noteIDs []int64
+		for _, arg := range args {
+			var noteID int64
+			if _, err := fmt.Sscanf(arg, "%d", &noteID); err != nil {
+				return usageErrorf("invalid note ID: %s", arg)
+			}
+			noteIDs = append(noteIDs, noteID)
 		}
 
 		// Open database
@@ -376,50 +481,379 @@ var clipExportCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		// Get video path
-		videos, err := db.SelectNoteVideosByNote(database, noteID)
-		if err != nil || len(videos) == 0 {
-			return fmt.Errorf("no video found for note ID %d", noteID)
+		// Notes to export with the details the manifest describes, loaded
+		// once per video
+		var targets []exportTarget
+		exportNotes := make(map[string][]db.ExportNote)
+		if selecting {
+			targets, err = selectExportTargets(database, exportSelection{
+				video:       videoFlag,
+				category:    category,
+				player:      player,
+				starred:     starred,
+				allVideos:   allVideos,
+				matchID:     matchID,
+				season:      seasonFlag,
+				seasonStart: seasonStart,
+			})
+			if err != nil {
+				return err
+			}
+			if len(targets) == 0 {
+				infoln("No notes match.")
+				return nil
+			}
+		} else {
+			for _, noteID := range noteIDs {
+				target, err := lookupExportTarget(database, noteID, exportNotes)
+				if err != nil {
+					return err
+				}
+				targets = append(targets, target)
+			}
 		}
-		videoPath := videos[0].Path
+		if len(targets) > 1 && outputPath != "" {
+			return usageErrorf("--output takes a single clip; use --dir for %d clips", len(targets))
+		}
+
+		// Batches resume: clips a previous, interrupted run finished are kept
+		resume := len(targets) > 1 && !force
+		failed, skipped := 0, 0
+		var manifest []clip.ManifestEntry
+		for _, target := range targets {
+			n := target.note
+
+			// Determine output path: several clips are sorted into
+			// <category>/<player> folders unless --flat is set
+			output := outputPath
+			if output == "" {
+				name := fmt.Sprintf("clip-%d.%s", n.ID, format)
+				output = filepath.Join(dir, name)
+				if len(targets) > 1 && !flat {
+					output = filepath.Join(dir, exportFolder(n), name)
+				}
+			}
+			if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			clips, err := exportNoteClip(database, n.ID, output, format, reencode, allAngles, resume, padding)
+			if err != nil {
+				if len(targets) == 1 {
+					return err
+				}
+				// Carry on with the rest; a re-run retries only the failures
+				fmt.Fprintf(os.Stderr, "Failed to export note %d: %v\n", n.ID, err)
+				failed++
+				continue
+			}
+			if clips[0].skipped {
+				skipped++
+			}
+			for _, c := range clips {
+				rel, err := filepath.Rel(dir, c.path)
+				if err != nil {
+					rel = c.path
+				}
+				manifest = append(manifest, clip.ManifestEntry{
+					File:      filepath.ToSlash(rel),
+					NoteID:    n.ID,
+					Video:     target.video,
+					Angle:     c.angle,
+					Start:     n.Start,
+					End:       n.End,
+					ClipStart: c.start,
+					ClipEnd:   c.end,
+					Category:  n.Category,
+					Player:    n.Player,
+					Team:      n.Team,
+					Outcome:   n.Outcome,
+					Text:      n.Text,
+					Starred:   n.Starred,
+				})
+			}
 
-		// Get timing
-		timings, err := db.SelectNoteTimingByNote(database, noteID)
-		if err != nil || len(timings) == 0 {
-			return fmt.Errorf("no timing found for note ID %d", noteID)
+			if uploadTarget != "" && !clips[0].skipped {
+				clipTitle := fmt.Sprintf("Clip %d", n.ID)
+				if title != "" && len(targets) == 1 {
+					clipTitle = title
+				} else if title != "" {
+					clipTitle = fmt.Sprintf("%s (note %d)", title, n.ID)
+				}
+				if err := uploadExported(uploadTarget, output, clipTitle); err != nil {
+					return err
+				}
+			}
 		}
-		startSec := timings[0].Start
-		endSec := timings[0].End
+		if len(targets) > 1 {
+			manifestDir := dir
+			if manifestDir == "" {
+				manifestDir = "."
+			}
+			if err := clip.WriteManifest(manifestDir, manifest); err != nil {
+				return err
+			}
+			done := fmt.Sprintf("Exported %d clips", len(targets)-failed-skipped)
+			if skipped > 0 {
+				done += fmt.Sprintf(" (%d already exported)", skipped)
+			}
+			infof("%s; described in %s and %s\n", done, filepath.Join(manifestDir, clip.ManifestCSV), filepath.Join(manifestDir, clip.ManifestJSON))
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d clips failed; run the same command again to retry them", failed, len(targets))
+		}
+		return nil
+	},
+}
+
+// exportSelection selects notes by their labels from one video, or from
+// every video of a match, a season or the database.
+type exportSelection struct {
+	// video is the --video flag; empty means the video open in mpv
+	video            string
+	category, player string
+	starred          bool
+	allVideos        bool
+	matchID          int64
+	season           string
+	seasonStart      int
+}
 
-		// Determine output path
-		if outputPath == "" {
-			outputPath = fmt.Sprintf("clip-%d.%s", noteID, format)
+// selectExportTargets returns the notes sel selects, in time order within
+// each video. Streamed and live videos have no local footage and are skipped
+// unless given with --video.
+func selectExportTargets(database *sql.DB, sel exportSelection) ([]exportTarget, error) {
+	var videoPaths []string
+	if sel.allVideos || sel.matchID != 0 || sel.season != "" {
+		inScope, err := exportScopeFilter(database, sel.matchID, sel.season, sel.seasonStart)
+		if err != nil {
+			return nil, err
 		}
+		videos, err := db.SelectVideos(database, db.VideoFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query videos: %w", err)
+		}
+		for _, v := range videos {
+			if !inScope(v) {
+				continue
+			}
+			// Streams and live matches have no local footage to cut
+			if videosrc.IsURL(v.Path) || strings.HasPrefix(v.Path, livePathPrefix) {
+				continue
+			}
+			videoPaths = append(videoPaths, v.Path)
+		}
+	} else {
+		videoPath, err := resolveVideoPath(sel.video)
+		if err != nil {
+			return nil, err
+		}
+		videoPaths = []string{videoPath}
+	}
 
-		// Build ffmpeg command
-		ffmpegArgs := buildFfmpegArgs(videoPath, startSec, endSec, outputPath, format, reencode)
+	var targets []exportTarget
+	for _, videoPath := range videoPaths {
+		notes, err := db.SelectNotesForExport(database, videoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query notes: %w", err)
+		}
+		for _, n := range notes {
+			if !catpath.Under(n.Category, sel.category) {
+				continue
+			}
+			if sel.player != "" && !strings.EqualFold(n.Player, sel.player) {
+				continue
+			}
+			if sel.starred && !n.Starred {
+				continue
+			}
+			targets = append(targets, exportTarget{note: n, video: videoPath})
+		}
+	}
+	return targets, nil
+}
 
-		fmt.Printf("Exporting clip (note %d) to %s...\n", noteID, outputPath)
+// exportScopeFilter reports whether a video is in the match or season clip
+// export selects from; every video is when neither is given.
+func exportScopeFilter(database *sql.DB, matchID int64, seasonFlag string, seasonStart int) (func(db.VideoSummary) bool, error) {
+	switch {
+	case matchID != 0:
+		if _, err := lookupMatch(database, matchID); err != nil {
+			return nil, err
+		}
+		paths, err := db.SelectMatchVideos(database, matchID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query match videos: %w", err)
+		}
+		linked := make(map[string]bool, len(paths))
+		for _, p := range paths {
+			linked[p] = true
+		}
+		return func(v db.VideoSummary) bool { return linked[v.Path] }, nil
+	case seasonFlag != "":
+		from, to, err := seasonRange(seasonFlag, seasonStart)
+		if err != nil {
+			return nil, err
+		}
+		ids, err := db.SelectSeasonVideoIDs(database, from.Format(reportDateLayout), to.Format(reportDateLayout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query season videos: %w", err)
+		}
+		inSeason := make(map[int64]bool, len(ids))
+		for _, id := range ids {
+			inSeason[id] = true
+		}
+		return func(v db.VideoSummary) bool { return inSeason[v.ID] }, nil
+	}
+	return func(db.VideoSummary) bool { return true }, nil
+}
+
+// exportTarget is a note selected for export and the video it belongs to.
+type exportTarget struct {
+	note  db.ExportNote
+	video string
+}
+
+// lookupExportTarget finds the export details of a note given by ID. cache
+// holds the notes of each video already loaded.
+func lookupExportTarget(database *sql.DB, noteID int64, cache map[string][]db.ExportNote) (exportTarget, error) {
+	videos, err := db.SelectNoteVideosByNote(database, noteID)
+	if err != nil || len(videos) == 0 {
+		return exportTarget{}, fmt.Errorf("no video found for note ID %d", noteID)
+	}
+	videoPath := videos[0].Path
+	notes, ok := cache[videoPath]
+	if !ok {
+		notes, err = db.SelectNotesForExport(database, videoPath)
+		if err != nil {
+			return exportTarget{}, fmt.Errorf("failed to query notes: %w", err)
+		}
+		cache[videoPath] = notes
+	}
+	for _, n := range notes {
+		if n.ID == noteID {
+			return exportTarget{note: n, video: videoPath}, nil
+		}
+	}
+	return exportTarget{}, notFoundErrorf("note %d not found", noteID)
+}
+
+// exportFolder returns the <category>/<player> folder a clip is sorted into
+// when exporting several. Notes without a player stay in the category folder.
+func exportFolder(n db.ExportNote) string {
+	category := clip.Slug(n.Category)
+	if category == "" {
+		category = "uncategorised"
+	}
+	return filepath.Join(category, clip.Slug(n.Player))
+}
+
+// exportedClip is a clip file written by exportNoteClip, with the cut taken in
+// reference time.
+type exportedClip struct {
+	path, angle string
+	start, end  float64
+	// skipped is set when the file was already exported by an earlier run
+	skipped bool
+}
+
+// exportNoteClip cuts a note's clip, widened by padding, from its video to
+// outputPath, and from every linked camera angle when allAngles is set. With
+// resume, files already exported in full are kept instead of cut again.
+func exportNoteClip(database *sql.DB, noteID int64, outputPath, format string, reencode, allAngles, resume bool, padding config.ClipPadding) ([]exportedClip, error) {
+	// Get video path
+	videos, err := db.SelectNoteVideosByNote(database, noteID)
+	if err != nil || len(videos) == 0 {
+		return nil, fmt.Errorf("no video found for note ID %d", noteID)
+	}
+	videoPath := videos[0].Path
+	if videosrc.IsURL(videoPath) {
+		return nil, fmt.Errorf("note %d belongs to a streamed video (%s)\nClip export needs a local copy: tagging-rugby-cli video relink <url> <local-file>", noteID, videoPath)
+	}
+
+	// Get timing
+	timings, err := db.SelectNoteTimingByNote(database, noteID)
+	if err != nil || len(timings) == 0 {
+		return nil, fmt.Errorf("no timing found for note ID %d", noteID)
+	}
+	startSec := math.Max(0, timings[0].Start-padding.Before)
+	endSec := timings[0].End + padding.After
+
+	// The main video first, then each linked angle shifted by its offset
+	type exportSource struct {
+		path, output, angle string
+		offset              float64
+	}
+	sources := []exportSource{{path: videoPath, output: outputPath}}
+	if allAngles {
+		videoID, err := db.SelectVideoIDByPath(database, videoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up video: %w", err)
+		}
+		angles, err := db.SelectVideoAngles(database, videoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query angles: %w", err)
+		}
+		if len(angles) == 0 {
+			infoln("No angles linked; exporting the main video only.")
+		}
+		ext := filepath.Ext(outputPath)
+		for _, a := range angles {
+			sources = append(sources, exportSource{
+				path:   a.Path,
+				output: strings.TrimSuffix(outputPath, ext) + "-" + a.Label + ext,
+				angle:  a.Label,
+				offset: a.Offset,
+			})
+		}
+	}
+
+	var clips []exportedClip
+	for _, src := range sources {
+		exported := exportedClip{path: src.output, angle: src.angle, start: startSec, end: endSec}
+		if resume && clip.Complete(src.output, endSec-startSec) {
+			infof("Skipping clip (note %d): %s is already exported\n", noteID, src.output)
+			exported.skipped = true
+			clips = append(clips, exported)
+			continue
+		}
+
+		// Build ffmpeg command, writing to a .part file renamed once finished
+		part := clip.PartPath(src.output)
+		ffmpegArgs := buildFfmpegArgs(src.path, startSec+src.offset, endSec+src.offset, part, format, reencode)
+
+		infof("Exporting clip (note %d) to %s...\n", noteID, src.output)
 
 		// Run ffmpeg
-		ffmpegCmd := exec.Command("ffmpeg", ffmpegArgs...)
+		ffmpegCmd := exec.Command(deps.Ffmpeg(), ffmpegArgs...)
 		ffmpegCmd.Stdout = os.Stdout
 		ffmpegCmd.Stderr = os.Stderr
 
 		if err := ffmpegCmd.Run(); err != nil {
-			return fmt.Errorf("ffmpeg export failed: %w", err)
+			os.Remove(part)
+			return nil, fmt.Errorf("ffmpeg export failed: %w", err)
+		}
+		if err := os.Rename(part, src.output); err != nil {
+			return nil, fmt.Errorf("failed to save clip: %w", err)
 		}
 
 		// Get file size
-		fileInfo, err := os.Stat(outputPath)
+		fileInfo, err := os.Stat(src.output)
 		if err == nil {
-			fmt.Printf("Exported clip (note %d) to %s (%.2f MB)\n", noteID, outputPath, float64(fileInfo.Size())/(1024*1024))
+			infof("Exported clip (note %d) to %s (%.2f MB)\n", noteID, src.output, float64(fileInfo.Size())/(1024*1024))
 		} else {
-			fmt.Printf("Exported clip (note %d) to %s\n", noteID, outputPath)
+			infof("Exported clip (note %d) to %s\n", noteID, src.output)
 		}
+		clips = append(clips, exported)
+	}
+	return clips, nil
+}
 
-		return nil
-	},
+// reencodeCodecs returns the video and audio encoders --reencode uses for format.
+func reencodeCodecs(format string) (video, audio string) {
+	if format == "webm" {
+		return "libvpx-vp9", "libopus"
+	}
+	return "libx264", "aac"
 }
 
 // buildFfmpegArgs builds the ffmpeg command arguments
@@ -433,12 +867,8 @@ func buildFfmpegArgs(videoPath string, startSec, endSec float64, outputPath, for
 
 	if reencode {
 		// Re-encode with appropriate codec for format
-		switch format {
-		case "webm":
-			args = append(args, "-c:v", "libvpx-vp9", "-c:a", "libopus")
-		case "mkv", "mp4":
-			args = append(args, "-c:v", "libx264", "-c:a", "aac")
-		}
+		video, audio := reencodeCodecs(format)
+		args = append(args, "-c:v", video, "-c:a", audio)
 	} else {
 		// Stream copy (fast, no re-encoding)
 		args = append(args, "-c", "copy")
@@ -453,11 +883,113 @@ func buildFfmpegArgs(videoPath string, startSec, endSec float64, outputPath, for
 	return args
 }
 
+var clipWorkerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "Render queued clips in the background",
+	Long: `Render the clips queued for tackles with ffmpeg, into the clips folder next to
+each video (<video dir>/clips/<category>/<player>), recording each clip's status,
+size and ffmpeg log. The TUI renders the queue while it is open; run the worker
+to render it without the TUI, or faster with --workers.
+
+The worker keeps waiting for new clips until stopped. Ctrl+C lets the clips being
+rendered finish, and a second Ctrl+C stops them at once and puts them back in the
+queue. With --once it exits when the queue is empty, e.g. from cron:
+
+  tagging-rugby-cli clip worker --once --workers 4
+
+Several workers, or a worker and the TUI, can share the queue: each clip is
+rendered once.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workers, _ := cmd.Flags().GetInt("workers")
+		once, _ := cmd.Flags().GetBool("once")
+		if workers < 1 {
+			return usageErrorf("invalid --workers: %d (want 1 or more)", workers)
+		}
+		if err := deps.CheckFfmpeg(); err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		// The first signal stops taking clips, the second abandons running ones
+		stop, stopCancel := context.WithCancel(context.Background())
+		abort, abortCancel := context.WithCancel(context.Background())
+		defer stopCancel()
+		defer abortCancel()
+		signals := make(chan os.Signal, 2)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(signals)
+		go func() {
+			<-signals
+			stopCancel()
+			infoln("Stopping after the clips being rendered (Ctrl+C again to stop now)...")
+			<-signals
+			abortCancel()
+		}()
+
+		var mu sync.Mutex
+		rendered, failed := 0, 0
+		processor := clip.Processor{
+			DB:      database,
+			Workers: workers,
+			Once:    once,
+			Done: func(c *db.PendingClip, size int64, err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					failed++
+					infof("Failed %s: %v\n", c.Filename, err)
+					return
+				}
+				rendered++
+				infof("Rendered %s (%.2f MB)\n", filepath.Join(c.Folder, c.Filename), float64(size)/(1024*1024))
+			},
+		}
+		if once {
+			infof("Rendering queued clips with %d worker(s)...\n", workers)
+		} else {
+			infof("Waiting for queued clips with %d worker(s) (Ctrl+C to stop)...\n", workers)
+		}
+		processor.Run(stop, abort)
+		processor.Wait()
+
+		infof("%d clip(s) rendered, %d failed.\n", rendered, failed)
+		if failed > 0 {
+			return fmt.Errorf("%d clip(s) failed to render", failed)
+		}
+		return nil
+	},
+}
+
 func init() {
 	// Add flags to clip export command
-	clipExportCmd.Flags().StringP("output", "o", "", "Custom output file path")
-	clipExportCmd.Flags().StringP("format", "f", "mp4", "Output format (mp4, webm, mkv)")
+	clipExportCmd.Flags().StringP("output", "o", "", "Custom output file path (single clip)")
+	clipExportCmd.Flags().String("dir", "", "Directory for the exported clips (default: clip_dir in config.json, else the current directory)")
+	clipExportCmd.Flags().String("video", "", "Video to select notes from (default: the video open in mpv)")
+	clipExportCmd.Flags().Bool("all", false, "Export every note of the video")
+	clipExportCmd.Flags().String("category", "", "Export the notes in this category and its subcategories")
+	clipExportCmd.Flags().String("player", "", "Export the notes and tackles of this player")
+	clipExportCmd.Flags().Bool("starred", false, "Export starred notes only")
+	clipExportCmd.Flags().Bool("all-videos", false, "Select notes from every video instead of one")
+	clipExportCmd.Flags().Int64("match", 0, "Select notes from the videos of this match")
+	clipExportCmd.Flags().String("season", "", "Select notes from a season's matches (e.g. 2025 or 2025/26)")
+	clipExportCmd.Flags().Int("season-start", season.DefaultStartMonth, "Month the season starts (1-12)")
+	clipExportCmd.Flags().Bool("force", false, "Export every clip again, even those already exported in full")
+	clipExportCmd.Flags().Bool("flat", false, "Write several clips straight into --dir instead of <category>/<player> folders")
+	clipExportCmd.Flags().StringP("format", "f", "mp4", "Output format (mp4, webm, mkv); clip_format in config.json changes the default")
 	clipExportCmd.Flags().Bool("reencode", false, "Re-encode video instead of stream copy")
+	clipExportCmd.Flags().String("upload", "", "Upload the exported clip ("+uploadTargetsHelp()+")")
+	clipExportCmd.Flags().String("title", "", "Title for the uploaded clip")
+	clipExportCmd.Flags().Bool("all-angles", false, "Also export the event from every linked camera angle")
+
+	clipWorkerCmd.Flags().IntP("workers", "j", 1, "Number of clips to render at once")
+	clipWorkerCmd.Flags().Bool("once", false, "Exit when no queued clips are left instead of waiting for more")
 
 	// Build command tree
 	clipCmd.AddCommand(clipStartCmd)
@@ -466,5 +998,6 @@ func init() {
 	clipCmd.AddCommand(clipPlayCmd)
 	clipCmd.AddCommand(clipStopCmd)
 	clipCmd.AddCommand(clipExportCmd)
+	clipCmd.AddCommand(clipWorkerCmd)
 	rootCmd.AddCommand(clipCmd)
 }
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/pkg/helpdoc"
+	"github.com/user/tagging-rugby-cli/script"
+	"github.com/user/tagging-rugby-cli/tui/keymap"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change the settings in config.json",
+	Long: `Show and change the settings in config.json, ~/.config/tagging-rugby/config.json,
+which set the defaults of the CLI and the TUI. 'config list' shows those with a
+single value, which 'config set' changes without editing the file:
+
+  tagging-rugby-cli config set step_sizes 0.5,1,5,15
+  tagging-rugby-cli config set clip_dir ~/clips
+  tagging-rugby-cli config unset clip_dir
+
+Settings with structure (webhooks, key remapping, note templates, ...) are
+edited in the file.`,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the settings 'config set' changes, with their values",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Setting\tValue\tDescription")
+		fmt.Fprintln(w, "-------\t-----\t-----------")
+		for _, o := range config.Options {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", o.Key, optionValue(o, cfg), o.Desc)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <setting>",
+	Short: "Print a setting's value",
+	Long: `Print a setting's value, or its default followed by "(default)" when it is
+unset. See 'config list' for the settings.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		o, err := lookupOption(args[0])
+		if err != nil {
+			return err
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		fmt.Println(optionValue(o, cfg))
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <setting> <value>",
+	Short: "Change a setting in config.json",
+	Long: `Change a setting in config.json; the CLI uses it from the next command and the
+TUI from its next start. Paths (db, mpv_socket, clip_dir) are saved absolute,
+and step_sizes takes seconds separated by commas. See 'config list' for the
+settings.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		o, err := lookupOption(args[0])
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(args[1]) == "" {
+			return usageErrorf("give %s a value, or clear it with 'config unset %s'", o.Key, o.Key)
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if err := o.Set(cfg, args[1]); err != nil {
+			return usageErrorf("%v", err)
+		}
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		infof("%s = %s\n", o.Key, optionValue(o, cfg))
+		return nil
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <setting>",
+	Short: "Remove a setting from config.json, restoring its default",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		o, err := lookupOption(args[0])
+		if err != nil {
+			return err
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		o.Unset(cfg)
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		infof("%s = %s (default)\n", o.Key, o.Default)
+		return nil
+	},
+}
+
+// lookupOption returns the setting called key, naming the settings when
+// there is none.
+func lookupOption(key string) (config.Option, error) {
+	o, ok := config.LookupOption(key)
+	if !ok {
+		return config.Option{}, usageErrorf("unknown setting: %s (settings: %s)", key, strings.Join(config.OptionKeys(), ", "))
+	}
+	return o, nil
+}
+
+// optionValue is a setting's value as 'config list' and 'config get' show it.
+func optionValue(o config.Option, cfg *config.Config) string {
+	if v := o.Get(cfg); v != "" {
+		return v
+	}
+	return o.Default + " (default)"
+}
+
+var configKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "List the TUI's key bindings and command aliases",
+	Long: `List the TUI actions that can be remapped, with the keys each is bound to, and
+the command mode aliases, checking both for mistakes: unknown actions, keys or
+commands, a key bound to two actions, and an alias shadowing a command.
+Remapped actions are marked with *.
+
+Remap keys in config.json with "keys", each action to the keys that trigger it
+instead of its defaults, and add aliases with "command_aliases":
+
+  {
+    "keys": {"note": ["a"], "scratch": ["ctrl+a"], "back": ["left"]},
+    "command_aliases": {"g": "goto", "fast": "speed 2"}
+  }
+
+An action's default keys stop working once it is remapped, unless another
+action is bound to them. Give an action an empty list to unbind it. Keys are
+named as the terminal reports them: "n", "N", "ctrl+e", "alt+x", "enter",
+"space", "left".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		km, keysErr := keymap.New(cfg.Keys)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Scope\tAction\tKeys\tDescription")
+		fmt.Fprintln(w, "-----\t------\t----\t-----------")
+		for _, b := range km.Bindings() {
+			keys := make([]string, len(b.Bound))
+			for i, k := range b.Bound {
+				keys[i] = keymap.Display(k)
+			}
+			name := b.Name
+			if b.Remapped {
+				name += " *"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", b.Scope, name, strings.Join(keys, " "), b.Desc)
+		}
+		w.Flush()
+
+		commands := append(helpdoc.CommandNames(), helpdoc.CommandAliases()...)
+		commands = append(commands, scriptCommands()...)
+		aliases, aliasErr := keymap.Aliases(cfg.CommandAliases, commands)
+		if len(aliases) > 0 {
+			fmt.Println()
+			w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "Alias\tCommand")
+			fmt.Fprintln(w, "-----\t-------")
+			names := make([]string, 0, len(aliases))
+			for name := range aliases {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Fprintf(w, ":%s\t:%s\n", name, strings.Join(aliases[name], " "))
+			}
+			w.Flush()
+		}
+
+		if err := errors.Join(keysErr, aliasErr); err != nil {
+			return usageErrorf("config.json: %v", strings.ReplaceAll(err.Error(), "\n", "; "))
+		}
+		return nil
+	},
+}
+
+// scriptCommands returns the commands the Lua scripts register, which
+// aliases may stand for. Scripts that fail to load add none.
+func scriptCommands() []string {
+	dir, err := script.Dir()
+	if err != nil {
+		return nil
+	}
+	engine := script.New(noScriptHost{})
+	defer engine.Close()
+	_ = engine.LoadDir(dir)
+	return engine.Commands()
+}
+
+// noScriptHost lets scripts load outside the TUI, to list their commands.
+type noScriptHost struct{}
+
+var errNoTUI = errors.New("not running in the TUI")
+
+func (noScriptHost) TimePos() (float64, error)                         { return 0, errNoTUI }
+func (noScriptHost) Seek(float64) error                                { return errNoTUI }
+func (noScriptHost) SeekRelative(float64) error                        { return errNoTUI }
+func (noScriptHost) InsertNote(string, string, float64) (int64, error) { return 0, errNoTUI }
+func (noScriptHost) Stats() (script.Stats, error)                      { return script.Stats{}, errNoTUI }
+func (noScriptHost) Message(string)                                    {}
+
+func init() {
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configKeysCmd)
+	rootCmd.AddCommand(configCmd)
+}
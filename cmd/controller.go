@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/controller"
+)
+
+var controllerCmd = &cobra.Command{
+	Use:   "controller",
+	Short: "Set up foot pedals, gamepads and MIDI controllers",
+	Long: `Set up a foot pedal, gamepad or MIDI controller to drive the TUI, so both
+hands stay free.
+
+Pedal and gamepad buttons are mapped to actions in config.json:
+
+  "pedal": {
+    "device": "/dev/input/by-id/usb-VEC_USB_Footpedal-event-if00",
+    "buttons": { "256": "back", "257": "play", "258": "tackle" }
+  }
+
+Use 'controller listen' to find the device's button codes. Linux only; the
+device must be readable by your user (usually via the input group).
+
+MIDI controllers (jog wheels, shuttle rings, pads) are set up interactively
+with 'controller map'.`,
+}
+
+var controllerListenCmd = &cobra.Command{
+	Use:   "listen <device>",
+	Short: "Print the code of each button pressed on a device",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		device, err := controller.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer device.Close()
+
+		fmt.Println("Actions:")
+		for _, name := range controller.ActionNames() {
+			fmt.Printf("  %-14s %s\n", name, controller.Actions[name])
+		}
+		fmt.Println()
+		fmt.Println("Press buttons to see their codes (Ctrl+C to stop)...")
+		for {
+			code, err := device.NextPress()
+			if err != nil {
+				return fmt.Errorf("failed to read device: %w", err)
+			}
+			fmt.Printf("button %d\n", code)
+		}
+	},
+}
+
+var controllerMapCmd = &cobra.Command{
+	Use:   "map <midi-device>",
+	Short: "Bind a MIDI controller's controls to actions interactively",
+	Long: `Bind the controls of a MIDI controller to TUI actions, one action at a time:
+press the button or turn the wheel for each action, or press Enter to keep
+its current binding. The device and bindings are saved to config.json.
+
+The device is an ALSA raw MIDI device such as /dev/snd/midiC1D0 (list them
+with 'amidi -l'). Jog wheels must send relative values (1-63 forward, 65-127
+back) and shuttle rings an absolute position centred on 64.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		device, err := controller.OpenMIDI(args[0])
+		if err != nil {
+			return err
+		}
+		defer device.Close()
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		bindings := make(map[string]string)
+		if cfg.MIDI != nil {
+			for control, action := range cfg.MIDI.Bindings {
+				bindings[control] = action
+			}
+		}
+
+		events := make(chan controller.MIDIEvent)
+		readErr := make(chan error, 1)
+		go func() {
+			for {
+				ev, err := device.Next()
+				if err != nil {
+					readErr <- err
+					return
+				}
+				events <- ev
+			}
+		}()
+		lines := make(chan struct{})
+		go func() {
+			scanner := bufio.NewScanner(os.Stdin)
+			for scanner.Scan() {
+				lines <- struct{}{}
+			}
+			close(lines)
+		}()
+
+		fmt.Println("Press or turn the control for each action, or press Enter to skip.")
+		bound := make(map[string]bool)
+		actions := append(controller.ActionNames(), controller.MIDIActionNames()...)
+		for _, action := range actions {
+			desc := controller.Actions[action]
+			if desc == "" {
+				desc = controller.MIDIActions[action]
+			}
+			fmt.Printf("%-14s %s: ", action, desc)
+		wait:
+			for {
+				select {
+				case ev := <-events:
+					// A control already bound in this run is still settling
+					if bound[ev.Control()] {
+						continue
+					}
+					for control, a := range bindings {
+						if a == action {
+							delete(bindings, control)
+						}
+					}
+					bindings[ev.Control()] = action
+					bound[ev.Control()] = true
+					fmt.Println(ev.Control())
+					// Let the rest of a wheel turn pass before the next prompt
+					drainMIDI(events, 500*time.Millisecond)
+					break wait
+				case _, ok := <-lines:
+					if !ok {
+						return fmt.Errorf("input closed")
+					}
+					fmt.Println("skipped")
+					break wait
+				case err := <-readErr:
+					return fmt.Errorf("failed to read device: %w", err)
+				}
+			}
+		}
+
+		cfg.MIDI = &config.MIDI{Device: args[0], Bindings: bindings}
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		infof("Saved %d binding(s) to config.json\n", len(bindings))
+		return nil
+	},
+}
+
+// drainMIDI discards events until none arrive for quiet.
+func drainMIDI(events <-chan controller.MIDIEvent, quiet time.Duration) {
+	for {
+		select {
+		case <-events:
+		case <-time.After(quiet):
+			return
+		}
+	}
+}
+
+func init() {
+	controllerCmd.AddCommand(controllerListenCmd)
+	controllerCmd.AddCommand(controllerMapCmd)
+	rootCmd.AddCommand(controllerCmd)
+}
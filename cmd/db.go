@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/season"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Maintain the database",
+	Long:  `Maintenance commands for the SQLite database.`,
+}
+
+var dbArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Move a season's videos and notes to an archive database",
+	Long: `Move a season's videos and notes (with tackles, clips, timings, periods, bookmarks, commentary and
+sessions) into a separate archive database, keeping the working database small
+and fast.
+
+A season runs from --season-start in the --season year to the day before it in
+the next year, e.g. --season 2023 covers 2023-08-01 to 2024-07-31 by default.
+Videos are placed by match date, or by the day they were first tagged when no
+match date is recorded.
+
+The archive has the full schema and can be browsed read-only:
+
+  tagging-rugby-cli --db season-2023.sqlite --read-only video list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		season, _ := cmd.Flags().GetString("season")
+		seasonStart := seasonStartFlag(cmd)
+		archivePath, _ := cmd.Flags().GetString("to")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		from, to, err := seasonRange(season, seasonStart)
+		if err != nil {
+			return err
+		}
+		archivePath, err = filepath.Abs(archivePath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve archive path: %w", err)
+		}
+		if _, err := os.Stat(archivePath); err == nil {
+			return fmt.Errorf("archive file already exists: %s", archivePath)
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoIDs, err := db.SelectSeasonVideoIDs(database, from.Format(reportDateLayout), to.Format(reportDateLayout))
+		if err != nil {
+			return fmt.Errorf("failed to query season videos: %w", err)
+		}
+		if len(videoIDs) == 0 {
+			infof("No videos found for season %s (%s to %s).\n",
+				season, from.Format(reportDateLayout), to.AddDate(0, 0, -1).Format(reportDateLayout))
+			return nil
+		}
+
+		if !yes {
+			fmt.Printf("Move %d video(s) from %s to %s into %s? [y/N] ",
+				len(videoIDs), from.Format(reportDateLayout), to.AddDate(0, 0, -1).Format(reportDateLayout), archivePath)
+			var response string
+			fmt.Scanln(&response)
+			if response != "y" && response != "Y" {
+				infoln("Archive cancelled.")
+				return nil
+			}
+		}
+
+		res, err := db.ArchiveVideos(database, videoIDs, archivePath)
+		if err != nil {
+			return fmt.Errorf("failed to archive season: %w", err)
+		}
+		infof("Archived %d video(s) and %d note(s) to %s\n", res.Videos, res.Notes, archivePath)
+		return nil
+	},
+}
+
+var dbSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the database schema as SQL or a Graphviz diagram",
+	Long: `Print the live schema of the database, for querying the SQLite file directly.
+
+--format sql (default) prints the CREATE statements of every table, index, view
+and trigger. --format dot prints an entity-relationship diagram in Graphviz
+format, one box per table with its columns and an arrow for each foreign key,
+e.g. from each note child table (note_tackles, note_details, ...) to notes:
+
+  tagging-rugby-cli db schema --format dot | dot -Tsvg > schema.svg`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "sql" && format != "dot" {
+			return usageErrorf("invalid format: %s (supported: sql, dot)", format)
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		objects, err := db.SelectSchema(database)
+		if err != nil {
+			return fmt.Errorf("failed to read schema: %w", err)
+		}
+		if format == "dot" {
+			writeSchemaDot(os.Stdout, objects)
+		} else {
+			writeSchemaSQL(os.Stdout, objects)
+		}
+		return nil
+	},
+}
+
+var dbQueryCmd = &cobra.Command{
+	Use:   "query <sql|->",
+	Short: "Run a read-only SQL query against the database",
+	Long: `Run an ad-hoc SQL query against the database and print the rows, without
+opening a separate SQLite client. Pass - to read the query from stdin.
+
+The database is opened read-only, so statements that would change it fail.
+See 'db schema' for the tables.
+
+  tagging-rugby-cli db query "SELECT player, COUNT(*) FROM note_tackles GROUP BY player"
+  tagging-rugby-cli db query --format csv "SELECT * FROM notes" > notes.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "csv" && format != "json" {
+			return usageErrorf("invalid format: %s (supported: table, csv, json)", format)
+		}
+		query := args[0]
+		if query == "-" {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("failed to read query: %w", err)
+			}
+			query = string(data)
+		}
+		if strings.TrimSpace(query) == "" {
+			return fmt.Errorf("empty query")
+		}
+
+		// Open database
+		database, err := db.OpenQueryOnly()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		res, err := db.RunQuery(database, query)
+		if err != nil {
+			if strings.Contains(err.Error(), "readonly") || strings.Contains(err.Error(), "read-only") {
+				return fmt.Errorf("query failed: %w\n'db query' only reads; change data with the other commands", err)
+			}
+			return fmt.Errorf("query failed: %w", err)
+		}
+
+		switch format {
+		case "csv":
+			return writeQueryCSV(os.Stdout, res)
+		case "json":
+			return writeQueryJSON(os.Stdout, res)
+		}
+		writeQueryTable(os.Stdout, res)
+		return nil
+	},
+}
+
+// queryValue formats a query result value as text; NULL becomes null and
+// BLOBs are base64 encoded.
+func queryValue(v any, null string) string {
+	switch v := v.(type) {
+	case nil:
+		return null
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// writeQueryTable prints the rows as an aligned table with a row count.
+func writeQueryTable(out io.Writer, res *db.QueryResult) {
+	if len(res.Columns) == 0 {
+		fmt.Fprintln(out, "No rows.")
+		return
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(res.Columns, "\t"))
+	dashes := make([]string, len(res.Columns))
+	for i, c := range res.Columns {
+		dashes[i] = strings.Repeat("-", len(c))
+	}
+	fmt.Fprintln(w, strings.Join(dashes, "\t"))
+	for _, row := range res.Rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				cells[i] = fmt.Sprintf("<blob %d bytes>", len(b))
+				continue
+			}
+			// Keep each row on one line
+			cells[i] = strings.NewReplacer("\n", " ", "\t", " ").Replace(queryValue(v, "NULL"))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	w.Flush()
+	fmt.Fprintf(out, "(%d row(s))\n", len(res.Rows))
+}
+
+// writeQueryCSV writes the rows as CSV with a header; NULL is an empty field.
+func writeQueryCSV(out io.Writer, res *db.QueryResult) error {
+	w := csv.NewWriter(out)
+	w.Write(res.Columns)
+	for _, row := range res.Rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = queryValue(v, "")
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// writeQueryJSON writes the rows as a JSON array of objects keyed by column.
+func writeQueryJSON(out io.Writer, res *db.QueryResult) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(queryObjects(res))
+}
+
+// queryObjects turns query rows into objects keyed by column, for JSON.
+// BLOBs are encoded as base64 strings.
+func queryObjects(res *db.QueryResult) []map[string]any {
+	rows := make([]map[string]any, 0, len(res.Rows))
+	for _, row := range res.Rows {
+		obj := make(map[string]any, len(row))
+		for i, v := range row {
+			obj[res.Columns[i]] = v
+		}
+		rows = append(rows, obj)
+	}
+	return rows
+}
+
+// writeSchemaSQL writes the CREATE statements of objects.
+func writeSchemaSQL(w io.Writer, objects []db.SchemaObject) {
+	for i, o := range objects {
+		if i > 0 && o.Type != objects[i-1].Type {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s;\n", strings.TrimSpace(o.SQL))
+		if o.Type == "table" {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// writeSchemaDot writes a Graphviz diagram of the tables and their foreign keys.
+func writeSchemaDot(w io.Writer, objects []db.SchemaObject) {
+	fmt.Fprintln(w, "digraph schema {")
+	fmt.Fprintln(w, "\trankdir=LR;")
+	fmt.Fprintln(w, "\tnode [shape=plaintext, fontname=\"Helvetica\"];")
+	fmt.Fprintln(w, "\tedge [arrowhead=crow, arrowtail=none];")
+	for _, o := range objects {
+		if o.Type != "table" {
+			continue
+		}
+		fmt.Fprintf(w, "\t%q [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">\n", o.Name)
+		fmt.Fprintf(w, "\t\t<tr><td bgcolor=\"lightgrey\"><b>%s</b></td></tr>\n", html.EscapeString(o.Name))
+		for _, c := range o.Columns {
+			name := html.EscapeString(c.Name)
+			if c.PrimaryKey > 0 {
+				name = "<u>" + name + "</u>"
+			}
+			fmt.Fprintf(w, "\t\t<tr><td port=%q align=\"left\">%s <font color=\"grey40\">%s</font></td></tr>\n",
+				c.Name, name, html.EscapeString(strings.ToLower(c.Type)))
+		}
+		fmt.Fprintln(w, "\t</table>>];")
+	}
+	for _, o := range objects {
+		for _, k := range o.ForeignKeys {
+			fmt.Fprintf(w, "\t%q:%q -> %q:%q;\n", k.Table, k.ToColumn, o.Name, k.From)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// seasonRange returns the [from, to) dates of a season given as "2023", "2023/24"
+// or "2023-24", starting on the 1st of the startMonth.
+func seasonRange(name string, startMonth int) (time.Time, time.Time, error) {
+	if startMonth < 1 || startMonth > 12 {
+		return time.Time{}, time.Time{}, usageErrorf("invalid --season-start: %d (want 1-12)", startMonth)
+	}
+	s, err := season.Parse(name, startMonth)
+	if err != nil {
+		return time.Time{}, time.Time{}, usageErrorf("invalid --season: %s (want a year, e.g. 2023)", name)
+	}
+	return s.From, s.To, nil
+}
+
+func init() {
+	dbArchiveCmd.Flags().String("season", "", "Season to archive (e.g. 2023 or 2023/24)")
+	dbArchiveCmd.Flags().Int("season-start", season.DefaultStartMonth, "Month the season starts (1-12)")
+	dbArchiveCmd.Flags().String("to", "", "Archive database file to create")
+	dbArchiveCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	dbArchiveCmd.MarkFlagRequired("season")
+	dbArchiveCmd.MarkFlagRequired("to")
+
+	dbSchemaCmd.Flags().String("format", "sql", "Output format (sql, dot)")
+
+	dbCmd.AddCommand(dbArchiveCmd)
+	dbQueryCmd.Flags().StringP("format", "f", "table", "Output format (table, csv, json)")
+
+	dbCmd.AddCommand(dbSchemaCmd)
+	dbCmd.AddCommand(dbQueryCmd)
+	rootCmd.AddCommand(dbCmd)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/tui"
+)
+
+// benchVideoPath is the placeholder video the synthetic events belong to.
+const benchVideoPath = "/bench/match.mp4"
+
+var debugCmd = &cobra.Command{
+	Use:    "debug",
+	Short:  "Developer tools for measuring performance",
+	Hidden: true,
+}
+
+var debugBenchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Time the TUI's list, stats and render work on synthetic events",
+	Long: `Load synthetic events into an in-memory database and time the work the TUI
+repeats while running: reloading the notes list, the stats panel query, a whole
+tick and a full screen render. Run it before and after a change to catch
+performance regressions. The real database is not touched.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		events, _ := cmd.Flags().GetInt("events")
+		iterations, _ := cmd.Flags().GetInt("iterations")
+		if events < 1 {
+			return usageErrorf("--events must be at least 1")
+		}
+
+		database, err := db.OpenMemory()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		start := time.Now()
+		if err := insertBenchEvents(database, events); err != nil {
+			return err
+		}
+		insertTime := time.Since(start)
+
+		results, err := tui.Bench(database, benchVideoPath, iterations)
+		if err != nil {
+			return fmt.Errorf("failed to run benchmark: %w", err)
+		}
+
+		fmt.Printf("%d events inserted in %s (%s each)\n\n", events, insertTime.Round(time.Millisecond), (insertTime / time.Duration(events)).Round(time.Microsecond))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Operation\tRuns\tMean\tMin\tMax")
+		fmt.Fprintln(w, "---------\t----\t----\t---\t---")
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", r.Name, r.Runs, r.Mean.Round(time.Microsecond), r.Min.Round(time.Microsecond), r.Max.Round(time.Microsecond))
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+// insertBenchEvents inserts n synthetic events spread over an 80 minute
+// match: mostly tackles across a squad of 23, the rest notes. The sequence is
+// the same on every run so timings are comparable.
+func insertBenchEvents(database *sql.DB, n int) error {
+	rng := rand.New(rand.NewSource(1))
+	outcomes := []string{"completed", "completed", "completed", "missed", "possible", "other"}
+	categories := []string{"lineout", "scrum", "ruck", "kick", "try", "penalty"}
+	for i := 0; i < n; i++ {
+		at := float64(i) * 4800 / float64(n)
+		children := db.NoteChildren{
+			Videos:  []db.NoteVideo{{Path: benchVideoPath, Format: "mp4"}},
+			Timings: []db.NoteTiming{{Start: at, End: at}},
+		}
+		category := "tackle"
+		if rng.Intn(10) < 3 {
+			category = categories[rng.Intn(len(categories))]
+			children.Details = []db.NoteDetail{{Type: "text", Note: fmt.Sprintf("Synthetic %s note %d", category, i+1)}}
+		} else {
+			children.Tackles = []db.NoteTackle{{
+				Player:  fmt.Sprintf("Player %d", rng.Intn(23)+1),
+				Attempt: 1,
+				Outcome: outcomes[rng.Intn(len(outcomes))],
+			}}
+		}
+		if rng.Intn(20) == 0 {
+			children.Highlights = []db.NoteHighlight{{Type: "star"}}
+		}
+		if _, err := db.InsertNoteWithChildren(database, category, children); err != nil {
+			return fmt.Errorf("failed to insert event: %w", err)
+		}
+	}
+	return nil
+}
+
+// startPprof serves net/http/pprof on addr for profiling a running TUI, e.g.
+// go tool pprof http://localhost:6060/debug/pprof/profile?seconds=30
+func startPprof(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to start pprof: %w", err)
+	}
+	go http.Serve(ln, mux)
+	return nil
+}
+
+func init() {
+	debugBenchCmd.Flags().Int("events", 1000, "Number of synthetic events to load")
+	debugBenchCmd.Flags().Int("iterations", 20, "Times to run each measured operation")
+
+	debugCmd.AddCommand(debugBenchCmd)
+	rootCmd.AddCommand(debugCmd)
+}
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/deps"
+	"github.com/user/tagging-rugby-cli/mpv"
+)
+
+// ffmpegCapabilities are the ffmpeg encoders and filters 'doctor' checks for,
+// with the commands that need them.
+var ffmpegCapabilities = []struct {
+	name   string
+	filter bool
+	usedBy string
+}{
+	{"libx264", false, "clip export --reencode (mp4, mkv), reel build"},
+	{"aac", false, "clip export --reencode (mp4, mkv), reel build"},
+	{"libvpx-vp9", false, "clip export --reencode -f webm"},
+	{"libopus", false, "clip export --reencode -f webm"},
+	{"drawtext", true, "tackle clip overlays, reel build --titles"},
+	{"silencedetect", true, "analyze halves"},
+	{"select", true, "analyze halves --scenes"},
+	{"showinfo", true, "analyze halves --scenes"},
+}
+
+// maxSocketPath is the longest Unix socket path every supported OS accepts
+// (macOS allows 104 bytes including the terminating NUL).
+const maxSocketPath = 103
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check system dependencies and the environment",
+	Long: `Check that all required system dependencies (mpv, ffmpeg) are installed and available,
+that ffmpeg is recent enough and has the encoders and filters the clip and analyze
+commands use. Binaries outside PATH are set in config.json:
+
+  {"binaries": {"mpv": "/opt/mpv/bin/mpv", "ffmpeg": "/opt/ffmpeg/bin/ffmpeg"}}
+
+Then check the environment: that mpv's IPC socket can be created, the database
+opens and its migrations are current, clips can be written to the current
+directory and the config directory, and the terminal shows Unicode and colours.
+Each failed check is followed by a hint on how to fix it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Checking dependencies...")
+		fmt.Println()
+
+		allGood := true
+
+		// Check mpv
+		if err := deps.CheckMpv(); err != nil {
+			fmt.Println("✗ mpv: NOT FOUND")
+			fmt.Printf("  %v\n", err)
+			allGood = false
+		} else {
+			fmt.Printf("✓ mpv: OK (%s)\n", lookPathOr(deps.Mpv()))
+		}
+
+		// Check ffmpeg, its version and the encoders and filters commands use
+		if err := deps.CheckFfmpeg(); err != nil {
+			var depErr *deps.DependencyError
+			if errors.As(err, &depErr) {
+				fmt.Println("✗ ffmpeg: NOT FOUND")
+			} else {
+				fmt.Println("✗ ffmpeg: UNUSABLE")
+			}
+			fmt.Printf("  %v\n", err)
+			allGood = false
+		} else {
+			version, _ := deps.FfmpegVersion()
+			fmt.Printf("✓ ffmpeg: OK (%s, %s)\n", version, lookPathOr(deps.Ffmpeg()))
+			complete := true
+			for _, c := range ffmpegCapabilities {
+				var missing []string
+				var err error
+				if c.filter {
+					missing, err = deps.MissingFfmpegFilters(c.name)
+				} else {
+					missing, err = deps.MissingFfmpegEncoders(c.name)
+				}
+				if err != nil {
+					fmt.Printf("  ✗ %v\n", err)
+					complete = false
+					break
+				}
+				if len(missing) > 0 {
+					fmt.Printf("  ✗ %s: missing, needed for %s\n", c.name, c.usedBy)
+					complete = false
+				}
+			}
+			if !complete {
+				allGood = false
+				fmt.Printf("  Install a full ffmpeg build from %s, or point \"binaries\".\"ffmpeg\" in config.json at one\n", deps.FfmpegInstallURL)
+			}
+		}
+
+		// ffprobe is optional: resumed exports use it to check clip lengths
+		if _, err := exec.LookPath(deps.Ffprobe()); err != nil {
+			fmt.Println("- ffprobe: not found (optional; resumed exports then only check clips aren't empty)")
+		} else {
+			fmt.Printf("✓ ffprobe: OK (%s)\n", lookPathOr(deps.Ffprobe()))
+		}
+
+		fmt.Println()
+		fmt.Println("Checking environment...")
+		fmt.Println()
+
+		readOnly, _ := cmd.Flags().GetBool("read-only")
+		checks := []func() bool{
+			checkMpvSocket,
+			func() bool { return checkDatabase(readOnly) },
+			checkExportDir,
+			checkConfigDir,
+		}
+		for _, check := range checks {
+			if !check() {
+				allGood = false
+			}
+		}
+		// Terminal shortcomings make the TUI look wrong but don't stop anything
+		checkTerminal()
+
+		fmt.Println()
+		if allGood {
+			fmt.Println("All checks passed!")
+		} else {
+			fmt.Println("Some checks failed. Follow the hints above to use all features.")
+			os.Exit(exitFailure)
+		}
+	},
+}
+
+// lookPathOr returns where bin resolves to, or bin itself when it can't.
+func lookPathOr(bin string) string {
+	if path, err := exec.LookPath(bin); err == nil {
+		return path
+	}
+	return bin
+}
+
+// checkMpvSocket checks mpv's IPC socket can be created at its path, or is
+// already served by a running mpv.
+func checkMpvSocket() bool {
+	path := mpv.SocketPath()
+	if len(path) > maxSocketPath {
+		fmt.Printf("✗ mpv socket: %s is longer than the %d bytes a Unix socket path may be\n", path, maxSocketPath)
+		fmt.Println("  Use a shorter temporary directory")
+		return false
+	}
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			fmt.Printf("✓ mpv socket: OK (%s, in use by a running mpv)\n", path)
+			return true
+		}
+		// A socket left by an mpv that exited is replaced, if we may remove it
+		if err := writable(filepath.Dir(path)); err != nil {
+			fmt.Printf("✗ mpv socket: stale %s can't be replaced: %v\n", path, err)
+			fmt.Printf("  Remove it: rm %s\n", path)
+			return false
+		}
+		fmt.Printf("✓ mpv socket: OK (%s, left by an earlier mpv and replaced on launch)\n", path)
+		return true
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		fmt.Printf("✗ mpv socket: can't create %s: %v\n", path, err)
+		fmt.Printf("  Check %s exists and is writable\n", filepath.Dir(path))
+		return false
+	}
+	l.Close()
+	fmt.Printf("✓ mpv socket: OK (%s)\n", path)
+	return true
+}
+
+// checkDatabase checks the database opens and its migrations are current,
+// without creating or migrating it.
+func checkDatabase(readOnly bool) bool {
+	path, err := db.Path()
+	if err != nil {
+		fmt.Printf("✗ database: can't locate it: %v\n", err)
+		fmt.Println("  Pass the file with --db")
+		return false
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// Open creates it, parent directories included
+		dir := filepath.Dir(path)
+		for {
+			if _, err := os.Stat(dir); err == nil || dir == filepath.Dir(dir) {
+				break
+			}
+			dir = filepath.Dir(dir)
+		}
+		if err := writable(dir); err != nil {
+			fmt.Printf("✗ database: %s doesn't exist and can't be created: %v\n", path, err)
+			fmt.Println("  Make the directory writable, or pass another file with --db")
+			return false
+		}
+		fmt.Printf("- database: %s not created yet (created on first use)\n", path)
+		return true
+	}
+
+	database, err := db.OpenReadOnly(path)
+	if err != nil {
+		fmt.Printf("✗ database: can't open %s: %v\n", path, err)
+		fmt.Println("  Check the file is a tagging-rugby-cli database and readable")
+		return false
+	}
+	defer database.Close()
+	pending, unknown, err := db.MigrationStatus(database)
+	if err != nil {
+		fmt.Printf("✗ database: can't read %s: %v\n", path, err)
+		fmt.Println("  The file may be damaged; restore it from a backup")
+		return false
+	}
+	if len(unknown) > 0 {
+		fmt.Printf("✗ database: %s was migrated by a newer tagging-rugby-cli\n", path)
+		fmt.Println("  Upgrade tagging-rugby-cli, or open it with --read-only")
+		return false
+	}
+	if !readOnly {
+		if err := writable(filepath.Dir(path)); err != nil {
+			fmt.Printf("✗ database: %s's directory isn't writable: %v\n", path, err)
+			fmt.Println("  Fix its permissions, or use --read-only to browse it")
+			return false
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			fmt.Printf("✗ database: %s isn't writable: %v\n", path, err)
+			fmt.Println("  Fix its permissions, or use --read-only to browse it")
+			return false
+		}
+		f.Close()
+	}
+	if len(pending) > 0 {
+		fmt.Printf("✓ database: OK (%s, %d migration(s) pending, applied when next opened)\n", path, len(pending))
+		return true
+	}
+	fmt.Printf("✓ database: OK (%s, migrations current)\n", path)
+	return true
+}
+
+// checkExportDir checks clips can be written to the current directory, where
+// 'clip export' puts them without --dir.
+func checkExportDir() bool {
+	dir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("✗ export directory: %v\n", err)
+		fmt.Println("  Run from an existing directory")
+		return false
+	}
+	if err := writable(dir); err != nil {
+		fmt.Printf("✗ export directory: %s isn't writable: %v\n", dir, err)
+		fmt.Println("  Run 'clip export' from a writable directory, or pass --dir")
+		return false
+	}
+	fmt.Printf("✓ export directory: OK (%s)\n", dir)
+	return true
+}
+
+// checkConfigDir checks config.json, profiles and crash dumps can be written.
+func checkConfigDir() bool {
+	dir, err := config.Dir()
+	if err != nil {
+		fmt.Printf("✗ config directory: %v\n", err)
+		fmt.Println("  Set HOME")
+		return false
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Printf("✗ config directory: can't create %s: %v\n", dir, err)
+		fmt.Printf("  Create it: mkdir -p %s\n", dir)
+		return false
+	}
+	if err := writable(dir); err != nil {
+		fmt.Printf("✗ config directory: %s isn't writable: %v\n", dir, err)
+		fmt.Printf("  Fix its permissions: chmod u+w %s\n", dir)
+		return false
+	}
+	if _, err := config.Load(); err != nil {
+		fmt.Printf("✗ config directory: %v\n", err)
+		fmt.Println("  Fix the JSON, or move config.json aside to start afresh")
+		return false
+	}
+	fmt.Printf("✓ config directory: OK (%s)\n", dir)
+	return true
+}
+
+// checkTerminal warns when the locale isn't UTF-8 or the terminal has no
+// colours, which garble the TUI's symbols and flatten its highlighting.
+func checkTerminal() {
+	locale := os.Getenv("LC_ALL")
+	if locale == "" {
+		locale = os.Getenv("LC_CTYPE")
+	}
+	if locale == "" {
+		locale = os.Getenv("LANG")
+	}
+	lower := strings.ToLower(locale)
+	if strings.Contains(lower, "utf-8") || strings.Contains(lower, "utf8") {
+		fmt.Printf("✓ unicode: OK (%s)\n", locale)
+	} else {
+		fmt.Printf("! unicode: locale %q isn't UTF-8; TUI symbols may show as ?\n", locale)
+		fmt.Println("  Set a UTF-8 locale, e.g. export LANG=en_GB.UTF-8")
+	}
+
+	switch termenv.NewOutput(os.Stdout).ColorProfile() {
+	case termenv.TrueColor:
+		fmt.Println("✓ colours: OK (true colour)")
+	case termenv.ANSI256:
+		fmt.Println("✓ colours: OK (256 colours)")
+	case termenv.ANSI:
+		fmt.Println("! colours: only 16 colours; the TUI's highlighting is approximated")
+		fmt.Println("  Set TERM=xterm-256color if your terminal supports it")
+	default:
+		fmt.Printf("! colours: none detected (TERM=%q, or output isn't a terminal)\n", os.Getenv("TERM"))
+		fmt.Println("  Run in a colour terminal, with TERM=xterm-256color")
+	}
+}
+
+// writable reports whether files can be created in dir.
+func writable(dir string) error {
+	f, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,569 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/mpv"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/tui/forms"
+	"github.com/user/tagging-rugby-cli/webhook"
+)
+
+// Valid event field types; "" is text
+var eventFieldTypes = []string{"text", "number", "player"}
+
+// reservedFieldNames are note detail types the note itself uses, so fields
+// named after them would be mistaken for the note's text, player or team.
+var reservedFieldNames = []string{"text", "notes", "player", "team"}
+
+var eventCmd = &cobra.Command{
+	Use:   "event",
+	Short: "Tag structured events such as lineouts, scrums and tries",
+	Long: `Tag events of a configurable type, each with its own fields: lineouts with the
+thrower and outcome, scrums with the feed, tries with the scorer. An event is a
+note in the category of its type with a detail per field, so it shows in the
+notes list, stats and exports like any other note.
+
+Lineout, scrum, turnover, try and penalty types come with a new database; add
+your own with 'event type add'.`,
+}
+
+var eventTypeCmd = &cobra.Command{
+	Use:   "type",
+	Short: "Manage event types",
+	Long:  `Add, list and remove the event types events are tagged with.`,
+}
+
+var eventTypeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List event types and their fields",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		types, err := db.SelectEventTypes(database)
+		if err != nil {
+			return fmt.Errorf("failed to query event types: %w", err)
+		}
+		if len(types) == 0 {
+			infoln("No event types. Use 'event type add' to add one.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Type\tDuration\tFields")
+		fmt.Fprintln(w, "----\t--------\t------")
+		for _, t := range types {
+			duration := "-"
+			if t.Duration > 0 {
+				duration = forms.FormatDuration(t.Duration) + "s"
+			}
+			specs := make([]string, len(t.Fields))
+			for i, f := range t.Fields {
+				specs[i] = fieldSpec(f)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", t.Name, duration, strings.Join(specs, ", "))
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var eventTypeAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or replace an event type",
+	Long: fmt.Sprintf(`Add an event type, or replace the fields and duration of the one with its name.
+Each --field is name[:type][=option|option...]: the type is text (the default),
+number or player, and options make the field a choice between them. Player
+fields complete from earlier entries and resolve shirt numbers like tackle
+players. Up to %d fields.
+
+  tagging-rugby-cli event type add maul --field metres:number --field outcome=held|collapsed|penalty --required outcome --duration 12
+
+The name may be a category path (e.g. set-piece/maul). --duration is how long
+an event lasts when tagged without one, so its clip covers the play.`, forms.MaxTemplateFields),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		specs, _ := cmd.Flags().GetStringArray("field")
+		required, _ := cmd.Flags().GetStringArray("required")
+		duration, _ := cmd.Flags().GetFloat64("duration")
+
+		name := catpath.Normalize(args[0])
+		if name == "" {
+			return usageErrorf("event type name must not be empty")
+		}
+		if strings.EqualFold(name, "tackle") {
+			return fmt.Errorf("tackle is built in (see 'tackle add')")
+		}
+		if duration < 0 {
+			return usageErrorf("invalid --duration: %g (want 0 or more seconds)", duration)
+		}
+		if len(specs) > forms.MaxTemplateFields {
+			return usageErrorf("too many fields: %d (at most %d)", len(specs), forms.MaxTemplateFields)
+		}
+
+		t := db.EventType{Name: name, Duration: duration}
+		seen := make(map[string]bool)
+		for _, spec := range specs {
+			f, err := parseFieldSpec(spec)
+			if err != nil {
+				return err
+			}
+			if seen[f.Name] {
+				return usageErrorf("duplicate field: %s", f.Name)
+			}
+			seen[f.Name] = true
+			t.Fields = append(t.Fields, f)
+		}
+		for _, r := range required {
+			found := false
+			for i := range t.Fields {
+				if t.Fields[i].Name == r {
+					t.Fields[i].Required = true
+					found = true
+				}
+			}
+			if !found {
+				return usageErrorf("--required %s is not a --field", r)
+			}
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		verb := "Added"
+		if existing, err := db.SelectEventType(database, name); err == nil {
+			verb = "Updated"
+			// Keep the name as first spelled
+			t.Name = existing.Name
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to look up event type: %w", err)
+		}
+		if err := db.UpsertEventType(database, t); err != nil {
+			return fmt.Errorf("failed to save event type: %w", err)
+		}
+		infof("%s event type %s with %d field(s)\n", verb, t.Name, len(t.Fields))
+		return nil
+	},
+}
+
+var eventTypeRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove an event type",
+	Long:  `Remove an event type. Events already tagged with it are kept as notes of its category.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		removed, err := db.DeleteEventType(database, catpath.Normalize(args[0]))
+		if err != nil {
+			return fmt.Errorf("failed to remove event type: %w", err)
+		}
+		if !removed {
+			return notFoundErrorf("no event type %q (see 'event type list')", args[0])
+		}
+		infof("Removed event type %s\n", args[0])
+		return nil
+	},
+}
+
+var eventAddCmd = &cobra.Command{
+	Use:   "add <type>",
+	Short: "Tag an event at the current timestamp",
+	Long: `Tag an event of the given type at the current video position, or at --time in
+--video. Give its fields with --field; required fields must be set and fields
+with options take one of them:
+
+  tagging-rugby-cli event add lineout --field thrower=Cowan --field outcome=won
+  tagging-rugby-cli event add try --video match.mp4 --time 54:10 --field scorer=11
+
+The event lasts the type's default duration unless --duration is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pairs, _ := cmd.Flags().GetStringArray("field")
+		text, _ := cmd.Flags().GetString("text")
+		videoFlag, _ := cmd.Flags().GetString("video")
+		timeFlag, _ := cmd.Flags().GetString("time")
+		duration, _ := cmd.Flags().GetFloat64("duration")
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		eventType, err := lookupEventType(database, args[0])
+		if err != nil {
+			return err
+		}
+		details, err := templateFieldDetails(eventType.Name, eventType.Fields, pairs)
+		if err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("duration") {
+			duration = eventType.Duration
+		} else if duration < 0 {
+			return usageErrorf("invalid --duration: %g (want 0 or more seconds)", duration)
+		}
+
+		videoPath, err := resolveVideoPath(videoFlag)
+		if err != nil {
+			return err
+		}
+		var timestamp float64
+		if timeFlag != "" {
+			if timestamp, err = timeutil.ParseTimeToSeconds(timeFlag); err != nil {
+				return usageErrorf("invalid --time: %w", err)
+			}
+		} else {
+			client := mpv.NewClient("")
+			if err := client.Connect(); err != nil {
+				return fmt.Errorf("failed to connect to mpv: %w\n(Is mpv running with a video open? Or pass --time)", err)
+			}
+			defer client.Close()
+			if timestamp, err = client.GetTimePos(); err != nil {
+				return fmt.Errorf("failed to get current timestamp: %w", err)
+			}
+		}
+
+		// Get video file metadata
+		var videoSize int64
+		if info, err := os.Stat(videoPath); err == nil {
+			videoSize = info.Size()
+		}
+		videoFormat := strings.TrimPrefix(filepath.Ext(videoPath), ".")
+
+		videoID, _ := db.SelectVideoIDByPath(database, videoPath)
+		if err := resolvePlayerFields(database, videoID, eventType.Fields, details); err != nil {
+			return err
+		}
+
+		children := db.NoteChildren{
+			Timings: []db.NoteTiming{
+				{Start: timestamp, End: timestamp + duration},
+			},
+			Videos: []db.NoteVideo{
+				{Path: videoPath, Size: videoSize, Format: videoFormat},
+			},
+		}
+		if text != "" {
+			children.Details = []db.NoteDetail{{Type: "text", Note: text}}
+		}
+		children.Details = append(children.Details, details...)
+
+		noteID, err := db.InsertNoteWithChildren(database, eventType.Name, children)
+		if err != nil {
+			return fmt.Errorf("failed to insert event: %w", err)
+		}
+		notifyWebhooks(webhook.NoteCreated, noteID, eventType.Name, children)
+
+		infof("Event added: %s, Note ID %d at %s\n", eventType.Name, noteID, timeutil.FormatTime(timestamp))
+		if summary := detailSummary(eventType.Fields, details); summary != "" {
+			infof("  %s\n", summary)
+		}
+		return nil
+	},
+}
+
+var eventListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the events tagged on a video",
+	Long: `List the events of every event type tagged on a video, with their fields, in
+time order. Uses the video open in mpv unless --video is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		typeFlag, _ := cmd.Flags().GetString("type")
+		videoFlag, _ := cmd.Flags().GetString("video")
+
+		videoPath, err := resolveVideoPath(videoFlag)
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		types, err := db.SelectEventTypes(database)
+		if err != nil {
+			return fmt.Errorf("failed to query event types: %w", err)
+		}
+		if typeFlag != "" {
+			t, err := lookupEventType(database, typeFlag)
+			if err != nil {
+				return err
+			}
+			types = []db.EventType{t}
+		}
+
+		notes, err := db.SelectNotesForExport(database, videoPath)
+		if err != nil {
+			return fmt.Errorf("failed to query notes: %w", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTime\tType\tFields\tText")
+		fmt.Fprintln(w, "--\t----\t----\t------\t----")
+		count := 0
+		for _, n := range notes {
+			t, ok := eventTypeOf(types, n.Category)
+			if !ok {
+				continue
+			}
+			details, err := db.SelectNoteDetailsByNote(database, n.ID)
+			if err != nil {
+				return fmt.Errorf("failed to load event %d: %w", n.ID, err)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", n.ID, timeutil.FormatTime(n.Start), n.Category, detailSummary(t.Fields, details), n.Text)
+			count++
+		}
+		w.Flush()
+
+		if count == 0 {
+			infoln("\nNo events found.")
+		} else {
+			infof("\n%d event(s) found.\n", count)
+		}
+		return nil
+	},
+}
+
+// lookupEventType returns the event type named name, with a friendly error
+// when there is none.
+func lookupEventType(database *sql.DB, name string) (db.EventType, error) {
+	t, err := db.SelectEventType(database, catpath.Normalize(name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.EventType{}, notFoundErrorf("no event type %q (see 'event type list')", name)
+	}
+	if err != nil {
+		return db.EventType{}, fmt.Errorf("failed to look up event type: %w", err)
+	}
+	return t, nil
+}
+
+// eventTypeOf returns the event type a note's category is, if any.
+func eventTypeOf(types []db.EventType, category string) (db.EventType, bool) {
+	category = catpath.Normalize(category)
+	for _, t := range types {
+		if strings.EqualFold(catpath.Normalize(t.Name), category) {
+			return t, true
+		}
+	}
+	return db.EventType{}, false
+}
+
+// noteFields returns the fields notes in category take: its note template in
+// config.json, else its event type. ok is false when it has neither.
+func noteFields(cfg *config.Config, database *sql.DB, category string) (t db.EventType, ok bool, err error) {
+	if category == "" {
+		return db.EventType{}, false, nil
+	}
+	if fields, ok := cfg.NoteTemplate(category); ok {
+		t = db.EventType{Name: category}
+		for _, f := range fields {
+			if strings.TrimSpace(f.Name) != "" {
+				t.Fields = append(t.Fields, db.EventField{Name: f.Name, Title: f.Title, Options: f.Options, Required: f.Required})
+			}
+		}
+		return t, true, nil
+	}
+	t, err = db.SelectEventType(database, catpath.Normalize(category))
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.EventType{}, false, nil
+	}
+	if err != nil {
+		return db.EventType{}, false, fmt.Errorf("failed to look up event type: %w", err)
+	}
+	return t, true, nil
+}
+
+// parseFieldSpec reads an 'event type add' field: name[:type][=option|option...].
+func parseFieldSpec(spec string) (db.EventField, error) {
+	nameType, options, hasOptions := strings.Cut(spec, "=")
+	name, typ, _ := strings.Cut(nameType, ":")
+	f := db.EventField{Name: strings.TrimSpace(name), Type: strings.ToLower(strings.TrimSpace(typ))}
+	if f.Name == "" {
+		return db.EventField{}, usageErrorf("invalid --field %q (want name[:type][=option|option...])", spec)
+	}
+	for _, r := range reservedFieldNames {
+		if strings.EqualFold(f.Name, r) {
+			return db.EventField{}, usageErrorf("invalid --field %q: %s is used by every note (reserved: %s)", spec, f.Name, strings.Join(reservedFieldNames, ", "))
+		}
+	}
+	if f.Type == "text" {
+		f.Type = ""
+	} else if f.Type != "" && !isValidFieldType(f.Type) {
+		return db.EventField{}, usageErrorf("invalid field type %q for %s (want %s)", typ, f.Name, strings.Join(eventFieldTypes, ", "))
+	}
+	if hasOptions {
+		for _, o := range strings.Split(options, "|") {
+			if o = strings.TrimSpace(o); o != "" {
+				f.Options = append(f.Options, o)
+			}
+		}
+		if len(f.Options) == 0 {
+			return db.EventField{}, usageErrorf("invalid --field %q: no options after =", spec)
+		}
+	}
+	return f, nil
+}
+
+// isValidFieldType reports whether typ is a valid event field type.
+func isValidFieldType(typ string) bool {
+	for _, t := range eventFieldTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldSpec writes a field the way 'event type add' reads it, with a * for
+// required fields.
+func fieldSpec(f db.EventField) string {
+	s := f.Name
+	if f.Type != "" {
+		s += ":" + f.Type
+	}
+	if len(f.Options) > 0 {
+		s += "=" + strings.Join(f.Options, "|")
+	}
+	if f.Required {
+		s += "*"
+	}
+	return s
+}
+
+// templateFieldDetails checks --field name=value pairs against the fields of
+// a category's note template or event type and returns them as note details,
+// in field order.
+func templateFieldDetails(category string, fields []db.EventField, pairs []string) ([]db.NoteDetail, error) {
+	values := make(map[string]string)
+	for _, pair := range pairs {
+		name, value, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(name) == "" {
+			return nil, usageErrorf("invalid --field %q (want name=value)", pair)
+		}
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	var details []db.NoteDetail
+	var names []string
+	for _, f := range fields {
+		names = append(names, f.Name)
+		value := values[f.Name]
+		delete(values, f.Name)
+		if value == "" {
+			if f.Required {
+				return nil, fmt.Errorf("%s notes need --field %s=<value>", category, f.Name)
+			}
+			continue
+		}
+		if len(f.Options) > 0 {
+			match := ""
+			for _, o := range f.Options {
+				if strings.EqualFold(o, value) {
+					match = o
+				}
+			}
+			if match == "" {
+				return nil, usageErrorf("invalid %s: %s (want one of %s)", f.Name, value, strings.Join(f.Options, ", "))
+			}
+			value = match
+		} else if f.Type == "number" {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, usageErrorf("invalid %s: %s (want a number)", f.Name, value)
+			}
+		}
+		details = append(details, db.NoteDetail{Type: f.Name, Note: value})
+	}
+	for name := range values {
+		return nil, usageErrorf("unknown field for %s: %s (fields: %s)", category, name, strings.Join(names, ", "))
+	}
+	return details, nil
+}
+
+// resolvePlayerFields resolves the values of player fields as tackle players
+// are: shirt numbers through the lineup and roster, then aliases.
+func resolvePlayerFields(database *sql.DB, videoID int64, fields []db.EventField, details []db.NoteDetail) error {
+	for i, d := range details {
+		for _, f := range fields {
+			if f.Name != d.Type || f.Type != "player" {
+				continue
+			}
+			name, err := db.ResolvePlayer(database, videoID, d.Note)
+			if err != nil {
+				return fmt.Errorf("failed to resolve %s: %w", f.Name, err)
+			}
+			details[i].Note = name
+		}
+	}
+	return nil
+}
+
+// detailSummary describes the field details of an event, e.g. "thrower:
+// Cowan, outcome: won".
+func detailSummary(fields []db.EventField, details []db.NoteDetail) string {
+	var parts []string
+	for _, f := range fields {
+		for _, d := range details {
+			if d.Type == f.Name {
+				parts = append(parts, f.Name+": "+d.Note)
+				break
+			}
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	eventTypeAddCmd.Flags().StringArray("field", nil, "Field as name[:text|number|player][=option|option...] (repeatable)")
+	eventTypeAddCmd.Flags().StringArray("required", nil, "Name of a field that must be filled in (repeatable)")
+	eventTypeAddCmd.Flags().Float64("duration", 0, "Seconds an event lasts when tagged without a duration")
+
+	eventAddCmd.Flags().StringArray("field", nil, "Field value as name=value (repeatable)")
+	eventAddCmd.Flags().StringP("text", "x", "", "Note text")
+	eventAddCmd.Flags().String("video", "", "Video path (defaults to the video open in mpv)")
+	eventAddCmd.Flags().String("time", "", "Timestamp (MM:SS or seconds, defaults to the mpv position)")
+	eventAddCmd.Flags().Float64("duration", 0, "Seconds the event lasts (default: the type's duration)")
+
+	eventListCmd.Flags().String("type", "", "Only list events of this type")
+	eventListCmd.Flags().String("video", "", "Video path (defaults to the video open in mpv)")
+
+	eventTypeCmd.AddCommand(eventTypeListCmd)
+	eventTypeCmd.AddCommand(eventTypeAddCmd)
+	eventTypeCmd.AddCommand(eventTypeRemoveCmd)
+	eventCmd.AddCommand(eventTypeCmd)
+	eventCmd.AddCommand(eventAddCmd)
+	eventCmd.AddCommand(eventListCmd)
+	rootCmd.AddCommand(eventCmd)
+}
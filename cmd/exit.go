@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/deps"
+	"github.com/user/tagging-rugby-cli/mpv"
+)
+
+// Exit codes, for scripts to tell failures apart. They are part of the CLI's
+// interface: don't renumber them.
+const (
+	exitOK = 0
+	// exitFailure is any failure without a more specific code
+	exitFailure = 1
+	// exitUsage is an unknown command, a bad flag or argument, or an invalid value
+	exitUsage = 2
+	// exitNotFound is a video, note, match or file that doesn't exist
+	exitNotFound = 3
+	// exitDependency is mpv, ffmpeg or ffprobe missing or unusable
+	exitDependency = 4
+	// exitIPC is mpv not running or not answering over its IPC socket
+	exitIPC = 5
+)
+
+// exitError gives an error its exit code.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// usageErrorf returns an error exiting with exitUsage, for values a command
+// rejects.
+func usageErrorf(format string, a ...any) error {
+	return &exitError{code: exitUsage, err: fmt.Errorf(format, a...)}
+}
+
+// notFoundErrorf returns an error exiting with exitNotFound.
+func notFoundErrorf(format string, a ...any) error {
+	return &exitError{code: exitNotFound, err: fmt.Errorf(format, a...)}
+}
+
+// runError marks an error returned by a command's RunE, as opposed to one
+// cobra raised before running it.
+type runError struct{ err error }
+
+func (e *runError) Error() string { return e.err.Error() }
+func (e *runError) Unwrap() error { return e.err }
+
+// markRunErrors wraps the RunE of c and its subcommands so exitCode can tell
+// their errors from cobra's own, which are all usage errors.
+func markRunErrors(c *cobra.Command) {
+	if run := c.RunE; run != nil {
+		c.RunE = func(cmd *cobra.Command, args []string) error {
+			if err := run(cmd, args); err != nil {
+				return &runError{err: err}
+			}
+			return nil
+		}
+	}
+	for _, sub := range c.Commands() {
+		markRunErrors(sub)
+	}
+}
+
+// isCobraError reports whether err is cobra's own, raised before running a
+// command, rather than one a command returned.
+func isCobraError(err error) bool {
+	var coded *exitError
+	var run *runError
+	return !errors.As(err, &coded) && !errors.As(err, &run)
+}
+
+// exitCode returns the exit code for an error from rootCmd.Execute: an
+// explicit code, else one worked out from the errors it wraps.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var coded *exitError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	if isCobraError(err) {
+		return exitUsage
+	}
+	var dep *deps.DependencyError
+	switch {
+	case errors.As(err, &dep), errors.Is(err, exec.ErrNotFound):
+		return exitDependency
+	case errors.Is(err, mpv.ErrSocketNotFound), errors.Is(err, mpv.ErrNotConnected):
+		return exitIPC
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, fs.ErrNotExist):
+		return exitNotFound
+	}
+	return exitFailure
+}
@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+	"github.com/user/tagging-rugby-cli/report"
+)
+
+// snapshotPrefix starts the name of every snapshot directory; the rest is the
+// time it was taken, so names sort oldest first.
+const (
+	snapshotPrefix     = "snapshot-"
+	snapshotTimeLayout = "2006-01-02-150405"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export data",
+	Long:  `Commands that export the whole database, or one video's notes.`,
+}
+
+var exportSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Write a dated JSON and CSV export of all data",
+	Long: `Write every table of the database to a dated snapshot directory in --dir, as
+one <table>.csv per table plus a snapshot.json holding them all, then delete the
+oldest snapshots beyond --keep. The database is only read.
+
+Made for a scheduler, e.g. a daily cron job on a shared club laptop:
+
+  0 18 * * * tagging-rugby-cli export snapshot --dir ~/rugby-backups --keep 30
+
+A snapshot is written to a temporary directory and renamed once complete, so an
+interrupted run never leaves a partial snapshot behind.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		keep, _ := cmd.Flags().GetInt("keep")
+		if keep < 0 {
+			return usageErrorf("invalid --keep: %d (want 0 to keep all, or more)", keep)
+		}
+
+		// Open database
+		database, err := db.OpenQueryOnly()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		objects, err := db.SelectSchema(database)
+		if err != nil {
+			return fmt.Errorf("failed to read schema: %w", err)
+		}
+
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create snapshot directory: %w", err)
+		}
+		now := time.Now()
+		name := snapshotPrefix + now.Format(snapshotTimeLayout)
+		final := filepath.Join(dir, name)
+		if _, err := os.Stat(final); err == nil {
+			return fmt.Errorf("snapshot already exists: %s", final)
+		}
+		tmp, err := os.MkdirTemp(dir, "."+name+"-*")
+		if err != nil {
+			return fmt.Errorf("failed to create snapshot directory: %w", err)
+		}
+		defer os.RemoveAll(tmp)
+
+		snapshot := struct {
+			CreatedAt time.Time                   `json:"created_at"`
+			Version   string                      `json:"version"`
+			Tables    map[string][]map[string]any `json:"tables"`
+		}{CreatedAt: now, Version: Version, Tables: make(map[string][]map[string]any)}
+		rows := 0
+		for _, o := range objects {
+			if o.Type != "table" {
+				continue
+			}
+			res, err := db.SelectTableRows(database, o.Name)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", o.Name, err)
+			}
+			f, err := os.Create(filepath.Join(tmp, o.Name+".csv"))
+			if err != nil {
+				return fmt.Errorf("failed to write %s.csv: %w", o.Name, err)
+			}
+			err = writeQueryCSV(f, res)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return fmt.Errorf("failed to write %s.csv: %w", o.Name, err)
+			}
+			snapshot.Tables[o.Name] = queryObjects(res)
+			rows += len(res.Rows)
+		}
+		data, err := json.MarshalIndent(snapshot, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode snapshot: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(tmp, "snapshot.json"), append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write snapshot.json: %w", err)
+		}
+		if err := os.Rename(tmp, final); err != nil {
+			return fmt.Errorf("failed to save snapshot: %w", err)
+		}
+		infof("Wrote snapshot of %d table(s), %d row(s) to %s\n", len(snapshot.Tables), rows, final)
+
+		pruned, err := pruneSnapshots(dir, keep)
+		if err != nil {
+			return fmt.Errorf("failed to prune old snapshots: %w", err)
+		}
+		for _, p := range pruned {
+			infof("Removed old snapshot %s\n", p)
+		}
+		return nil
+	},
+}
+
+var exportJSONCmd = &cobra.Command{
+	Use:   "json <video>",
+	Short: "Export a video's notes as a JSON document",
+	Long: `Export every note for a video with its timings, tackles, zones, details,
+highlights and clips as one JSON document, for 'import json' to load into
+another database.
+
+The document records the video's path, filesize and duration, so the notes can
+be re-attached to the same file on another machine even at a different path.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputPath, _ := cmd.Flags().GetString("output")
+
+		videoPath, err := resolveVideoPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.OpenQueryOnly()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		doc, err := db.ExportVideoNotes(database, videoPath)
+		if errors.Is(err, sql.ErrNoRows) {
+			return notFoundErrorf("video not found: %s", videoPath)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export notes: %w", err)
+		}
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode notes: %w", err)
+		}
+		data = append(data, '\n')
+
+		if outputPath == "" {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(outputPath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		infof("Exported %d note(s) for %s to %s\n", len(doc.Notes), videosrc.Base(videoPath), outputPath)
+		return nil
+	},
+}
+
+var exportSportscodeCmd = &cobra.Command{
+	Use:   "sportscode <video>",
+	Short: "Export a video's notes as a Sportscode XML timeline",
+	Long: `Export a video's notes as a Sportscode XML timeline, to open them in Hudl
+Sportscode or Nacsport alongside the footage.
+
+Each note becomes an instance coded by its category, from its start to its end
+(5 seconds when it has none). Its text is the instance's free text, and the
+tackle player, outcome, attempt, height and technique, the zone, the team, the
+star and any event type fields become labels. Notes without a time are left out.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputPath, _ := cmd.Flags().GetString("output")
+
+		videoPath, err := resolveVideoPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.OpenQueryOnly()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		doc, err := db.ExportVideoNotes(database, videoPath)
+		if errors.Is(err, sql.ErrNoRows) {
+			return notFoundErrorf("video not found: %s", videoPath)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export notes: %w", err)
+		}
+
+		out := os.Stdout
+		if outputPath != "" {
+			f, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+		written, err := report.WriteSportscodeXML(out, doc)
+		if err != nil {
+			return fmt.Errorf("failed to write timeline: %w", err)
+		}
+		// Only with -o, so stdout stays valid XML
+		if outputPath != "" {
+			infof("Exported %d instance(s) for %s to %s\n", written, videosrc.Base(videoPath), outputPath)
+			if untimed := len(doc.Notes) - written; untimed > 0 {
+				infof("%d note(s) without a time left out.\n", untimed)
+			}
+		}
+		return nil
+	},
+}
+
+// pruneSnapshots deletes all but the newest keep snapshots in dir and returns
+// the ones removed. keep 0 removes nothing.
+func pruneSnapshots(dir string, keep int) ([]string, error) {
+	if keep == 0 {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []string
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), snapshotPrefix) {
+			continue
+		}
+		// Only touch directories this command named
+		if _, err := time.Parse(snapshotTimeLayout, strings.TrimPrefix(e.Name(), snapshotPrefix)); err != nil {
+			continue
+		}
+		snapshots = append(snapshots, e.Name())
+	}
+	if len(snapshots) <= keep {
+		return nil, nil
+	}
+	sort.Strings(snapshots)
+	var removed []string
+	for _, name := range snapshots[:len(snapshots)-keep] {
+		path := filepath.Join(dir, name)
+		if err := os.RemoveAll(path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+func init() {
+	exportSnapshotCmd.Flags().String("dir", "", "Directory to write snapshots into")
+	exportSnapshotCmd.Flags().Int("keep", 14, "Number of snapshots to keep, oldest removed first (0 keeps all)")
+	exportSnapshotCmd.MarkFlagRequired("dir")
+
+	exportJSONCmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+	exportSportscodeCmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+
+	exportCmd.AddCommand(exportSnapshotCmd)
+	exportCmd.AddCommand(exportJSONCmd)
+	exportCmd.AddCommand(exportSportscodeCmd)
+	rootCmd.AddCommand(exportCmd)
+}
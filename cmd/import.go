@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/importer"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import events from other tools",
+	Long: `Import event markers exported by cloud tagging platforms as notes for a video,
+or a video's notes exported from another database with 'export json'.`,
+}
+
+var importVeoCmd = &cobra.Command{
+	Use:   "veo <export-file>",
+	Short: "Import Veo highlight markers (JSON or CSV)",
+	Long: `Import event markers from a Veo highlight export (JSON or CSV) as notes for a video.
+Each marker becomes a note whose category is the Veo tag (lowercased) unless --category is given.
+Markers already imported at the same timestamp are skipped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(cmd, importer.Veo, args[0])
+	},
+}
+
+var importHudlCmd = &cobra.Command{
+	Use:   "hudl <export-file>",
+	Short: "Import Hudl clip markers (JSON or CSV)",
+	Long: `Import clip markers from a Hudl export (JSON or CSV) as notes for a video.
+Each marker becomes a note whose category is the Hudl code/row (lowercased) unless --category is given.
+Markers already imported at the same timestamp are skipped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(cmd, importer.Hudl, args[0])
+	},
+}
+
+var importJSONCmd = &cobra.Command{
+	Use:   "json <file>",
+	Short: "Import a video's notes exported with 'export json'",
+	Long: `Import the notes in a document written by 'export json', with their timings,
+tackles, zones, details, highlights and clips.
+
+The notes are attached to the video registered at the exported path, else to
+the one with the same duration and filesize (the same file moved or copied to
+another machine), else to a new video at the exported path. --video attaches
+them to the given video instead.
+
+Notes keep the time they were created. Notes already present with the same
+category and start time (or creation time, for notes without timing) are
+skipped, so importing a document twice adds nothing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoFlag, _ := cmd.Flags().GetString("video")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read export file: %w", err)
+		}
+		var doc db.VideoTransfer
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return usageErrorf("failed to parse export file: %v", err)
+		}
+		if doc.Format > db.TransferFormat {
+			return usageErrorf("export file format %d is newer than this version supports (%d): upgrade tagging-rugby-cli", doc.Format, db.TransferFormat)
+		}
+		if doc.Video.Path == "" && videoFlag == "" {
+			return usageErrorf("export file has no video path: pass --video")
+		}
+		if len(doc.Notes) == 0 {
+			infoln("No notes found in export.")
+			return nil
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		video, how, err := resolveImportVideo(database, doc.Video, videoFlag)
+		if err != nil {
+			return err
+		}
+		infof("Importing into %s (%s)\n", video.Path, how)
+
+		imported, skipped := 0, 0
+		// Notes without timing this import added, which mustn't count as
+		// present when the document holds two alike
+		added := make(map[string]bool)
+		for _, n := range doc.Notes {
+			var exists bool
+			key := n.Category + "\x00" + n.CreatedAt.String()
+			switch {
+			case len(n.Timings) > 0:
+				exists, err = db.NoteExistsAt(database, video.Path, n.Category, n.Start())
+			case !added[key]:
+				exists, err = db.NoteExistsCreated(database, video.Path, n.Category, n.CreatedAt)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to check existing notes: %w", err)
+			}
+			if exists {
+				skipped++
+				continue
+			}
+			if len(n.Timings) == 0 {
+				added[key] = true
+			}
+			if dryRun {
+				fmt.Printf("%s  [%s] %s\n", timeutil.FormatTime(n.Start()), n.Category, transferNoteText(n))
+				imported++
+				continue
+			}
+			noteID, err := db.InsertNoteWithChildren(database, n.Category, n.Children(video))
+			if err != nil {
+				return fmt.Errorf("failed to insert note: %w", err)
+			}
+			if !n.CreatedAt.IsZero() {
+				if err := db.SetNoteCreatedAt(database, noteID, n.CreatedAt); err != nil {
+					return fmt.Errorf("failed to insert note: %w", err)
+				}
+			}
+			imported++
+		}
+
+		if dryRun {
+			infof("\n%d note(s) would be imported, %d already present.\n", imported, skipped)
+			return nil
+		}
+		// Keep the duration so the video can be matched by fingerprint again
+		if imported > 0 && video.Duration > 0 {
+			if videoID, err := db.SelectVideoIDByPath(database, video.Path); err == nil {
+				if _, err := db.EnsureVideoTiming(database, videoID, video.Duration); err != nil {
+					return fmt.Errorf("failed to save video duration: %w", err)
+				}
+			}
+		}
+		infof("Imported %d note(s) into %s (%d already present).\n", imported, videosrc.Base(video.Path), skipped)
+		return nil
+	},
+}
+
+// resolveImportVideo picks the video exported notes are imported into: the
+// --video flag, else the video at the exported path, else one matching its
+// fingerprint, else a new video at the exported path. The second result says
+// which, for the user.
+func resolveImportVideo(database *sql.DB, v db.TransferVideo, videoFlag string) (db.NoteVideo, string, error) {
+	target := db.NoteVideo{Path: v.Path, Size: v.Filesize, Duration: v.Duration, Format: v.Format}
+	if videoFlag != "" {
+		path, err := resolveVideoPath(videoFlag)
+		if err != nil {
+			return target, "", err
+		}
+		target.Path = path
+		target.Format = videosrc.Ext(path)
+		if info, err := os.Stat(path); err == nil {
+			target.Size = info.Size()
+		}
+		return target, "--video", nil
+	}
+	if _, err := db.SelectVideoFile(database, v.Path); err == nil {
+		return target, "same path", nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return target, "", fmt.Errorf("failed to look up video: %w", err)
+	}
+	if fp := db.VideoFingerprint(v.Duration, v.Filesize); fp != "" {
+		found, err := db.SelectVideoByFingerprint(database, fp, v.Filesize)
+		if err == nil {
+			target.Path = found.Path
+			target.Format = found.Format
+			return target, "matched by duration and filesize", nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return target, "", fmt.Errorf("failed to look up video: %w", err)
+		}
+	}
+	return target, "new video", nil
+}
+
+// transferNoteText is the first text detail of an exported note, for the
+// --dry-run listing.
+func transferNoteText(n db.TransferNote) string {
+	for _, d := range n.Details {
+		if d.Type == "text" || d.Type == "notes" {
+			return d.Note
+		}
+	}
+	return ""
+}
+
+// runImport parses the export file with src and inserts one note per marker.
+func runImport(cmd *cobra.Command, src importer.Source, exportPath string) error {
+	videoFlag, _ := cmd.Flags().GetString("video")
+	categoryFlag, _ := cmd.Flags().GetString("category")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	videoPath, err := resolveVideoPath(videoFlag)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(exportPath)
+	if err != nil {
+		return fmt.Errorf("failed to open export file: %w", err)
+	}
+	defer f.Close()
+
+	markers, err := importer.Parse(src, f)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s export: %w", src.Name, err)
+	}
+	if len(markers) == 0 {
+		infoln("No markers found in export.")
+		return nil
+	}
+
+	// Open database
+	database, err := db.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer database.Close()
+
+	var videoSize int64
+	if info, err := os.Stat(videoPath); err == nil {
+		videoSize = info.Size()
+	}
+
+	imported, skipped := 0, 0
+	for _, mk := range markers {
+		category := categoryFlag
+		if category == "" {
+			category = strings.ToLower(strings.TrimSpace(mk.Label))
+		}
+		if category == "" {
+			category = "note"
+		}
+
+		exists, err := db.NoteExistsAt(database, videoPath, category, mk.Start)
+		if err != nil {
+			return fmt.Errorf("failed to check existing notes: %w", err)
+		}
+		if exists {
+			skipped++
+			continue
+		}
+
+		text := mk.Label
+		if mk.Note != "" {
+			if text != "" {
+				text += ": "
+			}
+			text += mk.Note
+		}
+
+		if dryRun {
+			fmt.Printf("%s  [%s] %s\n", timeutil.FormatTime(mk.Start), category, text)
+			imported++
+			continue
+		}
+
+		children := db.NoteChildren{
+			Timings: []db.NoteTiming{
+				{Start: mk.Start, End: mk.End},
+			},
+			Videos: []db.NoteVideo{
+				{Path: videoPath, Size: videoSize, Format: videosrc.Ext(videoPath)},
+			},
+		}
+		if text != "" {
+			children.Details = append(children.Details, db.NoteDetail{Type: "text", Note: text})
+		}
+		if mk.Player != "" {
+			children.Details = append(children.Details, db.NoteDetail{Type: "player", Note: mk.Player})
+		}
+		if mk.Team != "" {
+			children.Details = append(children.Details, db.NoteDetail{Type: "team", Note: mk.Team})
+		}
+		children.Details = append(children.Details, db.NoteDetail{Type: "source", Note: src.Name})
+
+		if _, err := db.InsertNoteWithChildren(database, category, children); err != nil {
+			return fmt.Errorf("failed to insert note: %w", err)
+		}
+		imported++
+	}
+
+	if dryRun {
+		infof("\n%d marker(s) would be imported, %d already present.\n", imported, skipped)
+		return nil
+	}
+	infof("Imported %d marker(s) from %s into %s (%d already present).\n", imported, src.Name, videosrc.Base(videoPath), skipped)
+	return nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{importVeoCmd, importHudlCmd} {
+		c.Flags().String("video", "", "Video file path or URL (defaults to the video open in mpv)")
+		c.Flags().StringP("category", "c", "", "Category for all imported notes (defaults to the marker label)")
+		c.Flags().Bool("dry-run", false, "Print the markers that would be imported without saving")
+		importCmd.AddCommand(c)
+	}
+	importJSONCmd.Flags().String("video", "", "Video file path or URL to attach the notes to (defaults to the exported video)")
+	importJSONCmd.Flags().Bool("dry-run", false, "Print the notes that would be imported without saving")
+	importCmd.AddCommand(importJSONCmd)
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+	"github.com/user/tagging-rugby-cli/tui/forms"
+)
+
+var videoLineupCmd = &cobra.Command{
+	Use:   "lineup",
+	Short: "Manage a video's lineup of shirt numbers",
+	Long: `Map shirt numbers to players for a registered video. While tagging, players
+can then be entered by number wherever a player is asked for (the tackle form,
+'tackle add -p 7', F-key hotkeys, imports): the number is saved as the name on
+the lineup. Other numbers are saved as the roster player with that squad number
+(see 'player import'), or as entered.
+
+Shirts 1-15 are the starting XV and higher numbers the bench. 'video lineup
+edit' fills in the whole squad sheet at once.`,
+}
+
+var videoLineupEditCmd = &cobra.Command{
+	Use:   "edit <video>",
+	Short: "Edit a video's lineup in a form",
+	Long: `Open a form with the starting XV (1-15) and bench (16-23), filled in with the
+current lineup. Clearing a name takes that shirt off the lineup. Shirts above
+23 set with 'video lineup set' are kept.
+
+A video without a lineup starts from the roster's squad numbers (see 'player
+import'), or one team's with --team.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoPath, err := resolveVideoPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := lookupVideoID(database, videoPath)
+		if err != nil {
+			return err
+		}
+		lineup, err := db.SelectLineup(database, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to query lineup: %w", err)
+		}
+
+		var result forms.LineupFormResult
+		var kept []db.LineupPlayer
+		for _, p := range lineup {
+			if p.Number <= forms.LineupSize {
+				result.Players[p.Number-1] = p.Player
+			} else {
+				kept = append(kept, p)
+			}
+		}
+		if len(lineup) == 0 {
+			team, _ := cmd.Flags().GetString("team")
+			if err := fillLineupFromRoster(database, team, &result); err != nil {
+				return err
+			}
+		}
+		// Offer the names entered before, so each match's lineup spells them the same
+		players, err := db.SelectPlayerNames(database, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to query players: %w", err)
+		}
+		if err := forms.NewLineupForm(videosrc.Base(videoPath), &result, players).Run(); err != nil {
+			if errors.Is(err, huh.ErrUserAborted) {
+				infoln("Cancelled.")
+				return nil
+			}
+			return fmt.Errorf("failed to read lineup: %w", err)
+		}
+
+		var edited []db.LineupPlayer
+		for i, name := range result.Players {
+			if name = strings.TrimSpace(name); name != "" {
+				edited = append(edited, db.LineupPlayer{Number: i + 1, Player: name})
+			}
+		}
+		if err := db.ReplaceLineup(database, videoID, append(edited, kept...)); err != nil {
+			return fmt.Errorf("failed to save lineup: %w", err)
+		}
+		infof("Saved lineup for %s: %d player(s)\n", videosrc.Base(videoPath), len(edited)+len(kept))
+		return nil
+	},
+}
+
+// fillLineupFromRoster fills the lineup form's shirts with the roster players
+// wearing those squad numbers. Numbers worn by more than one player are left
+// empty.
+func fillLineupFromRoster(database *sql.DB, team string, result *forms.LineupFormResult) error {
+	roster, err := db.SelectRoster(database, team)
+	if err != nil {
+		return fmt.Errorf("failed to query roster: %w", err)
+	}
+	worn := make(map[int]int)
+	for _, p := range roster {
+		worn[p.Number]++
+	}
+	for _, p := range roster {
+		if p.Number >= 1 && p.Number <= forms.LineupSize && worn[p.Number] == 1 {
+			result.Players[p.Number-1] = p.Name
+		}
+	}
+	return nil
+}
+
+var videoLineupSetCmd = &cobra.Command{
+	Use:   "set <video> <number> <player>",
+	Short: "Put a player on a video's lineup",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		number, ok := db.ShirtNumber(args[1])
+		if !ok {
+			return usageErrorf("invalid shirt number: %s (want 1-99)", args[1])
+		}
+		player := strings.TrimSpace(args[2])
+		if player == "" {
+			return usageErrorf("player name must not be empty")
+		}
+		if _, isNumber := db.ShirtNumber(player); isNumber {
+			return usageErrorf("player name must not be a number: %s", player)
+		}
+		videoPath, err := resolveVideoPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := lookupVideoID(database, videoPath)
+		if err != nil {
+			return err
+		}
+		if err := db.SetLineupPlayer(database, videoID, number, player); err != nil {
+			return fmt.Errorf("failed to update lineup: %w", err)
+		}
+		infof("#%d is %s on %s\n", number, player, videosrc.Base(videoPath))
+		return nil
+	},
+}
+
+var videoLineupListCmd = &cobra.Command{
+	Use:   "list <video>",
+	Short: "List a video's lineup",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoPath, err := resolveVideoPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := lookupVideoID(database, videoPath)
+		if err != nil {
+			return err
+		}
+		lineup, err := db.SelectLineup(database, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to query lineup: %w", err)
+		}
+		if len(lineup) == 0 {
+			infoln("No lineup set.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "No.\tPlayer\tRole")
+		fmt.Fprintln(w, "---\t------\t----")
+		for _, p := range lineup {
+			role := "bench"
+			if p.Starting() {
+				role = "starting"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", p.Number, p.Player, role)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var videoLineupRemoveCmd = &cobra.Command{
+	Use:   "remove <video> <number>",
+	Short: "Take a shirt number off a video's lineup",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		number, ok := db.ShirtNumber(args[1])
+		if !ok {
+			return usageErrorf("invalid shirt number: %s (want 1-99)", args[1])
+		}
+		videoPath, err := resolveVideoPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := lookupVideoID(database, videoPath)
+		if err != nil {
+			return err
+		}
+		removed, err := db.DeleteLineupPlayer(database, videoID, number)
+		if err != nil {
+			return fmt.Errorf("failed to update lineup: %w", err)
+		}
+		if !removed {
+			return fmt.Errorf("#%d is not on the lineup of %s", number, videosrc.Base(videoPath))
+		}
+		infof("Removed #%d from the lineup\n", number)
+		return nil
+	},
+}
+
+var videoLineupClearCmd = &cobra.Command{
+	Use:   "clear <video>",
+	Short: "Remove a video's whole lineup",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoPath, err := resolveVideoPath(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := lookupVideoID(database, videoPath)
+		if err != nil {
+			return err
+		}
+		if err := db.ClearLineup(database, videoID); err != nil {
+			return fmt.Errorf("failed to clear lineup: %w", err)
+		}
+		infof("Cleared the lineup of %s\n", videosrc.Base(videoPath))
+		return nil
+	},
+}
+
+func init() {
+	videoLineupEditCmd.Flags().String("team", "", "Start an empty lineup from this roster team")
+
+	videoLineupCmd.AddCommand(videoLineupEditCmd)
+	videoLineupCmd.AddCommand(videoLineupSetCmd)
+	videoLineupCmd.AddCommand(videoLineupListCmd)
+	videoLineupCmd.AddCommand(videoLineupRemoveCmd)
+	videoLineupCmd.AddCommand(videoLineupClearCmd)
+	videoCmd.AddCommand(videoLineupCmd)
+}
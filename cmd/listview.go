@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// listView is how 'note list' and 'tackle list' print their tables: a page of
+// the matching rows with --limit and --offset, through a pager when the table
+// is longer than the terminal, or redrawn as events arrive with --watch.
+type listView struct {
+	limit  int
+	offset int
+	// watch is the time between redraws with --watch, 0 without it
+	watch   time.Duration
+	noPager bool
+}
+
+// addListViewFlags adds the flags listViewFlags reads to a list command.
+func addListViewFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("limit", 0, "Show at most this many rows (default: all)")
+	cmd.Flags().Int("offset", 0, "Skip this many matching rows first")
+	cmd.Flags().BoolP("watch", "w", false, "Redraw the table as events are tagged, until Ctrl+C")
+	cmd.Flags().Duration("interval", 2*time.Second, "Time between redraws with --watch")
+	cmd.Flags().Bool("no-pager", false, "Print long tables directly instead of through $PAGER")
+}
+
+// listViewFlags reads and checks the flags of addListViewFlags.
+func listViewFlags(cmd *cobra.Command) (listView, error) {
+	var v listView
+	v.limit, _ = cmd.Flags().GetInt("limit")
+	v.offset, _ = cmd.Flags().GetInt("offset")
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	v.noPager, _ = cmd.Flags().GetBool("no-pager")
+
+	if v.limit < 0 {
+		return v, usageErrorf("invalid --limit: %d (can't be negative)", v.limit)
+	}
+	if v.offset < 0 {
+		return v, usageErrorf("invalid --offset: %d (can't be negative)", v.offset)
+	}
+	if cmd.Flags().Changed("interval") && !watch {
+		return v, usageErrorf("--interval sets how often --watch redraws; add --watch")
+	}
+	if watch {
+		if interval < 100*time.Millisecond {
+			return v, usageErrorf("invalid --interval: %s (want at least 100ms)", interval)
+		}
+		v.watch = interval
+	}
+	return v, nil
+}
+
+// shows reports whether the i-th matching row (from 0) is on the page.
+func (v listView) shows(i int) bool {
+	return i >= v.offset && (v.limit == 0 || i < v.offset+v.limit)
+}
+
+// footer writes the count line under the table: the rows found, and which of
+// them are shown when the page leaves some out. noun is the plural, e.g.
+// "note(s)".
+func (v listView) footer(w io.Writer, count int, noun string) {
+	if quiet {
+		return
+	}
+	last := count
+	if v.limit > 0 && v.offset+v.limit < count {
+		last = v.offset + v.limit
+	}
+	if v.offset == 0 && last == count {
+		fmt.Fprintf(w, "\n%d %s found.\n", count, noun)
+		return
+	}
+	if v.offset >= count {
+		fmt.Fprintf(w, "\n%d %s found, none after --offset %d.\n", count, noun, v.offset)
+		return
+	}
+	fmt.Fprintf(w, "\nShowing %d-%d of %d %s.\n", v.offset+1, last, count, noun)
+}
+
+// run prints the table render writes: once, through the pager when stdout is
+// a terminal, or with --watch again every interval until Ctrl+C, redrawing
+// the screen when the table changed. title names the command in the watch
+// header.
+func (v listView) run(title string, render func(w io.Writer) error) error {
+	if v.watch == 0 {
+		var buf bytes.Buffer
+		if err := render(&buf); err != nil {
+			return err
+		}
+		return v.page(buf.Bytes())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ticker := time.NewTicker(v.watch)
+	defer ticker.Stop()
+
+	var last []byte
+	for {
+		var buf bytes.Buffer
+		if err := render(&buf); err != nil {
+			return err
+		}
+		if !bytes.Equal(buf.Bytes(), last) {
+			last = buf.Bytes()
+			// Home the cursor and clear the screen, as watch(1) does
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("Every %s: %s, updated %s (Ctrl+C to stop)\n\n", v.watch, title, time.Now().Format("15:04:05"))
+			os.Stdout.Write(last)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// page writes out to stdout, through $PAGER (less by default) when stdout is
+// a terminal. less is started with -FRX unless $LESS is set, so tables that
+// fit on the screen are printed as they are. Without a pager out is printed
+// directly.
+func (v listView) page(out []byte) error {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if v.noPager || !stdoutIsTerminal() {
+		pager = nil
+	} else if len(pager) == 0 {
+		pager = []string{"less"}
+	}
+	if len(pager) > 0 {
+		p := exec.Command(pager[0], pager[1:]...)
+		p.Stdin = bytes.NewReader(out)
+		p.Stdout = os.Stdout
+		p.Stderr = os.Stderr
+		if os.Getenv("LESS") == "" {
+			p.Env = append(os.Environ(), "LESS=FRX")
+		}
+		// Ctrl+C is for the pager, which stops on it rather than quitting
+		signal.Ignore(os.Interrupt)
+		err := p.Run()
+		signal.Reset(os.Interrupt)
+		// A pager that isn't installed falls back to printing directly
+		if err == nil {
+			return nil
+		} else if _, notFound := err.(*exec.Error); !notFound {
+			return fmt.Errorf("failed to run pager %s: %w", pager[0], err)
+		}
+	}
+	_, err := os.Stdout.Write(out)
+	return err
+}
+
+// stdoutIsTerminal reports whether stdout is a terminal rather than a file or
+// pipe.
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
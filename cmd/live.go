@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/mpv"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/tui"
+)
+
+// livePathPrefix marks the placeholder videos that hold live-match events.
+const livePathPrefix = "live:"
+
+var liveCmd = &cobra.Command{
+	Use:   "live <match-name>",
+	Short: "Tag a match in real time at the ground, without video",
+	Long: `Tag a match in real time in the TUI with no video. The timeline runs on the
+wall clock from the moment the live match is first opened, so every event is
+stamped with the time of day it was tagged. Reopening the same match name
+resumes it, with the clock still in step.
+
+Pausing or seeking moves the clock like a normal video, which is handy for
+back-dating an event seen a few seconds ago, but leaves later tags out of step
+with the wall clock until the match is reopened.
+
+When the footage arrives, convert the events to video timestamps with 'align'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		pprofAddr, _ := cmd.Flags().GetString("pprof")
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		path := livePathPrefix + name
+		videoID, startedAt, err := db.EnsureLiveVideo(database, path, name)
+		if err != nil {
+			return fmt.Errorf("failed to start live match: %w", err)
+		}
+
+		// A simulated player running on the wall clock since the match was opened
+		player := mpv.NewFake(0)
+		player.Clock = time.Now
+		player.TimePos = time.Since(startedAt).Seconds()
+		player.Paused = false
+
+		if pprofAddr != "" {
+			if err := startPprof(pprofAddr); err != nil {
+				return err
+			}
+		}
+
+		infof("Live match: %s (clock started %s)\n", name, startedAt.Local().Format("2006-01-02 15:04:05"))
+		if err := tui.Run(player, database, path, videoID, nil); err != nil {
+			return fmt.Errorf("TUI error: %w", err)
+		}
+		return nil
+	},
+}
+
+var alignCmd = &cobra.Command{
+	Use:   "align <match-name> <video-file>",
+	Short: "Convert live-match events to video timestamps",
+	Long: `Move the events tagged with 'live' onto the match video, converting their
+wall-clock times into video timestamps using one reference point: where kickoff
+is in the video and the time of day it happened.
+
+  tagging-rugby-cli align "Saints v Rams" match.mp4 --kickoff-video 00:03:21 --kickoff-wall 15:00:12
+
+--kickoff-wall is taken on the day the live match was opened unless a full
+"YYYY-MM-DD HH:MM:SS" is given. Events that would fall before the start of the
+video are placed at 0:00.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		kickoffVideoFlag, _ := cmd.Flags().GetString("kickoff-video")
+		kickoffWallFlag, _ := cmd.Flags().GetString("kickoff-wall")
+
+		kickoffVideo, err := timeutil.ParseTimeToSeconds(kickoffVideoFlag)
+		if err != nil {
+			return usageErrorf("invalid --kickoff-video: %w", err)
+		}
+
+		videoPath, err := filepath.Abs(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to resolve path: %w", err)
+		}
+		info, err := os.Stat(videoPath)
+		if os.IsNotExist(err) {
+			return notFoundErrorf("video file not found: %s", videoPath)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to access video file: %w", err)
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		liveID, startedAt, err := db.SelectLiveVideo(database, livePathPrefix+name)
+		if errors.Is(err, sql.ErrNoRows) {
+			return notFoundErrorf("live match not found: %s", name)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to query live match: %w", err)
+		}
+
+		kickoffWall, err := parseKickoffWall(kickoffWallFlag, startedAt.Local())
+		if err != nil {
+			return err
+		}
+
+		videoID, err := db.EnsureVideo(database, videoPath, info.Size(), "")
+		if err != nil {
+			return fmt.Errorf("failed to register video: %w", err)
+		}
+
+		// An event tagged at wall time w lands at kickoffVideo + (w - kickoffWall),
+		// and w is the live start plus the event's live timestamp
+		offset := kickoffVideo + startedAt.Sub(kickoffWall).Seconds()
+		moved, err := db.AlignLiveNotes(database, liveID, videoID, offset)
+		if err != nil {
+			return fmt.Errorf("failed to align events: %w", err)
+		}
+
+		// Tackle clips can now be cut from the footage
+		if err := db.QueueUnprocessedTackleClips(database, videoPath); err != nil {
+			return fmt.Errorf("failed to queue clips: %w", err)
+		}
+
+		infof("Aligned %d event(s) from %s onto %s (offset %+.1fs)\n", moved, name, filepath.Base(videoPath), offset)
+		return nil
+	},
+}
+
+// parseKickoffWall parses a kickoff time of day ("15:00:12" or "15:00") on the
+// day of liveStart, or a full "YYYY-MM-DD HH:MM:SS" local time.
+func parseKickoffWall(s string, liveStart time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", s, time.Local); err == nil {
+		return t, nil
+	}
+	layout := "15:04:05"
+	if strings.Count(s, ":") == 1 {
+		layout = "15:04"
+	}
+	t, err := time.ParseInLocation(layout, s, time.Local)
+	if err != nil {
+		return time.Time{}, usageErrorf("invalid --kickoff-wall: %s (want HH:MM:SS)", s)
+	}
+	y, mo, d := liveStart.Date()
+	return time.Date(y, mo, d, t.Hour(), t.Minute(), t.Second(), 0, time.Local), nil
+}
+
+func init() {
+	liveCmd.Flags().String("pprof", "", "Serve net/http/pprof on this address while the TUI runs (e.g. localhost:6060)")
+
+	alignCmd.Flags().String("kickoff-video", "", "Kickoff position in the video (MM:SS or HH:MM:SS)")
+	alignCmd.Flags().String("kickoff-wall", "", "Time of day kickoff happened (HH:MM:SS)")
+	alignCmd.MarkFlagRequired("kickoff-video")
+	alignCmd.MarkFlagRequired("kickoff-wall")
+
+	rootCmd.AddCommand(liveCmd)
+	rootCmd.AddCommand(alignCmd)
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/user/tagging-rugby-cli/pkg/helpdoc"
+)
+
+var manCmd = &cobra.Command{
+	Use:   "man",
+	Short: "Write man pages for every command and help topic",
+	Long: `Write a man page for every command to <dir>/man1, and one for each help topic
+(keybindings, command-mode, data-model) to <dir>/man7. The pages are built from
+the same definitions as --help, 'help <topic>' and the TUI help overlay.
+
+  tagging-rugby-cli man --dir ~/.local/share/man
+  man tagging-rugby-cli-note-add
+  man 7 tagging-rugby-cli-keybindings`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+
+		var pages []helpdoc.ManPage
+		addCommandPages(rootCmd, &pages)
+		for _, t := range helpdoc.Topics() {
+			pages = append(pages, t.ManPage(rootCmd.Name(), manSource()))
+		}
+
+		for _, p := range pages {
+			sub := filepath.Join(dir, fmt.Sprintf("man%d", p.Section))
+			if err := os.MkdirAll(sub, 0755); err != nil {
+				return fmt.Errorf("failed to create man directory: %w", err)
+			}
+			if err := os.WriteFile(filepath.Join(sub, p.Filename()), []byte(p.Roff()), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", p.Filename(), err)
+			}
+		}
+		infof("Wrote %d man page(s) to %s\n", len(pages), dir)
+		return nil
+	},
+}
+
+// helpTopicCommands returns a command per help topic. Commands without a Run
+// are listed by cobra as additional help topics, shown by 'help <topic>'.
+func helpTopicCommands() []*cobra.Command {
+	var cmds []*cobra.Command
+	for _, t := range helpdoc.Topics() {
+		cmds = append(cmds, &cobra.Command{
+			Use:   t.Name,
+			Short: t.Short,
+			Long:  t.Plain(),
+		})
+	}
+	return cmds
+}
+
+// manSource is the page footer naming the program and version.
+func manSource() string {
+	return rootCmd.Name() + " " + Version
+}
+
+// manName is a command's man page name, e.g. tagging-rugby-cli-note-add.
+func manName(c *cobra.Command) string {
+	return strings.ReplaceAll(c.CommandPath(), " ", "-")
+}
+
+// addCommandPages appends the man pages of c and its subcommands, leaving out
+// hidden commands, help and the help topics.
+func addCommandPages(c *cobra.Command, pages *[]helpdoc.ManPage) {
+	if !c.IsAvailableCommand() && c != rootCmd || c.Name() == "help" {
+		return
+	}
+	*pages = append(*pages, commandPage(c))
+	for _, sub := range c.Commands() {
+		addCommandPages(sub, pages)
+	}
+}
+
+// commandPage builds the man page of c from its cobra definition.
+func commandPage(c *cobra.Command) helpdoc.ManPage {
+	text := c.Long
+	if text == "" {
+		text = c.Short
+	}
+	p := helpdoc.ManPage{
+		Name:     manName(c),
+		Section:  1,
+		Short:    c.Short,
+		Source:   manSource(),
+		Synopsis: c.UseLine(),
+		Text:     text,
+	}
+	if c.HasAvailableSubCommands() {
+		s := helpdoc.Section{Title: "Commands"}
+		for _, sub := range c.Commands() {
+			if sub.IsAvailableCommand() {
+				s.Entries = append(s.Entries, helpdoc.Entry{Name: sub.Name(), Desc: sub.Short})
+			}
+		}
+		p.Sections = append(p.Sections, s)
+	}
+	if flags := flagEntries(c.NonInheritedFlags()); len(flags) > 0 {
+		p.Sections = append(p.Sections, helpdoc.Section{Title: "Options", Entries: flags})
+	}
+	if flags := flagEntries(c.InheritedFlags()); len(flags) > 0 {
+		p.Sections = append(p.Sections, helpdoc.Section{Title: "Global Options", Entries: flags})
+	}
+
+	if c.HasParent() {
+		p.SeeAlso = append(p.SeeAlso, manName(c.Parent())+"(1)")
+	}
+	for _, sub := range c.Commands() {
+		if sub.IsAvailableCommand() {
+			p.SeeAlso = append(p.SeeAlso, manName(sub)+"(1)")
+		}
+	}
+	if c == rootCmd {
+		for _, t := range helpdoc.Topics() {
+			p.SeeAlso = append(p.SeeAlso, rootCmd.Name()+"-"+t.Name+"(7)")
+		}
+	}
+	return p
+}
+
+// flagEntries lists flags as they read in --help, e.g. "-o, --output string".
+func flagEntries(flags *pflag.FlagSet) []helpdoc.Entry {
+	var entries []helpdoc.Entry
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+		name := "--" + f.Name
+		if f.Shorthand != "" {
+			name = "-" + f.Shorthand + ", " + name
+		}
+		varname, usage := pflag.UnquoteUsage(f)
+		if varname != "" {
+			name += " " + varname
+		}
+		if f.DefValue != "" && f.DefValue != "false" && f.DefValue != "0" && f.DefValue != "[]" {
+			usage += fmt.Sprintf(" (default %s)", f.DefValue)
+		}
+		entries = append(entries, helpdoc.Entry{Name: name, Desc: usage})
+	})
+	return entries
+}
+
+func init() {
+	manCmd.Flags().String("dir", "man", "Directory to write the man1 and man7 page directories into")
+
+	rootCmd.AddCommand(manCmd)
+	rootCmd.AddCommand(helpTopicCommands()...)
+}
@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/season"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+)
+
+var matchCmd = &cobra.Command{
+	Use:   "match",
+	Short: "Manage matches and the videos filmed of them",
+	Long: `Manage matches: the games videos are filmed of. Several videos (camera angles,
+or one file per half) can be linked to one match, so stats and clip exports can
+cover the whole match with --match, and a season of matches with --season.`,
+}
+
+var matchAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a match",
+	Long: `Add a match and print its ID. --video links registered videos to it straight
+away; details not given are then taken from the first video's match details
+(see 'video edit').
+
+  tagging-rugby-cli match add --opponent Saracens --date 2025-10-04 --home-away home --score 24-17
+  tagging-rugby-cli match add --video cam1.mp4 --video cam2.mp4`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var m db.Match
+		m.Opponent, _ = cmd.Flags().GetString("opponent")
+		m.Date, _ = cmd.Flags().GetString("date")
+		m.Competition, _ = cmd.Flags().GetString("competition")
+		m.HomeAway, _ = cmd.Flags().GetString("home-away")
+		score, _ := cmd.Flags().GetString("score")
+		videos, _ := cmd.Flags().GetStringArray("video")
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		var videoIDs []int64
+		for _, v := range videos {
+			videoPath, err := resolveVideoPath(v)
+			if err != nil {
+				return err
+			}
+			videoID, err := lookupVideoID(database, videoPath)
+			if err != nil {
+				return err
+			}
+			videoIDs = append(videoIDs, videoID)
+		}
+
+		// Fill what wasn't given from the match details recorded on the video
+		if len(videoIDs) > 0 {
+			info, err := db.SelectMatchInfo(database, videoIDs[0])
+			if err != nil {
+				return fmt.Errorf("failed to load match details: %w", err)
+			}
+			m.Opponent = orDefault(m.Opponent, info.Opponent)
+			m.Date = orDefault(m.Date, info.Date)
+			m.Competition = orDefault(m.Competition, info.Competition)
+			if m.HomeAway == "" && (strings.EqualFold(info.Venue, "home") || strings.EqualFold(info.Venue, "away")) {
+				m.HomeAway = strings.ToLower(info.Venue)
+			}
+			if score == "" {
+				score = resultScore(info.Result)
+			}
+		}
+
+		if m.Date != "" {
+			if _, err := time.Parse(reportDateLayout, m.Date); err != nil {
+				return usageErrorf("invalid --date (want YYYY-MM-DD): %s", m.Date)
+			}
+		}
+		m.HomeAway = strings.ToLower(m.HomeAway)
+		if m.HomeAway != "" && m.HomeAway != "home" && m.HomeAway != "away" {
+			return usageErrorf("invalid --home-away: %s (want home or away)", m.HomeAway)
+		}
+		if score != "" {
+			if m.ScoreFor, m.ScoreAgainst, err = parseScore(score); err != nil {
+				return err
+			}
+		}
+
+		matchID, err := db.InsertMatch(database, m)
+		if err != nil {
+			return fmt.Errorf("failed to add match: %w", err)
+		}
+		for _, videoID := range videoIDs {
+			if err := db.LinkVideoToMatch(database, videoID, matchID); err != nil {
+				return fmt.Errorf("failed to link video: %w", err)
+			}
+		}
+		m.ID = matchID
+		infof("Added match %d (%s", matchID, m.Title())
+		if m.Date != "" {
+			infof(", %s", m.Date)
+		}
+		infof(") with %d video(s)\n", len(videoIDs))
+		return nil
+	},
+}
+
+var matchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List matches with their videos",
+	Long: `List matches, latest first, with their result and linked videos.
+
+--season lists one season's matches; a season runs from --season-start in the
+--season year to the day before it in the next year.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seasonFlag, _ := cmd.Flags().GetString("season")
+		seasonStart := seasonStartFlag(cmd)
+
+		var from, to string
+		if seasonFlag != "" {
+			start, end, err := seasonRange(seasonFlag, seasonStart)
+			if err != nil {
+				return err
+			}
+			from, to = start.Format(reportDateLayout), end.Format(reportDateLayout)
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		matches, err := db.SelectMatches(database, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to query matches: %w", err)
+		}
+		if len(matches) == 0 {
+			infoln("No matches found.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tDate\tOpponent\tCompetition\tH/A\tResult\tVideos")
+		fmt.Fprintln(w, "--\t----\t--------\t-----------\t---\t------\t------")
+		for _, m := range matches {
+			paths, err := db.SelectMatchVideos(database, m.ID)
+			if err != nil {
+				return fmt.Errorf("failed to query match videos: %w", err)
+			}
+			names := make([]string, len(paths))
+			for i, p := range paths {
+				names[i] = videosrc.Base(p)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				m.ID, m.Date, m.Opponent, m.Competition, m.HomeAway, m.Result(), strings.Join(names, ", "))
+		}
+		w.Flush()
+
+		infof("\n%d match(es) found.\n", len(matches))
+		return nil
+	},
+}
+
+var matchLinkCmd = &cobra.Command{
+	Use:   "link <match-id> <video>...",
+	Short: "Link videos to a match",
+	Long: `Link registered videos (files or stream URLs) to a match, e.g. the other camera
+angles or the second half's file. A video belongs to one match; linking it
+again moves it.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		matchID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return usageErrorf("invalid match ID: %s", args[0])
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		m, err := lookupMatch(database, matchID)
+		if err != nil {
+			return err
+		}
+		for _, v := range args[1:] {
+			videoPath, err := resolveVideoPath(v)
+			if err != nil {
+				return err
+			}
+			videoID, err := lookupVideoID(database, videoPath)
+			if err != nil {
+				return err
+			}
+			if err := db.LinkVideoToMatch(database, videoID, matchID); err != nil {
+				return fmt.Errorf("failed to link video: %w", err)
+			}
+			infof("Linked %s to match %d (%s)\n", videosrc.Base(videoPath), matchID, m.Title())
+		}
+		return nil
+	},
+}
+
+var matchUnlinkCmd = &cobra.Command{
+	Use:   "unlink <video>...",
+	Short: "Unlink videos from their match",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		for _, v := range args {
+			videoPath, err := resolveVideoPath(v)
+			if err != nil {
+				return err
+			}
+			videoID, err := lookupVideoID(database, videoPath)
+			if err != nil {
+				return err
+			}
+			if err := db.LinkVideoToMatch(database, videoID, 0); err != nil {
+				return fmt.Errorf("failed to unlink video: %w", err)
+			}
+			infof("Unlinked %s\n", videosrc.Base(videoPath))
+		}
+		return nil
+	},
+}
+
+// lookupMatch returns a match, with a readable error when there is none.
+func lookupMatch(database *sql.DB, matchID int64) (db.Match, error) {
+	m, err := db.SelectMatchByID(database, matchID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return m, notFoundErrorf("match not found: %d (see 'match list')", matchID)
+	}
+	if err != nil {
+		return m, fmt.Errorf("failed to look up match: %w", err)
+	}
+	return m, nil
+}
+
+// parseScore reads a final score written from our side, e.g. "24-17".
+func parseScore(score string) (*int, *int, error) {
+	ours, theirs, ok := strings.Cut(strings.ReplaceAll(score, " ", ""), "-")
+	f, errF := strconv.Atoi(ours)
+	a, errA := strconv.Atoi(theirs)
+	if !ok || errF != nil || errA != nil || f < 0 || a < 0 {
+		return nil, nil, usageErrorf("invalid --score: %s (want ours-theirs, e.g. 24-17)", score)
+	}
+	return &f, &a, nil
+}
+
+// resultScore returns the score in a free-text video result such as
+// "W 24-17", or "" when there is none.
+func resultScore(result string) string {
+	for _, field := range strings.Fields(result) {
+		if _, _, err := parseScore(field); err == nil {
+			return field
+		}
+	}
+	return ""
+}
+
+// seasonStartFlag returns --season-start, or "season_start" from config.json
+// when the flag isn't given.
+func seasonStartFlag(cmd *cobra.Command) int {
+	start, _ := cmd.Flags().GetInt("season-start")
+	if !cmd.Flags().Changed("season-start") {
+		if cfg, err := config.Load(); err == nil && cfg.SeasonStart != 0 {
+			return cfg.SeasonStart
+		}
+	}
+	return start
+}
+
+// addScopeFlags adds the flags choosing the videos a stats command counts:
+// --video, --all, --match, and --season with --season-start.
+func addScopeFlags(c *cobra.Command) {
+	c.Flags().String("video", "", "Video file path (defaults to the video open in mpv)")
+	c.Flags().Bool("all", false, "Count across all videos")
+	c.Flags().Int64("match", 0, "Count across the videos of this match (see 'match list')")
+	c.Flags().String("season", "", "Count across a season's matches (e.g. 2025 or 2025/26)")
+	c.Flags().Int("season-start", season.DefaultStartMonth, "Month the season starts (1-12)")
+}
+
+// videoScopeFromFlags returns the videos chosen by the addScopeFlags flags,
+// the video open in mpv when none are given.
+func videoScopeFromFlags(cmd *cobra.Command, database *sql.DB) (db.VideoScope, error) {
+	videoFlag, _ := cmd.Flags().GetString("video")
+	all, _ := cmd.Flags().GetBool("all")
+	matchID, _ := cmd.Flags().GetInt64("match")
+	seasonFlag, _ := cmd.Flags().GetString("season")
+	seasonStart := seasonStartFlag(cmd)
+
+	given := 0
+	for _, set := range []bool{videoFlag != "", all, matchID != 0, seasonFlag != ""} {
+		if set {
+			given++
+		}
+	}
+	if given > 1 {
+		return db.VideoScope{}, fmt.Errorf("give one of --video, --all, --match or --season")
+	}
+
+	var scope db.VideoScope
+	switch {
+	case all:
+	case matchID != 0:
+		if _, err := lookupMatch(database, matchID); err != nil {
+			return scope, err
+		}
+		scope.MatchID = matchID
+	case seasonFlag != "":
+		from, to, err := seasonRange(seasonFlag, seasonStart)
+		if err != nil {
+			return scope, err
+		}
+		scope.From, scope.To = from.Format(reportDateLayout), to.Format(reportDateLayout)
+	default:
+		videoPath, err := resolveVideoPath(videoFlag)
+		if err != nil {
+			return scope, err
+		}
+		if scope.VideoID, err = lookupVideoID(database, videoPath); err != nil {
+			return scope, err
+		}
+	}
+	return scope, nil
+}
+
+func init() {
+	matchAddCmd.Flags().String("opponent", "", "Opponent team")
+	matchAddCmd.Flags().String("date", "", "Match date (YYYY-MM-DD)")
+	matchAddCmd.Flags().String("competition", "", "Competition (e.g. Premiership)")
+	matchAddCmd.Flags().String("home-away", "", "Whether we played at home or away")
+	matchAddCmd.Flags().String("score", "", "Final score, ours first (e.g. 24-17)")
+	matchAddCmd.Flags().StringArray("video", nil, "Registered video to link (repeatable)")
+
+	matchListCmd.Flags().String("season", "", "Only list this season's matches (e.g. 2025 or 2025/26)")
+	matchListCmd.Flags().Int("season-start", season.DefaultStartMonth, "Month the season starts (1-12)")
+
+	matchCmd.AddCommand(matchAddCmd)
+	matchCmd.AddCommand(matchListCmd)
+	matchCmd.AddCommand(matchLinkCmd)
+	matchCmd.AddCommand(matchUnlinkCmd)
+	rootCmd.AddCommand(matchCmd)
+}
@@ -3,15 +3,24 @@ package cmd
 import (
 	"database/sql"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
 	"github.com/user/tagging-rugby-cli/db"
 	"github.com/user/tagging-rugby-cli/mpv"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+	"github.com/user/tagging-rugby-cli/pkg/gameclock"
+	"github.com/user/tagging-rugby-cli/pkg/noteref"
 	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+	"github.com/user/tagging-rugby-cli/report"
+	"github.com/user/tagging-rugby-cli/webhook"
 )
 
 var noteCmd = &cobra.Command{
@@ -23,11 +32,45 @@ var noteCmd = &cobra.Command{
 var noteAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a note at the current timestamp",
-	Long:  `Add a timestamped note at the current video position. Creates a note with timing and video child records.`,
+	Long: `Add a timestamped note at the current video position. Creates a note with timing and video child records.
+
+When config.json has a note template for the category, or it is an event type
+(see 'event type list'), give its fields with --field; required fields must be
+set and fields with options take one of them:
+
+  tagging-rugby-cli note add -c lineout -x "Front ball" --field thrower=Cowan --field outcome=won
+
+The text may refer to other events of the video by event number, as in
+"knock-on after #17"; each must be one of the video's events.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
 		category, _ := cmd.Flags().GetString("category")
 		text, _ := cmd.Flags().GetString("text")
+		fields, _ := cmd.Flags().GetStringArray("field")
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		eventType, hasFields, err := noteFields(cfg, database, category)
+		if err != nil {
+			return err
+		}
+		if !hasFields && len(fields) > 0 {
+			return usageErrorf("category %q has no note template or event type for --field (see 'event type list')", category)
+		}
+		fieldDetails, err := templateFieldDetails(category, eventType.Fields, fields)
+		if err != nil {
+			return err
+		}
 
 		// Connect to mpv to get current timestamp and video path
 		client := mpv.NewClient("")
@@ -52,15 +95,22 @@ var noteAddCmd = &cobra.Command{
 			return fmt.Errorf("unexpected video path type: %T", videoPathRaw)
 		}
 
-		// Get video duration
-		duration, _ := client.GetDuration()
-
-		// Open database
-		database, err := db.Open()
+		// #17 in the text refers to the video's 17th event
+		events, err := db.CountVideoNotes(database, videoPath)
 		if err != nil {
-			return fmt.Errorf("failed to open database: %w", err)
+			return fmt.Errorf("failed to count notes: %w", err)
 		}
-		defer database.Close()
+		if err := noteref.Check(text, events); err != nil {
+			return usageErrorf("invalid --text: %v", err)
+		}
+		// Kept by event, as the new note renumbers those after it
+		targets, err := db.ResolveNoteRefs(database, videoPath, text)
+		if err != nil {
+			return fmt.Errorf("failed to resolve references: %w", err)
+		}
+
+		// Get video duration
+		duration, _ := client.GetDuration()
 
 		// Get video file metadata
 		var videoSize int64
@@ -69,10 +119,15 @@ var noteAddCmd = &cobra.Command{
 		}
 		videoFormat := strings.TrimPrefix(filepath.Ext(videoPath), ".")
 
-		// Build children
+		videoID, _ := db.SelectVideoIDByPath(database, videoPath)
+		if err := resolvePlayerFields(database, videoID, eventType.Fields, fieldDetails); err != nil {
+			return err
+		}
+
+		// Build children; an event type's notes last its default duration
 		children := db.NoteChildren{
 			Timings: []db.NoteTiming{
-				{Start: timestamp, End: timestamp},
+				{Start: timestamp, End: timestamp + eventType.Duration},
 			},
 			Videos: []db.NoteVideo{
 				{Path: videoPath, Duration: duration, Size: videoSize, Format: videoFormat},
@@ -85,14 +140,21 @@ var noteAddCmd = &cobra.Command{
 				{Type: "text", Note: text},
 			}
 		}
+		children.Details = append(children.Details, fieldDetails...)
 
 		// Insert note with children
 		noteID, err := db.InsertNoteWithChildren(database, category, children)
 		if err != nil {
 			return fmt.Errorf("failed to insert note: %w", err)
 		}
+		if text != "" {
+			if err := db.SaveNoteRefs(database, noteID, text, targets); err != nil {
+				return fmt.Errorf("failed to save references: %w", err)
+			}
+		}
+		notifyWebhooks(webhook.NoteCreated, noteID, category, children)
 
-		fmt.Printf("Note added: ID %d at %s\n", noteID, timeutil.FormatTime(timestamp))
+		infof("Note added: ID %d at %s\n", noteID, timeutil.FormatTime(timestamp))
 		return nil
 	},
 }
@@ -100,8 +162,27 @@ var noteAddCmd = &cobra.Command{
 var noteListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all notes for the current video",
-	Long:  `Display all notes for the current video as a table, sorted by timestamp.`,
+	Long: `Display all notes for the current video as a table, sorted by timestamp.
+The # column numbers the video's events 1..N in time order, for 'note goto #17'.
+
+--category lists one category and its subcategories: --category set-piece
+includes set-piece/lineout and set-piece/lineout/steal.
+
+Long tables are shown through $PAGER (less by default) when printing to a
+terminal; --no-pager prints them directly. --limit and --offset show a page of
+the matching notes, keeping their # numbers:
+
+  tagging-rugby-cli note list --limit 20 --offset 40
+
+--watch redraws the table every --interval as notes are tagged in the TUI in
+another terminal, until Ctrl+C.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		categoryFlag, _ := cmd.Flags().GetString("category")
+		view, err := listViewFlags(cmd)
+		if err != nil {
+			return err
+		}
+
 		// Connect to mpv to get current video path
 		client := mpv.NewClient("")
 		if err := client.Connect(); err != nil {
@@ -126,68 +207,198 @@ var noteListCmd = &cobra.Command{
 		}
 		defer database.Close()
 
-		// Query notes with video join to filter by current video, plus timing
-		rows, err := database.Query(
-			`SELECT n.id, n.category, COALESCE(nt.start, 0) as start_time
-			 FROM notes n
-			 INNER JOIN videos v ON v.id = n.video_id
-			 LEFT JOIN note_timing nt ON nt.note_id = n.id
-			 WHERE v.path = ?
-			 ORDER BY start_time ASC`, videoPath)
-		if err != nil {
-			return fmt.Errorf("failed to query notes: %w", err)
-		}
-		defer rows.Close()
+		return view.run("note list", func(out io.Writer) error {
+			// Query notes with video join to filter by current video, plus timing
+			rows, err := database.Query(
+				`SELECT n.id, n.category, COALESCE(nt.start, 0) as start_time
+				 FROM notes n
+				 INNER JOIN videos v ON v.id = n.video_id
+				 LEFT JOIN note_timing nt ON nt.note_id = n.id
+				 WHERE v.path = ?
+				 ORDER BY start_time ASC, n.id ASC`, videoPath)
+			if err != nil {
+				return fmt.Errorf("failed to query notes: %w", err)
+			}
+			defer rows.Close()
+
+			// Create table writer
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "#\tID\tTime\tCategory")
+			fmt.Fprintln(w, "-\t--\t----\t--------")
+
+			// Events are numbered 1..N in time order before filtering, so #17 is
+			// the same event 'note goto #17' jumps to
+			count := 0
+			number := 0
+			for rows.Next() {
+				var id int64
+				var category sql.NullString
+				var startTime float64
+
+				if err := rows.Scan(&id, &category, &startTime); err != nil {
+					return fmt.Errorf("failed to scan note: %w", err)
+				}
+				number++
+
+				timeStr := timeutil.FormatTime(startTime)
+
+				catStr := nullStringValue(category)
+				if !catpath.Under(catStr, categoryFlag) {
+					continue
+				}
+
+				if view.shows(count) {
+					fmt.Fprintf(w, "#%d\t%d\t%s\t%s\n", number, id, timeStr, catStr)
+				}
+				count++
+			}
 
-		// Create table writer
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "ID\tTime\tCategory")
-		fmt.Fprintln(w, "--\t----\t--------")
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("error iterating notes: %w", err)
+			}
 
-		count := 0
-		for rows.Next() {
-			var id int64
-			var category sql.NullString
-			var startTime float64
+			w.Flush()
 
-			if err := rows.Scan(&id, &category, &startTime); err != nil {
-				return fmt.Errorf("failed to scan note: %w", err)
+			if count == 0 {
+				if !quiet {
+					fmt.Fprintln(out, "\nNo matching notes found.")
+				}
+			} else {
+				view.footer(out, count, "note(s)")
 			}
 
-			timeStr := timeutil.FormatTime(startTime)
+			return nil
+		})
+	},
+}
+
+var noteExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export notes for a video as a document",
+	Long: `Export all notes for a video as a Markdown document, grouped by category or player,
+ready to paste into Notion or Confluence team pages.
+
+Timestamps use the game clock when match periods are recorded (see 'analyze halves'),
+otherwise video time. Uses the video open in mpv unless --video is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		videoFlag, _ := cmd.Flags().GetString("video")
+		outputPath, _ := cmd.Flags().GetString("output")
+
+		if format != "markdown" && format != "md" {
+			return usageErrorf("invalid format: %s (supported: markdown)", format)
+		}
+		if groupBy != "category" && groupBy != "player" {
+			return usageErrorf("invalid --group-by: %s (supported: category, player)", groupBy)
+		}
+
+		videoPath, err := resolveVideoPath(videoFlag)
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
 
-			catStr := nullStringValue(category)
+		notes, err := db.SelectNotesForExport(database, videoPath)
+		if err != nil {
+			return fmt.Errorf("failed to query notes: %w", err)
+		}
 
-			fmt.Fprintf(w, "%d\t%s\t%s\n", id, timeStr, catStr)
-			count++
+		clock, err := gameClockForVideo(database, videoPath)
+		if err != nil {
+			return err
 		}
 
-		if err := rows.Err(); err != nil {
-			return fmt.Errorf("error iterating notes: %w", err)
+		match := videosrc.Base(videoPath)
+		var info db.MatchInfo
+		var commentary string
+		var lineup []db.LineupPlayer
+		if videoID, err := db.SelectVideoIDByPath(database, videoPath); err == nil {
+			if info, err = db.SelectMatchInfo(database, videoID); err != nil {
+				return fmt.Errorf("failed to load match details: %w", err)
+			}
+			if title := info.Title(); title != "" {
+				match = title
+			}
+			if commentary, err = db.SelectCommentary(database, videoID); err != nil {
+				return fmt.Errorf("failed to load commentary: %w", err)
+			}
+			if lineup, err = db.SelectLineup(database, videoID); err != nil {
+				return fmt.Errorf("failed to load lineup: %w", err)
+			}
 		}
 
-		w.Flush()
+		out := os.Stdout
+		if outputPath != "" {
+			f, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
 
-		if count == 0 {
-			fmt.Println("\nNo matching notes found.")
-		} else {
-			fmt.Printf("\n%d note(s) found.\n", count)
+		doc := report.NotesDoc{
+			Match:      match,
+			Info:       info,
+			Commentary: commentary,
+			Lineup:     lineup,
+			Notes:      notes,
+			GroupBy:    groupBy,
+			Clock:      clock,
+		}
+		if err := report.WriteNotesMarkdown(out, doc); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
 		}
 
+		if outputPath != "" {
+			infof("Exported %d note(s) to %s\n", len(notes), outputPath)
+		}
 		return nil
 	},
 }
 
+// gameClockForVideo builds the game clock from the periods recorded for a video.
+// Videos without periods (or not yet registered) get an empty clock.
+func gameClockForVideo(database *sql.DB, videoPath string) (gameclock.Clock, error) {
+	videoID, err := db.SelectVideoIDByPath(database, videoPath)
+	if err == sql.ErrNoRows {
+		return gameclock.Clock{}, nil
+	}
+	if err != nil {
+		return gameclock.Clock{}, fmt.Errorf("failed to look up video: %w", err)
+	}
+	periods, err := db.SelectPeriodsByVideo(database, videoID)
+	if err != nil {
+		return gameclock.Clock{}, fmt.Errorf("failed to query periods: %w", err)
+	}
+	clockPeriods := make([]gameclock.Period, len(periods))
+	for i, p := range periods {
+		clockPeriods[i] = gameclock.Period{Name: p.Name, Start: p.Start, End: p.End}
+	}
+	return gameclock.New(clockPeriods), nil
+}
+
 var noteGotoCmd = &cobra.Command{
-	Use:   "goto <id>",
+	Use:   "goto <id|#n>",
 	Short: "Jump to a note's timestamp",
-	Long:  `Seek mpv to the timestamp of an existing note by ID.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Seek mpv to the timestamp of an existing note by ID, or by its event number
+in the open video: #17 is the 17th event in time order, as numbered by
+'note list' and the TUI's notes list.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var noteID int64
-		if _, err := fmt.Sscanf(args[0], "%d", &noteID); err != nil {
-			return fmt.Errorf("invalid note ID: %s", args[0])
+		// Connect to mpv
+		client := mpv.NewClient("")
+		if err := client.Connect(); err != nil {
+			return fmt.Errorf("failed to connect to mpv: %w\n(Is mpv running with a video open?)", err)
 		}
+		defer client.Close()
 
 		// Open database
 		database, err := db.Open()
@@ -196,10 +407,31 @@ var noteGotoCmd = &cobra.Command{
 		}
 		defer database.Close()
 
+		var noteID int64
+		if num, ok := strings.CutPrefix(args[0], "#"); ok {
+			number, err := strconv.Atoi(num)
+			if err != nil || number < 1 {
+				return usageErrorf("invalid event number: %s", args[0])
+			}
+			videoPath, err := client.GetProperty("path")
+			if err != nil {
+				return fmt.Errorf("failed to get video path: %w", err)
+			}
+			path, _ := videoPath.(string)
+			noteID, err = db.SelectNoteIDByNumber(database, path, number)
+			if err == sql.ErrNoRows {
+				return notFoundErrorf("no event #%d in the open video", number)
+			} else if err != nil {
+				return fmt.Errorf("failed to find event: %w", err)
+			}
+		} else if _, err := fmt.Sscanf(args[0], "%d", &noteID); err != nil {
+			return usageErrorf("invalid note ID: %s", args[0])
+		}
+
 		// Fetch the note
 		note, err := db.SelectNoteByID(database, noteID)
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("note with ID %d not found", noteID)
+			return notFoundErrorf("note with ID %d not found", noteID)
 		} else if err != nil {
 			return fmt.Errorf("failed to fetch note: %w", err)
 		}
@@ -215,21 +447,14 @@ var noteGotoCmd = &cobra.Command{
 			seekPos = timings[0].Start
 		}
 
-		// Connect to mpv
-		client := mpv.NewClient("")
-		if err := client.Connect(); err != nil {
-			return fmt.Errorf("failed to connect to mpv: %w\n(Is mpv running with a video open?)", err)
-		}
-		defer client.Close()
-
 		// Seek to the note's timestamp
 		if err := client.Seek(seekPos); err != nil {
 			return fmt.Errorf("failed to seek to timestamp: %w", err)
 		}
 
-		fmt.Printf("Jumped to note %d at %s\n", noteID, timeutil.FormatTime(seekPos))
+		infof("Jumped to note %d at %s\n", noteID, timeutil.FormatTime(seekPos))
 		if note.Category != "" {
-			fmt.Printf("  Category: %s\n", note.Category)
+			infof("  Category: %s\n", note.Category)
 		}
 
 		return nil
@@ -239,12 +464,12 @@ var noteGotoCmd = &cobra.Command{
 var noteDeleteCmd = &cobra.Command{
 	Use:   "delete <id>",
 	Short: "Delete a note",
-	Long:  `Delete an existing note by ID. Cascade deletes all child records. Prompts for confirmation unless --force is used.`,
+	Long:  `Delete an existing note by ID, with its child records (timing, tackles, zones, details, highlights, clip). Lists what will be removed, and the exported clip files that are kept on disk, and prompts for confirmation unless --force is used.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var noteID int64
 		if _, err := fmt.Sscanf(args[0], "%d", &noteID); err != nil {
-			return fmt.Errorf("invalid note ID: %s", args[0])
+			return usageErrorf("invalid note ID: %s", args[0])
 		}
 
 		force, _ := cmd.Flags().GetBool("force")
@@ -259,13 +484,22 @@ var noteDeleteCmd = &cobra.Command{
 		// Fetch the note to display before deletion
 		note, err := db.SelectNoteByID(database, noteID)
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("note with ID %d not found", noteID)
+			return notFoundErrorf("note with ID %d not found", noteID)
 		} else if err != nil {
 			return fmt.Errorf("failed to fetch note: %w", err)
 		}
 
-		// Display note info
-		fmt.Printf("Note %d (category: %s)\n", note.ID, note.Category)
+		preview, err := db.PreviewNoteDelete(database, noteID)
+		if err != nil {
+			return fmt.Errorf("failed to preview deletion: %w", err)
+		}
+
+		// Display note info and what goes with it
+		infof("Note %d (category: %s)\n", note.ID, note.Category)
+		infof("  Also deletes: %s\n", preview.Summary())
+		for _, path := range preview.ClipFiles {
+			infof("  Keeps clip file: %s\n", path)
+		}
 
 		// Prompt for confirmation unless --force
 		if !force {
@@ -273,17 +507,17 @@ var noteDeleteCmd = &cobra.Command{
 			var response string
 			fmt.Scanln(&response)
 			if response != "y" && response != "Y" {
-				fmt.Println("Deletion cancelled.")
+				infoln("Deletion cancelled.")
 				return nil
 			}
 		}
 
-		// Delete the note (cascade handles children)
+		// Delete the note (cascade handles children); exported clips stay on disk
 		if err := db.DeleteNote(database, noteID); err != nil {
 			return fmt.Errorf("failed to delete note: %w", err)
 		}
 
-		fmt.Printf("Note %d deleted.\n", noteID)
+		infof("Note %d deleted.\n", noteID)
 		return nil
 	},
 }
@@ -312,14 +546,24 @@ func init() {
 	// Add flags to note add command
 	noteAddCmd.Flags().StringP("category", "c", "", "Note category")
 	noteAddCmd.Flags().StringP("text", "x", "", "Note text")
+	noteAddCmd.Flags().StringArray("field", nil, "Template field as name=value (repeatable, see note_templates in config.json)")
 
 	// Add flags to note delete command
 	noteDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
 
+	// Flags for note export
+	noteExportCmd.Flags().StringP("format", "f", "markdown", "Output format (markdown)")
+	noteListCmd.Flags().StringP("category", "c", "", "Only list this category and its subcategories")
+	addListViewFlags(noteListCmd)
+	noteExportCmd.Flags().StringP("group-by", "g", "category", "Group notes by category or player")
+	noteExportCmd.Flags().String("video", "", "Video path (defaults to the video open in mpv)")
+	noteExportCmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+
 	// Build command tree
 	noteCmd.AddCommand(noteAddCmd)
 	noteCmd.AddCommand(noteListCmd)
 	noteCmd.AddCommand(noteDeleteCmd)
 	noteCmd.AddCommand(noteGotoCmd)
+	noteCmd.AddCommand(noteExportCmd)
 	rootCmd.AddCommand(noteCmd)
 }
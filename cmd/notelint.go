@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/lint"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+)
+
+var noteLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check note text and player names for inconsistent terminology",
+	Long: `Check every note in the database for terminology problems:
+
+  - words that look like a misspelling of a term in the dictionary
+  - terms written more than one way across notes ("lineout", "line-out")
+  - tackle players not on the squad list, or, without a squad list, player
+    names that are probably the same person written differently
+
+The dictionary is read from config.json:
+
+  {"dictionary": {"terms": ["lineout", "jackal", "pick and go"], "players": ["Jo Smith"]}}
+
+Without a dictionary only the spelling and player consistency checks run.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		var terms, squad []string
+		if cfg.Dictionary != nil {
+			terms, squad = cfg.Dictionary.Terms, cfg.Dictionary.Players
+		}
+		dict := lint.NewDictionary(terms)
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		notes, err := db.SelectLintNoteTexts(database)
+		if err != nil {
+			return fmt.Errorf("failed to query notes: %w", err)
+		}
+		players, err := db.SelectLintPlayers(database)
+		if err != nil {
+			return fmt.Errorf("failed to query players: %w", err)
+		}
+
+		noteIDs := make(map[int64]bool)
+		for _, n := range notes {
+			noteIDs[n.NoteID] = true
+		}
+
+		issues := 0
+		if !dict.Empty() {
+			var lines []string
+			for _, n := range notes {
+				for _, f := range dict.Check(n.Text) {
+					lines = append(lines, fmt.Sprintf("  Note %d (%s): %q → %s", n.NoteID, videosrc.Base(n.Path), f.Word, f.Term))
+				}
+			}
+			issues += printLintSection("Terms not matching the dictionary:", lines)
+		}
+
+		texts := make([]string, len(notes))
+		for i, n := range notes {
+			texts[i] = n.Text
+		}
+		var lines []string
+		for _, group := range lint.Variants(texts) {
+			lines = append(lines, "  "+formatVariants(group))
+		}
+		issues += printLintSection("Terms spelled more than one way:", lines)
+
+		lines = nil
+		if len(squad) > 0 {
+			onSquad := make(map[string]bool, len(squad))
+			for _, p := range squad {
+				onSquad[lint.Normalize(p)] = true
+			}
+			for _, p := range players {
+				if onSquad[lint.Normalize(p.Player)] {
+					continue
+				}
+				line := fmt.Sprintf("  %q (%d tackle(s)) is not on the squad list", p.Player, p.Uses)
+				if near := lint.ClosestName(p.Player, squad); near != "" {
+					line += fmt.Sprintf(", did you mean %q?", near)
+				}
+				lines = append(lines, line)
+			}
+			issues += printLintSection("Unknown players:", lines)
+		} else {
+			names := make([]lint.Variant, len(players))
+			for i, p := range players {
+				names[i] = lint.Variant{Spelling: p.Player, Count: p.Uses}
+			}
+			for _, group := range lint.SimilarNames(names) {
+				lines = append(lines, "  "+formatVariants(group))
+			}
+			if len(lines) > 0 {
+				// The hint isn't an issue
+				hint := "  Merge spellings with: tagging-rugby-cli player merge <from> <into>"
+				issues += printLintSection("Player names that may be the same person:", append(lines, hint)) - 1
+			}
+		}
+
+		if issues == 0 {
+			infof("No issues found in %d note(s).\n", len(noteIDs))
+			return nil
+		}
+		infof("%d issue(s) found in %d note(s).\n", issues, len(noteIDs))
+		return nil
+	},
+}
+
+// printLintSection prints a heading and its lines, if any, and returns the line count.
+func printLintSection(heading string, lines []string) int {
+	if len(lines) == 0 {
+		return 0
+	}
+	fmt.Println(heading)
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+	fmt.Println()
+	return len(lines)
+}
+
+// formatVariants formats spellings with their counts, e.g. "lineout (14), line-out (3)".
+func formatVariants(group []lint.Variant) string {
+	parts := make([]string, len(group))
+	for i, v := range group {
+		parts[i] = fmt.Sprintf("%s (%d)", v.Spelling, v.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	noteCmd.AddCommand(noteLintCmd)
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import "fmt"
+
+// quiet is set by --quiet: confirmations, progress and other informational
+// messages are left out, so only the data a command was asked for (tables,
+// exports) and errors are printed.
+var quiet bool
+
+// infof prints an informational message to stdout unless --quiet is set.
+func infof(format string, a ...any) {
+	if !quiet {
+		fmt.Printf(format, a...)
+	}
+}
+
+// infoln prints an informational line to stdout unless --quiet is set.
+func infoln(a ...any) {
+	if !quiet {
+		fmt.Println(a...)
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+	"github.com/user/tagging-rugby-cli/tui/forms"
+)
+
+// readVideoList reads a newline-separated list of video files or URLs, as
+// printed by find or fzf -m. Blank lines and repeats are skipped.
+func readVideoList(r io.Reader) ([]string, error) {
+	var videos []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		videos = append(videos, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read video list: %w", err)
+	}
+	return videos, nil
+}
+
+// pickVideo reads the video list for 'open -' from stdin and returns the one
+// chosen in a picker, or the only one listed. The path is "" when the picker
+// is cancelled.
+func pickVideo() (string, error) {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return "", fmt.Errorf("open - reads the videos from a pipe, e.g. find . -name '*.mp4' | tagging-rugby-cli open -")
+	}
+	videos, err := readVideoList(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	switch len(videos) {
+	case 0:
+		return "", fmt.Errorf("no videos on stdin")
+	case 1:
+		return videos[0], nil
+	}
+
+	var path string
+	if err := forms.NewVideoPickerForm(videoChoices(videos), &path).Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to pick video: %w", err)
+	}
+	return path, nil
+}
+
+// videoChoices labels the listed videos for the picker with their match and
+// note count when they have been opened before.
+func videoChoices(videos []string) []forms.VideoChoice {
+	known := make(map[string]db.VideoSummary)
+	if database, err := db.Open(); err == nil {
+		summaries, _ := db.SelectVideos(database, db.VideoFilter{})
+		for _, s := range summaries {
+			known[s.Path] = s
+		}
+		database.Close()
+	}
+
+	choices := make([]forms.VideoChoice, len(videos))
+	for i, v := range videos {
+		path := v
+		if !videosrc.IsURL(v) {
+			if abs, err := filepath.Abs(v); err == nil {
+				path = abs
+			}
+		}
+		label := videosrc.Base(v)
+		if s, ok := known[path]; ok {
+			if title := s.Match.Title(); title != "" {
+				label += " · " + title
+			}
+			label += fmt.Sprintf(" · %d notes", s.Notes)
+		} else {
+			label += " · new"
+		}
+		if dir := filepath.Dir(v); !videosrc.IsURL(v) && dir != "." {
+			label += " · " + dir
+		}
+		choices[i] = forms.VideoChoice{Path: v, Label: label}
+	}
+	return choices
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/importer"
+)
+
+var playerCmd = &cobra.Command{
+	Use:   "player",
+	Short: "Manage the roster and player names",
+	Long: `Import the club roster, merge different spellings of a player's name and manage
+the aliases that keep them merged.`,
+}
+
+var playerImportCmd = &cobra.Command{
+	Use:   "import <roster.csv>",
+	Short: "Import the club roster from a CSV file",
+	Long: `Add the players of a roster CSV, such as the club's registration spreadsheet
+saved as CSV, to the roster. The header row names the columns: name (or first
+and last name), and optionally number, position and team; other columns are
+ignored. Players already on the roster (by name, in any case) are updated, and
+empty cells keep what the roster had.
+
+  tagging-rugby-cli player import roster.csv
+  tagging-rugby-cli player import u18.csv --team U18
+
+Roster names are offered as completions in the TUI forms. A squad number
+entered as a player is saved as the roster player wearing it, unless the
+video's lineup says otherwise or several roster players share the number, and
+'video lineup edit' starts an empty lineup from the squad numbers.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		team, _ := cmd.Flags().GetString("team")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open roster: %w", err)
+		}
+		defer f.Close()
+
+		parsed, err := importer.ParseRoster(f)
+		if err != nil {
+			return fmt.Errorf("failed to read roster: %w", err)
+		}
+		players := make([]db.RosterPlayer, len(parsed))
+		for i, p := range parsed {
+			players[i] = db.RosterPlayer{Name: p.Name, Number: p.Number, Position: p.Position, Team: orDefault(p.Team, team)}
+		}
+
+		if dryRun {
+			printRoster(players)
+			infof("\n%d player(s) would be imported.\n", len(players))
+			return nil
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		imported, err := db.ImportRoster(database, players)
+		if err != nil {
+			return fmt.Errorf("failed to import roster: %w", err)
+		}
+		infof("Imported %d player(s): %d added, %d updated\n", len(players), imported.Added, imported.Updated)
+		return nil
+	},
+}
+
+var playerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the roster",
+	Long:  `List the players on the roster by team and squad number.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		team, _ := cmd.Flags().GetString("team")
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		roster, err := db.SelectRoster(database, team)
+		if err != nil {
+			return fmt.Errorf("failed to query roster: %w", err)
+		}
+		if len(roster) == 0 {
+			infoln("No players on the roster. Use 'player import' to add them.")
+			return nil
+		}
+		printRoster(roster)
+		infof("\n%d player(s) found.\n", len(roster))
+		return nil
+	},
+}
+
+// printRoster prints roster players as a table.
+func printRoster(roster []db.RosterPlayer) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "No.\tName\tPosition\tTeam")
+	fmt.Fprintln(w, "---\t----\t--------\t----")
+	for _, p := range roster {
+		number := ""
+		if p.Number > 0 {
+			number = strconv.Itoa(p.Number)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", number, p.Name, p.Position, p.Team)
+	}
+	w.Flush()
+}
+
+var playerMergeCmd = &cobra.Command{
+	Use:   "merge <from> <into>",
+	Short: "Merge one spelling of a player's name into another",
+	Long: `Rename every tackle and player tag spelled <from> (in any case) to <into>, so
+stats and reports count them as one player, and record <from> as an alias of
+<into>: tackles and notes entered later as <from> are saved as <into>.
+
+  tagging-rugby-cli player merge "Jonny S" "J. Smith"
+
+'note lint' lists names that may be the same person. Merging back the other way
+replaces the alias; 'player unalias' removes it.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, into := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+		if from == "" || into == "" {
+			return usageErrorf("player names must not be empty")
+		}
+		if from == into {
+			return fmt.Errorf("nothing to merge: %q and %q are the same name", from, into)
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		merged, err := db.MergePlayer(database, from, into)
+		if err != nil {
+			return fmt.Errorf("failed to merge players: %w", err)
+		}
+		infof("Merged %q into %q: %d tackle(s), %d player tag(s) renamed\n", from, into, merged.Tackles, merged.Details)
+		infof("New entries for %q will be saved as %q\n", from, into)
+		return nil
+	},
+}
+
+var playerAliasesCmd = &cobra.Command{
+	Use:   "aliases",
+	Short: "List player name aliases",
+	Long:  `List the spellings 'player merge' normalizes, with the name each is saved as.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		aliases, err := db.SelectPlayerAliases(database)
+		if err != nil {
+			return fmt.Errorf("failed to query aliases: %w", err)
+		}
+		if len(aliases) == 0 {
+			infoln("No player aliases. Use 'player merge' to merge two spellings.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Alias\tSaved as\tMerged")
+		fmt.Fprintln(w, "-----\t--------\t------")
+		for _, a := range aliases {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", a.Alias, a.Player, a.CreatedAt.Local().Format("2006-01-02 15:04"))
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var playerUnaliasCmd = &cobra.Command{
+	Use:   "unalias <alias>",
+	Short: "Stop normalizing a player name alias",
+	Long:  `Remove an alias, so new entries spelled that way are saved as entered. Tackles already merged keep their merged name.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		removed, err := db.DeletePlayerAlias(database, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to remove alias: %w", err)
+		}
+		if !removed {
+			return notFoundErrorf("alias not found: %s", args[0])
+		}
+		infof("Removed alias %q\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	playerImportCmd.Flags().String("team", "", "Team for players whose row has none")
+	playerImportCmd.Flags().Bool("dry-run", false, "Show the players read without importing them")
+	playerListCmd.Flags().String("team", "", "Only list this team's players")
+
+	playerCmd.AddCommand(playerImportCmd)
+	playerCmd.AddCommand(playerListCmd)
+	playerCmd.AddCommand(playerMergeCmd)
+	playerCmd.AddCommand(playerAliasesCmd)
+	playerCmd.AddCommand(playerUnaliasCmd)
+	rootCmd.AddCommand(playerCmd)
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/mpv"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+	"github.com/user/tagging-rugby-cli/plugin"
+	"github.com/user/tagging-rugby-cli/webhook"
+)
+
+// pluginTimeout bounds how long a plugin may run.
+const pluginTimeout = time.Minute
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Run external plugins",
+	Long: `Run executables from ~/.config/tagging-rugby/plugins.
+
+Each plugin is exposed as 'plugin <name>' here and as ':plugin <name>' in the TUI.
+The plugin receives JSON on stdin:
+
+  {"video": "/path/match.mp4", "timestamp": 754.2,
+   "note": {"id": 12, "category": "tackle", "time": 750.1, ...}, "args": []}
+
+and prints the actions to apply on stdout:
+
+  {"actions": [{"type": "insert_note", "category": "try", "text": "...", "time": 760},
+               {"type": "seek", "time": 700},
+               {"type": "message", "message": "done"}]}`,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed plugins",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plugins, err := plugin.List()
+		if err != nil {
+			return fmt.Errorf("failed to list plugins: %w", err)
+		}
+		if len(plugins) == 0 {
+			dir, _ := plugin.Dir()
+			infof("No plugins found in %s\n", dir)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Name\tPath")
+		fmt.Fprintln(w, "----\t----")
+		for _, p := range plugins {
+			fmt.Fprintf(w, "%s\t%s\n", p.Name, p.Path)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+// newPluginRunCmd builds the CLI subcommand for an installed plugin.
+func newPluginRunCmd(p plugin.Plugin) *cobra.Command {
+	c := &cobra.Command{
+		Use:   p.Name + " [args...]",
+		Short: "Run the " + p.Name + " plugin",
+		Long: fmt.Sprintf(`Run the %s plugin (%s).
+Video and timestamp come from the running mpv instance unless --video/--time are given.`, p.Name, p.Path),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlugin(cmd, p, args)
+		},
+	}
+	c.Flags().String("video", "", "Video path (defaults to the video open in mpv)")
+	c.Flags().String("time", "", "Timestamp (MM:SS or seconds, defaults to the mpv position)")
+	c.Flags().Int64("note", 0, "ID of the note to pass to the plugin")
+	return c
+}
+
+// runPlugin builds the plugin request, runs the plugin and applies its actions.
+func runPlugin(cmd *cobra.Command, p plugin.Plugin, args []string) error {
+	videoFlag, _ := cmd.Flags().GetString("video")
+	timeFlag, _ := cmd.Flags().GetString("time")
+	noteID, _ := cmd.Flags().GetInt64("note")
+
+	// mpv is optional: it supplies defaults and receives seek actions
+	client := mpv.NewClient("")
+	connected := client.Connect() == nil
+	if connected {
+		defer client.Close()
+	}
+
+	req := plugin.Request{Args: args}
+	videoPath, err := resolveVideoPath(videoFlag)
+	if err != nil {
+		return err
+	}
+	req.Video = videoPath
+
+	if timeFlag != "" {
+		req.Timestamp, err = timeutil.ParseTimeToSeconds(timeFlag)
+		if err != nil {
+			return usageErrorf("invalid --time: %w", err)
+		}
+	} else if connected {
+		req.Timestamp, _ = client.GetTimePos()
+	}
+
+	// Open database
+	database, err := db.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer database.Close()
+
+	if noteID > 0 {
+		req.Note, err = loadPluginNote(database, noteID)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+	defer cancel()
+	actions, err := p.Run(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range actions {
+		switch a.Type {
+		case plugin.ActionMessage:
+			fmt.Println(a.Message)
+		case plugin.ActionSeek:
+			if !connected {
+				infof("Skipped seek to %s (mpv is not running)\n", timeutil.FormatTime(*a.Time))
+				continue
+			}
+			if err := client.Seek(*a.Time); err != nil {
+				return fmt.Errorf("failed to seek: %w", err)
+			}
+			infof("Seeked to %s\n", timeutil.FormatTime(*a.Time))
+		case plugin.ActionInsertNote:
+			at := req.Timestamp
+			if a.Time != nil {
+				at = *a.Time
+			}
+			id, err := insertPluginNote(database, p.Name, req.Video, a, at)
+			if err != nil {
+				return err
+			}
+			infof("Note %d added at %s\n", id, timeutil.FormatTime(at))
+		}
+	}
+	return nil
+}
+
+// loadPluginNote loads the note summary passed to plugins.
+func loadPluginNote(database *sql.DB, id int64) (*plugin.Note, error) {
+	n, err := db.SelectNoteByID(database, id)
+	if err == sql.ErrNoRows {
+		return nil, notFoundErrorf("note not found: %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load note: %w", err)
+	}
+	note := &plugin.Note{ID: n.ID, Category: n.Category}
+
+	if timings, err := db.SelectNoteTimingByNote(database, id); err == nil && len(timings) > 0 {
+		note.Time = timings[0].Start
+	}
+	if tackles, err := db.SelectNoteTacklesByNote(database, id); err == nil && len(tackles) > 0 {
+		note.Player = tackles[0].Player
+	}
+	if details, err := db.SelectNoteDetailsByNote(database, id); err == nil {
+		for _, d := range details {
+			switch d.Type {
+			case "text":
+				note.Text = d.Note
+			case "player":
+				note.Player = d.Note
+			case "team":
+				note.Team = d.Note
+			}
+		}
+	}
+	return note, nil
+}
+
+// insertPluginNote inserts a note returned by a plugin insert_note action.
+func insertPluginNote(database *sql.DB, pluginName, videoPath string, a plugin.Action, at float64) (int64, error) {
+	category := a.Category
+	if category == "" {
+		category = "note"
+	}
+
+	video := db.NoteVideo{Path: videoPath, Format: videosrc.Ext(videoPath)}
+	if info, err := os.Stat(videoPath); err == nil {
+		video.Size = info.Size()
+	}
+
+	children := db.NoteChildren{
+		Timings: []db.NoteTiming{
+			{Start: at, End: at},
+		},
+		Videos: []db.NoteVideo{video},
+		Details: []db.NoteDetail{
+			{Type: "source", Note: "plugin:" + pluginName},
+		},
+	}
+	if a.Text != "" {
+		children.Details = append(children.Details, db.NoteDetail{Type: "text", Note: a.Text})
+	}
+
+	id, err := db.InsertNoteWithChildren(database, category, children)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert note: %w", err)
+	}
+	notifyWebhooks(webhook.NoteCreated, id, category, children)
+	return id, nil
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+
+	// Installed plugins become subcommands; a broken plugins dir must not break the CLI
+	if plugins, err := plugin.List(); err == nil {
+		for _, p := range plugins {
+			if p.Name == "list" {
+				continue
+			}
+			pluginCmd.AddCommand(newPluginRunCmd(p))
+		}
+	}
+
+	rootCmd.AddCommand(pluginCmd)
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/script"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Share an analyst setup between machines",
+	Long: `Export and import the interaction profile: player hotkeys, key remapping and
+command aliases, snippets, the terminology dictionary, form defaults, seek
+steps, overlay timing, the event gap warning, frame-step tuning, clip padding,
+pedal and MIDI bindings, and Lua scripts (custom keys and commands). A club can
+export one analyst's setup and import it everywhere else.
+
+Machine-specific settings (device paths, webhooks, upload credentials, the
+remote endpoint) are neither exported nor overwritten.`,
+}
+
+var profileExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Write the interaction profile to a file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		profile := config.ExportProfile(cfg)
+
+		scripts, err := readScripts()
+		if err != nil {
+			return err
+		}
+		if len(scripts) > 0 {
+			profile.Scripts = scripts
+		}
+
+		data, err := json.MarshalIndent(profile, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode profile: %w", err)
+		}
+		if err := os.WriteFile(args[0], append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write profile: %w", err)
+		}
+		infof("Exported profile to %s (%d script(s))\n", args[0], len(scripts))
+		return nil
+	},
+}
+
+var profileImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Replace the interaction settings with a profile",
+	Long: `Replace the interaction settings in config.json with those in a profile file,
+and write its scripts to the scripts directory. Scripts with the same name are
+overwritten; other scripts are left in place.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read profile: %w", err)
+		}
+		var profile config.Profile
+		if err := json.Unmarshal(data, &profile); err != nil {
+			return fmt.Errorf("failed to parse profile: %w", err)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if err := profile.Apply(cfg); err != nil {
+			return err
+		}
+
+		// Check every script name before writing anything
+		if err := checkScriptNames(profile.Scripts); err != nil {
+			return err
+		}
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		if err := writeScripts(profile.Scripts); err != nil {
+			return err
+		}
+		infof("Imported profile from %s (%d script(s))\n", args[0], len(profile.Scripts))
+		return nil
+	},
+}
+
+// readScripts returns the contents of the Lua scripts, by file name.
+func readScripts() (map[string]string, error) {
+	dir, err := script.Dir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate scripts: %w", err)
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*.lua"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	scripts := make(map[string]string)
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read script: %w", err)
+		}
+		scripts[filepath.Base(path)] = string(data)
+	}
+	return scripts, nil
+}
+
+// checkScriptNames rejects script names that aren't plain .lua file names,
+// which could write outside the scripts directory.
+func checkScriptNames(scripts map[string]string) error {
+	for name := range scripts {
+		if name != filepath.Base(name) || !strings.HasSuffix(name, ".lua") {
+			return usageErrorf("invalid script name: %s", name)
+		}
+	}
+	return nil
+}
+
+// writeScripts writes scripts into the scripts directory, naming each one
+// it replaces.
+func writeScripts(scripts map[string]string) error {
+	if len(scripts) == 0 {
+		return nil
+	}
+	dir, err := script.Dir()
+	if err != nil {
+		return fmt.Errorf("failed to locate scripts: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create scripts dir: %w", err)
+	}
+	for name, body := range scripts {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			infof("Replacing script %s\n", name)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			return fmt.Errorf("failed to write script: %w", err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	profileCmd.AddCommand(profileExportCmd)
+	profileCmd.AddCommand(profileImportCmd)
+	rootCmd.AddCommand(profileCmd)
+}
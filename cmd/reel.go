@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/clip"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/season"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+)
+
+// reelMinEvent is the seconds a reel shows of events marked at a single
+// moment, as the background clips do.
+const reelMinEvent = 4.0
+
+var reelCmd = &cobra.Command{
+	Use:   "reel",
+	Short: "Build highlight reels from tagged events",
+}
+
+var reelBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Stitch the clips of selected events into one highlight video",
+	Long: `Cut the events selected by --category (with its subcategories), --player and
+--starred, or --all, and join them into one video with ffmpeg, in time order:
+
+  tagging-rugby-cli reel build --player Smith -o smith.mp4
+  tagging-rugby-cli reel build --category lineout --match 3 --titles -o lineouts.mp4
+
+Events come from the video open in mpv, --video, --match (see 'match list'),
+--season or --all-videos, as for 'clip export'.
+
+Each event is widened by a lead-in and lead-out, from "clip_padding" in
+config.json unless --lead-in and --lead-out are given. With --titles, a card
+naming the event (category, player, outcome and time) is shown before it.
+
+Every segment is re-encoded to --size at 25 fps with H.264 and AAC, so footage
+from different cameras can be joined; a reel takes a while to build.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		videoFlag, _ := cmd.Flags().GetString("video")
+		all, _ := cmd.Flags().GetBool("all")
+		category, _ := cmd.Flags().GetString("category")
+		player, _ := cmd.Flags().GetString("player")
+		starred, _ := cmd.Flags().GetBool("starred")
+		allVideos, _ := cmd.Flags().GetBool("all-videos")
+		matchID, _ := cmd.Flags().GetInt64("match")
+		seasonFlag, _ := cmd.Flags().GetString("season")
+		titles, _ := cmd.Flags().GetBool("titles")
+		titleDuration, _ := cmd.Flags().GetFloat64("title-duration")
+		sizeFlag, _ := cmd.Flags().GetString("size")
+
+		if !all && category == "" && player == "" && !starred {
+			return usageErrorf("select events with --all, --category, --player or --starred")
+		}
+		if allVideos && videoFlag != "" {
+			return usageErrorf("--all-videos selects from every video, instead of --video")
+		}
+		if (matchID != 0 || seasonFlag != "") && (videoFlag != "" || allVideos) {
+			return usageErrorf("--match and --season select videos instead of --video or --all-videos")
+		}
+		if matchID != 0 && seasonFlag != "" {
+			return usageErrorf("give --match or --season, not both")
+		}
+		if ext := strings.ToLower(filepath.Ext(output)); ext != ".mp4" && ext != ".mkv" {
+			return usageErrorf("invalid output: %s (a reel is written as .mp4 or .mkv)", output)
+		}
+		var width, height int
+		if _, err := fmt.Sscanf(sizeFlag, "%dx%d", &width, &height); err != nil || width < 16 || height < 16 || width%2 != 0 || height%2 != 0 {
+			return usageErrorf("invalid --size: %s (want even WIDTHxHEIGHT, e.g. 1280x720)", sizeFlag)
+		}
+		if titles && titleDuration <= 0 {
+			return usageErrorf("invalid --title-duration: %g (want seconds above 0)", titleDuration)
+		}
+		if err := clip.CheckReelDeps(titles); err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		var padding config.ClipPadding
+		if cfg.ClipPadding != nil {
+			padding = *cfg.ClipPadding
+		}
+		if cmd.Flags().Changed("lead-in") {
+			padding.Before, _ = cmd.Flags().GetFloat64("lead-in")
+		}
+		if cmd.Flags().Changed("lead-out") {
+			padding.After, _ = cmd.Flags().GetFloat64("lead-out")
+		}
+		if padding.Before < 0 || padding.After < 0 {
+			return usageErrorf("lead-in and lead-out can't be negative")
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		targets, err := selectExportTargets(database, exportSelection{
+			video:       videoFlag,
+			category:    category,
+			player:      player,
+			starred:     starred,
+			allVideos:   allVideos,
+			matchID:     matchID,
+			season:      seasonFlag,
+			seasonStart: seasonStartFlag(cmd),
+		})
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			infoln("No notes match.")
+			return nil
+		}
+
+		reel := clip.Reel{Width: width, Height: height}
+		if titles {
+			reel.TitleDuration = titleDuration
+		}
+		var length float64
+		for _, t := range targets {
+			segment := reelSegment(t, padding)
+			if titles {
+				segment.Title = reelTitle(t.note)
+			}
+			reel.Segments = append(reel.Segments, segment)
+			length += segment.End - segment.Start + reel.TitleDuration
+		}
+		reel.Progress = func(i int, s clip.ReelSegment) {
+			infof("Cutting event %d/%d (%s at %s)...\n", i+1, len(reel.Segments), filepath.Base(s.VideoPath), timeutil.FormatTime(s.Start))
+		}
+
+		// Ctrl+C stops ffmpeg and leaves no partial reel
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := reel.Build(ctx, output); err != nil {
+			return fmt.Errorf("failed to build reel: %w", err)
+		}
+
+		infof("Built %s: %d event(s), %s long\n", output, len(targets), timeutil.FormatTime(length))
+		return nil
+	},
+}
+
+// reelSegment is the part of its video a reel shows of an event: the event
+// widened by padding, and at least reelMinEvent long before that.
+func reelSegment(t exportTarget, padding config.ClipPadding) clip.ReelSegment {
+	end := math.Max(t.note.End, t.note.Start+reelMinEvent)
+	return clip.ReelSegment{
+		VideoPath: t.video,
+		Start:     math.Max(0, t.note.Start-padding.Before),
+		End:       end + padding.After,
+	}
+}
+
+// reelTitle is the title card of an event: its labels, then its time and
+// text on the lines below.
+func reelTitle(n db.ExportNote) string {
+	var labels []string
+	for _, s := range []string{n.Category, n.Player, n.Outcome} {
+		if s != "" {
+			labels = append(labels, s)
+		}
+	}
+	title := strings.Join(labels, " · ") + "\n" + timeutil.FormatTime(n.Start)
+	if text := n.Text; text != "" {
+		if len(text) > 60 {
+			text = text[:57] + "..."
+		}
+		title += "\n" + text
+	}
+	return title
+}
+
+func init() {
+	reelBuildCmd.Flags().StringP("output", "o", "reel.mp4", "Reel file to write (.mp4 or .mkv)")
+	reelBuildCmd.Flags().String("video", "", "Video to select events from (default: the video open in mpv)")
+	reelBuildCmd.Flags().Bool("all", false, "Include every event of the video")
+	reelBuildCmd.Flags().String("category", "", "Include the events in this category and its subcategories")
+	reelBuildCmd.Flags().String("player", "", "Include the events and tackles of this player")
+	reelBuildCmd.Flags().Bool("starred", false, "Include starred events only")
+	reelBuildCmd.Flags().Bool("all-videos", false, "Select events from every video instead of one")
+	reelBuildCmd.Flags().Int64("match", 0, "Select events from the videos of this match")
+	reelBuildCmd.Flags().String("season", "", "Select events from a season's matches (e.g. 2025 or 2025/26)")
+	reelBuildCmd.Flags().Int("season-start", season.DefaultStartMonth, "Month the season starts (1-12)")
+	reelBuildCmd.Flags().Float64("lead-in", 0, "Seconds shown before each event (default: clip_padding before)")
+	reelBuildCmd.Flags().Float64("lead-out", 0, "Seconds shown after each event (default: clip_padding after)")
+	reelBuildCmd.Flags().Bool("titles", false, "Show a title card before each event")
+	reelBuildCmd.Flags().Float64("title-duration", 2, "Seconds each title card is shown")
+	reelBuildCmd.Flags().String("size", "1280x720", "Frame size of the reel")
+
+	reelCmd.AddCommand(reelBuildCmd)
+	rootCmd.AddCommand(reelCmd)
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/report"
+)
+
+// reportDateLayout is the date format accepted by report flags.
+const reportDateLayout = "2006-01-02"
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate summary reports across matches",
+	Long:  `Generate summary documents that aggregate several tagged matches.`,
+}
+
+var reportWeeklyCmd = &cobra.Command{
+	Use:   "weekly",
+	Short: "Summarize all matches tagged in a date range",
+	Long: `Summarize all matches tagged in a date range (default: the last 7 days) as
+Markdown or HTML, suitable for the weekly coaches' email.
+
+The report lists the matches with their lineups, each player's tackle
+completion per match with a trend, and every starred event with a link to its
+exported clip. A match is included when any of its notes were created in the
+range.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromFlag, _ := cmd.Flags().GetString("from")
+		toFlag, _ := cmd.Flags().GetString("to")
+		format, _ := cmd.Flags().GetString("format")
+		outputPath, _ := cmd.Flags().GetString("output")
+		opponent, _ := cmd.Flags().GetString("opponent")
+
+		if format != "markdown" && format != "md" && format != "html" {
+			return usageErrorf("invalid format: %s (supported: markdown, html)", format)
+		}
+
+		fromStr, toStr, endStr, err := parseDateRange(fromFlag, toFlag, 7)
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		matches, err := db.SelectReportMatches(database, fromStr, endStr)
+		if err != nil {
+			return fmt.Errorf("failed to query matches: %w", err)
+		}
+		if opponent != "" {
+			matches = filterReportMatches(matches, opponent)
+		}
+		tackles, err := db.SelectReportPlayerTackles(database, fromStr, endStr)
+		if err != nil {
+			return fmt.Errorf("failed to query tackles: %w", err)
+		}
+		flagged, err := db.SelectFlaggedClips(database, fromStr, endStr)
+		if err != nil {
+			return fmt.Errorf("failed to query starred events: %w", err)
+		}
+		weekly := report.BuildWeekly(fromStr, toStr, matches, tackles, flagged)
+		for i := range weekly.Matches {
+			if weekly.Matches[i].Lineup, err = db.SelectLineup(database, weekly.Matches[i].VideoID); err != nil {
+				return fmt.Errorf("failed to query lineup: %w", err)
+			}
+		}
+
+		out := os.Stdout
+		if outputPath != "" {
+			f, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		if format == "html" {
+			err = report.WriteHTML(out, weekly)
+		} else {
+			err = report.WriteMarkdown(out, weekly)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+
+		if outputPath != "" {
+			infof("Wrote weekly report (%d matches) to %s\n", len(weekly.Matches), outputPath)
+		}
+		return nil
+	},
+}
+
+// filterReportMatches keeps the matches whose opponent contains the given name (case-insensitive).
+func filterReportMatches(matches []db.ReportMatch, opponent string) []db.ReportMatch {
+	opponent = strings.ToLower(opponent)
+	var kept []db.ReportMatch
+	for _, m := range matches {
+		if strings.Contains(strings.ToLower(m.Match.Opponent), opponent) {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// parseDateRange parses --from/--to flags into YYYY-MM-DD strings. to defaults to
+// today and from to days-1 days before to. end is the day after to, for
+// half-open [from, end) comparisons that include the whole last day.
+func parseDateRange(fromFlag, toFlag string, days int) (from, to, end string, err error) {
+	toDate := time.Now()
+	if toFlag != "" {
+		t, err := time.Parse(reportDateLayout, toFlag)
+		if err != nil {
+			return "", "", "", usageErrorf("invalid --to date (want YYYY-MM-DD): %s", toFlag)
+		}
+		toDate = t
+	}
+	fromDate := toDate.AddDate(0, 0, -(days - 1))
+	if fromFlag != "" {
+		f, err := time.Parse(reportDateLayout, fromFlag)
+		if err != nil {
+			return "", "", "", usageErrorf("invalid --from date (want YYYY-MM-DD): %s", fromFlag)
+		}
+		fromDate = f
+	}
+	if fromDate.After(toDate) {
+		return "", "", "", fmt.Errorf("--from (%s) is after --to (%s)", fromDate.Format(reportDateLayout), toDate.Format(reportDateLayout))
+	}
+	return fromDate.Format(reportDateLayout), toDate.Format(reportDateLayout), toDate.AddDate(0, 0, 1).Format(reportDateLayout), nil
+}
+
+func init() {
+	reportWeeklyCmd.Flags().String("from", "", "Start date YYYY-MM-DD (default: 6 days before --to)")
+	reportWeeklyCmd.Flags().String("to", "", "End date YYYY-MM-DD, inclusive (default: today)")
+	reportWeeklyCmd.Flags().StringP("format", "f", "markdown", "Output format (markdown, html)")
+	reportWeeklyCmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+	reportWeeklyCmd.Flags().String("opponent", "", "Only include matches against this opponent")
+
+	reportCmd.AddCommand(reportWeeklyCmd)
+	rootCmd.AddCommand(reportCmd)
+}
@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/user/tagging-rugby-cli/clip"
+	"github.com/user/tagging-rugby-cli/config"
 	"github.com/user/tagging-rugby-cli/db"
 	"github.com/user/tagging-rugby-cli/deps"
 	"github.com/user/tagging-rugby-cli/mpv"
 	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+	"github.com/user/tagging-rugby-cli/suggest"
 	"github.com/user/tagging-rugby-cli/tui"
 )
 
@@ -30,6 +34,36 @@ Features:
   - Add timestamped notes, clips, and tackle events
   - Filter and search annotations
   - Export clips and statistics`,
+	// Execute prints errors once, with the usage only for cobra's own
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		dbPath, _ := cmd.Flags().GetString("db")
+		readOnly, _ := cmd.Flags().GetBool("read-only")
+		quiet, _ = cmd.Flags().GetBool("quiet")
+
+		// A broken config.json is reported by the commands that need it, so
+		// only apply its paths when it loads
+		if cfg, err := config.Load(); err == nil {
+			if cfg.Binaries != nil {
+				deps.SetPaths(cfg.Binaries.Mpv, cfg.Binaries.Ffmpeg, cfg.Binaries.Ffprobe)
+			}
+			if dbPath == "" {
+				db.SetPath(cfg.DB)
+			}
+			mpv.SetSocketPath(cfg.MpvSocket)
+		}
+
+		if dbPath != "" {
+			absPath, err := filepath.Abs(dbPath)
+			if err != nil {
+				return fmt.Errorf("failed to resolve --db path: %w", err)
+			}
+			db.SetPath(absPath)
+		}
+		db.SetReadOnly(readOnly)
+		return nil
+	},
 }
 
 var versionCmd = &cobra.Command{
@@ -41,58 +75,111 @@ var versionCmd = &cobra.Command{
 }
 
 var openCmd = &cobra.Command{
-	Use:   "open <video-file>",
-	Short: "Open a video file for analysis",
-	Long:  `Open a video file in mpv for analysis. The video player will launch and the CLI can be used to add notes and annotations.`,
-	Args:  cobra.ExactArgs(1),
+	Use:   "open <video-file|url|->",
+	Short: "Open a video file or stream URL for analysis",
+	Long: `Open a video file in mpv for analysis. The video player will launch and the CLI can be used to add notes and annotations.
+
+Any mpv-playable URL (YouTube, Veo, HLS playlists, ...) can be used instead of a file.
+Streams are registered with the URL as their path; clip export is disabled for them
+until a local copy is linked with 'video relink'.
+
+With - the videos are read from stdin, one per line, and picked from a list:
+
+  find ~/matches -name '*.mp4' | tagging-rugby-cli open - --tui
+  fd -e mkv | fzf -m | tagging-rugby-cli open -`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		videoPath := args[0]
+		if videoPath == "-" {
+			picked, err := pickVideo()
+			if err != nil {
+				return err
+			}
+			if picked == "" {
+				infoln("Cancelled.")
+				return nil
+			}
+			videoPath = picked
+		}
 		useTUI, _ := cmd.Flags().GetBool("tui")
-
-		// Resolve to absolute path
-		absPath, err := filepath.Abs(videoPath)
-		if err != nil {
-			return fmt.Errorf("failed to resolve path: %w", err)
+		suggestCmd, _ := cmd.Flags().GetString("suggest-cmd")
+		offline, _ := cmd.Flags().GetBool("offline")
+		pprofAddr, _ := cmd.Flags().GetString("pprof")
+		if offline {
+			// There is no player window to fall back to, so offline always uses the TUI
+			useTUI = true
 		}
 
-		// Check video file exists
-		info, err := os.Stat(absPath)
-		if os.IsNotExist(err) {
-			return fmt.Errorf("video file not found: %s", absPath)
-		}
-		if err != nil {
-			return fmt.Errorf("failed to access video file: %w", err)
-		}
-		if info.IsDir() {
-			return fmt.Errorf("path is a directory, not a video file: %s", absPath)
+		// Stream URLs (YouTube, Veo, HLS) are handed to mpv as-is and registered with
+		// the URL as their path; local files are resolved and checked on disk.
+		isStream := videosrc.IsURL(videoPath)
+		absPath := videoPath
+		var filesize int64
+		if !isStream {
+			// Resolve to absolute path
+			resolved, err := filepath.Abs(videoPath)
+			if err != nil {
+				return fmt.Errorf("failed to resolve path: %w", err)
+			}
+			absPath = resolved
+
+			// Check video file exists
+			info, err := os.Stat(absPath)
+			if os.IsNotExist(err) {
+				return notFoundErrorf("video file not found: %s", absPath)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to access video file: %w", err)
+			}
+			if info.IsDir() {
+				return usageErrorf("path is a directory, not a video file: %s", absPath)
+			}
+			filesize = info.Size()
 		}
 
-		// Launch mpv with video file
-		fmt.Printf("Opening video: %s\n", filepath.Base(absPath))
-		process, err := mpv.LaunchMpv(absPath)
-		if err != nil {
-			return fmt.Errorf("failed to launch mpv: %w", err)
+		// Launch mpv with video file or stream URL
+		if isStream {
+			infof("Opening stream: %s\n", absPath)
+		} else {
+			infof("Opening video: %s\n", filepath.Base(absPath))
 		}
 
-		// Wait briefly for socket to be ready
-		client := mpv.NewClient("")
-		var connectErr error
-		for i := 0; i < 50; i++ { // Wait up to 5 seconds
-			time.Sleep(100 * time.Millisecond)
-			connectErr = client.Connect()
-			if connectErr == nil {
-				break
+		// Offline mode tags against a simulated player instead of mpv
+		var client mpv.Player
+		var fake *mpv.Fake
+		process := &exec.Cmd{}
+		if offline {
+			fake = mpv.NewFake(0)
+			fake.Clock = time.Now
+			client = fake
+		} else {
+			var err error
+			process, err = mpv.LaunchMpv(absPath)
+			if err != nil {
+				return fmt.Errorf("failed to launch mpv: %w", err)
 			}
-		}
 
-		if connectErr != nil {
-			// Kill mpv if we couldn't connect
-			if process.Process != nil {
-				process.Process.Kill()
+			// Wait briefly for socket to be ready
+			ipc := mpv.NewClient("")
+			var connectErr error
+			for i := 0; i < 50; i++ { // Wait up to 5 seconds
+				time.Sleep(100 * time.Millisecond)
+				connectErr = ipc.Connect()
+				if connectErr == nil {
+					break
+				}
+			}
+
+			if connectErr != nil {
+				// Kill mpv if we couldn't connect
+				if process.Process != nil {
+					process.Process.Kill()
+				}
+				return fmt.Errorf("failed to connect to mpv: %w", connectErr)
 			}
-			return fmt.Errorf("failed to connect to mpv: %w", connectErr)
+			defer ipc.Close()
+			client = ipc
 		}
-		defer client.Close()
 
 		// Open database to check for existing session data
 		database, err := db.Open()
@@ -120,10 +207,13 @@ var openCmd = &cobra.Command{
 
 		// Print session info
 		if noteCount > 0 {
-			fmt.Printf("Resuming session: %d notes\n", noteCount)
-			fmt.Printf("Video: %s%s\n", filepath.Base(absPath), durationStr)
+			infof("Resuming session: %d notes\n", noteCount)
+			infof("Video: %s%s\n", videosrc.Base(absPath), durationStr)
 		} else {
-			fmt.Printf("Video session started: %s%s\n", filepath.Base(absPath), durationStr)
+			infof("Video session started: %s%s\n", videosrc.Base(absPath), durationStr)
+		}
+		if isStream {
+			infoln("Clip export is disabled for streams until a local copy is relinked (video relink).")
 		}
 
 		// Launch TUI if requested
@@ -147,14 +237,16 @@ var openCmd = &cobra.Command{
 				log.Printf("queue unprocessed tackle clips on startup: %v", err)
 			}
 
-			// Start background clip processor
+			// Start background clip processor. On exit a clip still rendering is
+			// stopped and put back in the queue before the database closes.
 			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
 			processor := clip.Processor{DB: database}
 			processor.Start(ctx)
+			defer processor.Wait()
+			defer cancel()
 
 			// Register the video in the database and get its ID
-			videoID, err := db.EnsureVideo(database, absPath, info.Size(), "")
+			videoID, err := db.EnsureVideo(database, absPath, filesize, "")
 			if err != nil {
 				videoID = 0
 			}
@@ -162,16 +254,32 @@ var openCmd = &cobra.Command{
 			// Ensure a video_timings row exists and resume from last stopped position
 			if videoID > 0 {
 				timing, timingErr := db.EnsureVideoTiming(database, videoID, duration)
+				if timingErr == nil && fake != nil {
+					// The simulated player takes its length from the last real session
+					fake.Duration = timing.Length
+				}
 				if timingErr == nil && timing.Stopped != nil && *timing.Stopped > 0 {
 					if seekErr := client.Seek(*timing.Stopped); seekErr == nil {
 						client.Pause()
-						fmt.Printf("Resuming from %s\n", timeutil.FormatTime(*timing.Stopped))
+						infof("Resuming from %s\n", timeutil.FormatTime(*timing.Stopped))
 					}
 				}
 			}
 
+			// Optional external suggester feeds the TUI review queue
+			var suggester suggest.Suggester
+			if suggestCmd != "" {
+				suggester = suggest.Command{Line: suggestCmd}
+			}
+
+			if pprofAddr != "" {
+				if err := startPprof(pprofAddr); err != nil {
+					return err
+				}
+			}
+
 			// Run TUI (blocks until quit)
-			if err := tui.Run(client, database, absPath, videoID); err != nil {
+			if err := tui.Run(client, database, absPath, videoID, suggester); err != nil {
 				if process.Process != nil {
 					process.Process.Kill()
 				}
@@ -195,56 +303,64 @@ var openCmd = &cobra.Command{
 	},
 }
 
-var doctorCmd = &cobra.Command{
-	Use:   "doctor",
-	Short: "Check system dependencies",
-	Long:  `Check that all required system dependencies (mpv, ffmpeg) are installed and available.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Checking dependencies...")
-		fmt.Println()
-
-		allGood := true
-
-		// Check mpv
-		if err := deps.CheckMpv(); err != nil {
-			fmt.Println("✗ mpv: NOT FOUND")
-			fmt.Printf("  Install from: %s\n", deps.MpvInstallURL)
-			allGood = false
-		} else {
-			fmt.Println("✓ mpv: OK")
-		}
-
-		// Check ffmpeg
-		if err := deps.CheckFfmpeg(); err != nil {
-			fmt.Println("✗ ffmpeg: NOT FOUND")
-			fmt.Printf("  Install from: %s\n", deps.FfmpegInstallURL)
-			allGood = false
-		} else {
-			fmt.Println("✓ ffmpeg: OK")
-		}
-
-		fmt.Println()
-		if allGood {
-			fmt.Println("All dependencies are installed!")
-		} else {
-			fmt.Println("Some dependencies are missing. Please install them to use all features.")
-			os.Exit(1)
-		}
-	},
-}
-
 func init() {
+	rootCmd.PersistentFlags().String("db", "", "Database file (default: \"db\" in config.json, else ~/.local/share/tagging-rugby-cli/data.db)")
+	rootCmd.PersistentFlags().Bool("read-only", false, "Open the database read-only (e.g. a season archive)")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Only print requested data (tables, exports) and errors")
+
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(openCmd)
-	rootCmd.AddCommand(doctorCmd)
 
 	// Flags for open command
 	openCmd.Flags().BoolP("tui", "t", false, "Launch TUI instead of CLI mode")
+	openCmd.Flags().String("suggest-cmd", "", "External command that suggests events for review in the TUI (video path is appended)")
+	openCmd.Flags().Bool("offline", false, "Tag in the TUI without mpv, using a simulated player clock")
+	openCmd.Flags().String("pprof", "", "Serve net/http/pprof on this address while the TUI runs (e.g. localhost:6060)")
 }
 
+// Execute runs the CLI and exits with the error's exit code (see exit.go). An
+// unknown command or a bad flag, which cobra raises, is followed by the
+// command's usage.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	markRunErrors(rootCmd)
+	cmd, err := rootCmd.ExecuteC()
+	if err == nil {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	if isCobraError(err) {
+		fmt.Fprint(os.Stderr, "\n"+cmd.UsageString())
+	}
+	os.Exit(exitCode(err))
+}
+
+// resolveVideoPath returns the absolute path of the given video, or the path of the
+// video currently open in mpv when videoFlag is empty. Stream URLs are returned unchanged.
+func resolveVideoPath(videoFlag string) (string, error) {
+	if videosrc.IsURL(videoFlag) {
+		return videoFlag, nil
+	}
+	if videoFlag != "" {
+		absPath, err := filepath.Abs(videoFlag)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve path: %w", err)
+		}
+		return absPath, nil
+	}
+
+	client := mpv.NewClient("")
+	if err := client.Connect(); err != nil {
+		return "", fmt.Errorf("failed to connect to mpv: %w\n(Is mpv running with a video open? Or pass --video)", err)
+	}
+	defer client.Close()
+
+	videoPathRaw, err := client.GetProperty("path")
+	if err != nil {
+		return "", fmt.Errorf("failed to get video path: %w", err)
+	}
+	videoPath, ok := videoPathRaw.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected video path type: %T", videoPathRaw)
 	}
+	return videoPath, nil
 }
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/report"
+)
+
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Review the tagging session log",
+	Long: `Review the tagging session log.
+
+A session is recorded each time the TUI is opened on a video, with its start and
+end time and the number of events added. Use it to account for review workload
+or to see when footage was processed.`,
+}
+
+var sessionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tagging sessions in a date range",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessions, err := loadSessions(cmd)
+		if err != nil {
+			return err
+		}
+		if len(sessions) == 0 {
+			infoln("No sessions found.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tStarted\tDuration\tEvents\tVideo")
+		fmt.Fprintln(w, "--\t-------\t--------\t------\t-----")
+		for _, s := range sessions {
+			duration := "open"
+			if s.EndedAt.Valid {
+				duration = formatSessionDuration(s.EndedAt.Time.Sub(s.StartedAt))
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n",
+				s.ID, s.StartedAt.Local().Format("2006-01-02 15:04"), duration, s.EventsAdded, s.Filename)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var sessionSummaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Summarize session time and events",
+	Long: `Summarize tagging time and events added, grouped by day, week or video.
+Sessions that were never closed count towards the session total but not the time.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, _ := cmd.Flags().GetString("by")
+
+		var key func(db.Session) string
+		switch by {
+		case "day":
+			key = func(s db.Session) string { return s.StartedAt.Local().Format("2006-01-02") }
+		case "week":
+			key = func(s db.Session) string {
+				year, week := s.StartedAt.Local().ISOWeek()
+				return fmt.Sprintf("%d-W%02d", year, week)
+			}
+		case "video":
+			key = func(s db.Session) string { return s.Filename }
+		default:
+			return usageErrorf("invalid --by: %s (supported: day, week, video)", by)
+		}
+
+		sessions, err := loadSessions(cmd)
+		if err != nil {
+			return err
+		}
+		if len(sessions) == 0 {
+			infoln("No sessions found.")
+			return nil
+		}
+
+		type total struct {
+			sessions int
+			duration time.Duration
+			events   int
+		}
+		totals := make(map[string]*total)
+		var groups []string
+		var all total
+		for _, s := range sessions {
+			k := key(s)
+			t, ok := totals[k]
+			if !ok {
+				t = &total{}
+				totals[k] = t
+				groups = append(groups, k)
+			}
+			t.sessions++
+			t.events += s.EventsAdded
+			all.sessions++
+			all.events += s.EventsAdded
+			if s.EndedAt.Valid {
+				d := s.EndedAt.Time.Sub(s.StartedAt)
+				t.duration += d
+				all.duration += d
+			}
+		}
+		if by == "video" {
+			sort.Strings(groups)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "%s\tSessions\tTime\tEvents\n", strings.ToUpper(by[:1])+by[1:])
+		fmt.Fprintln(w, "----\t--------\t----\t------")
+		for _, g := range groups {
+			t := totals[g]
+			fmt.Fprintf(w, "%s\t%d\t%s\t%d\n", g, t.sessions, formatSessionDuration(t.duration), t.events)
+		}
+		fmt.Fprintf(w, "Total\t%d\t%s\t%d\n", all.sessions, formatSessionDuration(all.duration), all.events)
+		w.Flush()
+		return nil
+	},
+}
+
+var sessionExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export sessions as an iCalendar file",
+	Long: `Export tagging sessions as an iCalendar (.ics) file, one event per session,
+for importing into Google Calendar, Outlook or Apple Calendar.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		outputPath, _ := cmd.Flags().GetString("output")
+
+		if format != "ical" && format != "ics" {
+			return usageErrorf("invalid format: %s (supported: ical)", format)
+		}
+
+		sessions, err := loadSessions(cmd)
+		if err != nil {
+			return err
+		}
+
+		out := os.Stdout
+		if outputPath != "" {
+			f, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		if err := report.WriteSessionsICal(out, sessions, time.Now()); err != nil {
+			return fmt.Errorf("failed to write calendar: %w", err)
+		}
+
+		if outputPath != "" {
+			infof("Exported %d session(s) to %s\n", len(sessions), outputPath)
+		}
+		return nil
+	},
+}
+
+// loadSessions opens the database and returns the sessions in the command's
+// --from/--to range (default: the last 30 days).
+func loadSessions(cmd *cobra.Command) ([]db.Session, error) {
+	fromFlag, _ := cmd.Flags().GetString("from")
+	toFlag, _ := cmd.Flags().GetString("to")
+
+	from, _, end, err := parseDateRange(fromFlag, toFlag, 30)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open database
+	database, err := db.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer database.Close()
+
+	sessions, err := db.SelectSessions(database, from, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// formatSessionDuration formats a duration as "1h05m" or "12m".
+func formatSessionDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	if h > 0 {
+		return fmt.Sprintf("%dh%02dm", h, m)
+	}
+	return fmt.Sprintf("%dm", m)
+}
+
+func init() {
+	for _, c := range []*cobra.Command{sessionListCmd, sessionSummaryCmd, sessionExportCmd} {
+		c.Flags().String("from", "", "Start date YYYY-MM-DD (default: 29 days before --to)")
+		c.Flags().String("to", "", "End date YYYY-MM-DD, inclusive (default: today)")
+	}
+	sessionSummaryCmd.Flags().String("by", "week", "Group by day, week or video")
+	sessionExportCmd.Flags().StringP("format", "f", "ical", "Output format (ical)")
+	sessionExportCmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+
+	sessionCmd.AddCommand(sessionListCmd)
+	sessionCmd.AddCommand(sessionSummaryCmd)
+	sessionCmd.AddCommand(sessionExportCmd)
+	rootCmd.AddCommand(sessionCmd)
+}
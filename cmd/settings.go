@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+)
+
+var settingsCmd = &cobra.Command{
+	Use:   "settings",
+	Short: "Hand the whole app setup over with a file or the database",
+	Long: `Export and import the settings bundle: all of config.json (hotkeys, snippets,
+note templates, the dictionary, form defaults, controller bindings and device
+paths, webhooks, upload targets) and the Lua scripts, in one file. The bundle
+can also be embedded in the database, so it travels with the data when the
+database is copied to another laptop. Event types already live in the database.
+
+Upload client secrets and the remote token are left out unless --secrets is
+given; importing a bundle without them keeps the ones already on the machine.
+
+To share only how an analyst drives the TUI, without machine settings, use
+'profile export' instead.`,
+}
+
+var settingsExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write the settings bundle to a file, the database, or both",
+	Long: `Write the settings bundle to a file, or with --embed store it in the database
+(replacing any bundle embedded before), or both.
+
+  tagging-rugby-cli settings export settings.json
+  tagging-rugby-cli settings export --embed`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		embed, _ := cmd.Flags().GetBool("embed")
+		secrets, _ := cmd.Flags().GetBool("secrets")
+		if len(args) == 0 && !embed {
+			return usageErrorf("give a file to write, or --embed to store the settings in the database")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		settings := config.NewSettings(cfg)
+		if !secrets {
+			settings.StripSecrets()
+		}
+		scripts, err := readScripts()
+		if err != nil {
+			return err
+		}
+		if len(scripts) > 0 {
+			settings.Scripts = scripts
+		}
+
+		data, err := json.MarshalIndent(settings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode settings: %w", err)
+		}
+		if len(args) > 0 {
+			if err := os.WriteFile(args[0], append(data, '\n'), 0600); err != nil {
+				return fmt.Errorf("failed to write settings: %w", err)
+			}
+			infof("Exported settings to %s (%d script(s))\n", args[0], len(scripts))
+		}
+		if embed {
+			database, err := db.Open()
+			if err != nil {
+				return fmt.Errorf("failed to open database: %w", err)
+			}
+			defer database.Close()
+			if err := db.UpsertSetting(database, db.SettingsBundle, string(data)); err != nil {
+				return fmt.Errorf("failed to embed settings: %w", err)
+			}
+			infof("Embedded settings in the database (%d script(s))\n", len(scripts))
+		}
+		return nil
+	},
+}
+
+var settingsImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Replace config.json and add the scripts from a settings bundle",
+	Long: `Replace config.json with the one in a settings bundle, read from a file or with
+--embedded from the database, and write its scripts to the scripts directory.
+The config.json replaced is kept as config.json.bak, and its db, mpv_socket and
+clip_dir stay. Scripts with the same name are overwritten; other scripts are
+left in place.
+
+  tagging-rugby-cli settings import settings.json
+  tagging-rugby-cli --db club.db settings import --embedded`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		embedded, _ := cmd.Flags().GetBool("embedded")
+		if len(args) > 0 == embedded {
+			return usageErrorf("give a settings file, or --embedded to read the settings from the database")
+		}
+
+		var data []byte
+		source := "the database"
+		if embedded {
+			database, err := db.Open()
+			if err != nil {
+				return fmt.Errorf("failed to open database: %w", err)
+			}
+			defer database.Close()
+			value, err := db.SelectSetting(database, db.SettingsBundle)
+			if errors.Is(err, sql.ErrNoRows) {
+				return notFoundErrorf("no settings embedded in the database (use 'settings export --embed')")
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read settings: %w", err)
+			}
+			data = []byte(value)
+		} else {
+			source = args[0]
+			var err error
+			data, err = os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read settings: %w", err)
+			}
+		}
+
+		var settings config.Settings
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return fmt.Errorf("failed to parse settings: %w", err)
+		}
+		if err := settings.Check(); err != nil {
+			return err
+		}
+		// Check every script name before writing anything
+		if err := checkScriptNames(settings.Scripts); err != nil {
+			return err
+		}
+
+		old, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		settings.KeepSecrets(old)
+		settings.KeepPaths(old)
+		if err := backupConfig(); err != nil {
+			return err
+		}
+		if err := config.Save(settings.Config); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		if err := writeScripts(settings.Scripts); err != nil {
+			return err
+		}
+		infof("Imported settings exported %s from %s (%d script(s))\n",
+			settings.ExportedAt.Local().Format("2006-01-02 15:04"), source, len(settings.Scripts))
+		return nil
+	},
+}
+
+// backupConfig copies config.json to config.json.bak, if there is one.
+func backupConfig() error {
+	path, err := config.Path()
+	if err != nil {
+		return fmt.Errorf("failed to locate config: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := os.WriteFile(path+".bak", data, 0600); err != nil {
+		return fmt.Errorf("failed to back up config: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	settingsExportCmd.Flags().Bool("embed", false, "Store the settings in the database")
+	settingsExportCmd.Flags().Bool("secrets", false, "Include upload client secrets and the remote token")
+	settingsImportCmd.Flags().Bool("embedded", false, "Read the settings embedded in the database")
+
+	settingsCmd.AddCommand(settingsExportCmd)
+	settingsCmd.AddCommand(settingsImportCmd)
+	rootCmd.AddCommand(settingsCmd)
+}
@@ -0,0 +1,497 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+	"github.com/user/tagging-rugby-cli/pkg/gameclock"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Match statistics reports",
+	Long: `Print statistics derived from tagged events for a video.
+
+With --explain each command prints the SQL behind its numbers instead, with
+the video and filters bound, and notes any arithmetic done after the query.
+Each statement runs as it is with 'db query':
+
+  tagging-rugby-cli stats tackles --zone 22 --explain | tagging-rugby-cli db query -`,
+}
+
+var statsPossessionCmd = &cobra.Command{
+	Use:   "possession",
+	Short: "Show possession and territory summary for a video",
+	Long: `Show possession % and territory % for a video, derived from the possession
+intervals recorded in the TUI (p / P / Y keys in video focus).
+
+Territory is the share of time the ball spent in the opposition half.
+Uses the video open in mpv unless --video is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoFlag, _ := cmd.Flags().GetString("video")
+		explain, _ := cmd.Flags().GetBool("explain")
+
+		videoPath, err := resolveVideoPath(videoFlag)
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := db.SelectVideoIDByPath(database, videoPath)
+		if err == sql.ErrNoRows {
+			return notFoundErrorf("video not found in database: %s", videoPath)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to look up video: %w", err)
+		}
+
+		if explain {
+			explainQuery("Possession intervals", db.SelectPossessionsByVideoSQL, videoID)
+			explainQuery("Last playback position, where an open interval ends", db.SelectVideoTimingByVideoSQL, videoID)
+			explainNote(
+				"Each interval lasts end - start; an open one (end NULL) runs to the later of",
+				"the last playback position and the latest interval start or end.",
+				"Possession % = a team's time / (home + away time).",
+				"Territory % = time with territory in the opposition half / (time with a",
+				"territory of home or away): home's is territory = 'away', away's territory = 'home'.",
+			)
+			return nil
+		}
+
+		possessions, err := db.SelectPossessionsByVideo(database, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to query possessions: %w", err)
+		}
+		if len(possessions) == 0 {
+			infoln("No possession data recorded for this video.")
+			return nil
+		}
+
+		// Count open intervals up to the last playback position (or latest recorded timestamp)
+		now := 0.0
+		var vt db.VideoTiming
+		if err := database.QueryRow(db.SelectVideoTimingByVideoSQL, videoID).Scan(&vt.ID, &vt.VideoID, &vt.Stopped, &vt.Length); err == nil && vt.Stopped != nil {
+			now = *vt.Stopped
+		}
+		for _, p := range possessions {
+			if p.Start > now {
+				now = p.Start
+			}
+			if p.End != nil && *p.End > now {
+				now = *p.End
+			}
+		}
+		summary := db.SummarizePossessions(possessions, now)
+		homePoss, awayPoss := summary.PossessionPct()
+		homeTerr, awayTerr := summary.TerritoryPct()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Team\tPossession\tTime\tTerritory")
+		fmt.Fprintln(w, "----\t----------\t----\t---------")
+		fmt.Fprintf(w, "Home\t%.0f%%\t%s\t%.0f%%\n", homePoss, timeutil.FormatTime(summary.HomeSeconds), homeTerr)
+		fmt.Fprintf(w, "Away\t%.0f%%\t%s\t%.0f%%\n", awayPoss, timeutil.FormatTime(summary.AwaySeconds), awayTerr)
+		w.Flush()
+
+		infof("\n%d possession interval(s) found.\n", len(possessions))
+		return nil
+	},
+}
+
+var statsTimelineCmd = &cobra.Command{
+	Use:   "timeline",
+	Short: "Chart a video's events per 10 minutes of each half",
+	Long: `Count a video's events, tackles and missed tackles in windows of game time
+(10 minutes by default), split by half, to show fatigue patterns such as
+missed tackles piling up late in a half.
+
+Windows follow the game clock when match periods are recorded (see 'analyze
+halves'), with added time in its own window; otherwise video time is used.
+Uses the video open in mpv unless --video is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoFlag, _ := cmd.Flags().GetString("video")
+		minutes, _ := cmd.Flags().GetInt("window")
+		explain, _ := cmd.Flags().GetBool("explain")
+		if minutes < 1 {
+			return usageErrorf("invalid --window: %d (want minutes, 1 or more)", minutes)
+		}
+
+		videoPath, err := resolveVideoPath(videoFlag)
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		if explain {
+			return explainTimeline(database, videoPath, minutes)
+		}
+
+		notes, err := db.SelectNotesForExport(database, videoPath)
+		if err != nil {
+			return fmt.Errorf("failed to query notes: %w", err)
+		}
+		if len(notes) == 0 {
+			infoln("No events recorded for this video.")
+			return nil
+		}
+		clock, err := gameClockForVideo(database, videoPath)
+		if err != nil {
+			return err
+		}
+
+		end := 0.0
+		for _, n := range notes {
+			end = max(end, n.Start)
+		}
+		windows := clock.Windows(float64(minutes*60), end)
+		counts := make([]timelineCount, len(windows))
+		outside := 0
+		for _, n := range notes {
+			i := gameclock.WindowAt(windows, n.Start)
+			if i < 0 {
+				outside++
+				continue
+			}
+			counts[i].Events++
+			if n.Category == "tackle" {
+				counts[i].Tackles++
+				switch n.Outcome {
+				case "completed":
+					counts[i].Completed++
+				case "missed":
+					counts[i].Missed++
+				}
+			}
+		}
+		mostEvents := 0
+		for _, c := range counts {
+			mostEvents = max(mostEvents, c.Events)
+		}
+
+		if !clock.HasPeriods() {
+			fmt.Println("Video time (no match periods recorded: see 'analyze halves')")
+		}
+		var w *tabwriter.Writer
+		var period timelineCount
+		for i, win := range windows {
+			if i == 0 || win.Period != windows[i-1].Period {
+				if clock.HasPeriods() {
+					fmt.Printf("%s\n", win.PeriodName)
+				}
+				w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "Minutes\tEvents\tTackles\tMissed\tComp %")
+				fmt.Fprintln(w, "-------\t------\t-------\t------\t------")
+				period = timelineCount{}
+			}
+			c := counts[i]
+			events := fmt.Sprint(c.Events)
+			if bar := strings.Repeat("█", c.Events*timelineBarWidth/max(mostEvents, 1)); bar != "" {
+				events = bar + " " + events
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", win.Label, events, c.Tackles, c.Missed, c.completion())
+			period.add(c)
+
+			if i == len(windows)-1 || windows[i+1].Period != win.Period {
+				w.Flush()
+				fmt.Printf("%d event(s), %d tackle(s), %s completed\n\n", period.Events, period.Tackles, period.completion())
+			}
+		}
+		if outside > 0 {
+			infof("%d event(s) before kick-off, at half time or after full time not charted.\n", outside)
+		}
+		return nil
+	},
+}
+
+var statsTacklesCmd = &cobra.Command{
+	Use:   "tackles",
+	Short: "Show tackle completion by player or zone",
+	Long: `Show tackle counts and completion % per player, or per field zone with
+--by zone, with how many missed tackles were covered by a follow-up (the
+Followed field of the tackle form).
+
+Narrow the tackles with --zone (e.g. completion in the 22 against midfield) and
+--followed yes|no. Uses the video open in mpv unless --video, --all, --match
+or --season is given.
+
+  tagging-rugby-cli stats tackles --by zone
+  tagging-rugby-cli stats tackles --zone 22 --all
+  tagging-rugby-cli stats tackles --season 2025`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter := db.TackleFilter{}
+		filter.Zone, _ = cmd.Flags().GetString("zone")
+		filter.Followed, _ = cmd.Flags().GetString("followed")
+		filter.GroupBy, _ = cmd.Flags().GetString("by")
+		explain, _ := cmd.Flags().GetBool("explain")
+		if err := filter.Validate(); err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		if filter.VideoScope, err = videoScopeFromFlags(cmd, database); err != nil {
+			return err
+		}
+
+		if explain {
+			explainQuery("Tackles by outcome per "+filter.GroupBy, db.SelectTackleBreakdownSQL, filter.GroupBy, filter.VideoID, filter.Zone, filter.Followed, filter.MatchID, filter.From, filter.To)
+			explainNote(
+				"Comp % = completed / (completed + missed); possible and other tackles are left out.",
+				"Covered = missed tackles with a followed detail / missed.",
+				"TOTAL sums the rows.",
+			)
+			return nil
+		}
+
+		stats, err := db.SelectTackleBreakdown(database, filter)
+		if err != nil {
+			return fmt.Errorf("failed to query tackle stats: %w", err)
+		}
+		if len(stats) == 0 {
+			infoln("No tackles match.")
+			return nil
+		}
+
+		group := "Player"
+		if filter.GroupBy == "zone" {
+			group = "Zone"
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "%s\tTotal\tCompleted\tMissed\tComp %%\tCovered\n", group)
+		fmt.Fprintf(w, "%s\t-----\t---------\t------\t------\t-------\n", strings.Repeat("-", len(group)))
+		var sum db.TackleBreakdown
+		for _, s := range stats {
+			name := s.Group
+			if name == "" {
+				name = "(none)"
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\n", name, s.Total, s.Completed, s.Missed, completionPct(s.Completed, s.Missed), coveredPct(s.Covered, s.Missed))
+			sum.Total += s.Total
+			sum.Completed += s.Completed
+			sum.Missed += s.Missed
+			sum.Covered += s.Covered
+		}
+		fmt.Fprintf(w, "TOTAL\t%d\t%d\t%d\t%s\t%s\n", sum.Total, sum.Completed, sum.Missed, completionPct(sum.Completed, sum.Missed), coveredPct(sum.Covered, sum.Missed))
+		w.Flush()
+		return nil
+	},
+}
+
+// completionPct formats completed / (completed + missed), or "-" when undefined.
+func completionPct(completed, missed int) string {
+	if completed+missed == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", float64(completed)/float64(completed+missed)*100)
+}
+
+// coveredPct formats how many missed tackles were covered, e.g. "3/4 75%".
+func coveredPct(covered, missed int) string {
+	if missed == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d %.0f%%", covered, missed, float64(covered)/float64(missed)*100)
+}
+
+// explainQuery prints a query for --explain, with its arguments bound and a
+// comment saying what it reads.
+func explainQuery(what, query string, args ...any) {
+	fmt.Printf("-- %s\n%s\n\n", what, strings.TrimSpace(db.BindSQL(query, args...)))
+}
+
+// explainNote prints the arithmetic done on query results as SQL comments.
+func explainNote(lines ...string) {
+	for _, l := range lines {
+		fmt.Printf("-- %s\n", l)
+	}
+}
+
+// explainTimeline prints the queries behind 'stats timeline' and the video
+// time each window spans.
+func explainTimeline(database *sql.DB, videoPath string, minutes int) error {
+	explainQuery("Events, with start in video seconds", db.SelectNotesForExportSQL, videoPath)
+	clock, err := gameClockForVideo(database, videoPath)
+	if err != nil {
+		return err
+	}
+	if !clock.HasPeriods() {
+		explainNote(
+			"No match periods recorded: events are counted in windows of video time,",
+			fmt.Sprintf("%d minute(s) each from 0 (window = CAST(start / %d AS INTEGER)).", minutes, minutes*60),
+		)
+	} else {
+		videoID, err := lookupVideoID(database, videoPath)
+		if err != nil {
+			return err
+		}
+		explainQuery("Match periods, which windows are measured from", db.SelectPeriodsByVideoSQL, videoID)
+		explainNote("Events are counted in the window whose video time holds their start:")
+		for _, w := range clock.Windows(float64(minutes*60), 0) {
+			explainNote(fmt.Sprintf("  %s %-6s start >= %s AND start < %s", w.PeriodName, w.Label, fmt.Sprint(w.Start), fmt.Sprint(w.End)))
+		}
+		explainNote("A period's last window includes its end; other events are not charted.")
+	}
+	explainNote(
+		"Tackles are events with category 'tackle', Missed those with outcome 'missed'.",
+		"Comp % = completed / (completed + missed).",
+	)
+	return nil
+}
+
+// timelineBarWidth is the length of the longest events bar in 'stats timeline'.
+const timelineBarWidth = 20
+
+// timelineCount is the events counted in one window (or period) of 'stats timeline'.
+type timelineCount struct {
+	Events    int
+	Tackles   int
+	Completed int
+	Missed    int
+}
+
+var statsCategoriesCmd = &cobra.Command{
+	Use:   "categories",
+	Short: "Show event counts per category, rolled up the category tree",
+	Long: `Show how many events each category has. Category paths such as
+set-piece/lineout/steal are shown as a tree, where each level counts the events
+in it and all its subcategories.
+
+--depth rolls the tree up to that many levels, and --under narrows it to one
+category's subcategories. Uses the video open in mpv unless --video, --all,
+--match or --season is given.
+
+  tagging-rugby-cli stats categories --depth 1
+  tagging-rugby-cli stats categories --under set-piece --all`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		depth, _ := cmd.Flags().GetInt("depth")
+		under, _ := cmd.Flags().GetString("under")
+		explain, _ := cmd.Flags().GetBool("explain")
+		if depth < 0 {
+			return usageErrorf("invalid --depth: %d (want 0 for all levels, or more)", depth)
+		}
+		under = catpath.Normalize(under)
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		scope, err := videoScopeFromFlags(cmd, database)
+		if err != nil {
+			return err
+		}
+
+		if explain {
+			explainQuery("Events per category path", db.SelectCategoryCountsSQL, scope.VideoID, scope.MatchID, scope.From, scope.To)
+			lines := []string{
+				"Each category's count adds up the rows for it and every path below it",
+				"(set-piece counts set-piece, set-piece/lineout and so on), in any case.",
+			}
+			if under != "" {
+				lines = append(lines, "Only paths under "+under+" are shown.")
+			}
+			explainNote(lines...)
+			return nil
+		}
+
+		rows, err := db.SelectCategoryCounts(database, scope)
+		if err != nil {
+			return fmt.Errorf("failed to count categories: %w", err)
+		}
+		var counts []catpath.Count
+		total := 0
+		for _, r := range rows {
+			if catpath.Under(r.Category, under) {
+				counts = append(counts, catpath.Count{Path: r.Category, Count: r.Uses})
+				total += r.Uses
+			}
+		}
+		if total == 0 {
+			infoln("No categorised events found.")
+			return nil
+		}
+
+		// Depth counts from the --under category, so --depth 1 lists its children
+		if depth > 0 {
+			depth += catpath.Depth(under)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Category\tEvents\t%")
+		fmt.Fprintln(w, "--------\t------\t-")
+		for _, c := range catpath.Tree(counts, depth) {
+			levels := catpath.Levels(c.Path)
+			indent := len(levels) - 1
+			name := levels[indent]
+			if under != "" {
+				// Start the tree at the --under category, leaving out its parents
+				if indent < catpath.Depth(under)-1 {
+					continue
+				}
+				indent -= catpath.Depth(under) - 1
+			}
+			fmt.Fprintf(w, "%s%s\t%d\t%.0f%%\n", strings.Repeat("  ", indent), name, c.Count, float64(c.Count)/float64(total)*100)
+		}
+		w.Flush()
+
+		infof("\n%d event(s) in %d category path(s).\n", total, len(counts))
+		return nil
+	},
+}
+
+// add adds another window's counts.
+func (c *timelineCount) add(o timelineCount) {
+	c.Events += o.Events
+	c.Tackles += o.Tackles
+	c.Completed += o.Completed
+	c.Missed += o.Missed
+}
+
+// completion formats the tackle completion %, or "-" with no completed or missed tackles.
+func (c timelineCount) completion() string {
+	return completionPct(c.Completed, c.Missed)
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.PersistentFlags().Bool("explain", false, "Print the SQL behind the numbers instead of running it")
+	statsCmd.AddCommand(statsPossessionCmd)
+	statsCmd.AddCommand(statsTimelineCmd)
+	statsCmd.AddCommand(statsTacklesCmd)
+	statsCmd.AddCommand(statsCategoriesCmd)
+
+	statsPossessionCmd.Flags().String("video", "", "Video file path (defaults to the video open in mpv)")
+	statsTimelineCmd.Flags().String("video", "", "Video file path (defaults to the video open in mpv)")
+	statsTimelineCmd.Flags().Int("window", 10, "Window length in minutes")
+	addScopeFlags(statsTacklesCmd)
+	statsTacklesCmd.Flags().String("zone", "", "Only count tackles in this zone")
+	statsTacklesCmd.Flags().String("followed", "", "Only count tackles followed up (yes) or not (no)")
+	statsTacklesCmd.Flags().String("by", "player", "Group by player or zone")
+	addScopeFlags(statsCategoriesCmd)
+	statsCategoriesCmd.Flags().Int("depth", 0, "Roll categories up to this many levels (0 for all)")
+	statsCategoriesCmd.Flags().String("under", "", "Only show this category's subcategories")
+}
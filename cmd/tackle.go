@@ -3,6 +3,7 @@ package cmd
 import (
 	"database/sql"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/user/tagging-rugby-cli/db"
 	"github.com/user/tagging-rugby-cli/mpv"
 	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/webhook"
 )
 
 // Valid outcome values for tackles
@@ -35,18 +37,18 @@ var tackleAddCmd = &cobra.Command{
 
 		// Validate required flags
 		if player == "" {
-			return fmt.Errorf("--player is required")
+			return usageErrorf("--player is required")
 		}
 		if attempt == 0 {
-			return fmt.Errorf("--attempt is required")
+			return usageErrorf("--attempt is required")
 		}
 		if outcome == "" {
-			return fmt.Errorf("--outcome is required")
+			return usageErrorf("--outcome is required")
 		}
 
 		// Validate outcome value
 		if !isValidOutcome(outcome) {
-			return fmt.Errorf("invalid outcome '%s': must be one of: missed, completed, possible, other", outcome)
+			return usageErrorf("invalid outcome '%s': must be one of: missed, completed, possible, other", outcome)
 		}
 
 		// Connect to mpv to get current timestamp and video path
@@ -86,6 +88,12 @@ var tackleAddCmd = &cobra.Command{
 		}
 		videoFormat := strings.TrimPrefix(filepath.Ext(videoPath), ".")
 
+		// A shirt number becomes the name on the video's lineup
+		videoID, _ := db.SelectVideoIDByPath(database, videoPath)
+		if name, err := db.ResolvePlayer(database, videoID, player); err == nil {
+			player = name
+		}
+
 		// Insert note with tackle and timing child rows
 		children := db.NoteChildren{
 			Tackles: []db.NoteTackle{
@@ -103,9 +111,10 @@ var tackleAddCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to insert tackle: %w", err)
 		}
+		notifyWebhooks(webhook.TackleCreated, noteID, "tackle", children)
 
-		fmt.Printf("Tackle recorded: Note ID %d at %s\n", noteID, timeutil.FormatTime(timestamp))
-		fmt.Printf("  Player: %s, Attempt: %d, Outcome: %s\n", player, attempt, outcome)
+		infof("Tackle recorded: Note ID %d at %s\n", noteID, timeutil.FormatTime(timestamp))
+		infof("  Player: %s, Attempt: %d, Outcome: %s\n", player, attempt, outcome)
 		return nil
 	},
 }
@@ -113,11 +122,20 @@ var tackleAddCmd = &cobra.Command{
 var tackleListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all tackles for the current video",
-	Long:  `Display all tackles for the current video as a table, sorted by timestamp.`,
+	Long: `Display all tackles for the current video as a table, sorted by timestamp.
+
+Long tables are shown through $PAGER (less by default) when printing to a
+terminal; --no-pager prints them directly. --limit and --offset show a page of
+the matching tackles, and --watch redraws the table every --interval as
+tackles are tagged in the TUI in another terminal, until Ctrl+C.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get filter flags
 		playerFilter, _ := cmd.Flags().GetString("player")
 		outcomeFilter, _ := cmd.Flags().GetString("outcome")
+		view, err := listViewFlags(cmd)
+		if err != nil {
+			return err
+		}
 
 		// Connect to mpv to get current video path
 		client := mpv.NewClient("")
@@ -163,52 +181,58 @@ var tackleListCmd = &cobra.Command{
 
 		query += " ORDER BY nt_time.start ASC"
 
-		// Query tackles
-		rows, err := database.Query(query, queryArgs...)
-		if err != nil {
-			return fmt.Errorf("failed to query tackles: %w", err)
-		}
-		defer rows.Close()
-
-		// Create table writer
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "NoteID\tTime\tPlayer\tAttempt\tOutcome")
-		fmt.Fprintln(w, "------\t----\t------\t-------\t-------")
-
-		count := 0
-		for rows.Next() {
-			var noteID int64
-			var timestamp float64
-			var attemptVal int
-			var player, outcome sql.NullString
-
-			if err := rows.Scan(&noteID, &timestamp, &player, &attemptVal, &outcome); err != nil {
-				return fmt.Errorf("failed to scan tackle: %w", err)
+		return view.run("tackle list", func(out io.Writer) error {
+			// Query tackles
+			rows, err := database.Query(query, queryArgs...)
+			if err != nil {
+				return fmt.Errorf("failed to query tackles: %w", err)
+			}
+			defer rows.Close()
+
+			// Create table writer
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NoteID\tTime\tPlayer\tAttempt\tOutcome")
+			fmt.Fprintln(w, "------\t----\t------\t-------\t-------")
+
+			count := 0
+			for rows.Next() {
+				var noteID int64
+				var timestamp float64
+				var attemptVal int
+				var player, outcome sql.NullString
+
+				if err := rows.Scan(&noteID, &timestamp, &player, &attemptVal, &outcome); err != nil {
+					return fmt.Errorf("failed to scan tackle: %w", err)
+				}
+
+				timeStr := timeutil.FormatTime(timestamp)
+
+				playerStr := nullStringValue(player)
+				outcomeStr := nullStringValue(outcome)
+
+				if view.shows(count) {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n",
+						noteID, timeStr, playerStr, attemptVal, outcomeStr)
+				}
+				count++
 			}
 
-			timeStr := timeutil.FormatTime(timestamp)
-
-			playerStr := nullStringValue(player)
-			outcomeStr := nullStringValue(outcome)
-
-			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n",
-				noteID, timeStr, playerStr, attemptVal, outcomeStr)
-			count++
-		}
-
-		if err := rows.Err(); err != nil {
-			return fmt.Errorf("error iterating tackles: %w", err)
-		}
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("error iterating tackles: %w", err)
+			}
 
-		w.Flush()
+			w.Flush()
 
-		if count == 0 {
-			fmt.Println("\nNo tackles found for this video.")
-		} else {
-			fmt.Printf("\n%d tackle(s) found.\n", count)
-		}
+			if count == 0 {
+				if !quiet {
+					fmt.Fprintln(out, "\nNo tackles found for this video.")
+				}
+			} else {
+				view.footer(out, count, "tackle(s)")
+			}
 
-		return nil
+			return nil
+		})
 	},
 }
 
@@ -230,7 +254,7 @@ var tackleExportCmd = &cobra.Command{
 		// Get required --player flag
 		player, _ := cmd.Flags().GetString("player")
 		if player == "" {
-			return fmt.Errorf("--player is required")
+			return usageErrorf("--player is required")
 		}
 
 		// Get optional flags
@@ -300,7 +324,7 @@ var tackleExportCmd = &cobra.Command{
 		fmt.Fprintf(file, "Dominant:  %d\n", dominantCount)
 		fmt.Fprintf(file, "Passive:   %d\n", passiveCount)
 
-		fmt.Printf("Exported tackle stats for %s to %s\n", player, outputPath)
+		infof("Exported tackle stats for %s to %s\n", player, outputPath)
 		return nil
 	},
 }
@@ -314,6 +338,7 @@ func init() {
 	// Add filter flags to tackle list command
 	tackleListCmd.Flags().StringP("player", "p", "", "Filter by player name or number")
 	tackleListCmd.Flags().StringP("outcome", "o", "", "Filter by outcome: missed, completed, possible, other")
+	addListViewFlags(tackleListCmd)
 
 	// Add flags to tackle export command
 	tackleExportCmd.Flags().StringP("player", "p", "", "Player name or number to export (required)")
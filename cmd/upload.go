@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/upload"
+)
+
+var uploadCmd = &cobra.Command{
+	Use:   "upload",
+	Short: "Upload clips to YouTube, Google Drive or Dropbox",
+	Long: `Upload exported clips and reels to cloud services.
+
+Each service uses your own OAuth app (create one in the Google Cloud console or
+the Dropbox App Console) and is authorized once with 'upload login'. YouTube
+uploads are unlisted. Tokens are stored in ~/.config/tagging-rugby/tokens.`,
+}
+
+var uploadLoginCmd = &cobra.Command{
+	Use:   "login <youtube|drive|dropbox>",
+	Short: "Authorize an upload target",
+	Long: `Authorize an upload target. The client ID/secret (and optional folder) are saved
+to config.json the first time, so later logins only need the target name.
+
+For Google targets, register http://127.0.0.1 as a redirect URI (desktop app).
+For Dropbox, the authorization code shown by Dropbox is pasted back here.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clientID, _ := cmd.Flags().GetString("client-id")
+		clientSecret, _ := cmd.Flags().GetString("client-secret")
+		folder, _ := cmd.Flags().GetString("folder")
+
+		app := config.UploadTarget{ClientID: clientID, ClientSecret: clientSecret, Folder: folder}
+		if err := upload.Login(context.Background(), args[0], app, os.Stdin, os.Stdout); err != nil {
+			return fmt.Errorf("failed to log in: %w", err)
+		}
+
+		infof("Logged in to %s\n", args[0])
+		return nil
+	},
+}
+
+var uploadLogoutCmd = &cobra.Command{
+	Use:   "logout <youtube|drive|dropbox>",
+	Short: "Forget the stored token for an upload target",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := upload.Logout(args[0]); err != nil {
+			return fmt.Errorf("failed to log out: %w", err)
+		}
+
+		infof("Logged out of %s\n", args[0])
+		return nil
+	},
+}
+
+var uploadFileCmd = &cobra.Command{
+	Use:   "file <youtube|drive|dropbox> <file>",
+	Short: "Upload an existing video file",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title, _ := cmd.Flags().GetString("title")
+
+		if _, err := os.Stat(args[1]); err != nil {
+			return fmt.Errorf("failed to access file: %w", err)
+		}
+		return uploadExported(args[0], args[1], title)
+	},
+}
+
+// uploadExported uploads a file to target and prints the resulting link.
+func uploadExported(target, path, title string) error {
+	infof("Uploading %s to %s...\n", path, target)
+	link, err := upload.File(context.Background(), target, path, title)
+	if err != nil {
+		return fmt.Errorf("failed to upload: %w", err)
+	}
+	fmt.Printf("Uploaded: %s\n", link)
+	return nil
+}
+
+func init() {
+	uploadLoginCmd.Flags().String("client-id", "", "OAuth client ID (Dropbox: app key)")
+	uploadLoginCmd.Flags().String("client-secret", "", "OAuth client secret (Dropbox: app secret)")
+	uploadLoginCmd.Flags().String("folder", "", "Drive folder ID or Dropbox folder path for uploads")
+	uploadFileCmd.Flags().String("title", "", "Title for the upload (defaults to the file name)")
+
+	uploadCmd.AddCommand(uploadLoginCmd)
+	uploadCmd.AddCommand(uploadLogoutCmd)
+	uploadCmd.AddCommand(uploadFileCmd)
+	rootCmd.AddCommand(uploadCmd)
+}
+
+// uploadTargetsHelp lists the targets for flag help text.
+func uploadTargetsHelp() string {
+	return strings.Join(upload.Names(), ", ")
+}
+
+// isUploadTarget reports whether name is a supported upload target.
+func isUploadTarget(name string) bool {
+	for _, n := range upload.Names() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+	"github.com/user/tagging-rugby-cli/tui/forms"
+)
+
+var videoCmd = &cobra.Command{
+	Use:   "video",
+	Short: "Manage registered videos",
+	Long:  `Manage the videos (local files and stream URLs) registered in the database.`,
+}
+
+var videoRelinkCmd = &cobra.Command{
+	Use:   "relink <current-path|url> <local-file>",
+	Short: "Point a registered video at a local file",
+	Long: `Point a registered video (typically a stream URL) at a local copy of the same match.
+All notes stay attached. Tackle clips are queued immediately and rendered by the
+background clip processor the next time the TUI is running.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldPath := args[0]
+		if !videosrc.IsURL(oldPath) {
+			absOld, err := filepath.Abs(oldPath)
+			if err != nil {
+				return fmt.Errorf("failed to resolve path: %w", err)
+			}
+			oldPath = absOld
+		}
+
+		newPath, err := filepath.Abs(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to resolve path: %w", err)
+		}
+		info, err := os.Stat(newPath)
+		if os.IsNotExist(err) {
+			return notFoundErrorf("video file not found: %s", newPath)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to access video file: %w", err)
+		}
+		if info.IsDir() {
+			return usageErrorf("path is a directory, not a video file: %s", newPath)
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		if err := db.RelinkVideo(database, oldPath, newPath, info.Size()); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return notFoundErrorf("video not found in database: %s", oldPath)
+			}
+			return fmt.Errorf("failed to relink video: %w", err)
+		}
+
+		// Clip export is now possible: queue tackle clips for the local copy
+		if err := db.QueueUnprocessedTackleClips(database, newPath); err != nil {
+			return fmt.Errorf("failed to queue clips: %w", err)
+		}
+
+		infof("Relinked %s -> %s\n", oldPath, newPath)
+		return nil
+	},
+}
+
+var videoListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered videos with match details",
+	Long: `List registered videos with their match details, most recent match first.
+
+Filter by opponent, competition or month, and group the listing by month
+(a calendar-style listing), opponent or competition.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		month, _ := cmd.Flags().GetString("month")
+
+		var groupKey func(db.VideoSummary) string
+		switch groupBy {
+		case "":
+		case "month":
+			groupKey = func(v db.VideoSummary) string { return monthHeading(v.Match.Date) }
+		case "opponent":
+			groupKey = func(v db.VideoSummary) string { return orDefault(v.Match.Opponent, "No opponent") }
+		case "competition":
+			groupKey = func(v db.VideoSummary) string { return orDefault(v.Match.Competition, "No competition") }
+		default:
+			return usageErrorf("invalid --group-by: %s (supported: month, opponent, competition)", groupBy)
+		}
+
+		filter := db.VideoFilter{}
+		filter.Opponent, _ = cmd.Flags().GetString("opponent")
+		filter.Competition, _ = cmd.Flags().GetString("competition")
+		if month != "" {
+			start, err := time.Parse(videoMonthLayout, month)
+			if err != nil {
+				return usageErrorf("invalid --month (want YYYY-MM): %s", month)
+			}
+			filter.From = start.Format(reportDateLayout)
+			filter.To = start.AddDate(0, 1, 0).Format(reportDateLayout)
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videos, err := db.SelectVideos(database, filter)
+		if err != nil {
+			return fmt.Errorf("failed to query videos: %w", err)
+		}
+		if len(videos) == 0 {
+			infoln("No videos found.")
+			return nil
+		}
+
+		if groupKey == nil {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tDate\tOpponent\tCompetition\tVenue\tResult\tNotes\tVideo")
+			fmt.Fprintln(w, "--\t----\t--------\t-----------\t-----\t------\t-----\t-----")
+			for _, v := range videos {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+					v.ID, v.Match.Date, v.Match.Opponent, v.Match.Competition, v.Match.Venue, v.Match.Result, v.Notes, v.Filename)
+			}
+			w.Flush()
+			return nil
+		}
+
+		// Grouped listing: one heading per group, groups in date order (or by name)
+		groups := make(map[string][]db.VideoSummary)
+		var order []string
+		for _, v := range videos {
+			k := groupKey(v)
+			if _, ok := groups[k]; !ok {
+				order = append(order, k)
+			}
+			groups[k] = append(groups[k], v)
+		}
+		if groupBy != "month" {
+			sort.Strings(order)
+		}
+		for i, k := range order {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(k)
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, v := range groups[k] {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%d notes\t#%d %s\n",
+					dayLabel(v.Match.Date), orDefault(v.Match.Title(), "-"), v.Match.Result, v.Match.Venue, v.Notes, v.ID, v.Filename)
+			}
+			w.Flush()
+		}
+		return nil
+	},
+}
+
+var videoCalendarCmd = &cobra.Command{
+	Use:   "calendar [YYYY-MM]",
+	Short: "Show a month calendar of matches",
+	Long: `Show a month calendar with match days marked (*), followed by the matches
+played that month. Defaults to the current month.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if len(args) == 1 {
+			t, err := time.Parse(videoMonthLayout, args[0])
+			if err != nil {
+				return usageErrorf("invalid month (want YYYY-MM): %s", args[0])
+			}
+			start = t
+		}
+		end := start.AddDate(0, 1, 0)
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videos, err := db.SelectVideos(database, db.VideoFilter{
+			From: start.Format(reportDateLayout),
+			To:   end.Format(reportDateLayout),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to query videos: %w", err)
+		}
+
+		matchDays := make(map[int]bool)
+		for _, v := range videos {
+			if d, err := time.Parse(reportDateLayout, v.Match.Date); err == nil {
+				matchDays[d.Day()] = true
+			}
+		}
+		printMonthGrid(start, matchDays)
+
+		if len(videos) == 0 {
+			infoln("\nNo matches this month.")
+			return nil
+		}
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		// Oldest first reads naturally under a calendar
+		for i := len(videos) - 1; i >= 0; i-- {
+			v := videos[i]
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t#%d %s\n",
+				dayLabel(v.Match.Date), orDefault(v.Match.Title(), "-"), v.Match.Competition, v.Match.Result, v.Match.Venue, v.ID, v.Filename)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+// videoMonthLayout is the month format accepted by video listing flags.
+const videoMonthLayout = "2006-01"
+
+// printMonthGrid prints a Monday-first calendar for the month starting at start,
+// marking the given days with "*".
+func printMonthGrid(start time.Time, marked map[int]bool) {
+	title := start.Format("January 2006")
+	const width = 4 * 7
+	fmt.Printf("%*s\n", (width+len(title))/2, title)
+	fmt.Println(" Mo  Tu  We  Th  Fr  Sa  Su")
+
+	// Offset of the 1st from Monday
+	offset := (int(start.Weekday()) + 6) % 7
+	fmt.Print(strings.Repeat("    ", offset))
+	days := start.AddDate(0, 1, -1).Day()
+	for day := 1; day <= days; day++ {
+		mark := " "
+		if marked[day] {
+			mark = "*"
+		}
+		fmt.Printf(" %2d%s", day, mark)
+		if (offset+day)%7 == 0 || day == days {
+			fmt.Println()
+		}
+	}
+}
+
+// monthHeading returns "March 2025" for a YYYY-MM-DD date, or "No date".
+func monthHeading(date string) string {
+	t, err := time.Parse(reportDateLayout, date)
+	if err != nil {
+		return "No date"
+	}
+	return t.Format("January 2006")
+}
+
+// dayLabel returns "Sat 01" for a YYYY-MM-DD date, or "-".
+func dayLabel(date string) string {
+	t, err := time.Parse(reportDateLayout, date)
+	if err != nil {
+		return "-"
+	}
+	return t.Format("Mon 02")
+}
+
+// orDefault returns s, or def when s is empty.
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+var videoEditCmd = &cobra.Command{
+	Use:   "edit [path|url]",
+	Short: "Record match details for a video",
+	Long: `Record match details (opponent, date, venue, result, competition) for a registered video.
+
+With no detail flags an interactive form is shown, pre-filled with the current values.
+With flags, only the given fields are changed. Uses the video open in mpv when no
+path is given.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoArg := ""
+		if len(args) == 1 {
+			videoArg = args[0]
+		}
+		videoPath, err := resolveVideoPath(videoArg)
+		if err != nil {
+			return err
+		}
+
+		// Open database
+		database, err := db.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer database.Close()
+
+		videoID, err := db.SelectVideoIDByPath(database, videoPath)
+		if errors.Is(err, sql.ErrNoRows) {
+			return notFoundErrorf("video not found in database: %s", videoPath)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to look up video: %w", err)
+		}
+		info, err := db.SelectMatchInfo(database, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to load match details: %w", err)
+		}
+
+		fields := map[string]*string{
+			"opponent":    &info.Opponent,
+			"date":        &info.Date,
+			"venue":       &info.Venue,
+			"result":      &info.Result,
+			"competition": &info.Competition,
+		}
+		changed := false
+		for name, field := range fields {
+			if cmd.Flags().Changed(name) {
+				*field, _ = cmd.Flags().GetString(name)
+				changed = true
+			}
+		}
+
+		if changed {
+			if info.Date != "" {
+				if _, err := time.Parse(reportDateLayout, info.Date); err != nil {
+					return usageErrorf("invalid --date (want YYYY-MM-DD): %s", info.Date)
+				}
+			}
+		} else {
+			result := forms.MatchFormResult{Opponent: info.Opponent, Date: info.Date, Venue: info.Venue, Result: info.Result, Competition: info.Competition}
+			if err := forms.NewMatchForm(videosrc.Base(videoPath), &result).Run(); err != nil {
+				if errors.Is(err, huh.ErrUserAborted) {
+					infoln("Cancelled.")
+					return nil
+				}
+				return fmt.Errorf("failed to read match details: %w", err)
+			}
+			info = db.MatchInfo{Opponent: result.Opponent, Date: result.Date, Venue: result.Venue, Result: result.Result, Competition: result.Competition}
+		}
+
+		if err := db.UpdateMatchInfo(database, videoID, info); err != nil {
+			return fmt.Errorf("failed to save match details: %w", err)
+		}
+		infof("Updated match details for %s\n", videosrc.Base(videoPath))
+		return nil
+	},
+}
+
+func init() {
+	videoListCmd.Flags().String("opponent", "", "Only show matches against this opponent")
+	videoListCmd.Flags().String("competition", "", "Only show matches in this competition")
+	videoListCmd.Flags().String("month", "", "Only show matches played in this month (YYYY-MM)")
+	videoListCmd.Flags().StringP("group-by", "g", "", "Group by month, opponent or competition")
+
+	videoEditCmd.Flags().String("opponent", "", "Opponent team")
+	videoEditCmd.Flags().String("date", "", "Match date (YYYY-MM-DD)")
+	videoEditCmd.Flags().String("venue", "", "Venue")
+	videoEditCmd.Flags().String("result", "", "Result (e.g. \"W 24-17\")")
+	videoEditCmd.Flags().String("competition", "", "Competition (e.g. Premiership)")
+
+	videoCmd.AddCommand(videoListCmd)
+	videoCmd.AddCommand(videoEditCmd)
+	videoCmd.AddCommand(videoCalendarCmd)
+	videoCmd.AddCommand(videoRelinkCmd)
+	rootCmd.AddCommand(videoCmd)
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/webhook"
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manage webhooks for note events",
+	Long: `Manage webhooks that receive a JSON POST when notes or tackles are created or starred.
+
+Events: note.created, tackle.created, note.starred.
+Payloads include "text" and "content" summary fields, so Slack and Discord
+incoming-webhook URLs can be used directly. Webhooks are stored in
+~/.config/tagging-rugby/config.json.`,
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured webhooks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if len(cfg.Webhooks) == 0 {
+			infoln("No webhooks configured.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "URL\tEvents")
+		fmt.Fprintln(w, "---\t------")
+		for _, hook := range cfg.Webhooks {
+			events := "all"
+			if len(hook.Events) > 0 {
+				events = strings.Join(hook.Events, ", ")
+			}
+			fmt.Fprintf(w, "%s\t%s\n", hook.URL, events)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var webhookAddCmd = &cobra.Command{
+	Use:   "add <url>",
+	Short: "Add a webhook",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		events, _ := cmd.Flags().GetStringSlice("event")
+
+		u, err := url.Parse(args[0])
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return usageErrorf("invalid webhook URL: %s", args[0])
+		}
+		for _, e := range events {
+			if !isWebhookEvent(e) {
+				return usageErrorf("invalid event '%s': must be one of %s", e, strings.Join(webhook.Events, ", "))
+			}
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		for _, hook := range cfg.Webhooks {
+			if hook.URL == args[0] {
+				return fmt.Errorf("webhook already configured: %s", args[0])
+			}
+		}
+		cfg.Webhooks = append(cfg.Webhooks, config.Webhook{URL: args[0], Events: events})
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		infof("Webhook added: %s\n", args[0])
+		return nil
+	},
+}
+
+var webhookRemoveCmd = &cobra.Command{
+	Use:   "remove <url>",
+	Short: "Remove a webhook",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		kept := cfg.Webhooks[:0]
+		for _, hook := range cfg.Webhooks {
+			if hook.URL != args[0] {
+				kept = append(kept, hook)
+			}
+		}
+		if len(kept) == len(cfg.Webhooks) {
+			return notFoundErrorf("webhook not found: %s", args[0])
+		}
+		cfg.Webhooks = kept
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		infof("Webhook removed: %s\n", args[0])
+		return nil
+	},
+}
+
+var webhookTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a sample event to every webhook",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if len(cfg.Webhooks) == 0 {
+			infoln("No webhooks configured.")
+			return nil
+		}
+
+		payload := webhook.NewPayload(webhook.NoteCreated, 0, "note", db.NoteChildren{
+			Details: []db.NoteDetail{{Type: "text", Note: "Test event from tagging-rugby-cli"}},
+		})
+		// Ignore event filters so every URL is exercised
+		hooks := make([]config.Webhook, len(cfg.Webhooks))
+		for i, hook := range cfg.Webhooks {
+			hooks[i] = config.Webhook{URL: hook.URL}
+		}
+		n := webhook.New(hooks)
+		n.Notify(payload)
+		n.Wait()
+
+		errs := n.TakeErrors()
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
+		}
+		infof("Delivered to %d of %d webhook(s)\n", len(hooks)-len(errs), len(hooks))
+		return nil
+	},
+}
+
+// isWebhookEvent reports whether name is a known webhook event.
+func isWebhookEvent(name string) bool {
+	for _, e := range webhook.Events {
+		if e == name {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyWebhooks delivers a note event to the configured webhooks and waits for completion.
+// Delivery failures are printed as warnings; they never fail the command.
+func notifyWebhooks(event string, noteID int64, category string, children db.NoteChildren) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+		return
+	}
+	if len(cfg.Webhooks) == 0 {
+		return
+	}
+
+	n := webhook.New(cfg.Webhooks)
+	payload := webhook.NewPayload(event, noteID, category, children)
+	n.Notify(payload)
+	if payload.Starred && event != webhook.NoteStarred {
+		payload.Event = webhook.NoteStarred
+		n.Notify(payload)
+	}
+	n.Wait()
+	for _, err := range n.TakeErrors() {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+func init() {
+	webhookAddCmd.Flags().StringSlice("event", nil, "Only send these events (repeatable; default all)")
+
+	webhookCmd.AddCommand(webhookListCmd)
+	webhookCmd.AddCommand(webhookAddCmd)
+	webhookCmd.AddCommand(webhookRemoveCmd)
+	webhookCmd.AddCommand(webhookTestCmd)
+	rootCmd.AddCommand(webhookCmd)
+}
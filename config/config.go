@@ -0,0 +1,273 @@
+// Package config locates the user's configuration directory and loads the
+// optional config.json stored there.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+)
+
+// Config is the contents of config.json. Missing fields keep their zero values.
+type Config struct {
+	// DB is the database file used unless --db is given; empty uses
+	// ~/.local/share/tagging-rugby-cli/data.db
+	DB string `json:"db,omitempty"`
+	// MpvSocket is the IPC socket mpv is launched with and reached on; empty
+	// uses /tmp/tagging-rugby-mpv.sock
+	MpvSocket string `json:"mpv_socket,omitempty"`
+	// StepSize is the seconds the TUI's seek step starts at; 0 means 1
+	StepSize float64 `json:"step_size,omitempty"`
+	// StepSizes are the seek steps in seconds that < and > cycle through in
+	// the TUI, ascending; empty uses DefaultStepSizes
+	StepSizes []float64 `json:"step_sizes,omitempty"`
+	// ClipDir is the folder 'clip export' writes to without --dir or --output
+	ClipDir string `json:"clip_dir,omitempty"`
+	// ClipFormat is the format 'clip export' writes without --format; empty
+	// means mp4
+	ClipFormat string `json:"clip_format,omitempty"`
+	// OverlayProximity is the seconds a note stays on the TUI's video overlay
+	// after its time; 0 means 2
+	OverlayProximity float64 `json:"overlay_proximity,omitempty"`
+	// TickMS is the milliseconds between the TUI's refreshes while the video
+	// plays; 0 means 100
+	TickMS int `json:"tick_ms,omitempty"`
+	// EventGapWarning is the seconds of video since the last event after which
+	// the TUI warns that nothing has been tagged; 0 never warns
+	EventGapWarning float64 `json:"event_gap_warning,omitempty"`
+	// Webhooks receive a JSON POST when notes are created or starred
+	Webhooks []Webhook `json:"webhooks,omitempty"`
+	// Uploads holds the OAuth app credentials for each upload target, keyed by
+	// target name ("youtube", "drive", "dropbox")
+	Uploads map[string]UploadTarget `json:"uploads,omitempty"`
+	// FrameStep tunes hold-to-seek on Ctrl+H / Ctrl+L in the TUI
+	FrameStep *FrameStep `json:"frame_step,omitempty"`
+	// GameClock shows a game-clock column next to video time in the TUI notes list
+	GameClock bool `json:"game_clock,omitempty"`
+	// EventDuration is the default duration in seconds pre-filled in the TUI note
+	// and tackle forms; 0 leaves new events with no duration
+	EventDuration float64 `json:"event_duration,omitempty"`
+	// StickyTackle pre-fills the TUI tackle form with the last player and outcome
+	StickyTackle bool `json:"sticky_tackle,omitempty"`
+	// CrossFilter makes moving through the TUI stats tables filter the notes
+	// list and timeline to the selected player as it changes
+	CrossFilter bool `json:"cross_filter,omitempty"`
+	// SeasonStart is the month (1-12) seasons start in, for the TUI stats
+	// view's season scope and as the CLI's --season-start default; 0 uses August
+	SeasonStart int `json:"season_start,omitempty"`
+	// PlayerKeys maps function key numbers to players for one-key tackle logging
+	// in the TUI ("2": "Smith" makes F2 log Smith); unmapped keys use the number
+	PlayerKeys map[string]string `json:"player_keys,omitempty"`
+	// Keys remaps the TUI's keys: each action (see 'config keys') to the keys
+	// that trigger it instead of its defaults, e.g. {"note": ["a"]}
+	Keys map[string][]string `json:"keys,omitempty"`
+	// CommandAliases are extra TUI command mode words, each standing for a
+	// command and its leading arguments, e.g. {"g": "goto", "fast": "speed 2"}
+	CommandAliases map[string]string `json:"command_aliases,omitempty"`
+	// Pedal maps the buttons of a foot pedal or gamepad to TUI actions
+	Pedal *Pedal `json:"pedal,omitempty"`
+	// MIDI maps the controls of a MIDI controller (jog wheel, shuttle ring,
+	// buttons) to TUI actions; set up with 'controller map'
+	MIDI *MIDI `json:"midi,omitempty"`
+	// Remote serves an HTTP endpoint for Stream Deck style button boxes while
+	// the TUI runs
+	Remote *Remote `json:"remote,omitempty"`
+	// IdleMinutes is how long the TUI waits with the video paused and no input
+	// before slowing its refresh to save power; 0 means 5 minutes, negative never
+	IdleMinutes float64 `json:"idle_minutes,omitempty"`
+	// Snippets are reusable note texts keyed by name, with {placeholders} filled
+	// in when used (e.g. "line speed slow on {player}'s side")
+	Snippets map[string]string `json:"snippets,omitempty"`
+	// NoteTemplates are extra TUI note form fields per category, keyed by
+	// category name in any case (e.g. "lineout" asking for the thrower and outcome)
+	NoteTemplates map[string][]TemplateField `json:"note_templates,omitempty"`
+	// Dictionary is the club's terminology, checked as notes are typed and by 'note lint'
+	Dictionary *Dictionary `json:"dictionary,omitempty"`
+	// ClipPadding widens clips cut by 'clip export' around the event's timing
+	ClipPadding *ClipPadding `json:"clip_padding,omitempty"`
+	// Binaries points at mpv, ffmpeg and ffprobe when they aren't the ones in PATH
+	Binaries *Binaries `json:"binaries,omitempty"`
+}
+
+// TemplateField is a field a note template adds to the TUI note form. Its
+// value is saved as a note detail of type Name.
+type TemplateField struct {
+	Name string `json:"name"`
+	// Title labels the field in the form; empty uses Name
+	Title string `json:"title,omitempty"`
+	// Options makes the field a choice between these values instead of free text
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required,omitempty"`
+}
+
+// NoteTemplate returns the template fields for category, matched in any case.
+func (c *Config) NoteTemplate(category string) ([]TemplateField, bool) {
+	category = catpath.Normalize(category)
+	for name, fields := range c.NoteTemplates {
+		if strings.EqualFold(catpath.Normalize(name), category) {
+			return fields, true
+		}
+	}
+	return nil, false
+}
+
+// Binaries are explicit paths to the external programs; empty fields use PATH.
+type Binaries struct {
+	Mpv     string `json:"mpv,omitempty"`
+	Ffmpeg  string `json:"ffmpeg,omitempty"`
+	Ffprobe string `json:"ffprobe,omitempty"`
+}
+
+// Dictionary lists the club's preferred spellings.
+type Dictionary struct {
+	// Terms are the preferred spellings of rugby and club terms (e.g. "lineout")
+	Terms []string `json:"terms,omitempty"`
+	// Players is the squad list; tackle players not on it are flagged
+	Players []string `json:"players,omitempty"`
+}
+
+// Pedal is a Linux input device whose buttons trigger TUI actions.
+type Pedal struct {
+	// Device is the evdev device, e.g. /dev/input/by-id/usb-VEC_USB_Footpedal-event-if00
+	Device string `json:"device"`
+	// Buttons maps button codes (as printed by 'controller listen') to actions
+	// such as "play", "back" or "tackle"
+	Buttons map[string]string `json:"buttons"`
+}
+
+// MIDI is a raw MIDI device whose controls trigger TUI actions.
+type MIDI struct {
+	// Device is the ALSA raw MIDI device, e.g. /dev/snd/midiC1D0
+	Device string `json:"device"`
+	// Bindings maps controls ("note:36", "cc:10") to actions such as "play" or "jog"
+	Bindings map[string]string `json:"bindings"`
+}
+
+// Remote configures the TUI's HTTP action endpoint.
+type Remote struct {
+	// Listen is the address to serve on, e.g. "127.0.0.1:7373"
+	Listen string `json:"listen"`
+	// Token must be sent as ?token= or an X-Token header; the TUI generates
+	// one when it is empty
+	Token string `json:"token,omitempty"`
+}
+
+// ClipPadding is the lead-in and run-out added to exported clips.
+type ClipPadding struct {
+	// Before is the seconds of lead-in before the event starts
+	Before float64 `json:"before"`
+	// After is the seconds of run-out after the event ends
+	After float64 `json:"after"`
+}
+
+// FrameStep configures the accelerate-on-hold curve for frame stepping.
+// Terminals report held keys as repeated presses, so a key counts as held
+// while repeats arrive less than HoldGapMS apart.
+type FrameStep struct {
+	HoldGapMS int `json:"hold_gap_ms,omitempty"`
+	// Ramp lists the step size used once a key has repeated After times.
+	// Seconds of 0 means a single frame.
+	Ramp []RampStage `json:"ramp,omitempty"`
+}
+
+// RampStage is one step of the frame-step acceleration curve.
+type RampStage struct {
+	After   int     `json:"after"`
+	Seconds float64 `json:"seconds"`
+}
+
+// UploadTarget holds the user's own OAuth app credentials for an upload service.
+type UploadTarget struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	// Folder is the Drive folder ID or Dropbox folder path uploads go into
+	Folder string `json:"folder,omitempty"`
+}
+
+// Webhook is a URL notified on note events.
+type Webhook struct {
+	URL string `json:"url"`
+	// Events limits which events are sent (e.g. "note.created"); empty means all
+	Events []string `json:"events,omitempty"`
+}
+
+// Wants reports whether the webhook subscribes to event.
+func (w Webhook) Wants(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Dir returns the configuration directory, ~/.config/tagging-rugby.
+func Dir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, ".config", "tagging-rugby"), nil
+}
+
+// Path returns the path to config.json.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// Load reads config.json. A missing file yields an empty config.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Save writes cfg to config.json, creating the directory if needed. The file
+// holds secrets (upload client secrets, the remote token), so only the user
+// can read it, as with the upload tokens.
+func Save(cfg *Config) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	// WriteFile keeps the mode of a config.json saved before
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Option is a config.json setting holding a single value, which 'config get',
+// 'config set' and 'config list' read and change by its JSON key. Settings
+// with structure (webhooks, keys, templates) are edited in the file.
+type Option struct {
+	Key  string
+	Desc string
+	// Default describes what is used while the option is unset
+	Default string
+	get     func(*Config) string
+	set     func(*Config, string) error
+}
+
+// Get returns the option's value in cfg, or "" when it is unset.
+func (o Option) Get(cfg *Config) string {
+	return o.get(cfg)
+}
+
+// Set parses value and stores it in cfg, leaving cfg unchanged when value is
+// invalid.
+func (o Option) Set(cfg *Config, value string) error {
+	if err := o.set(cfg, value); err != nil {
+		return fmt.Errorf("invalid %s: %w", o.Key, err)
+	}
+	return nil
+}
+
+// Unset clears the option in cfg, so its default is used again.
+func (o Option) Unset(cfg *Config) {
+	// The empty value stores each option's zero value
+	_ = o.set(cfg, "")
+}
+
+// Options are the settings 'config set' changes, in the order 'config list'
+// shows them.
+var Options = []Option{
+	stringOption("db", "Database file, unless --db is given", "~/.local/share/tagging-rugby-cli/data.db",
+		func(c *Config) *string { return &c.DB }, absPath),
+	stringOption("mpv_socket", "mpv's IPC socket, used to launch and talk to mpv", "/tmp/tagging-rugby-mpv.sock",
+		func(c *Config) *string { return &c.MpvSocket }, absPath),
+	floatOption("step_size", "Seconds the TUI's seek step starts at", "1",
+		func(c *Config) *float64 { return &c.StepSize }, positive),
+	{
+		Key:     "step_sizes",
+		Desc:    "Seek steps in seconds that < and > cycle through in the TUI",
+		Default: formatSteps(DefaultStepSizes),
+		get:     func(c *Config) string { return formatSteps(c.StepSizes) },
+		set: func(c *Config, value string) error {
+			steps, err := ParseSteps(value)
+			if err != nil {
+				return err
+			}
+			c.StepSizes = steps
+			return nil
+		},
+	},
+	stringOption("clip_dir", "Folder 'clip export' writes to without --dir or --output", "the current directory",
+		func(c *Config) *string { return &c.ClipDir }, absPath),
+	stringOption("clip_format", "Format 'clip export' writes without --format (mp4, webm, mkv)", "mp4",
+		func(c *Config) *string { return &c.ClipFormat }, clipFormat),
+	floatOption("overlay_proximity", "Seconds a note stays on the video overlay after its time", "2",
+		func(c *Config) *float64 { return &c.OverlayProximity }, positive),
+	intOption("tick_ms", "Milliseconds between the TUI's refreshes while playing", "100",
+		func(c *Config) *int { return &c.TickMS }, func(n int) error {
+			if n < 10 || n > 1000 {
+				return fmt.Errorf("want 10 to 1000")
+			}
+			return nil
+		}),
+	floatOption("event_gap_warning", "Seconds of video since the last event after which the TUI warns", "never",
+		func(c *Config) *float64 { return &c.EventGapWarning }, positive),
+	floatOption("idle_minutes", "Minutes paused without input before the TUI refreshes slowly; -1 never", "5",
+		func(c *Config) *float64 { return &c.IdleMinutes }, nil),
+	floatOption("event_duration", "Seconds pre-filled as the duration of new TUI events", "none",
+		func(c *Config) *float64 { return &c.EventDuration }, func(f float64) error {
+			if f < 0 {
+				return fmt.Errorf("can't be negative")
+			}
+			return nil
+		}),
+	intOption("season_start", "Month (1-12) seasons start in", "8",
+		func(c *Config) *int { return &c.SeasonStart }, func(n int) error {
+			if n < 1 || n > 12 {
+				return fmt.Errorf("want a month, 1-12")
+			}
+			return nil
+		}),
+	boolOption("game_clock", "Show the game clock column in the TUI notes list", "false",
+		func(c *Config) *bool { return &c.GameClock }),
+	boolOption("sticky_tackle", "Pre-fill the TUI tackle form with the last player and outcome", "false",
+		func(c *Config) *bool { return &c.StickyTackle }),
+	boolOption("cross_filter", "Filter the TUI notes list by the player selected in the stats tables", "false",
+		func(c *Config) *bool { return &c.CrossFilter }),
+}
+
+// LookupOption returns the option with the JSON key key.
+func LookupOption(key string) (Option, bool) {
+	for _, o := range Options {
+		if o.Key == key {
+			return o, true
+		}
+	}
+	return Option{}, false
+}
+
+// OptionKeys returns the keys of the options, sorted.
+func OptionKeys() []string {
+	keys := make([]string, len(Options))
+	for i, o := range Options {
+		keys[i] = o.Key
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DefaultStepSizes are the TUI's seek steps in seconds when "step_sizes" is
+// unset.
+var DefaultStepSizes = []float64{0.1, 0.5, 1, 2, 5, 10, 30}
+
+// ParseSteps parses seek steps given as seconds separated by commas or
+// spaces ("0.5,1,5"), returning them in ascending order.
+func ParseSteps(value string) ([]float64, error) {
+	fields := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	steps := make([]float64, 0, len(fields))
+	seen := make(map[float64]bool)
+	for _, f := range fields {
+		step, err := strconv.ParseFloat(f, 64)
+		if err != nil || step <= 0 {
+			return nil, fmt.Errorf("%q is not a number of seconds above 0", f)
+		}
+		if !seen[step] {
+			seen[step] = true
+			steps = append(steps, step)
+		}
+	}
+	sort.Float64s(steps)
+	return steps, nil
+}
+
+// formatSteps joins seek steps as ParseSteps reads them.
+func formatSteps(steps []float64) string {
+	parts := make([]string, len(steps))
+	for i, s := range steps {
+		parts[i] = strconv.FormatFloat(s, 'g', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+func positive(f float64) error {
+	if f <= 0 {
+		return fmt.Errorf("want a number above 0")
+	}
+	return nil
+}
+
+func clipFormat(s string) (string, error) {
+	switch s {
+	case "mp4", "webm", "mkv":
+		return s, nil
+	}
+	return "", fmt.Errorf("%s is not mp4, webm or mkv", s)
+}
+
+// absPath stores paths absolute, as they are used from any directory.
+func absPath(s string) (string, error) {
+	return filepath.Abs(s)
+}
+
+func stringOption(key, desc, def string, field func(*Config) *string, parse func(string) (string, error)) Option {
+	return Option{
+		Key: key, Desc: desc, Default: def,
+		get: func(c *Config) string { return *field(c) },
+		set: func(c *Config, value string) error {
+			if value != "" && parse != nil {
+				parsed, err := parse(value)
+				if err != nil {
+					return err
+				}
+				value = parsed
+			}
+			*field(c) = value
+			return nil
+		},
+	}
+}
+
+func floatOption(key, desc, def string, field func(*Config) *float64, check func(float64) error) Option {
+	return Option{
+		Key: key, Desc: desc, Default: def,
+		get: func(c *Config) string {
+			if *field(c) == 0 {
+				return ""
+			}
+			return strconv.FormatFloat(*field(c), 'g', -1, 64)
+		},
+		set: func(c *Config, value string) error {
+			if value == "" {
+				*field(c) = 0
+				return nil
+			}
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("%q is not a number", value)
+			}
+			if check != nil {
+				if err := check(f); err != nil {
+					return err
+				}
+			}
+			*field(c) = f
+			return nil
+		},
+	}
+}
+
+func intOption(key, desc, def string, field func(*Config) *int, check func(int) error) Option {
+	return Option{
+		Key: key, Desc: desc, Default: def,
+		get: func(c *Config) string {
+			if *field(c) == 0 {
+				return ""
+			}
+			return strconv.Itoa(*field(c))
+		},
+		set: func(c *Config, value string) error {
+			if value == "" {
+				*field(c) = 0
+				return nil
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%q is not a whole number", value)
+			}
+			if check != nil {
+				if err := check(n); err != nil {
+					return err
+				}
+			}
+			*field(c) = n
+			return nil
+		},
+	}
+}
+
+func boolOption(key, desc, def string, field func(*Config) *bool) Option {
+	return Option{
+		Key: key, Desc: desc, Default: def,
+		get: func(c *Config) string {
+			if !*field(c) {
+				return ""
+			}
+			return "true"
+		},
+		set: func(c *Config, value string) error {
+			if value == "" {
+				*field(c) = false
+				return nil
+			}
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%q is not true or false", value)
+			}
+			*field(c) = b
+			return nil
+		},
+	}
+}
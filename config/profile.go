@@ -0,0 +1,104 @@
+package config
+
+import "fmt"
+
+// ProfileVersion is the current interaction profile format.
+const ProfileVersion = 1
+
+// Profile is the shareable part of the configuration: how an analyst drives
+// the TUI (hotkeys, key remapping, quick-tag snippets, seek steps, controller
+// bindings, scripts), without device paths, credentials or webhooks, so a club
+// can give every analyst the same setup.
+type Profile struct {
+	Version          int                        `json:"version"`
+	PlayerKeys       map[string]string          `json:"player_keys,omitempty"`
+	Keys             map[string][]string        `json:"keys,omitempty"`
+	CommandAliases   map[string]string          `json:"command_aliases,omitempty"`
+	Snippets         map[string]string          `json:"snippets,omitempty"`
+	NoteTemplates    map[string][]TemplateField `json:"note_templates,omitempty"`
+	Dictionary       *Dictionary                `json:"dictionary,omitempty"`
+	EventDuration    float64                    `json:"event_duration,omitempty"`
+	StickyTackle     bool                       `json:"sticky_tackle,omitempty"`
+	GameClock        bool                       `json:"game_clock,omitempty"`
+	CrossFilter      bool                       `json:"cross_filter,omitempty"`
+	StepSize         float64                    `json:"step_size,omitempty"`
+	StepSizes        []float64                  `json:"step_sizes,omitempty"`
+	OverlayProximity float64                    `json:"overlay_proximity,omitempty"`
+	EventGapWarning  float64                    `json:"event_gap_warning,omitempty"`
+	FrameStep        *FrameStep                 `json:"frame_step,omitempty"`
+	ClipPadding      *ClipPadding               `json:"clip_padding,omitempty"`
+	// PedalButtons and MIDIBindings are the controller mappings; each machine
+	// keeps its own device path
+	PedalButtons map[string]string `json:"pedal_buttons,omitempty"`
+	MIDIBindings map[string]string `json:"midi_bindings,omitempty"`
+	// Scripts are the Lua key handlers and commands, by file name
+	Scripts map[string]string `json:"scripts,omitempty"`
+}
+
+// ExportProfile returns the interaction settings of cfg as a profile. Scripts
+// are added by the caller.
+func ExportProfile(cfg *Config) *Profile {
+	p := &Profile{
+		Version:          ProfileVersion,
+		PlayerKeys:       cfg.PlayerKeys,
+		Keys:             cfg.Keys,
+		CommandAliases:   cfg.CommandAliases,
+		Snippets:         cfg.Snippets,
+		NoteTemplates:    cfg.NoteTemplates,
+		Dictionary:       cfg.Dictionary,
+		EventDuration:    cfg.EventDuration,
+		StickyTackle:     cfg.StickyTackle,
+		GameClock:        cfg.GameClock,
+		CrossFilter:      cfg.CrossFilter,
+		StepSize:         cfg.StepSize,
+		StepSizes:        cfg.StepSizes,
+		OverlayProximity: cfg.OverlayProximity,
+		EventGapWarning:  cfg.EventGapWarning,
+		FrameStep:        cfg.FrameStep,
+		ClipPadding:      cfg.ClipPadding,
+	}
+	if cfg.Pedal != nil {
+		p.PedalButtons = cfg.Pedal.Buttons
+	}
+	if cfg.MIDI != nil {
+		p.MIDIBindings = cfg.MIDI.Bindings
+	}
+	return p
+}
+
+// Apply replaces the interaction settings of cfg with the profile's. Device
+// paths, webhooks, upload credentials and the remote endpoint are kept.
+func (p *Profile) Apply(cfg *Config) error {
+	if p.Version < 1 || p.Version > ProfileVersion {
+		return fmt.Errorf("unsupported profile version %d", p.Version)
+	}
+	cfg.PlayerKeys = p.PlayerKeys
+	cfg.Keys = p.Keys
+	cfg.CommandAliases = p.CommandAliases
+	cfg.Snippets = p.Snippets
+	cfg.NoteTemplates = p.NoteTemplates
+	cfg.Dictionary = p.Dictionary
+	cfg.EventDuration = p.EventDuration
+	cfg.StickyTackle = p.StickyTackle
+	cfg.GameClock = p.GameClock
+	cfg.CrossFilter = p.CrossFilter
+	cfg.StepSize = p.StepSize
+	cfg.StepSizes = p.StepSizes
+	cfg.OverlayProximity = p.OverlayProximity
+	cfg.EventGapWarning = p.EventGapWarning
+	cfg.FrameStep = p.FrameStep
+	cfg.ClipPadding = p.ClipPadding
+	switch {
+	case cfg.Pedal != nil:
+		cfg.Pedal.Buttons = p.PedalButtons
+	case p.PedalButtons != nil:
+		cfg.Pedal = &Pedal{Buttons: p.PedalButtons}
+	}
+	switch {
+	case cfg.MIDI != nil:
+		cfg.MIDI.Bindings = p.MIDIBindings
+	case p.MIDIBindings != nil:
+		cfg.MIDI = &MIDI{Bindings: p.MIDIBindings}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// SettingsVersion is the current settings bundle format.
+const SettingsVersion = 1
+
+// Settings bundles the whole setup of a machine: config.json as it is, device
+// paths and webhooks included, and the Lua scripts. Unlike a Profile it is
+// meant for handing a laptop, or a database, over to another analyst. The
+// database, mpv socket and clip folder are the importer's own (KeepPaths).
+type Settings struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	Config     *Config   `json:"config"`
+	// Scripts are the Lua key handlers and commands, by file name
+	Scripts map[string]string `json:"scripts,omitempty"`
+}
+
+// NewSettings bundles cfg; scripts are added by the caller.
+func NewSettings(cfg *Config) *Settings {
+	return &Settings{Version: SettingsVersion, ExportedAt: time.Now().UTC(), Config: cfg}
+}
+
+// Check rejects bundles this version can't read.
+func (s *Settings) Check() error {
+	if s.Version < 1 || s.Version > SettingsVersion {
+		return fmt.Errorf("unsupported settings version %d", s.Version)
+	}
+	if s.Config == nil {
+		return fmt.Errorf("settings have no config")
+	}
+	return nil
+}
+
+// StripSecrets clears the upload client secrets and the remote endpoint's
+// token, so the bundle can be shared without them.
+func (s *Settings) StripSecrets() {
+	if len(s.Config.Uploads) > 0 {
+		uploads := make(map[string]UploadTarget, len(s.Config.Uploads))
+		for name, t := range s.Config.Uploads {
+			t.ClientSecret = ""
+			uploads[name] = t
+		}
+		s.Config.Uploads = uploads
+	}
+	if s.Config.Remote != nil {
+		remote := *s.Config.Remote
+		remote.Token = ""
+		s.Config.Remote = &remote
+	}
+}
+
+// KeepSecrets fills the secrets a stripped bundle lacks from the config it
+// replaces, for upload targets and a remote endpoint both have.
+func (s *Settings) KeepSecrets(old *Config) {
+	for name, t := range s.Config.Uploads {
+		if prev, ok := old.Uploads[name]; ok && t.ClientSecret == "" && prev.ClientID == t.ClientID {
+			t.ClientSecret = prev.ClientSecret
+			s.Config.Uploads[name] = t
+		}
+	}
+	if s.Config.Remote != nil && s.Config.Remote.Token == "" && old.Remote != nil {
+		s.Config.Remote.Token = old.Remote.Token
+	}
+}
+
+// KeepPaths keeps the database file, mpv socket and clip folder of the config
+// the bundle replaces: they are paths on the exporter's machine, and the
+// database may be the very one the bundle was read from.
+func (s *Settings) KeepPaths(old *Config) {
+	s.Config.DB = old.DB
+	s.Config.MpvSocket = old.MpvSocket
+	s.Config.ClipDir = old.ClipDir
+}
@@ -0,0 +1,45 @@
+// Package controller reads hardware controllers (foot pedals, gamepads and MIDI
+// controllers) and names the TUI actions their buttons can be mapped to.
+package controller
+
+import "sort"
+
+// Actions are the TUI actions a controller button can trigger, with a short
+// description for 'controller listen'.
+var Actions = map[string]string{
+	"play":          "Toggle play/pause",
+	"back":          "Seek back by the step size",
+	"forward":       "Seek forward by the step size",
+	"frame-back":    "Step back one frame",
+	"frame-forward": "Step forward one frame",
+	"note":          "Open the note form",
+	"tackle":        "Open the tackle form",
+	"bookmark":      "Bookmark the current position",
+}
+
+// MIDIActions are the extra actions for MIDI knobs and wheels, which use the
+// control's value rather than just its press.
+var MIDIActions = map[string]string{
+	"jog":     "Jog wheel: step a frame per tick",
+	"scrub":   "Jog wheel: seek by the step size per tick",
+	"shuttle": "Shuttle ring: play at a speed set by how far it is turned from 64",
+}
+
+// ActionNames returns the action names in alphabetical order.
+func ActionNames() []string {
+	return sortedNames(Actions)
+}
+
+// MIDIActionNames returns the MIDI-only action names in alphabetical order.
+func MIDIActionNames() []string {
+	return sortedNames(MIDIActions)
+}
+
+func sortedNames(actions map[string]string) []string {
+	names := make([]string, 0, len(actions))
+	for name := range actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
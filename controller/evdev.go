@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Linux input event types and values used here (linux/input-event-codes.h).
+const (
+	evKey    = 0x01
+	keyPress = 1
+)
+
+// eventSize is the size of a struct input_event: a timeval (two longs) then
+// type, code and value.
+var eventSize = 2*strconv.IntSize/8 + 8
+
+// Device is a Linux evdev input device such as a USB foot pedal or gamepad.
+// Most pedals present themselves as keyboards, so the device is grabbed while
+// open to keep its key presses out of the terminal.
+type Device struct {
+	f *os.File
+}
+
+// Open opens and grabs an evdev device, e.g. /dev/input/by-id/usb-...-event-kbd.
+// The user needs read access to it (usually membership of the input group).
+func Open(path string) (*Device, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	if err := grab(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("grab %s: %w", path, err)
+	}
+	return &Device{f: f}, nil
+}
+
+// NextPress blocks until a button or key is pressed and returns its code.
+// Releases and auto-repeats are skipped.
+func (d *Device) NextPress() (uint16, error) {
+	buf := make([]byte, eventSize)
+	for {
+		if _, err := io.ReadFull(d.f, buf); err != nil {
+			return 0, err
+		}
+		ev := buf[eventSize-8:]
+		typ := binary.LittleEndian.Uint16(ev[0:2])
+		code := binary.LittleEndian.Uint16(ev[2:4])
+		value := int32(binary.LittleEndian.Uint32(ev[4:8]))
+		if typ == evKey && value == keyPress {
+			return code, nil
+		}
+	}
+}
+
+// Close releases the device.
+func (d *Device) Close() error {
+	return d.f.Close()
+}
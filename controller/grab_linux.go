@@ -0,0 +1,20 @@
+//go:build linux
+
+package controller
+
+import (
+	"os"
+	"syscall"
+)
+
+// eviocgrab is EVIOCGRAB, _IOW('E', 0x90, int).
+const eviocgrab = 0x40044590
+
+// grab takes exclusive access to the device, so its events reach no other reader.
+func grab(f *os.File) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), eviocgrab, 1)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package controller
+
+import (
+	"errors"
+	"os"
+)
+
+// grab fails: evdev devices only exist on Linux.
+func grab(f *os.File) error {
+	return errors.New("input devices are only supported on Linux")
+}
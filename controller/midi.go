@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// MIDIEvent is a note or control change from a MIDI controller. Channels are
+// ignored: a control is identified by its kind and number alone.
+type MIDIEvent struct {
+	// Kind is "note" for a key or button, "cc" for a knob, wheel or ring
+	Kind   string
+	Number byte
+	// Value is the note velocity, or the controller value
+	Value byte
+}
+
+// Control returns the binding name of the event's control, e.g. "note:36" or "cc:10".
+func (e MIDIEvent) Control() string {
+	return fmt.Sprintf("%s:%d", e.Kind, e.Number)
+}
+
+// Pressed reports whether the event is a button going down: a note on, or a
+// control change to a non-zero value.
+func (e MIDIEvent) Pressed() bool {
+	return e.Value > 0
+}
+
+// Jog returns the ticks a relative (jog wheel) control moved: values 1-63 turn
+// forward, 65-127 backward (two's complement), 0 and 64 not at all.
+func (e MIDIEvent) Jog() int {
+	switch {
+	case e.Value > 0 && e.Value < 64:
+		return int(e.Value)
+	case e.Value > 64:
+		return int(e.Value) - 128
+	default:
+		return 0
+	}
+}
+
+// MIDIDevice is an ALSA raw MIDI device, e.g. /dev/snd/midiC1D0. Controllers
+// that are not MIDI themselves, such as the Contour ShuttlePRO, can be exposed
+// as one through a MIDI bridge.
+type MIDIDevice struct {
+	f      *os.File
+	r      *bufio.Reader
+	status byte
+}
+
+// OpenMIDI opens a raw MIDI device for reading.
+func OpenMIDI(path string) (*MIDIDevice, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	return &MIDIDevice{f: f, r: bufio.NewReader(f)}, nil
+}
+
+// Next blocks until the next note or control change. Note offs, other channel
+// messages, system exclusive and real-time bytes are skipped. Running status
+// (data bytes reusing the previous status byte) is supported.
+func (d *MIDIDevice) Next() (MIDIEvent, error) {
+	for {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return MIDIEvent{}, err
+		}
+		switch {
+		case b >= 0xF8:
+			// Real-time bytes (clock, start, stop) may appear anywhere
+			continue
+		case b == 0xF0:
+			if _, err := d.r.ReadBytes(0xF7); err != nil {
+				return MIDIEvent{}, err
+			}
+			d.status = 0
+			continue
+		case b >= 0xF0:
+			d.status = 0
+			continue
+		case b >= 0x80:
+			d.status = b
+			if b, err = d.r.ReadByte(); err != nil {
+				return MIDIEvent{}, err
+			}
+		}
+		if d.status == 0 {
+			continue
+		}
+
+		// b is the first data byte
+		kind := d.status & 0xF0
+		if kind == 0xC0 || kind == 0xD0 {
+			// Program change and channel pressure have one data byte
+			continue
+		}
+		value, err := d.r.ReadByte()
+		if err != nil {
+			return MIDIEvent{}, err
+		}
+		switch kind {
+		case 0x90:
+			if value > 0 {
+				return MIDIEvent{Kind: "note", Number: b, Value: value}, nil
+			}
+		case 0xB0:
+			return MIDIEvent{Kind: "cc", Number: b, Value: value}, nil
+		}
+	}
+}
+
+// Close releases the device.
+func (d *MIDIDevice) Close() error {
+	return d.f.Close()
+}
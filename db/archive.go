@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// archiveTables are the tables moved to a season archive, parents before children.
+// Each entry is the table name and the condition selecting the archived rows,
+// written against the temp.archive_videos ID list.
+var archiveTables = []struct {
+	name  string
+	where string
+}{
+	{"matches", "id IN (SELECT match_id FROM main.videos WHERE id IN (SELECT id FROM temp.archive_videos))"},
+	{"videos", "id IN (SELECT id FROM temp.archive_videos)"},
+	{"video_timings", "video_id IN (SELECT id FROM temp.archive_videos)"},
+	{"notes", "video_id IN (SELECT id FROM temp.archive_videos)"},
+	{"note_clips", "note_id IN (SELECT id FROM main.notes WHERE video_id IN (SELECT id FROM temp.archive_videos))"},
+	{"note_timing", "note_id IN (SELECT id FROM main.notes WHERE video_id IN (SELECT id FROM temp.archive_videos))"},
+	{"note_tackles", "note_id IN (SELECT id FROM main.notes WHERE video_id IN (SELECT id FROM temp.archive_videos))"},
+	{"note_zones", "note_id IN (SELECT id FROM main.notes WHERE video_id IN (SELECT id FROM temp.archive_videos))"},
+	{"note_details", "note_id IN (SELECT id FROM main.notes WHERE video_id IN (SELECT id FROM temp.archive_videos))"},
+	{"note_refs", "detail_id IN (SELECT id FROM main.note_details WHERE note_id IN (SELECT id FROM main.notes WHERE video_id IN (SELECT id FROM temp.archive_videos)))"},
+	{"note_highlights", "note_id IN (SELECT id FROM main.notes WHERE video_id IN (SELECT id FROM temp.archive_videos))"},
+	{"possessions", "video_id IN (SELECT id FROM temp.archive_videos)"},
+	{"periods", "video_id IN (SELECT id FROM temp.archive_videos)"},
+	{"sessions", "video_id IN (SELECT id FROM temp.archive_videos)"},
+	{"bookmarks", "video_id IN (SELECT id FROM temp.archive_videos)"},
+	{"commentary", "video_id IN (SELECT id FROM temp.archive_videos)"},
+	{"video_angles", "video_id IN (SELECT id FROM temp.archive_videos)"},
+	{"video_waveforms", "video_id IN (SELECT id FROM temp.archive_videos)"},
+	{"lineups", "video_id IN (SELECT id FROM temp.archive_videos)"},
+}
+
+// ArchiveResult counts the rows moved by ArchiveVideos.
+type ArchiveResult struct {
+	Videos int64
+	Notes  int64
+}
+
+// SelectSeasonVideoIDs returns the videos whose match falls in [from, to) ("YYYY-MM-DD").
+// Videos are placed by their linked match's date, else their own match date,
+// else the day their first note was created.
+func SelectSeasonVideoIDs(database *sql.DB, from, to string) ([]int64, error) {
+	rows, err := database.Query(SelectSeasonVideoIDsSQL, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ArchiveVideos moves the given videos, their matches and everything attached to them (notes and
+// their details, timings, possessions, periods, sessions, bookmarks, commentary, camera angles,
+// waveforms, lineups) into a new database at archivePath, then removes them from the working
+// database and compacts it.
+// The archive has the full schema, so it can be opened like any other database
+// (e.g. with --db and --read-only). archivePath must not already exist.
+func ArchiveVideos(database *sql.DB, videoIDs []int64, archivePath string) (ArchiveResult, error) {
+	var res ArchiveResult
+	if _, err := os.Stat(archivePath); err == nil {
+		return res, fmt.Errorf("archive already exists: %s", archivePath)
+	}
+
+	// Create the archive with the current schema. DELETE journal mode keeps it a
+	// single self-contained file that opens read-only from anywhere.
+	archive, err := OpenPath(archivePath)
+	if err != nil {
+		return res, fmt.Errorf("create archive: %w", err)
+	}
+	if _, err := archive.Exec("PRAGMA journal_mode = DELETE"); err != nil {
+		archive.Close()
+		return res, fmt.Errorf("set archive journal mode: %w", err)
+	}
+	if err := archive.Close(); err != nil {
+		return res, fmt.Errorf("close archive: %w", err)
+	}
+
+	// ATTACH and temp tables are per connection, so pin one for the whole move.
+	ctx := context.Background()
+	conn, err := database.Conn(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS archive", archivePath); err != nil {
+		return res, fmt.Errorf("attach archive: %w", err)
+	}
+	defer conn.ExecContext(ctx, "DETACH DATABASE archive")
+
+	if _, err := conn.ExecContext(ctx, "CREATE TEMP TABLE IF NOT EXISTS archive_videos (id INTEGER PRIMARY KEY)"); err != nil {
+		return res, fmt.Errorf("create archive id list: %w", err)
+	}
+	defer conn.ExecContext(ctx, "DROP TABLE IF EXISTS temp.archive_videos")
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return res, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM temp.archive_videos"); err != nil {
+		return res, fmt.Errorf("reset archive id list: %w", err)
+	}
+	for _, id := range videoIDs {
+		if _, err := tx.Exec("INSERT INTO temp.archive_videos (id) VALUES (?)", id); err != nil {
+			return res, fmt.Errorf("insert archive id: %w", err)
+		}
+	}
+
+	// Copy parents first so foreign keys in the archive are satisfied
+	for _, t := range archiveTables {
+		cols, err := tableColumns(tx, "archive", t.name)
+		if err != nil {
+			return res, err
+		}
+		colList := strings.Join(cols, ", ")
+		query := fmt.Sprintf("INSERT INTO archive.%s (%s) SELECT %s FROM main.%s WHERE %s", t.name, colList, colList, t.name, t.where)
+		result, err := tx.Exec(query)
+		if err != nil {
+			return res, fmt.Errorf("copy %s: %w", t.name, err)
+		}
+		n, _ := result.RowsAffected()
+		switch t.name {
+		case "videos":
+			res.Videos = n
+		case "notes":
+			res.Notes = n
+		}
+	}
+
+	// Delete children first; the note_* conditions still need main.notes
+	for i := len(archiveTables) - 1; i >= 0; i-- {
+		t := archiveTables[i]
+		if _, err := tx.Exec(fmt.Sprintf("DELETE FROM main.%s WHERE %s", t.name, t.where)); err != nil {
+			return res, fmt.Errorf("delete %s: %w", t.name, err)
+		}
+	}
+	// The videos are gone by the time matches come round above; a match stays
+	// in the working database while it still has videos there
+	if _, err := tx.Exec(`DELETE FROM main.matches
+		WHERE id IN (SELECT id FROM archive.matches)
+		  AND id NOT IN (SELECT match_id FROM main.videos WHERE match_id IS NOT NULL)`); err != nil {
+		return res, fmt.Errorf("delete matches: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return res, fmt.Errorf("commit archive: %w", err)
+	}
+
+	// Reclaim the space freed in the working database
+	if _, err := conn.ExecContext(ctx, "DETACH DATABASE archive"); err != nil {
+		return res, fmt.Errorf("detach archive: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "VACUUM"); err != nil {
+		return res, fmt.Errorf("vacuum: %w", err)
+	}
+	return res, nil
+}
+
+// tableColumns returns the column names of schema.table.
+func tableColumns(tx *sql.Tx, schema, table string) ([]string, error) {
+	rows, err := tx.Query("SELECT name FROM pragma_table_info(?, ?)", table, schema)
+	if err != nil {
+		return nil, fmt.Errorf("read %s columns: %w", table, err)
+	}
+	defer rows.Close()
+
+	var cols []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		cols = append(cols, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("table %s not found in %s", table, schema)
+	}
+	return cols, nil
+}
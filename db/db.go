@@ -2,21 +2,48 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 
 	_ "modernc.org/sqlite"
 )
 
+// pathOverride replaces the default database location when set (the --db flag).
+var pathOverride string
+
+// readOnly opens databases without creating, migrating or writing to them.
+var readOnly bool
+
+// SetPath overrides the database location used by Open.
+func SetPath(path string) {
+	pathOverride = path
+}
+
+// SetReadOnly makes Open open the database read-only. Migrations are not run,
+// so older databases (e.g. season archives) are opened as they are.
+func SetReadOnly(ro bool) {
+	readOnly = ro
+}
+
 // Open opens or creates the SQLite database at the default location.
-// The database file is created at ~/.local/share/tagging-rugby-cli/data.db.
+// The database file is created at ~/.local/share/tagging-rugby-cli/data.db
+// unless overridden with SetPath.
 // Parent directories are created if they don't exist.
 func Open() (*sql.DB, error) {
 	dbPath, err := getDBPath()
 	if err != nil {
 		return nil, err
 	}
+	if readOnly {
+		return OpenReadOnly(dbPath)
+	}
+	return OpenPath(dbPath)
+}
 
+// OpenPath opens or creates the SQLite database at dbPath and runs migrations.
+func OpenPath(dbPath string) (*sql.DB, error) {
 	// Create parent directories if they don't exist
 	dir := filepath.Dir(dbPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -53,26 +80,93 @@ func Open() (*sql.DB, error) {
 		return nil, err
 	}
 
+	// Refuse databases migrated by a newer release before touching them
+	if err := checkSchemaVersion(db, dbPath); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	// Run migrations
+	if err := runMigrations(db, dbPath); err != nil {
+		db.Close()
+		return nil, err
+	}
+
 	// Ensure the UNIQUE INDEX on note_clips(note_id) exists. This index is
 	// required for the ON CONFLICT(note_id) upsert in UpsertNoteClipPending.
 	// Existing databases that were migrated before this index was added to
 	// the migration file won't have it, so we create it here idempotently.
+	// This runs after migrations so that note_clips exists on a fresh database.
 	if _, err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_note_clips_note_id ON note_clips(note_id)"); err != nil {
 		db.Close()
 		return nil, err
 	}
 
-	// Run migrations
-	if err := runMigrations(db); err != nil {
+	return db, nil
+}
+
+// OpenReadOnly opens an existing SQLite database without writing to it.
+// No migrations are run and the file is never created.
+func OpenReadOnly(dbPath string) (*sql.DB, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec("PRAGMA busy_timeout = 5000"); err != nil {
 		db.Close()
 		return nil, err
 	}
+	return db, nil
+}
+
+// memoryDBs numbers in-memory databases so each OpenMemory call gets its own.
+var memoryDBs atomic.Int64
 
+// OpenMemory opens a new, migrated in-memory database, e.g. for tests or for
+// driving the TUI from scripts. Connections in the pool share one database,
+// which lasts until the returned *sql.DB is closed.
+func OpenMemory() (*sql.DB, error) {
+	// foreign_keys is set per connection through the DSN
+	name := fmt.Sprintf("file:memdb%d?mode=memory&cache=shared&_pragma=foreign_keys(1)", memoryDBs.Add(1))
+	db, err := sql.Open("sqlite", name)
+	if err != nil {
+		return nil, err
+	}
+	// Keep a connection open so the database outlives idle connections
+	db.SetMaxIdleConns(4)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	if err := runMigrations(db, ""); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_note_clips_note_id ON note_clips(note_id)"); err != nil {
+		db.Close()
+		return nil, err
+	}
 	return db, nil
 }
 
+// Path returns the database file Open uses.
+func Path() (string, error) {
+	return getDBPath()
+}
+
 // getDBPath returns the path to the database file.
 func getDBPath() (string, error) {
+	if pathOverride != "" {
+		return pathOverride, nil
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
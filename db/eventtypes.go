@@ -0,0 +1,72 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// UpsertEventType adds an event type, or replaces the fields and duration of
+// the one with its name.
+func UpsertEventType(database *sql.DB, t EventType) error {
+	fields := t.Fields
+	if fields == nil {
+		fields = []EventField{}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("encode event fields: %w", err)
+	}
+	if _, err := database.Exec(UpsertEventTypeSQL, t.Name, string(data), t.Duration); err != nil {
+		return fmt.Errorf("save event type: %w", err)
+	}
+	return nil
+}
+
+// SelectEventTypes returns the event types by name.
+func SelectEventTypes(database *sql.DB) ([]EventType, error) {
+	rows, err := database.Query(SelectEventTypesSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []EventType
+	for rows.Next() {
+		t, err := scanEventType(rows)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return types, rows.Err()
+}
+
+// SelectEventType returns the event type named name in any case; the error is
+// sql.ErrNoRows when there is none.
+func SelectEventType(database *sql.DB, name string) (EventType, error) {
+	return scanEventType(database.QueryRow(SelectEventTypeSQL, name))
+}
+
+// DeleteEventType removes an event type, reporting whether it existed. Events
+// already tagged with it are kept as notes of its category.
+func DeleteEventType(database *sql.DB, name string) (bool, error) {
+	result, err := database.Exec(DeleteEventTypeSQL, name)
+	if err != nil {
+		return false, fmt.Errorf("delete event type: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func scanEventType(row interface{ Scan(...any) error }) (EventType, error) {
+	var t EventType
+	var fields string
+	if err := row.Scan(&t.ID, &t.Name, &fields, &t.Duration); err != nil {
+		return EventType{}, err
+	}
+	if err := json.Unmarshal([]byte(fields), &t.Fields); err != nil {
+		return EventType{}, fmt.Errorf("event type %s: invalid fields: %w", t.Name, err)
+	}
+	return t, nil
+}
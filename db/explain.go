@@ -0,0 +1,68 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BindSQL returns query with its parameters (?1 numbered or bare ?) replaced
+// by args as SQL literals, so a statement can be read, or run with 'db query',
+// exactly as the app runs it. Question marks inside quoted strings are left
+// alone, as are parameters without an argument.
+func BindSQL(query string, args ...any) string {
+	var b strings.Builder
+	next := 0
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch c {
+		case '\'', '"':
+			// Copy a quoted string or identifier through unchanged
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 1
+		case '?':
+			j := i + 1
+			for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+				j++
+			}
+			n := next + 1
+			if j > i+1 {
+				n, _ = strconv.Atoi(query[i+1 : j])
+			}
+			next = n
+			if n < 1 || n > len(args) {
+				b.WriteString(query[i:j])
+			} else {
+				b.WriteString(sqlLiteral(args[n-1]))
+			}
+			i = j - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// sqlLiteral formats a query argument as an SQLite literal.
+func sqlLiteral(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -2,11 +2,17 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+	"github.com/user/tagging-rugby-cli/pkg/noteref"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
 )
 
 // EnsureVideoTiming selects the video_timing row for the given videoID; inserts one (with stopped=NULL) if not found.
@@ -54,8 +60,8 @@ func EnsureVideo(db *sql.DB, path string, filesize int64, format string) (int64,
 	if err != sql.ErrNoRows {
 		return 0, fmt.Errorf("select video by path: %w", err)
 	}
-	base := filepath.Base(path)
-	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	base := videosrc.Base(path)
+	ext := videosrc.Ext(path)
 	result, err := db.Exec(InsertVideoSQL, path, base, ext, format, filesize)
 	if err != nil {
 		return 0, fmt.Errorf("insert video: %w", err)
@@ -63,9 +69,187 @@ func EnsureVideo(db *sql.DB, path string, filesize int64, format string) (int64,
 	return result.LastInsertId()
 }
 
+// SelectVideoIDByPath returns the ID of the video with the given path.
+// Returns sql.ErrNoRows when the video has not been registered.
+func SelectVideoIDByPath(database *sql.DB, path string) (int64, error) {
+	var videoID int64
+	if err := database.QueryRow(SelectVideoByPathSQL, path).Scan(&videoID); err != nil {
+		return 0, err
+	}
+	return videoID, nil
+}
+
+// RelinkVideo points the video registered at oldPath to newPath (e.g. a local copy of a
+// streamed match), updating its filename, extension and filesize. Notes stay attached
+// because they reference the video by ID.
+func RelinkVideo(database *sql.DB, oldPath, newPath string, filesize int64) error {
+	videoID, err := SelectVideoIDByPath(database, oldPath)
+	if err != nil {
+		return fmt.Errorf("select video by path: %w", err)
+	}
+	if _, err := SelectVideoIDByPath(database, newPath); err == nil {
+		return fmt.Errorf("video already registered: %s", newPath)
+	}
+	if _, err := database.Exec(UpdateVideoPathSQL, newPath, videosrc.Base(newPath), videosrc.Ext(newPath), filesize, videoID); err != nil {
+		return fmt.Errorf("update video path: %w", err)
+	}
+	return nil
+}
+
+// SelectLiveVideo returns the ID of the live-match placeholder video at path and
+// the wall-clock time its note timings count from.
+// Returns sql.ErrNoRows when there is no such live match.
+func SelectLiveVideo(database *sql.DB, path string) (int64, time.Time, error) {
+	var videoID int64
+	var startedAt time.Time
+	err := database.QueryRow(SelectLiveVideoSQL, path).Scan(&videoID, &startedAt)
+	return videoID, startedAt, err
+}
+
+// EnsureLiveVideo returns the placeholder video for live-match tagging at path,
+// creating it (and starting its wall clock) if needed.
+func EnsureLiveVideo(database *sql.DB, path, name string) (int64, time.Time, error) {
+	videoID, startedAt, err := SelectLiveVideo(database, path)
+	if err == nil {
+		return videoID, startedAt, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, time.Time{}, fmt.Errorf("select live video: %w", err)
+	}
+	if _, err := database.Exec(InsertLiveVideoSQL, path, name); err != nil {
+		return 0, time.Time{}, fmt.Errorf("insert live video: %w", err)
+	}
+	videoID, startedAt, err = SelectLiveVideo(database, path)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("select live video: %w", err)
+	}
+	return videoID, startedAt, nil
+}
+
+// AlignLiveNotes moves the notes tagged live on liveVideoID onto videoID, shifting
+// their timings by offset seconds. Timings that would fall before the start of the
+// video are clamped to 0. Returns the number of notes moved.
+func AlignLiveNotes(database *sql.DB, liveVideoID, videoID int64, offset float64) (int64, error) {
+	tx, err := database.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(ShiftNoteTimingsByVideoSQL, offset, offset, liveVideoID); err != nil {
+		return 0, fmt.Errorf("shift note timings: %w", err)
+	}
+	result, err := tx.Exec(MoveNotesToVideoSQL, videoID, liveVideoID)
+	if err != nil {
+		return 0, fmt.Errorf("move notes: %w", err)
+	}
+	moved, _ := result.RowsAffected()
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return moved, nil
+}
+
+// InsertVideoAngle links a secondary camera angle to a video and returns its ID.
+func InsertVideoAngle(database *sql.DB, a VideoAngle) (int64, error) {
+	result, err := database.Exec(InsertVideoAngleSQL, a.VideoID, a.Path, a.Label, a.Offset)
+	if err != nil {
+		return 0, fmt.Errorf("insert video angle: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// SelectVideoAngles returns the angles linked to a video in the order they were added.
+func SelectVideoAngles(database *sql.DB, videoID int64) ([]VideoAngle, error) {
+	rows, err := database.Query(SelectVideoAnglesByVideoSQL, videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var angles []VideoAngle
+	for rows.Next() {
+		var a VideoAngle
+		if err := rows.Scan(&a.ID, &a.VideoID, &a.Path, &a.Label, &a.Offset); err != nil {
+			return nil, err
+		}
+		angles = append(angles, a)
+	}
+	return angles, rows.Err()
+}
+
+// DeleteVideoAngle unlinks the angle with label from a video.
+// Returns sql.ErrNoRows when the video has no such angle.
+func DeleteVideoAngle(database *sql.DB, videoID int64, label string) error {
+	result, err := database.Exec(DeleteVideoAngleSQL, videoID, label)
+	if err != nil {
+		return fmt.Errorf("delete video angle: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UpsertWaveform stores the audio peaks computed for a video, replacing any previous ones.
+func UpsertWaveform(database *sql.DB, w Waveform) error {
+	if _, err := database.Exec(UpsertVideoWaveformSQL, w.VideoID, w.SecondsPerPeak, w.Peaks); err != nil {
+		return fmt.Errorf("upsert waveform: %w", err)
+	}
+	return nil
+}
+
+// SelectWaveform returns the audio peaks stored for a video.
+// Returns sql.ErrNoRows when none have been computed.
+func SelectWaveform(database *sql.DB, videoID int64) (Waveform, error) {
+	var w Waveform
+	err := database.QueryRow(SelectVideoWaveformSQL, videoID).Scan(&w.VideoID, &w.SecondsPerPeak, &w.Peaks)
+	return w, err
+}
+
+// SelectMatchInfo returns the match metadata recorded for a video.
+func SelectMatchInfo(database *sql.DB, videoID int64) (MatchInfo, error) {
+	var m MatchInfo
+	err := database.QueryRow(SelectVideoMatchInfoSQL, videoID).Scan(&m.Opponent, &m.Date, &m.Venue, &m.Result, &m.Competition)
+	return m, err
+}
+
+// UpdateMatchInfo replaces the match metadata for a video.
+func UpdateMatchInfo(database *sql.DB, videoID int64, m MatchInfo) error {
+	if _, err := database.Exec(UpdateVideoMatchInfoSQL, m.Opponent, m.Date, m.Venue, m.Result, m.Competition, videoID); err != nil {
+		return fmt.Errorf("update match info: %w", err)
+	}
+	return nil
+}
+
+// SelectVideos returns the registered videos matching the filter, most recent match
+// first; videos without a match date come last.
+func SelectVideos(database *sql.DB, f VideoFilter) ([]VideoSummary, error) {
+	rows, err := database.Query(SelectVideosSQL,
+		f.Opponent, f.Opponent, f.Competition, f.Competition, f.From, f.From, f.To, f.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var videos []VideoSummary
+	for rows.Next() {
+		var v VideoSummary
+		if err := rows.Scan(&v.ID, &v.Path, &v.Filename, &v.Match.Opponent, &v.Match.Date, &v.Match.Venue, &v.Match.Result, &v.Match.Competition, &v.Notes); err != nil {
+			return nil, err
+		}
+		if v.Filename == "" {
+			v.Filename = filepath.Base(v.Path)
+		}
+		videos = append(videos, v)
+	}
+	return videos, rows.Err()
+}
+
 // InsertNote inserts a new note with the given video_id and returns its ID.
+// The category path is stored normalized (see catpath.Normalize).
 func InsertNote(db *sql.DB, category string, videoID int64) (int64, error) {
-	result, err := db.Exec(InsertNoteSQL, category, videoID)
+	result, err := db.Exec(InsertNoteSQL, catpath.Normalize(category), videoID)
 	if err != nil {
 		return 0, fmt.Errorf("insert note: %w", err)
 	}
@@ -83,8 +267,8 @@ func getOrCreateVideo(tx *sql.Tx, v NoteVideo) (int64, error) {
 	if err != sql.ErrNoRows {
 		return 0, fmt.Errorf("query video by path: %w", err)
 	}
-	base := filepath.Base(v.Path)
-	ext := strings.TrimPrefix(filepath.Ext(v.Path), ".")
+	base := videosrc.Base(v.Path)
+	ext := videosrc.Ext(v.Path)
 	result, err := tx.Exec(InsertVideoSQL, v.Path, base, ext, v.Format, v.Size)
 	if err != nil {
 		return 0, fmt.Errorf("insert video: %w", err)
@@ -110,6 +294,26 @@ func SelectNextPendingClip(database *sql.DB) (*PendingClip, error) {
 	return &c, nil
 }
 
+// SelectVideoClips returns the video's timed events, clip marks aside, with
+// the status of their clip exports, in time order.
+func SelectVideoClips(database *sql.DB, videoID int64) ([]VideoClip, error) {
+	rows, err := database.Query(SelectVideoClipsSQL, videoID)
+	if err != nil {
+		return nil, fmt.Errorf("select video clips: %w", err)
+	}
+	defer rows.Close()
+
+	var clips []VideoClip
+	for rows.Next() {
+		var c VideoClip
+		if err := rows.Scan(&c.NoteID, &c.Category, &c.Start, &c.Player, &c.Attempt, &c.Outcome, &c.Starred, &c.Status, &c.Log); err != nil {
+			return nil, fmt.Errorf("scan video clip: %w", err)
+		}
+		clips = append(clips, c)
+	}
+	return clips, rows.Err()
+}
+
 // MarkClipProcessing updates a note_clips row to processing status with the given start time.
 func MarkClipProcessing(db *sql.DB, clipID int64, startedAt time.Time) error {
 	_, err := db.Exec(MarkClipProcessingSQL, startedAt, clipID)
@@ -119,6 +323,63 @@ func MarkClipProcessing(db *sql.DB, clipID int64, startedAt time.Time) error {
 	return nil
 }
 
+// ClaimPendingClip marks a pending note_clips row as processing, reporting
+// false when it is no longer pending because another worker, in this process
+// or another, claimed it first.
+func ClaimPendingClip(db *sql.DB, clipID int64, startedAt time.Time) (bool, error) {
+	res, err := db.Exec(ClaimPendingClipSQL, startedAt, clipID)
+	if err != nil {
+		return false, fmt.Errorf("claim pending clip: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("claim pending clip: %w", err)
+	}
+	return n > 0, nil
+}
+
+// RequeueClip returns a processing note_clips row to pending, for a worker
+// stopped part-way through rendering it.
+func RequeueClip(db *sql.DB, clipID int64) error {
+	if _, err := db.Exec(RequeueClipSQL, clipID); err != nil {
+		return fmt.Errorf("requeue clip: %w", err)
+	}
+	return nil
+}
+
+// RequeueStaleClips returns processing note_clips rows claimed before before
+// to pending: their worker was killed without putting them back. It returns
+// how many were requeued.
+func RequeueStaleClips(database *sql.DB, before time.Time) (int, error) {
+	rows, err := database.Query(SelectProcessingClipsSQL)
+	if err != nil {
+		return 0, fmt.Errorf("select processing clips: %w", err)
+	}
+	var stale []int64
+	for rows.Next() {
+		var id int64
+		var startedAt sql.NullTime
+		if err := rows.Scan(&id, &startedAt); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan processing clip: %w", err)
+		}
+		if !startedAt.Valid || startedAt.Time.Before(before) {
+			stale = append(stale, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("select processing clips: %w", err)
+	}
+
+	for i, id := range stale {
+		if err := RequeueClip(database, id); err != nil {
+			return i, err
+		}
+	}
+	return len(stale), nil
+}
+
 // MarkClipComplete updates a note_clips row to complete status with the given finish time and filesize.
 func MarkClipComplete(db *sql.DB, clipID int64, finishedAt time.Time, filesize int64) error {
 	_, err := db.Exec(MarkClipCompleteSQL, finishedAt, filesize, clipID)
@@ -210,19 +471,40 @@ func InsertNoteDetail(db *sql.DB, noteID int64, detailType, note string) error {
 	return nil
 }
 
-// InsertNoteHighlight inserts a note_highlights row.
+// InsertNoteHighlight inserts a note_highlights row and marks the note edited.
 func InsertNoteHighlight(db *sql.DB, noteID int64, highlightType string) error {
 	_, err := db.Exec(InsertNoteHighlightSQL, noteID, highlightType)
 	if err != nil {
 		return fmt.Errorf("insert note highlight: %w", err)
 	}
+	return TouchNote(db, noteID)
+}
+
+// DeleteNoteHighlight removes one type of highlight from a note and marks the
+// note edited.
+func DeleteNoteHighlight(database *sql.DB, noteID int64, highlightType string) error {
+	if _, err := database.Exec(DeleteNoteHighlightSQL, noteID, highlightType); err != nil {
+		return fmt.Errorf("delete note highlight: %w", err)
+	}
+	return TouchNote(database, noteID)
+}
+
+// TouchNote marks a note as edited now, for 'changes'.
+func TouchNote(database *sql.DB, noteID int64) error {
+	if _, err := database.Exec(TouchNoteSQL, noteID); err != nil {
+		return fmt.Errorf("touch note: %w", err)
+	}
 	return nil
 }
 
 // QueueClipIfNeeded checks if the note has all required data (category, timing, tackle) and queues a clip
 // generation job by upserting a pending note_clips row. Silently returns nil if any data is missing.
+// Streamed (URL) videos are skipped: ffmpeg clips need a local copy, see RelinkVideo.
 // Note: path computation is inlined here (same logic as clip.ClipPaths) to avoid an import cycle between db and clip.
 func QueueClipIfNeeded(database *sql.DB, noteID int64, videoPath string) error {
+	if videosrc.IsURL(videoPath) {
+		return nil
+	}
 	note, err := SelectNoteByID(database, noteID)
 	if err != nil {
 		return nil
@@ -239,9 +521,11 @@ func QueueClipIfNeeded(database *sql.DB, noteID int64, videoPath string) error {
 	}
 
 	t := tackles[0]
-	categorySlug := strings.ToLower(strings.ReplaceAll(note.Category, " ", "_"))
-	playerSlug := strings.ToLower(strings.ReplaceAll(t.Player, " ", "_"))
-	outcomeSlug := strings.ToLower(strings.ReplaceAll(t.Outcome, " ", "_"))
+	// Same as clip.Slug: category paths like set-piece/lineout stay one folder
+	slug := strings.NewReplacer(" ", "_", "/", "_", "\\", "_")
+	categorySlug := strings.ToLower(slug.Replace(note.Category))
+	playerSlug := strings.ToLower(slug.Replace(t.Player))
+	outcomeSlug := strings.ToLower(slug.Replace(t.Outcome))
 	folder := filepath.Join(filepath.Dir(videoPath), "clips", categorySlug, playerSlug)
 	totalSecs := int(timings[0].Start)
 	hours := totalSecs / 3600
@@ -282,8 +566,8 @@ func InsertNoteWithChildren(database *sql.DB, category string, children NoteChil
 		videoID = id
 	}
 
-	// Insert parent note with video_id.
-	result, err := tx.Exec(InsertNoteSQL, category, videoID)
+	// Insert parent note with video_id, its category path normalized.
+	result, err := tx.Exec(InsertNoteSQL, catpath.Normalize(category), videoID)
 	if err != nil {
 		return 0, fmt.Errorf("insert note: %w", err)
 	}
@@ -302,7 +586,11 @@ func InsertNoteWithChildren(database *sql.DB, category string, children NoteChil
 		}
 	}
 	for _, t := range children.Tackles {
-		if _, err := tx.Exec(InsertNoteTackleSQL, noteID, t.Player, t.Attempt, t.Outcome, t.Height, t.Technique); err != nil {
+		player, err := normalizePlayer(tx, videoID, t.Player)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(InsertNoteTackleSQL, noteID, player, t.Attempt, t.Outcome, t.Height, t.Technique); err != nil {
 			return 0, fmt.Errorf("insert note tackle: %w", err)
 		}
 	}
@@ -312,7 +600,11 @@ func InsertNoteWithChildren(database *sql.DB, category string, children NoteChil
 		}
 	}
 	for _, d := range children.Details {
-		if _, err := tx.Exec(InsertNoteDetailSQL, noteID, d.Type, d.Note); err != nil {
+		note, err := normalizeDetail(tx, videoID, d)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(InsertNoteDetailSQL, noteID, d.Type, note); err != nil {
 			return 0, fmt.Errorf("insert note detail: %w", err)
 		}
 	}
@@ -326,7 +618,8 @@ func InsertNoteWithChildren(database *sql.DB, category string, children NoteChil
 		return 0, fmt.Errorf("commit transaction: %w", err)
 	}
 
-	if len(children.Videos) > 0 {
+	// A note given its clip, e.g. one imported with its clips, keeps it
+	if len(children.Videos) > 0 && len(children.Clips) == 0 {
 		if err := QueueClipIfNeeded(database, noteID, children.Videos[0].Path); err != nil {
 			log.Printf("queue clip after insert: %v", err)
 		}
@@ -343,6 +636,12 @@ func UpdateNoteWithChildren(database *sql.DB, noteID int64, children NoteChildre
 	}
 	defer tx.Rollback()
 
+	// The note's video, for resolving shirt numbers against its lineup
+	var videoID int64
+	if err := tx.QueryRow(SelectNoteVideoIDSQL, noteID).Scan(&videoID); err != nil {
+		return fmt.Errorf("select note video: %w", err)
+	}
+
 	// Delete existing child rows
 	if _, err := tx.Exec(DeleteNoteDetailsSQL, noteID); err != nil {
 		return fmt.Errorf("delete note details: %w", err)
@@ -359,7 +658,11 @@ func UpdateNoteWithChildren(database *sql.DB, noteID int64, children NoteChildre
 
 	// Re-insert child records
 	for _, t := range children.Tackles {
-		if _, err := tx.Exec(InsertNoteTackleSQL, noteID, t.Player, t.Attempt, t.Outcome, t.Height, t.Technique); err != nil {
+		player, err := normalizePlayer(tx, videoID, t.Player)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(InsertNoteTackleSQL, noteID, player, t.Attempt, t.Outcome, t.Height, t.Technique); err != nil {
 			return fmt.Errorf("insert note tackle: %w", err)
 		}
 	}
@@ -369,7 +672,11 @@ func UpdateNoteWithChildren(database *sql.DB, noteID int64, children NoteChildre
 		}
 	}
 	for _, d := range children.Details {
-		if _, err := tx.Exec(InsertNoteDetailSQL, noteID, d.Type, d.Note); err != nil {
+		note, err := normalizeDetail(tx, videoID, d)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(InsertNoteDetailSQL, noteID, d.Type, note); err != nil {
 			return fmt.Errorf("insert note detail: %w", err)
 		}
 	}
@@ -378,6 +685,9 @@ func UpdateNoteWithChildren(database *sql.DB, noteID int64, children NoteChildre
 			return fmt.Errorf("insert note highlight: %w", err)
 		}
 	}
+	if _, err := tx.Exec(TouchNoteSQL, noteID); err != nil {
+		return fmt.Errorf("touch note: %w", err)
+	}
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("commit transaction: %w", err)
@@ -406,13 +716,35 @@ func UpdateNoteTiming(database *sql.DB, noteID int64, start, end float64) error
 	if rows == 0 {
 		return sql.ErrNoRows
 	}
-	return nil
+	return TouchNote(database, noteID)
+}
+
+// UpdateNoteText replaces the text of a note's first detail row, the one shown in
+// the notes list. A note without details gets a new "text" detail.
+func UpdateNoteText(database *sql.DB, noteID int64, text string) error {
+	result, err := database.Exec(UpdateNoteDetailTextSQL, text, noteID)
+	if err != nil {
+		return fmt.Errorf("update note text: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	}
+	if rows == 0 {
+		if err := InsertNoteDetail(database, noteID, "text", text); err != nil {
+			return err
+		}
+	}
+	return TouchNote(database, noteID)
 }
 
 // QueueUnprocessedTackleClips queues clip generation for all tackle notes on the given video
 // that have no note_clips row or have a note_clips row in 'error' status.
 // This is called on startup so that notes from previous sessions (or failed clips) are retried.
 func QueueUnprocessedTackleClips(database *sql.DB, videoPath string) error {
+	if videosrc.IsURL(videoPath) {
+		return nil
+	}
 	rows, err := database.Query(`
 		SELECT n.id
 		FROM notes n
@@ -459,6 +791,129 @@ func SelectNoteByID(database *sql.DB, id int64) (*Note, error) {
 	return &n, nil
 }
 
+// SelectNoteIDByNumber returns the ID of a video's nth event, numbering its
+// notes 1..N in time order. Returns sql.ErrNoRows if the video has fewer.
+func SelectNoteIDByNumber(database *sql.DB, videoPath string, number int) (int64, error) {
+	var id int64
+	if err := database.QueryRow(SelectNoteIDByNumberSQL, videoPath, number).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// CountVideoNotes returns how many events the video has, which are numbered
+// 1..N by SelectNoteIDByNumber.
+func CountVideoNotes(database *sql.DB, videoPath string) (int, error) {
+	var count int
+	if err := database.QueryRow(CountVideoNotesSQL, videoPath).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count video notes: %w", err)
+	}
+	return count, nil
+}
+
+// ResolveNoteRefs returns the IDs of the events the #17 references in text
+// point to, in order (see noteref.Find), numbered as SelectNoteIDByNumber
+// numbers them; 0 for a number the video has no event for. Resolve before
+// saving the text, as a new note renumbers those after it.
+func ResolveNoteRefs(database *sql.DB, videoPath, text string) ([]int64, error) {
+	refs := noteref.Find(text)
+	targets := make([]int64, len(refs))
+	for i, r := range refs {
+		id, err := SelectNoteIDByNumber(database, videoPath, r.Number)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("resolve #%d: %w", r.Number, err)
+		}
+		targets[i] = id
+	}
+	return targets, nil
+}
+
+// SaveNoteRefs keeps the events the references in a note's text point to,
+// by ID, so they follow the events when the video is renumbered: targets are
+// from ResolveNoteRefs, and text is the note's saved text detail. A 0 target
+// isn't kept, leaving that reference as written.
+func SaveNoteRefs(database *sql.DB, noteID int64, text string, targets []int64) error {
+	tx, err := database.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var detailID int64
+	if err := tx.QueryRow(SelectNoteDetailIDByTextSQL, noteID, text).Scan(&detailID); err != nil {
+		return fmt.Errorf("select note text: %w", err)
+	}
+	if _, err := tx.Exec(DeleteNoteRefsSQL, detailID); err != nil {
+		return fmt.Errorf("delete note refs: %w", err)
+	}
+	for position, target := range targets {
+		if target == 0 {
+			continue
+		}
+		if _, err := tx.Exec(InsertNoteRefSQL, detailID, position, target); err != nil {
+			return fmt.Errorf("insert note ref: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// SelectNoteRefNumbers returns the current numbers of the events the video's
+// note texts refer to: by note_details ID, then by the reference's position,
+// for noteref.Renumber. An event deleted since is number 0.
+func SelectNoteRefNumbers(database *sql.DB, videoPath string) (map[int64]map[int]int, error) {
+	rows, err := database.Query(SelectNoteRefNumbersSQL, videoPath, videoPath)
+	if err != nil {
+		return nil, fmt.Errorf("select note refs: %w", err)
+	}
+	defer rows.Close()
+
+	numbers := make(map[int64]map[int]int)
+	for rows.Next() {
+		var detailID int64
+		var position, number int
+		if err := rows.Scan(&detailID, &position, &number); err != nil {
+			return nil, fmt.Errorf("scan note ref: %w", err)
+		}
+		if numbers[detailID] == nil {
+			numbers[detailID] = make(map[int]int)
+		}
+		numbers[detailID][position] = number
+	}
+	return numbers, rows.Err()
+}
+
+// NoteExistsAt reports whether the video already has a note of the given category starting at start.
+// Used by importers to skip markers that were imported before.
+func NoteExistsAt(database *sql.DB, videoPath, category string, start float64) (bool, error) {
+	var count int
+	if err := database.QueryRow(SelectNoteExistsAtSQL, videoPath, category, start).Scan(&count); err != nil {
+		return false, fmt.Errorf("select note exists: %w", err)
+	}
+	return count > 0, nil
+}
+
+// SelectNotesForExport returns every note for a video, flattened for export and ordered by start time.
+func SelectNotesForExport(database *sql.DB, videoPath string) ([]ExportNote, error) {
+	rows, err := database.Query(SelectNotesForExportSQL, videoPath)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notes []ExportNote
+	for rows.Next() {
+		var n ExportNote
+		if err := rows.Scan(&n.ID, &n.Category, &n.Start, &n.End, &n.Text, &n.Player, &n.Team, &n.Outcome, &n.Starred); err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
+
 // SelectNotes returns all notes ordered by created_at DESC.
 func SelectNotes(database *sql.DB) ([]Note, error) {
 	rows, err := database.Query(SelectNotesSQL)
@@ -709,6 +1164,36 @@ func QueryExportProgress(database *sql.DB, videoPath string) (ExportProgress, er
 	return ep, nil
 }
 
+// PreviewNoteDelete counts the child records deleting a note would cascade to,
+// and finds its exported clip files on disk. Returns sql.ErrNoRows when the
+// note does not exist.
+func PreviewNoteDelete(database *sql.DB, noteID int64) (NoteDeletePreview, error) {
+	if _, err := SelectNoteByID(database, noteID); err != nil {
+		return NoteDeletePreview{}, err
+	}
+	var p NoteDeletePreview
+	err := database.QueryRow(SelectNoteDeletePreviewSQL, noteID).Scan(
+		&p.Timings, &p.Tackles, &p.Zones, &p.Details, &p.Highlights, &p.Clips,
+	)
+	if err != nil {
+		return NoteDeletePreview{}, fmt.Errorf("count note children: %w", err)
+	}
+	clips, err := SelectNoteClipsByNote(database, noteID)
+	if err != nil {
+		return NoteDeletePreview{}, fmt.Errorf("select note clips: %w", err)
+	}
+	for _, c := range clips {
+		if c.Folder == "" || c.Filename == "" {
+			continue
+		}
+		path := filepath.Join(c.Folder, c.Filename)
+		if _, err := os.Stat(path); err == nil {
+			p.ClipFiles = append(p.ClipFiles, path)
+		}
+	}
+	return p, nil
+}
+
 // DeleteNote deletes a note by ID. Cascade handles child records.
 func DeleteNote(database *sql.DB, id int64) error {
 	result, err := database.Exec(DeleteNoteSQL, id)
@@ -724,3 +1209,440 @@ func DeleteNote(database *sql.DB, id int64) error {
 	}
 	return nil
 }
+
+// StartPossession closes any open possession interval for the video at the given
+// time and opens a new one for team/territory starting at that time.
+func StartPossession(database *sql.DB, videoID int64, team, territory string, at float64) error {
+	tx, err := database.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(CloseOpenPossessionsSQL, at, videoID); err != nil {
+		return fmt.Errorf("close open possession: %w", err)
+	}
+	if _, err := tx.Exec(InsertPossessionSQL, videoID, team, territory, at); err != nil {
+		return fmt.Errorf("insert possession: %w", err)
+	}
+	return tx.Commit()
+}
+
+// EndPossession closes any open possession interval for the video at the given time.
+func EndPossession(database *sql.DB, videoID int64, at float64) error {
+	if _, err := database.Exec(CloseOpenPossessionsSQL, at, videoID); err != nil {
+		return fmt.Errorf("close open possession: %w", err)
+	}
+	return nil
+}
+
+// SelectOpenPossession returns the currently open possession interval for the video.
+// Returns nil, nil when no interval is open.
+func SelectOpenPossession(database *sql.DB, videoID int64) (*Possession, error) {
+	var p Possession
+	err := database.QueryRow(SelectOpenPossessionSQL, videoID).Scan(&p.ID, &p.VideoID, &p.Team, &p.Territory, &p.Start, &p.End)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select open possession: %w", err)
+	}
+	return &p, nil
+}
+
+// SelectPossessionsByVideo returns all possession intervals for a video ordered by start.
+func SelectPossessionsByVideo(database *sql.DB, videoID int64) ([]Possession, error) {
+	rows, err := database.Query(SelectPossessionsByVideoSQL, videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var possessions []Possession
+	for rows.Next() {
+		var p Possession
+		if err := rows.Scan(&p.ID, &p.VideoID, &p.Team, &p.Territory, &p.Start, &p.End); err != nil {
+			return nil, err
+		}
+		possessions = append(possessions, p)
+	}
+	return possessions, rows.Err()
+}
+
+// SummarizePossessions totals possession and territory time from the given intervals.
+// Open intervals are counted up to now; intervals starting after now are ignored.
+func SummarizePossessions(possessions []Possession, now float64) PossessionSummary {
+	var s PossessionSummary
+	for _, p := range possessions {
+		end := now
+		if p.End != nil {
+			end = *p.End
+		}
+		d := end - p.Start
+		if d <= 0 {
+			continue
+		}
+		switch p.Team {
+		case "home":
+			s.HomeSeconds += d
+		case "away":
+			s.AwaySeconds += d
+		}
+		switch p.Territory {
+		case "home":
+			s.HomeHalfSeconds += d
+		case "away":
+			s.AwayHalfSeconds += d
+		}
+	}
+	return s
+}
+
+// ReplacePeriods replaces all periods for a video with the given ones in a transaction.
+func ReplacePeriods(database *sql.DB, videoID int64, periods []Period) error {
+	tx, err := database.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(DeletePeriodsByVideoSQL, videoID); err != nil {
+		return fmt.Errorf("delete periods: %w", err)
+	}
+	for _, p := range periods {
+		if _, err := tx.Exec(InsertPeriodSQL, videoID, p.Name, p.Start, p.End); err != nil {
+			return fmt.Errorf("insert period: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// SelectPeriodsByVideo returns all periods for a video ordered by start.
+func SelectPeriodsByVideo(database *sql.DB, videoID int64) ([]Period, error) {
+	rows, err := database.Query(SelectPeriodsByVideoSQL, videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var periods []Period
+	for rows.Next() {
+		var p Period
+		if err := rows.Scan(&p.ID, &p.VideoID, &p.Name, &p.Start, &p.End); err != nil {
+			return nil, err
+		}
+		periods = append(periods, p)
+	}
+	return periods, rows.Err()
+}
+
+// InsertBookmark adds a bookmark at time seconds in a video and returns its ID.
+func InsertBookmark(database *sql.DB, videoID int64, time float64) (int64, error) {
+	result, err := database.Exec(InsertBookmarkSQL, videoID, time)
+	if err != nil {
+		return 0, fmt.Errorf("insert bookmark: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// SelectBookmarksByVideo returns all bookmarks for a video ordered by time.
+func SelectBookmarksByVideo(database *sql.DB, videoID int64) ([]Bookmark, error) {
+	rows, err := database.Query(SelectBookmarksByVideoSQL, videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookmarks []Bookmark
+	for rows.Next() {
+		var b Bookmark
+		if err := rows.Scan(&b.ID, &b.VideoID, &b.Time); err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, b)
+	}
+	return bookmarks, rows.Err()
+}
+
+// DeleteBookmark removes a single bookmark.
+func DeleteBookmark(database *sql.DB, bookmarkID int64) error {
+	if _, err := database.Exec(DeleteBookmarkSQL, bookmarkID); err != nil {
+		return fmt.Errorf("delete bookmark: %w", err)
+	}
+	return nil
+}
+
+// DeleteBookmarksByVideo removes all bookmarks for a video.
+func DeleteBookmarksByVideo(database *sql.DB, videoID int64) error {
+	if _, err := database.Exec(DeleteBookmarksByVideoSQL, videoID); err != nil {
+		return fmt.Errorf("delete bookmarks: %w", err)
+	}
+	return nil
+}
+
+// SelectCommentary returns the match commentary for a video, or "" when none has been written.
+func SelectCommentary(database *sql.DB, videoID int64) (string, error) {
+	var body string
+	err := database.QueryRow(SelectCommentaryByVideoSQL, videoID).Scan(&body)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return body, err
+}
+
+// UpsertCommentary saves the match commentary for a video, replacing any previous text.
+func UpsertCommentary(database *sql.DB, videoID int64, body string) error {
+	if _, err := database.Exec(UpsertCommentarySQL, videoID, body); err != nil {
+		return fmt.Errorf("upsert commentary: %w", err)
+	}
+	return nil
+}
+
+// StartSession records the start of a tagging session on a video and returns its ID.
+func StartSession(database *sql.DB, videoID int64) (int64, error) {
+	result, err := database.Exec(InsertSessionSQL, videoID)
+	if err != nil {
+		return 0, fmt.Errorf("insert session: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// EndSession closes a session, counting the notes added to its video since it started.
+func EndSession(database *sql.DB, sessionID int64) error {
+	if _, err := database.Exec(EndSessionSQL, sessionID); err != nil {
+		return fmt.Errorf("end session: %w", err)
+	}
+	return nil
+}
+
+// SelectSession returns a session by ID, or sql.ErrNoRows.
+func SelectSession(database *sql.DB, id int64) (Session, error) {
+	var s Session
+	err := database.QueryRow(SelectSessionSQL, id).Scan(&s.ID, &s.VideoID, &s.StartedAt, &s.EndedAt, &s.EventsAdded)
+	return s, err
+}
+
+// SelectPreviousSession returns the video's most recent finished session, or
+// sql.ErrNoRows when it has none.
+func SelectPreviousSession(database *sql.DB, videoID int64) (Session, error) {
+	var s Session
+	err := database.QueryRow(SelectPreviousSessionSQL, videoID).Scan(&s.ID, &s.VideoID, &s.StartedAt, &s.EndedAt, &s.EventsAdded)
+	return s, err
+}
+
+// SelectNoteChanges returns the video's notes added or edited since the given
+// session started, least recently changed first.
+func SelectNoteChanges(database *sql.DB, videoID, sessionID int64) ([]NoteChange, error) {
+	rows, err := database.Query(SelectNoteChangesSQL, videoID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []NoteChange
+	for rows.Next() {
+		var c NoteChange
+		if err := rows.Scan(&c.NoteID, &c.Category, &c.Time, &c.Player, &c.Text, &c.CreatedAt, &c.UpdatedAt, &c.Added); err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+// SelectSessions returns sessions started in [from, to), oldest first.
+// from and to are "YYYY-MM-DD" dates compared against sessions.started_at.
+func SelectSessions(database *sql.DB, from, to string) ([]Session, error) {
+	rows, err := database.Query(SelectSessionsSQL, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []Session
+	for rows.Next() {
+		var s Session
+		if err := rows.Scan(&s.ID, &s.VideoID, &s.Path, &s.Filename, &s.StartedAt, &s.EndedAt, &s.EventsAdded); err != nil {
+			return nil, err
+		}
+		if s.Filename == "" {
+			s.Filename = filepath.Base(s.Path)
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// SelectReplayEvents returns a video's notes in the order they were tagged.
+// A non-zero sessionID limits them to notes created during that session.
+func SelectReplayEvents(database *sql.DB, videoID, sessionID int64) ([]ReplayEvent, error) {
+	rows, err := database.Query(SelectReplayEventsSQL, videoID, sessionID, sessionID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []ReplayEvent
+	for rows.Next() {
+		var e ReplayEvent
+		if err := rows.Scan(&e.NoteID, &e.Category, &e.CreatedAt, &e.Time); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// SelectReportMatches returns videos with notes created in [from, to), ordered by match
+// date (falling back to the first tag for videos without one).
+// from and to are "YYYY-MM-DD" dates compared against notes.created_at.
+func SelectReportMatches(database *sql.DB, from, to string) ([]ReportMatch, error) {
+	rows, err := database.Query(SelectReportMatchesSQL, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []ReportMatch
+	for rows.Next() {
+		var m ReportMatch
+		if err := rows.Scan(&m.VideoID, &m.Path, &m.Filename, &m.FirstTagged, &m.Notes, &m.Match.Opponent, &m.Match.Date, &m.Match.Venue, &m.Match.Result, &m.Match.Competition, &m.Commentary); err != nil {
+			return nil, err
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+// SelectReportPlayerTackles returns per-video tackle counts for each player in [from, to).
+func SelectReportPlayerTackles(database *sql.DB, from, to string) ([]ReportPlayerTackles, error) {
+	rows, err := database.Query(SelectReportPlayerTacklesSQL, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []ReportPlayerTackles
+	for rows.Next() {
+		var t ReportPlayerTackles
+		if err := rows.Scan(&t.VideoID, &t.Player, &t.Total, &t.Completed, &t.Missed); err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// SelectFlaggedClips returns starred notes created in [from, to) with their clip files.
+func SelectFlaggedClips(database *sql.DB, from, to string) ([]FlaggedClip, error) {
+	rows, err := database.Query(SelectReportFlaggedSQL, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clips []FlaggedClip
+	for rows.Next() {
+		var c FlaggedClip
+		if err := rows.Scan(&c.NoteID, &c.VideoID, &c.Category, &c.Start, &c.Player, &c.Text, &c.ClipFolder, &c.ClipFile, &c.ClipStatus); err != nil {
+			return nil, err
+		}
+		clips = append(clips, c)
+	}
+	return clips, rows.Err()
+}
+
+// SelectLintNoteTexts returns the text of every note, oldest first.
+func SelectLintNoteTexts(database *sql.DB) ([]LintNoteText, error) {
+	rows, err := database.Query(SelectLintNoteTextsSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var texts []LintNoteText
+	for rows.Next() {
+		var t LintNoteText
+		if err := rows.Scan(&t.NoteID, &t.Path, &t.Text); err != nil {
+			return nil, err
+		}
+		texts = append(texts, t)
+	}
+	return texts, rows.Err()
+}
+
+// SelectLintPlayers returns each distinct tackle player name with its use count.
+func SelectLintPlayers(database *sql.DB) ([]PlayerUse, error) {
+	rows, err := database.Query(SelectLintPlayersSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []PlayerUse
+	for rows.Next() {
+		var p PlayerUse
+		if err := rows.Scan(&p.Player, &p.Uses); err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
+
+// SelectCategoryCounts returns each note category used within scope, most
+// used first.
+func SelectCategoryCounts(database *sql.DB, scope VideoScope) ([]CategoryCount, error) {
+	rows, err := database.Query(SelectCategoryCountsSQL, scope.VideoID, scope.MatchID, scope.From, scope.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []CategoryCount
+	for rows.Next() {
+		var c CategoryCount
+		if err := rows.Scan(&c.Category, &c.Uses); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// SelectPlayerNames returns the player names entered before, in tackles, note
+// players and lineups, and those on the roster, once each in any case:
+// videoID's lineup first, then the most used. Bare shirt numbers are left out.
+func SelectPlayerNames(database *sql.DB, videoID int64) ([]string, error) {
+	return selectNames(database, SelectPlayerNamesSQL, videoID)
+}
+
+// SelectZoneNames returns the zones entered before, once each in any case,
+// most used first.
+func SelectZoneNames(database *sql.DB) ([]string, error) {
+	return selectNames(database, SelectZoneNamesSQL)
+}
+
+// SelectRecentPlayers returns the last limit players tackles were tagged for
+// on a video, most recent first, once each in any case.
+func SelectRecentPlayers(database *sql.DB, videoID int64, limit int) ([]string, error) {
+	return selectNames(database, SelectRecentPlayersSQL, videoID, limit)
+}
+
+func selectNames(database *sql.DB, query string, args ...any) ([]string, error) {
+	rows, err := database.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// LineupStarters is the number of starting players: shirts 1-15 start, higher
+// numbers are on the bench.
+const LineupStarters = 15
+
+// Starting reports whether the player is in the starting XV.
+func (p LineupPlayer) Starting() bool {
+	return p.Number <= LineupStarters
+}
+
+// SelectLineup returns a video's lineup, by shirt number.
+func SelectLineup(database *sql.DB, videoID int64) ([]LineupPlayer, error) {
+	rows, err := database.Query(SelectLineupSQL, videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lineup []LineupPlayer
+	for rows.Next() {
+		var p LineupPlayer
+		if err := rows.Scan(&p.Number, &p.Player); err != nil {
+			return nil, err
+		}
+		lineup = append(lineup, p)
+	}
+	return lineup, rows.Err()
+}
+
+// SetLineupPlayer puts a player on a video's lineup under a shirt number,
+// replacing whoever wore it.
+func SetLineupPlayer(database *sql.DB, videoID int64, number int, player string) error {
+	if _, err := database.Exec(UpsertLineupPlayerSQL, videoID, number, player); err != nil {
+		return fmt.Errorf("set lineup player: %w", err)
+	}
+	return nil
+}
+
+// DeleteLineupPlayer removes a shirt number from a video's lineup. It returns
+// false when the number wasn't on it.
+func DeleteLineupPlayer(database *sql.DB, videoID int64, number int) (bool, error) {
+	res, err := database.Exec(DeleteLineupPlayerSQL, videoID, number)
+	if err != nil {
+		return false, fmt.Errorf("delete lineup player: %w", err)
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// ClearLineup removes a video's whole lineup.
+func ClearLineup(database *sql.DB, videoID int64) error {
+	if _, err := database.Exec(DeleteLineupSQL, videoID); err != nil {
+		return fmt.Errorf("clear lineup: %w", err)
+	}
+	return nil
+}
+
+// ReplaceLineup replaces a video's whole lineup in one transaction, as saved
+// from the lineup editor.
+func ReplaceLineup(database *sql.DB, videoID int64, lineup []LineupPlayer) error {
+	tx, err := database.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(DeleteLineupSQL, videoID); err != nil {
+		return fmt.Errorf("clear lineup: %w", err)
+	}
+	for _, p := range lineup {
+		if _, err := tx.Exec(UpsertLineupPlayerSQL, videoID, p.Number, p.Player); err != nil {
+			return fmt.Errorf("set lineup player: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// InsertMatch adds a match and returns its ID.
+func InsertMatch(database *sql.DB, m Match) (int64, error) {
+	result, err := database.Exec(InsertMatchSQL, m.Opponent, m.Date, m.Competition, m.HomeAway, m.ScoreFor, m.ScoreAgainst)
+	if err != nil {
+		return 0, fmt.Errorf("insert match: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// SelectMatches returns the matches played in [from, to) ("YYYY-MM-DD", ""
+// for no bound), latest first, with matches without a date last. A date bound
+// leaves out matches without a date.
+func SelectMatches(database *sql.DB, from, to string) ([]Match, error) {
+	rows, err := database.Query(SelectMatchesSQL, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []Match
+	for rows.Next() {
+		m, err := scanMatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+// SelectMatchByID returns a match; the error is sql.ErrNoRows when there is none.
+func SelectMatchByID(database *sql.DB, id int64) (Match, error) {
+	return scanMatch(database.QueryRow(SelectMatchByIDSQL, id))
+}
+
+func scanMatch(row interface{ Scan(...any) error }) (Match, error) {
+	var m Match
+	var scoreFor, scoreAgainst sql.NullInt64
+	if err := row.Scan(&m.ID, &m.Opponent, &m.Date, &m.Competition, &m.HomeAway, &scoreFor, &scoreAgainst, &m.Videos); err != nil {
+		return Match{}, err
+	}
+	if scoreFor.Valid && scoreAgainst.Valid {
+		f, a := int(scoreFor.Int64), int(scoreAgainst.Int64)
+		m.ScoreFor, m.ScoreAgainst = &f, &a
+	}
+	return m, nil
+}
+
+// SelectMatchVideos returns the paths of the videos linked to a match, in the
+// order they were registered.
+func SelectMatchVideos(database *sql.DB, matchID int64) ([]string, error) {
+	rows, err := database.Query(SelectMatchVideosSQL, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var id int64
+		var path string
+		if err := rows.Scan(&id, &path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, rows.Err()
+}
+
+// LinkVideoToMatch links a video to a match, or unlinks it for matchID 0.
+func LinkVideoToMatch(database *sql.DB, videoID, matchID int64) error {
+	var match any
+	if matchID > 0 {
+		match = matchID
+	}
+	if _, err := database.Exec(UpdateVideoMatchSQL, match, videoID); err != nil {
+		return fmt.Errorf("link video to match: %w", err)
+	}
+	return nil
+}
+
+// SelectVideoMatchDate returns the match a video is linked to (0 for none) and
+// the day its match was played, which places it in a season: the match's date,
+// else the video's own match date, else the day its first note was created
+// ("" for a video without notes or dates).
+func SelectVideoMatchDate(database *sql.DB, videoID int64) (matchID int64, date string, err error) {
+	err = database.QueryRow(SelectVideoMatchDateSQL, videoID).Scan(&matchID, &date)
+	return matchID, date, err
+}
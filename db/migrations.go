@@ -4,79 +4,65 @@ import (
 	"database/sql"
 	"embed"
 	"fmt"
+	"log"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 //go:embed all:sql/migrations
 var migrationsFS embed.FS
 
 // runMigrations bootstraps schema_migrations (via create_tables.sql) then
-// applies any unapplied versioned migrations in order.
-func runMigrations(db *sql.DB) error {
+// applies any unapplied versioned migrations in order, all in one transaction
+// so a failure leaves the database as it was. When dbPath is set and the
+// database already has data, it is first copied to a backup file.
+func runMigrations(db *sql.DB, dbPath string) error {
 	// Bootstrap: create schema_migrations table before anything else.
 	// This is the only table created outside of versioned migrations.
 	if _, err := db.Exec(BootstrapSQL); err != nil {
 		return fmt.Errorf("bootstrap schema_migrations: %w", err)
 	}
 
-	// Read all migration files
-	entries, err := migrationsFS.ReadDir("sql/migrations")
+	migrations, err := embeddedMigrations()
 	if err != nil {
-		return fmt.Errorf("reading migrations dir: %w", err)
+		return err
 	}
 
-	// Parse and sort migration files by version number
-	type migration struct {
-		version int
-		name    string
+	// Get already-applied versions
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
 	}
-	var migrations []migration
-	for _, e := range entries {
-		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
-			continue
-		}
-		// Parse version from NNN_name.sql format
-		parts := strings.SplitN(e.Name(), "_", 2)
-		if len(parts) < 2 {
-			continue
+	var pending []migration
+	for _, m := range migrations {
+		if !applied[m.version] {
+			pending = append(pending, m)
 		}
-		v, err := strconv.Atoi(parts[0])
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// A fresh database has nothing worth backing up
+	if dbPath != "" && len(applied) > 0 {
+		backupPath, err := backupBeforeMigration(db, dbPath)
 		if err != nil {
-			continue
+			return fmt.Errorf("backing up database before migrating: %w", err)
 		}
-		migrations = append(migrations, migration{version: v, name: e.Name()})
+		log.Printf("backed up database to %s before applying %d migration(s)", backupPath, len(pending))
 	}
-	sort.Slice(migrations, func(i, j int) bool {
-		return migrations[i].version < migrations[j].version
-	})
 
-	// Get already-applied versions
-	applied := make(map[int]bool)
-	rows, err := db.Query("SELECT version FROM schema_migrations")
+	tx, err := db.Begin()
 	if err != nil {
-		return fmt.Errorf("querying schema_migrations: %w", err)
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var v int
-		if err := rows.Scan(&v); err != nil {
-			return fmt.Errorf("scanning migration version: %w", err)
-		}
-		applied[v] = true
-	}
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("iterating migration versions: %w", err)
+		return fmt.Errorf("beginning migration transaction: %w", err)
 	}
+	defer tx.Rollback()
 
 	// Apply unapplied migrations in order
-	for _, m := range migrations {
-		if applied[m.version] {
-			continue
-		}
-
+	for _, m := range pending {
 		sqlBytes, err := migrationsFS.ReadFile(filepath.Join("sql/migrations", m.name))
 		if err != nil {
 			return fmt.Errorf("reading migration %s: %w", m.name, err)
@@ -87,16 +73,11 @@ func runMigrations(db *sql.DB) error {
 		// skip the SQL body but still mark the migration as applied.
 		// This handles migrations that reference tables which may have already been
 		// removed (e.g. note_videos on a fresh database).
-		skip, err := shouldSkip(db, sqlContent)
+		skip, err := shouldSkip(tx, sqlContent)
 		if err != nil {
 			return fmt.Errorf("checking preconditions for migration %s: %w", m.name, err)
 		}
 
-		tx, err := db.Begin()
-		if err != nil {
-			return fmt.Errorf("beginning transaction for migration %d: %w", m.version, err)
-		}
-
 		if !skip {
 			// Execute each statement in the migration separately.
 			// This allows graceful handling of idempotent DDL like
@@ -108,29 +89,166 @@ func runMigrations(db *sql.DB) error {
 					if strings.Contains(err.Error(), "duplicate column") {
 						continue
 					}
-					tx.Rollback()
 					return fmt.Errorf("executing migration %s: %w", m.name, err)
 				}
 			}
 		}
 
 		if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", m.version); err != nil {
-			tx.Rollback()
 			return fmt.Errorf("recording migration %d: %w", m.version, err)
 		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing migrations: %w", err)
+	}
+	return nil
+}
+
+// backupBeforeMigration copies the database to <dbPath>.pre-migration-<time>.bak
+// and returns the backup's path. VACUUM INTO writes a consistent copy even
+// with the WAL in use.
+func backupBeforeMigration(db *sql.DB, dbPath string) (string, error) {
+	backupPath := fmt.Sprintf("%s.pre-migration-%s.bak", dbPath, time.Now().Format("20060102-150405"))
+	if _, err := db.Exec("VACUUM INTO ?", backupPath); err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
+// migration is a versioned migration file, NNN_name.sql.
+type migration struct {
+	version int
+	name    string
+}
+
+// embeddedMigrations returns the migration files built into the binary,
+// sorted by version.
+func embeddedMigrations() ([]migration, error) {
+	// Read all migration files
+	entries, err := migrationsFS.ReadDir("sql/migrations")
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations dir: %w", err)
+	}
+
+	// Parse and sort migration files by version number
+	var migrations []migration
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		// Parse version from NNN_name.sql format
+		parts := strings.SplitN(e.Name(), "_", 2)
+		if len(parts) < 2 {
+			continue
+		}
+		v, err := strconv.Atoi(parts[0])
+		if err != nil {
+			continue
+		}
+		migrations = append(migrations, migration{version: v, name: e.Name()})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+// appliedMigrations returns the versions recorded in schema_migrations.
+func appliedMigrations(db *sql.DB) (map[int]bool, error) {
+	applied := make(map[int]bool)
+	rows, err := db.Query("SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("querying schema_migrations: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("scanning migration version: %w", err)
+		}
+		applied[v] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating migration versions: %w", err)
+	}
+	return applied, nil
+}
 
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("committing migration %d: %w", m.version, err)
+// MigrationStatus compares a database's applied migrations with the ones
+// built into this binary. Pending lists migrations not yet applied; unknown
+// lists applied versions this binary doesn't have, i.e. the database was
+// migrated by a newer release. It doesn't write, so it works on databases
+// opened with OpenReadOnly.
+func MigrationStatus(db *sql.DB) (pending, unknown []int, err error) {
+	migrations, err := embeddedMigrations()
+	if err != nil {
+		return nil, nil, err
+	}
+	var exists int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='schema_migrations'").Scan(&exists); err != nil {
+		return nil, nil, err
+	}
+	applied := make(map[int]bool)
+	if exists > 0 {
+		if applied, err = appliedMigrations(db); err != nil {
+			return nil, nil, err
+		}
+	}
+	known := make(map[int]bool)
+	for _, m := range migrations {
+		known[m.version] = true
+		if !applied[m.version] {
+			pending = append(pending, m.version)
 		}
 	}
+	for v := range applied {
+		if !known[v] {
+			unknown = append(unknown, v)
+		}
+	}
+	sort.Ints(unknown)
+	return pending, unknown, nil
+}
 
-	return nil
+// SchemaTooNewError is returned when opening a database migrated by a newer
+// release, whose schema this binary may not understand.
+type SchemaTooNewError struct {
+	Path string
+	// Version is the database's newest migration, Known this binary's
+	Version, Known int
+}
+
+func (e *SchemaTooNewError) Error() string {
+	return fmt.Sprintf("database %s has schema version %d, newer than this tagging-rugby-cli understands (%d)\n"+
+		"Upgrade tagging-rugby-cli, or browse it without changes with --read-only", e.Path, e.Version, e.Known)
+}
+
+// checkSchemaVersion returns a *SchemaTooNewError when the database has
+// migrations this binary doesn't know.
+func checkSchemaVersion(db *sql.DB, path string) error {
+	_, unknown, err := MigrationStatus(db)
+	if err != nil {
+		return err
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	migrations, err := embeddedMigrations()
+	if err != nil {
+		return err
+	}
+	known := 0
+	if len(migrations) > 0 {
+		known = migrations[len(migrations)-1].version
+	}
+	return &SchemaTooNewError{Path: path, Version: unknown[len(unknown)-1], Known: known}
 }
 
 // shouldSkip checks for -- requires-table: directives in the migration SQL.
 // If any required table is absent from the database, it returns true so the
 // migration body is skipped (but the version is still recorded as applied).
-func shouldSkip(db *sql.DB, sqlContent string) (bool, error) {
+func shouldSkip(tx *sql.Tx, sqlContent string) (bool, error) {
 	for _, line := range strings.Split(sqlContent, "\n") {
 		line = strings.TrimSpace(line)
 		if !strings.HasPrefix(line, "-- requires-table:") {
@@ -141,7 +259,7 @@ func shouldSkip(db *sql.DB, sqlContent string) (bool, error) {
 			continue
 		}
 		var count int
-		err := db.QueryRow(
+		err := tx.QueryRow(
 			"SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?", table,
 		).Scan(&count)
 		if err != nil {
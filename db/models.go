@@ -1,6 +1,11 @@
 package db
 
-import "time"
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Note represents a row in the notes table.
 type Note struct {
@@ -92,6 +97,23 @@ type PendingClip struct {
 	End       float64
 }
 
+// VideoClip is a timed event of a video with the state of its clip export,
+// for picking which events to export and following their progress.
+type VideoClip struct {
+	NoteID   int64
+	Category string
+	Start    float64
+	// Player, Attempt and Outcome are the event's first tackle, if any
+	Player  string
+	Attempt int
+	Outcome string
+	Starred bool
+	// Status is the clip's export status, empty when none was queued
+	Status string
+	// Log is ffmpeg's output when the export failed
+	Log string
+}
+
 // ExportProgress holds aggregate clip export counts for the active video.
 type ExportProgress struct {
 	TotalTackles   int
@@ -100,6 +122,44 @@ type ExportProgress struct {
 	ErrorClips     int
 }
 
+// NoteDeletePreview lists what deleting a note removes along with it, and the
+// exported clip files it leaves on disk.
+type NoteDeletePreview struct {
+	Timings    int
+	Tackles    int
+	Zones      int
+	Details    int
+	Highlights int
+	Clips      int
+	// ClipFiles are the note's exported clip files that exist on disk; they
+	// are kept when the note is deleted
+	ClipFiles []string
+}
+
+// Summary describes the child records, e.g. "1 timing, 1 tackle, 1 clip",
+// or "no child records".
+func (p NoteDeletePreview) Summary() string {
+	var parts []string
+	for _, c := range []struct {
+		n    int
+		name string
+	}{
+		{p.Timings, "timing"}, {p.Tackles, "tackle"}, {p.Zones, "zone"},
+		{p.Details, "detail"}, {p.Highlights, "highlight"}, {p.Clips, "clip"},
+	} {
+		switch {
+		case c.n == 1:
+			parts = append(parts, "1 "+c.name)
+		case c.n > 1:
+			parts = append(parts, fmt.Sprintf("%d %ss", c.n, c.name))
+		}
+	}
+	if len(parts) == 0 {
+		return "no child records"
+	}
+	return strings.Join(parts, ", ")
+}
+
 // VideoTiming represents a row in the video_timings table.
 type VideoTiming struct {
 	ID      int64
@@ -107,3 +167,362 @@ type VideoTiming struct {
 	Stopped *float64
 	Length  float64
 }
+
+// Possession represents a row in the possessions table.
+// End is nil while the interval is still open.
+type Possession struct {
+	ID        int64
+	VideoID   int64
+	Team      string
+	Territory string
+	Start     float64
+	End       *float64
+}
+
+// PossessionSummary holds the time each team spent in possession and
+// the time the ball spent in each team's half, derived from possession intervals.
+type PossessionSummary struct {
+	HomeSeconds     float64
+	AwaySeconds     float64
+	HomeHalfSeconds float64
+	AwayHalfSeconds float64
+}
+
+// PossessionPct returns the home and away possession percentages (0 when no data).
+func (s PossessionSummary) PossessionPct() (home, away float64) {
+	total := s.HomeSeconds + s.AwaySeconds
+	if total <= 0 {
+		return 0, 0
+	}
+	return s.HomeSeconds / total * 100, s.AwaySeconds / total * 100
+}
+
+// TerritoryPct returns the home and away territory percentages (0 when no data).
+// A team's territory is the share of time the ball spent in the opposition half.
+func (s PossessionSummary) TerritoryPct() (home, away float64) {
+	total := s.HomeHalfSeconds + s.AwayHalfSeconds
+	if total <= 0 {
+		return 0, 0
+	}
+	return s.AwayHalfSeconds / total * 100, s.HomeHalfSeconds / total * 100
+}
+
+// Period represents a row in the periods table.
+type Period struct {
+	ID      int64
+	VideoID int64
+	Name    string
+	Start   float64
+	End     float64
+}
+
+// Match represents a row in the matches table: one game, which several videos
+// (camera angles, halves) can be linked to. Date is YYYY-MM-DD and HomeAway is
+// "home", "away" or ""; the scores are nil until the result is recorded.
+type Match struct {
+	ID           int64
+	Opponent     string
+	Date         string
+	Competition  string
+	HomeAway     string
+	ScoreFor     *int
+	ScoreAgainst *int
+	// Videos is the number of videos linked to the match
+	Videos int
+}
+
+// Title returns "vs <opponent>", or "Match <id>" when no opponent is recorded.
+func (m Match) Title() string {
+	if m.Opponent == "" {
+		return fmt.Sprintf("Match %d", m.ID)
+	}
+	return "vs " + m.Opponent
+}
+
+// Result returns the result with the score from our side, e.g. "W 24-17",
+// or "" when no score is recorded.
+func (m Match) Result() string {
+	if m.ScoreFor == nil || m.ScoreAgainst == nil {
+		return ""
+	}
+	outcome := "D"
+	switch {
+	case *m.ScoreFor > *m.ScoreAgainst:
+		outcome = "W"
+	case *m.ScoreFor < *m.ScoreAgainst:
+		outcome = "L"
+	}
+	return fmt.Sprintf("%s %d-%d", outcome, *m.ScoreFor, *m.ScoreAgainst)
+}
+
+// VideoScope selects the videos stats are counted over: one video, the videos
+// of a match, or those whose match was played in [From, To) ("YYYY-MM-DD", a
+// season). Fields left zero don't narrow it, so the zero scope is every video.
+type VideoScope struct {
+	VideoID int64
+	MatchID int64
+	From    string
+	To      string
+}
+
+// MatchInfo holds the match metadata columns of the videos table.
+// Date is YYYY-MM-DD; all fields are "" when not recorded.
+type MatchInfo struct {
+	Opponent    string
+	Date        string
+	Venue       string
+	Result      string
+	Competition string
+}
+
+// Title returns "vs <opponent>", or "" when no opponent is recorded.
+func (m MatchInfo) Title() string {
+	if m.Opponent == "" {
+		return ""
+	}
+	return "vs " + m.Opponent
+}
+
+// VideoFilter narrows SelectVideos. Opponent and Competition match substrings
+// (case-insensitive); From and To bound the match date as [From, To). Empty
+// fields don't filter; a date bound excludes videos without a match date.
+type VideoFilter struct {
+	Opponent    string
+	Competition string
+	From        string
+	To          string
+}
+
+// VideoSummary is a registered video with its match metadata and note count.
+type VideoSummary struct {
+	ID       int64
+	Path     string
+	Filename string
+	Match    MatchInfo
+	Notes    int
+}
+
+// Bookmark represents a row in the bookmarks table.
+type Bookmark struct {
+	ID      int64
+	VideoID int64
+	Time    float64
+}
+
+// VideoAngle represents a row in the video_angles table.
+// Offset is added to the main video's time to get the same moment in Path.
+type VideoAngle struct {
+	ID      int64
+	VideoID int64
+	Path    string
+	Label   string
+	Offset  float64
+}
+
+// Waveform represents a row in the video_waveforms table.
+// Peaks holds one audio level (0-255) per SecondsPerPeak of video.
+type Waveform struct {
+	VideoID        int64
+	SecondsPerPeak float64
+	Peaks          []byte
+}
+
+// Session represents a row in the sessions table, joined with its video.
+// EndedAt is invalid while the session is still open.
+type Session struct {
+	ID          int64
+	VideoID     int64
+	Path        string
+	Filename    string
+	StartedAt   time.Time
+	EndedAt     sql.NullTime
+	EventsAdded int
+}
+
+// NoteChange is a note added or edited since a session started.
+type NoteChange struct {
+	NoteID    int64
+	Category  string
+	Time      float64
+	Player    string
+	Text      string
+	CreatedAt time.Time
+	UpdatedAt sql.NullTime
+	// Added is set for notes created since the session started; the rest were edited
+	Added bool
+}
+
+// ReplayEvent is a note as it was tagged: its creation time and its position in the video.
+type ReplayEvent struct {
+	NoteID    int64
+	Category  string
+	CreatedAt time.Time
+	Time      float64
+}
+
+// ReportMatch is a video with notes created in a report's date range.
+type ReportMatch struct {
+	VideoID     int64
+	Path        string
+	Filename    string
+	FirstTagged string
+	Notes       int
+	Match       MatchInfo
+	Commentary  string
+}
+
+// ReportPlayerTackles holds a player's tackle counts for one video.
+type ReportPlayerTackles struct {
+	VideoID   int64
+	Player    string
+	Total     int
+	Completed int
+	Missed    int
+}
+
+// FlaggedClip is a starred note with its clip file (if exported).
+type FlaggedClip struct {
+	NoteID     int64
+	VideoID    int64
+	Category   string
+	Start      float64
+	Player     string
+	Text       string
+	ClipFolder string
+	ClipFile   string
+	ClipStatus string
+}
+
+// ExportNote is a note flattened with its main details for export.
+type ExportNote struct {
+	ID       int64
+	Category string
+	Start    float64
+	End      float64
+	Text     string
+	Player   string
+	Team     string
+	Outcome  string
+	Starred  bool
+}
+
+// LintNoteText is a note's text with the video it belongs to, for 'note lint'.
+type LintNoteText struct {
+	NoteID int64
+	Path   string
+	Text   string
+}
+
+// TackleBreakdown is the tackle counts of one group (a player or a zone) of
+// 'stats tackles' and the TUI stats.
+type TackleBreakdown struct {
+	// Group is the player, or the zone ("" for tackles without one)
+	Group     string
+	Total     int
+	Completed int
+	Missed    int
+	Possible  int
+	Other     int
+	Starred   int
+	// Covered is the number of missed tackles someone followed up
+	Covered int
+}
+
+// PlayerUse is a tackle player name and how many tackles use it.
+type PlayerUse struct {
+	Player string
+	Uses   int
+}
+
+// CategoryCount is a note category path and how many notes use it.
+type CategoryCount struct {
+	Category string
+	Uses     int
+}
+
+// PlayerAlias maps another spelling of a player's name to the canonical one.
+type PlayerAlias struct {
+	Alias     string
+	Player    string
+	CreatedAt time.Time
+}
+
+// PlayerMerge counts the rows 'player merge' renamed.
+type PlayerMerge struct {
+	Tackles int64
+	Details int64
+}
+
+// LineupPlayer is a shirt number on a video's lineup and the player wearing it.
+type LineupPlayer struct {
+	Number int
+	Player string
+}
+
+// RosterPlayer is a player on the club roster. Number is 0 for a player
+// without a squad number.
+type RosterPlayer struct {
+	Name     string
+	Number   int
+	Position string
+	Team     string
+}
+
+// RosterImport counts the players 'player import' added and updated.
+type RosterImport struct {
+	Added   int
+	Updated int
+}
+
+// EventType is a kind of structured event, such as a lineout or a try.
+// Events of a type are notes in the category of its name, with a note detail
+// per field, typed by the field's name.
+type EventType struct {
+	ID     int64
+	Name   string
+	Fields []EventField
+	// Duration is the seconds an event lasts when none is given, 0 for an instant
+	Duration float64
+}
+
+// EventField is one field of an event type.
+type EventField struct {
+	Name string `json:"name"`
+	// Title labels the field in forms; empty uses Name
+	Title string `json:"title,omitempty"`
+	// Type is "text" (the default), "number" or "player"; player fields resolve
+	// shirt numbers and aliases like the tackle player
+	Type string `json:"type,omitempty"`
+	// Options makes the field a choice between these values
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required,omitempty"`
+}
+
+// SchemaObject is a table, index, view or trigger of the live schema.
+type SchemaObject struct {
+	// Type is "table", "index", "view" or "trigger"
+	Type string
+	Name string
+	// Table is the table an index or trigger belongs to (the object itself for tables)
+	Table string
+	// SQL is the CREATE statement as stored by SQLite
+	SQL string
+	// Columns and ForeignKeys are only filled in for tables
+	Columns     []SchemaColumn
+	ForeignKeys []SchemaForeignKey
+}
+
+// SchemaColumn is a column of a table.
+type SchemaColumn struct {
+	Name string
+	Type string
+	// PrimaryKey is the column's position in the primary key, 0 if not part of it
+	PrimaryKey int
+}
+
+// SchemaForeignKey links a table's column to the column of another table.
+type SchemaForeignKey struct {
+	From     string
+	Table    string
+	ToColumn string
+}
@@ -0,0 +1,235 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// queryRower is satisfied by both *sql.DB and *sql.Tx.
+type queryRower interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// ShirtNumber parses a player entered as a shirt number, e.g. "7" or "#7".
+func ShirtNumber(s string) (int, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > 99 {
+		return 0, false
+	}
+	return n, true
+}
+
+// ResolvePlayer returns the name a player entry is saved under on a video: a
+// shirt number becomes the name on the video's lineup, else the roster player
+// with that squad number when only one has it, then any alias from 'player
+// merge' applies. Entries without either are returned unchanged.
+func ResolvePlayer(database *sql.DB, videoID int64, name string) (string, error) {
+	return normalizePlayer(database, videoID, name)
+}
+
+func normalizePlayer(q queryRower, videoID int64, name string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return name, nil
+	}
+	if n, ok := ShirtNumber(name); ok && videoID > 0 {
+		var player string
+		err := q.QueryRow(SelectLineupPlayerSQL, videoID, n).Scan(&player)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = q.QueryRow(SelectRosterNumberSQL, n).Scan(&player)
+			if errors.Is(err, sql.ErrNoRows) {
+				err = nil
+			} else if err != nil {
+				return "", fmt.Errorf("look up roster: %w", err)
+			}
+		} else if err != nil {
+			return "", fmt.Errorf("look up lineup: %w", err)
+		}
+		if player != "" {
+			name = player
+		}
+	}
+	var player string
+	err := q.QueryRow(SelectPlayerAliasSQL, name).Scan(&player)
+	if errors.Is(err, sql.ErrNoRows) {
+		return name, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("look up player alias: %w", err)
+	}
+	return player, nil
+}
+
+// normalizeDetail returns a detail's text, normalized when it names a player.
+func normalizeDetail(q queryRower, videoID int64, d NoteDetail) (string, error) {
+	if d.Type != "player" {
+		return d.Note, nil
+	}
+	return normalizePlayer(q, videoID, d.Note)
+}
+
+// MergePlayer renames every tackle player, player detail, lineup and roster
+// entry spelled from (in any case) to into, and records from as an alias of into so
+// later entries are saved as into. Aliases of from are repointed at into, and
+// one sending into elsewhere is dropped, so merges can be chained or reversed.
+func MergePlayer(database *sql.DB, from, into string) (PlayerMerge, error) {
+	var m PlayerMerge
+	tx, err := database.Begin()
+	if err != nil {
+		return m, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Touch before renaming, while the notes can still be told apart
+	if _, err := tx.Exec(TouchPlayerNotesSQL, from, into); err != nil {
+		return m, fmt.Errorf("touch notes: %w", err)
+	}
+	res, err := tx.Exec(MergePlayerTacklesSQL, from, into)
+	if err != nil {
+		return m, fmt.Errorf("merge tackles: %w", err)
+	}
+	if m.Tackles, err = res.RowsAffected(); err != nil {
+		return m, err
+	}
+	res, err = tx.Exec(MergePlayerDetailsSQL, from, into)
+	if err != nil {
+		return m, fmt.Errorf("merge player details: %w", err)
+	}
+	if m.Details, err = res.RowsAffected(); err != nil {
+		return m, err
+	}
+	if _, err := tx.Exec(MergePlayerLineupsSQL, from, into); err != nil {
+		return m, fmt.Errorf("merge lineups: %w", err)
+	}
+	// The roster keeps one entry: into's, when it already has one
+	if _, err := tx.Exec(MergePlayerRosterSQL, from, into); err != nil {
+		return m, fmt.Errorf("merge roster: %w", err)
+	}
+	if _, err := tx.Exec(DeleteMergedRosterPlayerSQL, from, into); err != nil {
+		return m, fmt.Errorf("merge roster: %w", err)
+	}
+
+	if _, err := tx.Exec(UpdatePlayerAliasTargetsSQL, from, into); err != nil {
+		return m, fmt.Errorf("update aliases: %w", err)
+	}
+	// into is canonical now: drop an alias sending it elsewhere, and aliases
+	// left pointing at themselves
+	if _, err := tx.Exec(DeleteStalePlayerAliasesSQL, into); err != nil {
+		return m, fmt.Errorf("update aliases: %w", err)
+	}
+	if _, err := tx.Exec(UpsertPlayerAliasSQL, from, into); err != nil {
+		return m, fmt.Errorf("record alias: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return m, fmt.Errorf("commit transaction: %w", err)
+	}
+	return m, nil
+}
+
+// SelectPlayerAliases returns all player aliases, grouped by canonical name.
+func SelectPlayerAliases(database *sql.DB) ([]PlayerAlias, error) {
+	rows, err := database.Query(SelectPlayerAliasesSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aliases []PlayerAlias
+	for rows.Next() {
+		var a PlayerAlias
+		if err := rows.Scan(&a.Alias, &a.Player, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, a)
+	}
+	return aliases, rows.Err()
+}
+
+// DeletePlayerAlias removes an alias, so the spelling is kept as entered
+// again. It returns false when there was no such alias.
+func DeletePlayerAlias(database *sql.DB, alias string) (bool, error) {
+	res, err := database.Exec(DeletePlayerAliasSQL, alias)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// SelectPlayerTrends returns each player's tackle counts in the limit most
+// recent matches other than videoID, oldest first, for trend sparklines.
+func SelectPlayerTrends(database *sql.DB, videoID int64, limit int) ([]ReportPlayerTackles, error) {
+	rows, err := database.Query(SelectPlayerTrendsSQL, videoID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []ReportPlayerTackles
+	for rows.Next() {
+		var t ReportPlayerTackles
+		if err := rows.Scan(&t.VideoID, &t.Player, &t.Total, &t.Completed, &t.Missed); err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// ImportRoster adds players to the roster, updating those already on it (by
+// name, in any case). Empty fields keep what the roster had.
+func ImportRoster(database *sql.DB, players []RosterPlayer) (RosterImport, error) {
+	var imp RosterImport
+	tx, err := database.Begin()
+	if err != nil {
+		return imp, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var before, after int
+	if err := tx.QueryRow(CountRosterSQL).Scan(&before); err != nil {
+		return imp, fmt.Errorf("count roster: %w", err)
+	}
+	for _, p := range players {
+		var number any
+		if p.Number > 0 {
+			number = p.Number
+		}
+		if _, err := tx.Exec(UpsertRosterPlayerSQL, p.Name, number, p.Position, p.Team); err != nil {
+			return imp, fmt.Errorf("import %s: %w", p.Name, err)
+		}
+	}
+	if err := tx.QueryRow(CountRosterSQL).Scan(&after); err != nil {
+		return imp, fmt.Errorf("count roster: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return imp, fmt.Errorf("commit transaction: %w", err)
+	}
+	imp.Added = after - before
+	imp.Updated = len(players) - imp.Added
+	return imp, nil
+}
+
+// SelectRoster returns the roster, or one team's players ("" for all), by
+// team and squad number.
+func SelectRoster(database *sql.DB, team string) ([]RosterPlayer, error) {
+	rows, err := database.Query(SelectRosterSQL, team)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roster []RosterPlayer
+	for rows.Next() {
+		var p RosterPlayer
+		if err := rows.Scan(&p.Name, &p.Number, &p.Position, &p.Team); err != nil {
+			return nil, err
+		}
+		roster = append(roster, p)
+	}
+	return roster, rows.Err()
+}
@@ -20,9 +20,48 @@ var SelectNotesSQL string
 //go:embed sql/select_note_by_id.sql
 var SelectNoteByIDSQL string
 
+//go:embed sql/select_note_video_id.sql
+var SelectNoteVideoIDSQL string
+
+//go:embed sql/select_note_id_by_number.sql
+var SelectNoteIDByNumberSQL string
+
+//go:embed sql/count_video_notes.sql
+var CountVideoNotesSQL string
+
+//go:embed sql/select_note_ref_numbers.sql
+var SelectNoteRefNumbersSQL string
+
+//go:embed sql/select_note_detail_id_by_text.sql
+var SelectNoteDetailIDByTextSQL string
+
+//go:embed sql/delete_note_refs.sql
+var DeleteNoteRefsSQL string
+
+//go:embed sql/insert_note_ref.sql
+var InsertNoteRefSQL string
+
+//go:embed sql/select_note_exists_at.sql
+var SelectNoteExistsAtSQL string
+
+//go:embed sql/select_notes_for_export.sql
+var SelectNotesForExportSQL string
+
+//go:embed sql/select_notes_by_video.sql
+var SelectNotesByVideoSQL string
+
+//go:embed sql/select_note_exists_created.sql
+var SelectNoteExistsCreatedSQL string
+
+//go:embed sql/update_note_created_at.sql
+var UpdateNoteCreatedAtSQL string
+
 //go:embed sql/delete_note.sql
 var DeleteNoteSQL string
 
+//go:embed sql/select_note_delete_preview.sql
+var SelectNoteDeletePreviewSQL string
+
 // Video queries
 
 //go:embed sql/insert_video.sql
@@ -31,6 +70,78 @@ var InsertVideoSQL string
 //go:embed sql/select_video_by_path.sql
 var SelectVideoByPathSQL string
 
+//go:embed sql/select_video_file.sql
+var SelectVideoFileSQL string
+
+//go:embed sql/select_video_files_by_size.sql
+var SelectVideoFilesBySizeSQL string
+
+//go:embed sql/update_video_path.sql
+var UpdateVideoPathSQL string
+
+//go:embed sql/select_video_match_info.sql
+var SelectVideoMatchInfoSQL string
+
+//go:embed sql/update_video_match_info.sql
+var UpdateVideoMatchInfoSQL string
+
+//go:embed sql/select_videos.sql
+var SelectVideosSQL string
+
+//go:embed sql/select_season_video_ids.sql
+var SelectSeasonVideoIDsSQL string
+
+// Match queries
+
+//go:embed sql/insert_match.sql
+var InsertMatchSQL string
+
+//go:embed sql/select_matches.sql
+var SelectMatchesSQL string
+
+//go:embed sql/select_match_by_id.sql
+var SelectMatchByIDSQL string
+
+//go:embed sql/select_match_videos.sql
+var SelectMatchVideosSQL string
+
+//go:embed sql/update_video_match.sql
+var UpdateVideoMatchSQL string
+
+//go:embed sql/select_video_match_date.sql
+var SelectVideoMatchDateSQL string
+
+//go:embed sql/insert_live_video.sql
+var InsertLiveVideoSQL string
+
+//go:embed sql/select_live_video.sql
+var SelectLiveVideoSQL string
+
+//go:embed sql/shift_note_timings_by_video.sql
+var ShiftNoteTimingsByVideoSQL string
+
+//go:embed sql/move_notes_to_video.sql
+var MoveNotesToVideoSQL string
+
+// Video angle queries
+
+//go:embed sql/insert_video_angle.sql
+var InsertVideoAngleSQL string
+
+//go:embed sql/select_video_angles_by_video.sql
+var SelectVideoAnglesByVideoSQL string
+
+//go:embed sql/delete_video_angle.sql
+var DeleteVideoAngleSQL string
+
+// Waveform queries
+
+//go:embed sql/upsert_video_waveform.sql
+var UpsertVideoWaveformSQL string
+
+//go:embed sql/select_video_waveform.sql
+var SelectVideoWaveformSQL string
+
 // VideoTiming queries
 
 //go:embed sql/insert_video_timing.sql
@@ -102,6 +213,9 @@ var DeleteNoteZonesSQL string
 //go:embed sql/delete_note_highlights.sql
 var DeleteNoteHighlightsSQL string
 
+//go:embed sql/delete_note_highlight.sql
+var DeleteNoteHighlightSQL string
+
 //go:embed sql/delete_note_tackles.sql
 var DeleteNoteTacklesSQL string
 
@@ -110,6 +224,12 @@ var DeleteNoteTacklesSQL string
 //go:embed sql/update_note_timing.sql
 var UpdateNoteTimingSQL string
 
+//go:embed sql/touch_note.sql
+var TouchNoteSQL string
+
+//go:embed sql/update_note_detail_text.sql
+var UpdateNoteDetailTextSQL string
+
 //go:embed sql/update_note_clip.sql
 var UpdateNoteClipSQL string
 
@@ -119,6 +239,15 @@ var UpsertNoteClipPendingSQL string
 //go:embed sql/mark_clip_processing.sql
 var MarkClipProcessingSQL string
 
+//go:embed sql/claim_pending_clip.sql
+var ClaimPendingClipSQL string
+
+//go:embed sql/requeue_clip.sql
+var RequeueClipSQL string
+
+//go:embed sql/select_processing_clips.sql
+var SelectProcessingClipsSQL string
+
 //go:embed sql/mark_clip_complete.sql
 var MarkClipCompleteSQL string
 
@@ -128,6 +257,9 @@ var MarkClipErrorSQL string
 //go:embed sql/select_next_pending_clip.sql
 var SelectNextPendingClipSQL string
 
+//go:embed sql/select_video_clips.sql
+var SelectVideoClipsSQL string
+
 // Joined queries for TUI views
 
 //go:embed sql/select_notes_with_timing.sql
@@ -142,3 +274,214 @@ var SelectTackleStatsSQL string
 //go:embed sql/select_export_progress.sql
 var SelectExportProgressSQL string
 
+// Possession queries
+
+//go:embed sql/insert_possession.sql
+var InsertPossessionSQL string
+
+//go:embed sql/close_open_possessions.sql
+var CloseOpenPossessionsSQL string
+
+//go:embed sql/select_possessions_by_video.sql
+var SelectPossessionsByVideoSQL string
+
+//go:embed sql/select_open_possession.sql
+var SelectOpenPossessionSQL string
+
+// Period queries
+
+//go:embed sql/insert_period.sql
+var InsertPeriodSQL string
+
+//go:embed sql/select_periods_by_video.sql
+var SelectPeriodsByVideoSQL string
+
+//go:embed sql/delete_periods_by_video.sql
+var DeletePeriodsByVideoSQL string
+
+// Bookmark queries
+
+//go:embed sql/insert_bookmark.sql
+var InsertBookmarkSQL string
+
+//go:embed sql/select_bookmarks_by_video.sql
+var SelectBookmarksByVideoSQL string
+
+//go:embed sql/delete_bookmark.sql
+var DeleteBookmarkSQL string
+
+//go:embed sql/delete_bookmarks_by_video.sql
+var DeleteBookmarksByVideoSQL string
+
+// Commentary queries
+
+//go:embed sql/select_commentary_by_video.sql
+var SelectCommentaryByVideoSQL string
+
+//go:embed sql/upsert_commentary.sql
+var UpsertCommentarySQL string
+
+// Session queries
+
+//go:embed sql/insert_session.sql
+var InsertSessionSQL string
+
+//go:embed sql/end_session.sql
+var EndSessionSQL string
+
+//go:embed sql/select_sessions.sql
+var SelectSessionsSQL string
+
+//go:embed sql/select_replay_events.sql
+var SelectReplayEventsSQL string
+
+//go:embed sql/select_session.sql
+var SelectSessionSQL string
+
+//go:embed sql/select_previous_session.sql
+var SelectPreviousSessionSQL string
+
+//go:embed sql/select_note_changes.sql
+var SelectNoteChangesSQL string
+
+// Report queries
+
+//go:embed sql/select_report_matches.sql
+var SelectReportMatchesSQL string
+
+//go:embed sql/select_report_player_tackles.sql
+var SelectReportPlayerTacklesSQL string
+
+//go:embed sql/select_report_flagged.sql
+var SelectReportFlaggedSQL string
+
+// Lint queries
+
+//go:embed sql/select_lint_note_texts.sql
+var SelectLintNoteTextsSQL string
+
+//go:embed sql/select_lint_players.sql
+var SelectLintPlayersSQL string
+
+//go:embed sql/select_category_counts.sql
+var SelectCategoryCountsSQL string
+
+//go:embed sql/select_player_names.sql
+var SelectPlayerNamesSQL string
+
+//go:embed sql/select_zone_names.sql
+var SelectZoneNamesSQL string
+
+//go:embed sql/select_recent_players.sql
+var SelectRecentPlayersSQL string
+
+// Schema queries
+
+//go:embed sql/select_schema_objects.sql
+var SelectSchemaObjectsSQL string
+
+//go:embed sql/select_table_columns.sql
+var SelectTableColumnsSQL string
+
+//go:embed sql/select_table_foreign_keys.sql
+var SelectTableForeignKeysSQL string
+
+// Player alias queries
+
+//go:embed sql/select_player_alias.sql
+var SelectPlayerAliasSQL string
+
+//go:embed sql/select_player_aliases.sql
+var SelectPlayerAliasesSQL string
+
+//go:embed sql/upsert_player_alias.sql
+var UpsertPlayerAliasSQL string
+
+//go:embed sql/delete_player_alias.sql
+var DeletePlayerAliasSQL string
+
+//go:embed sql/update_player_alias_targets.sql
+var UpdatePlayerAliasTargetsSQL string
+
+//go:embed sql/delete_stale_player_aliases.sql
+var DeleteStalePlayerAliasesSQL string
+
+//go:embed sql/touch_player_notes.sql
+var TouchPlayerNotesSQL string
+
+//go:embed sql/merge_player_tackles.sql
+var MergePlayerTacklesSQL string
+
+//go:embed sql/merge_player_details.sql
+var MergePlayerDetailsSQL string
+
+//go:embed sql/merge_player_lineups.sql
+var MergePlayerLineupsSQL string
+
+//go:embed sql/merge_player_roster.sql
+var MergePlayerRosterSQL string
+
+//go:embed sql/delete_merged_roster_player.sql
+var DeleteMergedRosterPlayerSQL string
+
+// Lineup queries
+
+//go:embed sql/select_lineup.sql
+var SelectLineupSQL string
+
+//go:embed sql/select_lineup_player.sql
+var SelectLineupPlayerSQL string
+
+//go:embed sql/upsert_lineup_player.sql
+var UpsertLineupPlayerSQL string
+
+//go:embed sql/delete_lineup_player.sql
+var DeleteLineupPlayerSQL string
+
+//go:embed sql/delete_lineup.sql
+var DeleteLineupSQL string
+
+// Roster queries
+
+//go:embed sql/upsert_roster_player.sql
+var UpsertRosterPlayerSQL string
+
+//go:embed sql/select_roster.sql
+var SelectRosterSQL string
+
+//go:embed sql/select_roster_number.sql
+var SelectRosterNumberSQL string
+
+//go:embed sql/count_roster.sql
+var CountRosterSQL string
+
+// Event type queries
+
+//go:embed sql/upsert_event_type.sql
+var UpsertEventTypeSQL string
+
+//go:embed sql/select_event_types.sql
+var SelectEventTypesSQL string
+
+//go:embed sql/select_event_type.sql
+var SelectEventTypeSQL string
+
+//go:embed sql/delete_event_type.sql
+var DeleteEventTypeSQL string
+
+// Settings queries
+
+//go:embed sql/upsert_setting.sql
+var UpsertSettingSQL string
+
+//go:embed sql/select_setting.sql
+var SelectSettingSQL string
+
+//go:embed sql/select_player_trends.sql
+var SelectPlayerTrendsSQL string
+
+//go:embed sql/select_tackle_breakdown.sql
+var SelectTackleBreakdownSQL string
+
+//go:embed sql/select_tackle_zones.sql
+var SelectTackleZonesSQL string
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// OpenQueryOnly opens the database at the default location for ad-hoc
+// queries: read-only at the file level and with query_only set, so no
+// statement can change it.
+func OpenQueryOnly() (*sql.DB, error) {
+	dbPath, err := getDBPath()
+	if err != nil {
+		return nil, err
+	}
+	database, err := OpenReadOnly(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	// One connection, so the pragma applies to every statement
+	database.SetMaxOpenConns(1)
+	if _, err := database.Exec("PRAGMA query_only = ON"); err != nil {
+		database.Close()
+		return nil, err
+	}
+	return database, nil
+}
+
+// QueryResult is the outcome of an ad-hoc query. Values are int64, float64,
+// string, []byte or nil (NULL).
+type QueryResult struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// RunQuery runs an ad-hoc SQL query and collects its rows. TEXT values come
+// back as strings.
+func RunQuery(database *sql.DB, query string, args ...any) (*QueryResult, error) {
+	rows, err := database.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	res := &QueryResult{Columns: columns}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		ptrs := make([]any, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		res.Rows = append(res.Rows, values)
+	}
+	return res, rows.Err()
+}
+
+// SelectTableRows returns every row of a table, e.g. for snapshots.
+func SelectTableRows(database *sql.DB, table string) (*QueryResult, error) {
+	return RunQuery(database, `SELECT * FROM "`+strings.ReplaceAll(table, `"`, `""`)+`"`)
+}
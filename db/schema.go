@@ -0,0 +1,82 @@
+package db
+
+import (
+	"database/sql"
+)
+
+// SelectSchema returns the live schema: tables first, with their columns and
+// foreign keys, then indexes, views and triggers.
+func SelectSchema(database *sql.DB) ([]SchemaObject, error) {
+	rows, err := database.Query(SelectSchemaObjectsSQL)
+	if err != nil {
+		return nil, err
+	}
+	var objects []SchemaObject
+	for rows.Next() {
+		var o SchemaObject
+		if err := rows.Scan(&o.Type, &o.Name, &o.Table, &o.SQL); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		objects = append(objects, o)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range objects {
+		if objects[i].Type != "table" {
+			continue
+		}
+		if objects[i].Columns, err = selectTableColumns(database, objects[i].Name); err != nil {
+			return nil, err
+		}
+		if objects[i].ForeignKeys, err = selectTableForeignKeys(database, objects[i].Name); err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+func selectTableColumns(database *sql.DB, table string) ([]SchemaColumn, error) {
+	rows, err := database.Query(SelectTableColumnsSQL, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []SchemaColumn
+	for rows.Next() {
+		var c SchemaColumn
+		if err := rows.Scan(&c.Name, &c.Type, &c.PrimaryKey); err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	return columns, rows.Err()
+}
+
+func selectTableForeignKeys(database *sql.DB, table string) ([]SchemaForeignKey, error) {
+	rows, err := database.Query(SelectTableForeignKeysSQL, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []SchemaForeignKey
+	for rows.Next() {
+		var k SchemaForeignKey
+		var to sql.NullString
+		if err := rows.Scan(&k.From, &k.Table, &to); err != nil {
+			return nil, err
+		}
+		// A key naming no column references the parent's primary key
+		k.ToColumn = to.String
+		if k.ToColumn == "" {
+			k.ToColumn = "id"
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
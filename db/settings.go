@@ -0,0 +1,25 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// SettingsBundle names the setting holding the embedded settings bundle.
+const SettingsBundle = "bundle"
+
+// UpsertSetting stores value under name, replacing any earlier value.
+func UpsertSetting(database *sql.DB, name, value string) error {
+	if _, err := database.Exec(UpsertSettingSQL, name, value); err != nil {
+		return fmt.Errorf("save setting: %w", err)
+	}
+	return nil
+}
+
+// SelectSetting returns the value stored under name; the error is
+// sql.ErrNoRows when there is none.
+func SelectSetting(database *sql.DB, name string) (string, error) {
+	var value string
+	err := database.QueryRow(SelectSettingSQL, name).Scan(&value)
+	return value, err
+}
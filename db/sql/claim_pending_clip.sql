@@ -0,0 +1 @@
+UPDATE note_clips SET status='processing', started_at=? WHERE id=? AND status='pending'
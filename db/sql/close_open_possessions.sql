@@ -0,0 +1 @@
+UPDATE possessions SET end = ? WHERE video_id = ? AND end IS NULL;
@@ -0,0 +1 @@
+DELETE FROM bookmarks WHERE id = ?;
@@ -0,0 +1 @@
+DELETE FROM bookmarks WHERE video_id = ?;
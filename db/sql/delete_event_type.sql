@@ -0,0 +1 @@
+DELETE FROM event_types WHERE name = ?1 COLLATE NOCASE;
@@ -0,0 +1 @@
+DELETE FROM lineups WHERE video_id = ? AND number = ?;
@@ -0,0 +1 @@
+DELETE FROM players WHERE name = ?1 COLLATE NOCASE AND name != ?2 COLLATE NOCASE;
@@ -0,0 +1 @@
+DELETE FROM note_highlights WHERE note_id = ? AND type = ?;
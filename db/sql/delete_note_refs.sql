@@ -0,0 +1 @@
+DELETE FROM note_refs WHERE detail_id = ?;
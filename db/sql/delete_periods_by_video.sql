@@ -0,0 +1 @@
+DELETE FROM periods WHERE video_id = ?;
@@ -0,0 +1 @@
+DELETE FROM player_aliases WHERE alias = ?;
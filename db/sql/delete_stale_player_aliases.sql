@@ -0,0 +1,2 @@
+DELETE FROM player_aliases
+WHERE (alias = ?1 AND player != ?1) OR alias = player COLLATE BINARY;
@@ -0,0 +1 @@
+DELETE FROM video_angles WHERE video_id = ? AND label = ?;
@@ -0,0 +1,7 @@
+UPDATE sessions
+SET ended_at = CURRENT_TIMESTAMP,
+    events_added = (
+        SELECT COUNT(*) FROM notes n
+        WHERE n.video_id = sessions.video_id AND n.created_at >= sessions.started_at
+    )
+WHERE id = ?;
@@ -0,0 +1 @@
+INSERT INTO bookmarks (video_id, time) VALUES (?, ?);
@@ -0,0 +1 @@
+INSERT INTO videos (path, filename, extension, format, filesize, live_started_at) VALUES (?, ?, '', 'live', 0, CURRENT_TIMESTAMP);
@@ -0,0 +1,2 @@
+INSERT INTO matches (opponent, match_date, competition, home_away, score_for, score_against)
+VALUES (?, ?, ?, ?, ?, ?);
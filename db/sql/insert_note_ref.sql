@@ -0,0 +1 @@
+INSERT INTO note_refs (detail_id, position, target_id) VALUES (?, ?, ?);
@@ -0,0 +1 @@
+INSERT INTO periods (video_id, name, start, end) VALUES (?, ?, ?, ?);
@@ -0,0 +1 @@
+INSERT INTO possessions (video_id, team, territory, start) VALUES (?, ?, ?, ?);
@@ -0,0 +1 @@
+INSERT INTO sessions (video_id) VALUES (?);
@@ -0,0 +1 @@
+INSERT INTO video_angles (video_id, path, label, offset_seconds) VALUES (?, ?, ?, ?);
@@ -0,0 +1 @@
+UPDATE note_details SET note = ?2 WHERE type = 'player' AND note = ?1 COLLATE NOCASE AND note != ?2;
@@ -0,0 +1 @@
+UPDATE lineups SET player = ?2 WHERE player = ?1 COLLATE NOCASE AND player != ?2;
@@ -0,0 +1 @@
+UPDATE OR IGNORE players SET name = ?2 WHERE name = ?1 COLLATE NOCASE AND name != ?2;
@@ -0,0 +1 @@
+UPDATE note_tackles SET player = ?2 WHERE player = ?1 COLLATE NOCASE AND player != ?2;
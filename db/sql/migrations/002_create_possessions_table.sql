@@ -0,0 +1,14 @@
+-- Migration 002: Create possessions table.
+-- Each row is a contiguous interval during which one team had the ball
+-- in a given territory. An interval with end = NULL is still open.
+
+CREATE TABLE IF NOT EXISTS possessions (
+    id INTEGER PRIMARY KEY,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    team TEXT NOT NULL,
+    territory TEXT NOT NULL,
+    start REAL NOT NULL,
+    end REAL
+);
+
+CREATE INDEX IF NOT EXISTS idx_possessions_video_id ON possessions(video_id);
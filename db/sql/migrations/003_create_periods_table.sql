@@ -0,0 +1,13 @@
+-- Migration 003: Create periods table.
+-- Match periods (halves, extra time) for a video, used for game-clock display
+-- and chapter markers. Periods are ordered by start time.
+
+CREATE TABLE IF NOT EXISTS periods (
+    id INTEGER PRIMARY KEY,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    start REAL NOT NULL,
+    end REAL NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_periods_video_id ON periods(video_id);
@@ -0,0 +1,8 @@
+-- Migration 005: Add match metadata columns to videos.
+-- Recorded per video with 'video edit' and used in listings, report headers
+-- and opponent filters. match_date is YYYY-MM-DD.
+
+ALTER TABLE videos ADD COLUMN opponent TEXT;
+ALTER TABLE videos ADD COLUMN match_date TEXT;
+ALTER TABLE videos ADD COLUMN venue TEXT;
+ALTER TABLE videos ADD COLUMN result TEXT;
@@ -0,0 +1,3 @@
+-- Migration 006: Add competition to the video match details.
+
+ALTER TABLE videos ADD COLUMN competition TEXT;
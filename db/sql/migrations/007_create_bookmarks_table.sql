@@ -0,0 +1,12 @@
+-- Migration 007: Create bookmarks table.
+-- Bookmarks are bare "come back to this" positions in a video. They are kept
+-- apart from notes so they never appear in stats, exports or reports.
+
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id INTEGER PRIMARY KEY,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    time REAL NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_bookmarks_video_id ON bookmarks(video_id);
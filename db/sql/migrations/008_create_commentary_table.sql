@@ -0,0 +1,10 @@
+-- Migration 008: Create commentary table.
+-- Free-form match commentary (Markdown), one document per video, kept apart
+-- from the time-stamped notes.
+
+CREATE TABLE IF NOT EXISTS commentary (
+    id INTEGER PRIMARY KEY,
+    video_id INTEGER NOT NULL UNIQUE REFERENCES videos(id) ON DELETE CASCADE,
+    body TEXT NOT NULL DEFAULT '',
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
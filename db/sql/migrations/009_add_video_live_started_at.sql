@@ -0,0 +1,5 @@
+-- Migration 009: Add live_started_at to videos.
+-- Set on placeholder videos created by live-match tagging (no footage yet);
+-- note timings on them are seconds since this wall-clock time.
+
+ALTER TABLE videos ADD COLUMN live_started_at DATETIME;
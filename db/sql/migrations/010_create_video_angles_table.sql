@@ -0,0 +1,13 @@
+-- Migration 010: Create video_angles table.
+-- Secondary camera angles of a video's match. offset_seconds is added to the
+-- main video's time to get the same moment in the angle's file.
+
+CREATE TABLE IF NOT EXISTS video_angles (
+    id INTEGER PRIMARY KEY,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    path TEXT NOT NULL,
+    label TEXT NOT NULL,
+    offset_seconds REAL NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_video_angles_video_label ON video_angles(video_id, label);
@@ -0,0 +1,9 @@
+-- Migration 011: Create video_waveforms table.
+-- Audio peak levels computed by 'analyze waveform', one byte (0-255) per
+-- seconds_per_peak of video, drawn as a strip under the TUI timeline.
+
+CREATE TABLE IF NOT EXISTS video_waveforms (
+    video_id INTEGER PRIMARY KEY REFERENCES videos(id) ON DELETE CASCADE,
+    seconds_per_peak REAL NOT NULL,
+    peaks BLOB NOT NULL
+);
@@ -0,0 +1,5 @@
+-- Migration 012: Add notes.updated_at.
+-- Set whenever a note or its details are edited after it was created, and
+-- NULL for notes never edited. Used to show what changed since the last session.
+
+ALTER TABLE notes ADD COLUMN updated_at DATETIME;
@@ -0,0 +1,10 @@
+-- Migration 013: Create player_aliases table.
+-- An alias maps another spelling of a player's name to the canonical one, as
+-- recorded by 'player merge'. New tackles and player details are normalized
+-- through it when saved. Aliases match case-insensitively.
+
+CREATE TABLE IF NOT EXISTS player_aliases (
+    alias TEXT PRIMARY KEY COLLATE NOCASE,
+    player TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,13 @@
+-- Migration 014: Create lineups table.
+-- A video's lineup maps shirt numbers to players, so players can be entered by
+-- number while tagging and saved by name. Numbers 1-15 start, the rest are on
+-- the bench.
+
+CREATE TABLE IF NOT EXISTS lineups (
+    id INTEGER PRIMARY KEY,
+    video_id INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    number INTEGER NOT NULL,
+    player TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (video_id, number)
+);
@@ -0,0 +1,33 @@
+-- Migration 015: Create matches table.
+-- A match groups the videos of one game (camera angles, halves) so stats and
+-- exports can cover the whole match. match_date is YYYY-MM-DD, home_away is
+-- 'home', 'away' or '', and the scores are NULL until the result is recorded.
+
+CREATE TABLE IF NOT EXISTS matches (
+    id INTEGER PRIMARY KEY,
+    opponent TEXT NOT NULL DEFAULT '',
+    match_date TEXT NOT NULL DEFAULT '',
+    competition TEXT NOT NULL DEFAULT '',
+    home_away TEXT NOT NULL DEFAULT '',
+    score_for INTEGER,
+    score_against INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE videos ADD COLUMN match_id INTEGER REFERENCES matches(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_videos_match_id ON videos(match_id);
+
+-- The day each video's match was played, which places it in a season: the
+-- linked match's date, else the video's own match date, else the day its
+-- first note was created.
+CREATE VIEW IF NOT EXISTS video_match_dates AS
+SELECT v.id AS video_id,
+       v.match_id,
+       COALESCE(
+         NULLIF(m.match_date, ''),
+         NULLIF(v.match_date, ''),
+         (SELECT date(MIN(n.created_at)) FROM notes n WHERE n.video_id = v.id)
+       ) AS match_date
+FROM videos v
+LEFT JOIN matches m ON m.id = v.match_id;
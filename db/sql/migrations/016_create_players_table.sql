@@ -0,0 +1,13 @@
+-- Migration 016: Create players table.
+-- The club roster, seeded from its registration spreadsheet with 'player
+-- import'. Names are unique in any case. A squad number resolves to its player
+-- when the video's lineup doesn't have it and no other roster player wears it.
+
+CREATE TABLE IF NOT EXISTS players (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    number INTEGER,
+    position TEXT NOT NULL DEFAULT '',
+    team TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,22 @@
+-- Migration 017: Create event_types table.
+-- An event type is a kind of structured event beyond tackles (lineouts, scrums,
+-- tries...). Events of a type are notes in the category of its name, with its
+-- fields saved as note details. fields is a JSON array of
+-- {"name", "title", "type", "options", "required"} objects, where type is
+-- 'text', 'number' or 'player'. default_duration is the seconds an event
+-- lasts when none is given, 0 for an instant.
+
+CREATE TABLE IF NOT EXISTS event_types (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    fields TEXT NOT NULL DEFAULT '[]',
+    default_duration REAL NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT OR IGNORE INTO event_types (name, fields, default_duration) VALUES
+    ('lineout', '[{"name":"thrower","type":"player"},{"name":"jumper","type":"player"},{"name":"outcome","options":["won","lost","not straight"]}]', 8),
+    ('scrum', '[{"name":"feed","options":["ours","theirs"],"required":true},{"name":"outcome","options":["won","lost","penalty","free kick","reset"]}]', 15),
+    ('turnover', '[{"name":"won_by","title":"Won by","type":"player"},{"name":"cause","options":["jackal","interception","knock on","maul","kick"]}]', 5),
+    ('try', '[{"name":"scorer","type":"player","required":true},{"name":"assist","type":"player"},{"name":"conversion","options":["made","missed"]}]', 20),
+    ('penalty', '[{"name":"against","options":["us","them"],"required":true},{"name":"offence","options":["offside","not releasing","hands in ruck","high tackle","scrum","maul","other"]},{"name":"outcome","options":["kick at goal","touch","scrum","tap"]}]', 10);
@@ -0,0 +1,11 @@
+-- Migration 018: Create settings table.
+-- App settings kept with the data, by name. 'settings export --embed' stores
+-- the settings bundle (config.json and the Lua scripts) under 'bundle' as
+-- JSON, so a database handed to another analyst carries the setup it was
+-- tagged with.
+
+CREATE TABLE IF NOT EXISTS settings (
+    name TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
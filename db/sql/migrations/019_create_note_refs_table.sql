@@ -0,0 +1,12 @@
+-- Migration 019: Create note_refs table.
+-- The events the #17 references in a note's text point to, by note ID, so
+-- they keep pointing at them when tagging or deleting events renumbers the
+-- video. position is the reference's place among the text's references, from
+-- 0, and target_id is NULL once the event is deleted.
+
+CREATE TABLE IF NOT EXISTS note_refs (
+    detail_id INTEGER NOT NULL REFERENCES note_details(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    target_id INTEGER REFERENCES notes(id) ON DELETE SET NULL,
+    PRIMARY KEY (detail_id, position)
+);
@@ -0,0 +1 @@
+UPDATE notes SET video_id = ? WHERE video_id = ?;
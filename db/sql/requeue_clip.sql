@@ -0,0 +1 @@
+UPDATE note_clips SET status='pending', started_at=NULL WHERE id=? AND status='processing'
@@ -0,0 +1 @@
+SELECT id, video_id, time FROM bookmarks WHERE video_id = ? ORDER BY time ASC;
@@ -0,0 +1,9 @@
+SELECT n.category,
+       COUNT(*) AS uses
+FROM notes n
+WHERE n.category IS NOT NULL AND n.category != ''
+  AND (?1 = 0 OR n.video_id = ?1)
+  AND (?2 = 0 OR n.video_id IN (SELECT id FROM videos WHERE match_id = ?2))
+  AND (?3 = '' OR n.video_id IN (SELECT video_id FROM video_match_dates WHERE match_date >= ?3 AND match_date < ?4))
+GROUP BY n.category
+ORDER BY uses DESC, n.category;
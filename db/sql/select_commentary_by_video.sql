@@ -0,0 +1 @@
+SELECT body FROM commentary WHERE video_id = ?;
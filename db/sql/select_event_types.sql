@@ -0,0 +1,3 @@
+SELECT id, name, fields, default_duration
+FROM event_types
+ORDER BY name COLLATE NOCASE;
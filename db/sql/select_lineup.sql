@@ -0,0 +1 @@
+SELECT number, player FROM lineups WHERE video_id = ? ORDER BY number;
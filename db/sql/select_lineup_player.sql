@@ -0,0 +1 @@
+SELECT player FROM lineups WHERE video_id = ? AND number = ?;
@@ -0,0 +1,8 @@
+SELECT n.id,
+       COALESCE(v.path, ''),
+       nd.note
+FROM note_details nd
+INNER JOIN notes n ON n.id = nd.note_id
+LEFT JOIN videos v ON v.id = n.video_id
+WHERE nd.type = 'text' AND nd.note IS NOT NULL AND nd.note != ''
+ORDER BY n.id;
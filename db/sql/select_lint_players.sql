@@ -0,0 +1,6 @@
+SELECT nt.player,
+       COUNT(*) AS uses
+FROM note_tackles nt
+WHERE nt.player IS NOT NULL AND nt.player != ''
+GROUP BY nt.player
+ORDER BY nt.player;
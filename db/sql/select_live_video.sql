@@ -0,0 +1 @@
+SELECT id, live_started_at FROM videos WHERE path = ? AND live_started_at IS NOT NULL LIMIT 1;
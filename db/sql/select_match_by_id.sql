@@ -0,0 +1,4 @@
+SELECT m.id, m.opponent, m.match_date, m.competition, m.home_away, m.score_for, m.score_against,
+       (SELECT COUNT(*) FROM videos v WHERE v.match_id = m.id)
+FROM matches m
+WHERE m.id = ?;
@@ -0,0 +1,4 @@
+SELECT id, path
+FROM videos
+WHERE match_id = ?
+ORDER BY id;
@@ -0,0 +1,6 @@
+SELECT m.id, m.opponent, m.match_date, m.competition, m.home_away, m.score_for, m.score_against,
+       (SELECT COUNT(*) FROM videos v WHERE v.match_id = m.id)
+FROM matches m
+WHERE (?1 = '' OR m.match_date >= ?1)
+  AND (?2 = '' OR m.match_date < ?2)
+ORDER BY m.match_date = '', m.match_date DESC, m.id DESC;
@@ -5,16 +5,16 @@ SELECT
     nc.filename,
     v.path,
     n.category,
-    nt.player,
-    nt.attempt,
-    nt.outcome,
+    COALESCE(nt.player, ''),
+    COALESCE(nt.attempt, 0),
+    COALESCE(nt.outcome, ''),
     ntim.start,
     ntim.end
 FROM note_clips nc
 INNER JOIN notes n ON n.id = nc.note_id
 INNER JOIN videos v ON v.id = n.video_id
 INNER JOIN note_timing ntim ON ntim.note_id = nc.note_id
-INNER JOIN note_tackles nt ON nt.note_id = nc.note_id
-WHERE nc.status = 'pending'
+LEFT JOIN note_tackles nt ON nt.note_id = nc.note_id
+WHERE nc.status = 'pending' AND COALESCE(nc.folder, '') <> ''
 ORDER BY nc.id ASC
 LIMIT 1
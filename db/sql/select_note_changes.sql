@@ -0,0 +1,16 @@
+SELECT n.id,
+       COALESCE(n.category, ''),
+       COALESCE(nt.start, 0),
+       COALESCE((SELECT tk.player FROM note_tackles tk WHERE tk.note_id = n.id LIMIT 1),
+                (SELECT d.note FROM note_details d WHERE d.note_id = n.id AND d.type = 'player' LIMIT 1), ''),
+       COALESCE((SELECT d.note FROM note_details d WHERE d.note_id = n.id AND d.type IN ('text', 'notes') ORDER BY d.id LIMIT 1), ''),
+       n.created_at,
+       n.updated_at,
+       n.created_at >= s.started_at
+FROM notes n
+INNER JOIN sessions s ON s.id = ?2
+LEFT JOIN note_timing nt ON nt.note_id = n.id
+WHERE n.video_id = ?1
+  AND (n.created_at >= s.started_at OR n.updated_at >= s.started_at)
+GROUP BY n.id
+ORDER BY COALESCE(n.updated_at, n.created_at), n.id;
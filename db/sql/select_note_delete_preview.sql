@@ -0,0 +1,7 @@
+SELECT
+    (SELECT COUNT(*) FROM note_timing WHERE note_id = ?1),
+    (SELECT COUNT(*) FROM note_tackles WHERE note_id = ?1),
+    (SELECT COUNT(*) FROM note_zones WHERE note_id = ?1),
+    (SELECT COUNT(*) FROM note_details WHERE note_id = ?1),
+    (SELECT COUNT(*) FROM note_highlights WHERE note_id = ?1),
+    (SELECT COUNT(*) FROM note_clips WHERE note_id = ?1);
@@ -0,0 +1 @@
+SELECT id FROM note_details WHERE note_id = ? AND note = ? ORDER BY id LIMIT 1;
@@ -0,0 +1,5 @@
+SELECT COUNT(*)
+FROM notes n
+INNER JOIN videos v ON v.id = n.video_id
+INNER JOIN note_timing nt ON nt.note_id = n.id
+WHERE v.path = ? AND n.category = ? AND ABS(nt.start - ?) < 0.01;
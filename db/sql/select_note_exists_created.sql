@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+FROM notes n
+INNER JOIN videos v ON v.id = n.video_id
+WHERE v.path = ? AND n.category = ? AND n.created_at = ?;
@@ -0,0 +1,9 @@
+SELECT id
+FROM (
+    SELECT n.id, ROW_NUMBER() OVER (ORDER BY COALESCE(nt.start, 0), n.id) AS number
+    FROM notes n
+    INNER JOIN videos v ON v.id = n.video_id
+    LEFT JOIN note_timing nt ON nt.note_id = n.id
+    WHERE v.path = ?
+)
+WHERE number = ?;
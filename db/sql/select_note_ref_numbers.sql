@@ -0,0 +1,13 @@
+SELECT r.detail_id, r.position, COALESCE(e.number, 0)
+FROM note_refs r
+INNER JOIN note_details d ON d.id = r.detail_id
+INNER JOIN notes n ON n.id = d.note_id
+INNER JOIN videos v ON v.id = n.video_id
+LEFT JOIN (
+    SELECT n.id, ROW_NUMBER() OVER (ORDER BY COALESCE(nt.start, 0), n.id) AS number
+    FROM notes n
+    INNER JOIN videos v ON v.id = n.video_id
+    LEFT JOIN note_timing nt ON nt.note_id = n.id
+    WHERE v.path = ?
+) e ON e.id = r.target_id
+WHERE v.path = ?;
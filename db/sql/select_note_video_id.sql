@@ -0,0 +1 @@
+SELECT COALESCE(video_id, 0) FROM notes WHERE id = ?;
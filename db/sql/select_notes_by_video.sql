@@ -0,0 +1 @@
+SELECT id, COALESCE(category, ''), created_at FROM notes WHERE video_id = ? ORDER BY id;
@@ -0,0 +1,16 @@
+SELECT n.id,
+       COALESCE(n.category, ''),
+       COALESCE(nt.start, 0),
+       COALESCE(nt.end, 0),
+       COALESCE((SELECT d.note FROM note_details d WHERE d.note_id = n.id AND d.type IN ('text', 'notes') ORDER BY d.id LIMIT 1), ''),
+       COALESCE((SELECT tk.player FROM note_tackles tk WHERE tk.note_id = n.id LIMIT 1),
+                (SELECT d.note FROM note_details d WHERE d.note_id = n.id AND d.type = 'player' LIMIT 1), ''),
+       COALESCE((SELECT d.note FROM note_details d WHERE d.note_id = n.id AND d.type = 'team' LIMIT 1), ''),
+       COALESCE((SELECT tk.outcome FROM note_tackles tk WHERE tk.note_id = n.id LIMIT 1), ''),
+       EXISTS (SELECT 1 FROM note_highlights nh WHERE nh.note_id = n.id AND nh.type = 'star')
+FROM notes n
+INNER JOIN videos v ON v.id = n.video_id
+LEFT JOIN note_timing nt ON nt.note_id = n.id
+WHERE v.path = ?
+GROUP BY n.id
+ORDER BY 3 ASC, n.id ASC;
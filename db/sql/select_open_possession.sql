@@ -0,0 +1 @@
+SELECT id, video_id, team, territory, start, end FROM possessions WHERE video_id = ? AND end IS NULL ORDER BY start DESC LIMIT 1;
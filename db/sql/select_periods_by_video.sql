@@ -0,0 +1 @@
+SELECT id, video_id, name, start, end FROM periods WHERE video_id = ? ORDER BY start ASC;
@@ -0,0 +1 @@
+SELECT player FROM player_aliases WHERE alias = ?;
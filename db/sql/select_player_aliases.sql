@@ -0,0 +1,3 @@
+SELECT alias, player, created_at
+FROM player_aliases
+ORDER BY player COLLATE NOCASE, alias COLLATE NOCASE;
@@ -0,0 +1,16 @@
+SELECT player
+FROM (
+    SELECT player, COUNT(*) AS uses, 0 AS on_lineup FROM note_tackles
+    WHERE player IS NOT NULL AND TRIM(player) != '' GROUP BY player
+    UNION ALL
+    SELECT note, COUNT(*), 0 FROM note_details
+    WHERE type = 'player' AND TRIM(note) != '' GROUP BY note
+    UNION ALL
+    SELECT player, COUNT(*), MAX(video_id = ?1) FROM lineups
+    WHERE TRIM(player) != '' GROUP BY player
+    UNION ALL
+    SELECT name, 0, 0 FROM players
+)
+WHERE CAST(player AS INTEGER) || '' != player
+GROUP BY player COLLATE NOCASE
+ORDER BY MAX(on_lineup) DESC, SUM(uses) DESC, player;
@@ -0,0 +1,21 @@
+WITH recent AS (
+    SELECT v.id, COALESCE(NULLIF(v.match_date, ''), MIN(n.created_at)) AS played
+    FROM videos v
+    INNER JOIN notes n ON n.video_id = v.id
+    INNER JOIN note_tackles nt ON nt.note_id = n.id
+    WHERE v.id != ?
+    GROUP BY v.id
+    ORDER BY played DESC
+    LIMIT ?
+)
+SELECT r.id,
+       nt.player,
+       COUNT(*) AS total,
+       SUM(CASE WHEN nt.outcome = 'completed' THEN 1 ELSE 0 END) AS completed,
+       SUM(CASE WHEN nt.outcome = 'missed' THEN 1 ELSE 0 END) AS missed
+FROM recent r
+INNER JOIN notes n ON n.video_id = r.id
+INNER JOIN note_tackles nt ON nt.note_id = n.id
+WHERE nt.player IS NOT NULL AND nt.player != ''
+GROUP BY r.id, nt.player
+ORDER BY r.played, r.id, nt.player;
@@ -0,0 +1 @@
+SELECT id, video_id, team, territory, start, end FROM possessions WHERE video_id = ? ORDER BY start ASC;
@@ -0,0 +1,5 @@
+SELECT s.id, s.video_id, s.started_at, s.ended_at, s.events_added
+FROM sessions s
+WHERE s.video_id = ? AND s.ended_at IS NOT NULL
+ORDER BY s.started_at DESC, s.id DESC
+LIMIT 1;
@@ -0,0 +1 @@
+SELECT id, started_at FROM note_clips WHERE status='processing'
@@ -0,0 +1,7 @@
+SELECT nt.player
+FROM note_tackles nt
+INNER JOIN notes n ON n.id = nt.note_id
+WHERE n.video_id = ?1 AND nt.player IS NOT NULL AND TRIM(nt.player) != ''
+GROUP BY nt.player COLLATE NOCASE
+ORDER BY MAX(n.id) DESC
+LIMIT ?2;
@@ -0,0 +1,9 @@
+SELECT n.id, COALESCE(n.category, ''), n.created_at, COALESCE(nt.start, 0)
+FROM notes n
+LEFT JOIN note_timing nt ON nt.note_id = n.id
+WHERE n.video_id = ?
+  AND (? = 0 OR (
+      n.created_at >= (SELECT started_at FROM sessions WHERE id = ?)
+      AND n.created_at <= COALESCE((SELECT ended_at FROM sessions WHERE id = ?), CURRENT_TIMESTAMP)
+  ))
+ORDER BY n.created_at, n.id;
@@ -0,0 +1,15 @@
+SELECT n.id,
+       n.video_id,
+       n.category,
+       COALESCE((SELECT MIN(t.start) FROM note_timing t WHERE t.note_id = n.id), 0),
+       COALESCE((SELECT tk.player FROM note_tackles tk WHERE tk.note_id = n.id LIMIT 1), ''),
+       COALESCE((SELECT d.note FROM note_details d WHERE d.note_id = n.id AND d.type IN ('text', 'notes') LIMIT 1), ''),
+       COALESCE(nc.folder, ''),
+       COALESCE(nc.filename, ''),
+       COALESCE(nc.status, '')
+FROM notes n
+INNER JOIN note_highlights nh ON nh.note_id = n.id AND nh.type = 'star'
+LEFT JOIN note_clips nc ON nc.note_id = n.id
+WHERE n.created_at >= ? AND n.created_at < ?
+GROUP BY n.id
+ORDER BY n.video_id, 4;
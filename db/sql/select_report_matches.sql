@@ -0,0 +1,9 @@
+SELECT v.id, COALESCE(v.path, ''), COALESCE(v.filename, ''), MIN(n.created_at), COUNT(n.id),
+       COALESCE(v.opponent, ''), COALESCE(v.match_date, ''), COALESCE(v.venue, ''), COALESCE(v.result, ''), COALESCE(v.competition, ''),
+       COALESCE(c.body, '')
+FROM videos v
+INNER JOIN notes n ON n.video_id = v.id
+LEFT JOIN commentary c ON c.video_id = v.id
+WHERE n.created_at >= ? AND n.created_at < ?
+GROUP BY v.id
+ORDER BY COALESCE(NULLIF(v.match_date, ''), MIN(n.created_at));
@@ -0,0 +1,11 @@
+SELECT n.video_id,
+       nt.player,
+       COUNT(*) AS total,
+       SUM(CASE WHEN nt.outcome = 'completed' THEN 1 ELSE 0 END) AS completed,
+       SUM(CASE WHEN nt.outcome = 'missed' THEN 1 ELSE 0 END) AS missed
+FROM note_tackles nt
+INNER JOIN notes n ON n.id = nt.note_id
+WHERE n.created_at >= ? AND n.created_at < ?
+  AND nt.player IS NOT NULL AND nt.player != ''
+GROUP BY n.video_id, nt.player
+ORDER BY nt.player;
@@ -0,0 +1,4 @@
+SELECT name, COALESCE(number, 0), position, team
+FROM players
+WHERE ?1 = '' OR team = ?1 COLLATE NOCASE
+ORDER BY team COLLATE NOCASE, number IS NULL, number, name COLLATE NOCASE;
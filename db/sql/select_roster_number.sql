@@ -0,0 +1 @@
+SELECT MIN(name) FROM players WHERE number = ?1 HAVING COUNT(*) = 1;
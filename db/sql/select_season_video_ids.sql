@@ -0,0 +1,4 @@
+SELECT video_id
+FROM video_match_dates
+WHERE match_date >= ? AND match_date < ?
+ORDER BY video_id;
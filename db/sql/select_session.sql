@@ -0,0 +1,3 @@
+SELECT s.id, s.video_id, s.started_at, s.ended_at, s.events_added
+FROM sessions s
+WHERE s.id = ?;
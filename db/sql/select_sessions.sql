@@ -0,0 +1,5 @@
+SELECT s.id, s.video_id, COALESCE(v.path, ''), COALESCE(v.filename, ''), s.started_at, s.ended_at, s.events_added
+FROM sessions s
+INNER JOIN videos v ON v.id = s.video_id
+WHERE s.started_at >= ? AND s.started_at < ?
+ORDER BY s.started_at;
@@ -0,0 +1 @@
+SELECT value FROM settings WHERE name = ?;
@@ -0,0 +1,3 @@
+SELECT name, type, pk
+FROM pragma_table_info(?)
+ORDER BY cid;
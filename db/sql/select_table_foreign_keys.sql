@@ -0,0 +1,3 @@
+SELECT "from", "table", "to"
+FROM pragma_foreign_key_list(?)
+ORDER BY id, seq;
@@ -0,0 +1,20 @@
+SELECT CASE WHEN ?1 = 'zone' THEN COALESCE(NULLIF(z.horizontal, ''), '') ELSE nt.player END AS grp,
+       COUNT(*) AS total,
+       SUM(CASE WHEN nt.outcome = 'completed' THEN 1 ELSE 0 END) AS completed,
+       SUM(CASE WHEN nt.outcome = 'missed' THEN 1 ELSE 0 END) AS missed,
+       SUM(CASE WHEN nt.outcome = 'possible' THEN 1 ELSE 0 END) AS possible,
+       SUM(CASE WHEN nt.outcome = 'other' THEN 1 ELSE 0 END) AS other,
+       SUM(CASE WHEN nh.note_id IS NOT NULL THEN 1 ELSE 0 END) AS starred,
+       SUM(CASE WHEN nt.outcome = 'missed' AND f.note_id IS NOT NULL THEN 1 ELSE 0 END) AS covered
+FROM note_tackles nt
+INNER JOIN notes n ON n.id = nt.note_id
+LEFT JOIN (SELECT note_id, MIN(horizontal) AS horizontal FROM note_zones GROUP BY note_id) z ON z.note_id = n.id
+LEFT JOIN (SELECT DISTINCT note_id FROM note_details WHERE type = 'followed' AND TRIM(note) != '') f ON f.note_id = n.id
+LEFT JOIN (SELECT DISTINCT note_id FROM note_highlights WHERE type = 'star') nh ON nh.note_id = n.id
+WHERE (?2 = 0 OR n.video_id = ?2)
+  AND (?5 = 0 OR n.video_id IN (SELECT id FROM videos WHERE match_id = ?5))
+  AND (?6 = '' OR n.video_id IN (SELECT video_id FROM video_match_dates WHERE match_date >= ?6 AND match_date < ?7))
+  AND (?3 = '' OR z.horizontal = ?3 COLLATE NOCASE)
+  AND (?4 = '' OR (?4 = 'yes') = (f.note_id IS NOT NULL))
+GROUP BY CASE WHEN ?1 = 'zone' THEN LOWER(COALESCE(z.horizontal, '')) ELSE nt.player END
+ORDER BY total DESC, grp;
@@ -0,0 +1,9 @@
+SELECT MIN(z.horizontal)
+FROM note_zones z
+INNER JOIN note_tackles nt ON nt.note_id = z.note_id
+INNER JOIN notes n ON n.id = z.note_id
+WHERE z.horizontal != '' AND (?1 = 0 OR n.video_id = ?1)
+  AND (?2 = 0 OR n.video_id IN (SELECT id FROM videos WHERE match_id = ?2))
+  AND (?3 = '' OR n.video_id IN (SELECT video_id FROM video_match_dates WHERE match_date >= ?3 AND match_date < ?4))
+GROUP BY LOWER(z.horizontal)
+ORDER BY LOWER(z.horizontal);
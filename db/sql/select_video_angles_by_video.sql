@@ -0,0 +1 @@
+SELECT id, video_id, path, label, offset_seconds FROM video_angles WHERE video_id = ? ORDER BY id;
@@ -0,0 +1,17 @@
+SELECT
+    n.id,
+    n.category,
+    ntim.start,
+    COALESCE(nt.player, ''),
+    COALESCE(nt.attempt, 0),
+    COALESCE(nt.outcome, ''),
+    EXISTS (SELECT 1 FROM note_highlights h WHERE h.note_id = n.id AND h.type = 'star'),
+    COALESCE(nc.status, ''),
+    COALESCE(nc.log, '')
+FROM notes n
+INNER JOIN note_timing ntim ON ntim.note_id = n.id
+LEFT JOIN note_tackles nt ON nt.note_id = n.id
+LEFT JOIN note_clips nc ON nc.note_id = n.id
+WHERE n.video_id = ? AND n.category <> 'clip'
+GROUP BY n.id
+ORDER BY ntim.start ASC, n.id ASC
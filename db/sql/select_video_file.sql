@@ -0,0 +1,5 @@
+SELECT v.id, v.path, COALESCE(v.format, ''), COALESCE(v.filesize, 0), COALESCE(vt.length, 0)
+FROM videos v
+LEFT JOIN video_timings vt ON vt.video_id = v.id
+WHERE v.path = ?
+LIMIT 1;
@@ -0,0 +1,3 @@
+SELECT COALESCE(match_id, 0), COALESCE(match_date, '')
+FROM video_match_dates
+WHERE video_id = ?;
@@ -0,0 +1,3 @@
+SELECT COALESCE(opponent, ''), COALESCE(match_date, ''), COALESCE(venue, ''), COALESCE(result, ''), COALESCE(competition, '')
+FROM videos
+WHERE id = ?;
@@ -0,0 +1 @@
+SELECT video_id, seconds_per_peak, peaks FROM video_waveforms WHERE video_id = ?;
@@ -0,0 +1,10 @@
+SELECT v.id, COALESCE(v.path, ''), COALESCE(v.filename, ''),
+       COALESCE(v.opponent, ''), COALESCE(v.match_date, ''), COALESCE(v.venue, ''), COALESCE(v.result, ''),
+       COALESCE(v.competition, ''),
+       (SELECT COUNT(*) FROM notes n WHERE n.video_id = v.id)
+FROM videos v
+WHERE (? = '' OR v.opponent LIKE '%' || ? || '%')
+  AND (? = '' OR v.competition LIKE '%' || ? || '%')
+  AND (? = '' OR v.match_date >= ?)
+  AND (? = '' OR v.match_date < ?)
+ORDER BY COALESCE(v.match_date, '') = '', v.match_date DESC, v.id DESC;
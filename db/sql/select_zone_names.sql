@@ -0,0 +1,5 @@
+SELECT horizontal
+FROM note_zones
+WHERE horizontal IS NOT NULL AND TRIM(horizontal) != ''
+GROUP BY horizontal COLLATE NOCASE
+ORDER BY COUNT(*) DESC, horizontal;
@@ -0,0 +1,3 @@
+UPDATE note_timing
+SET start = MAX(0, start + ?), end = MAX(0, end + ?)
+WHERE note_id IN (SELECT id FROM notes WHERE video_id = ?);
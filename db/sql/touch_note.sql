@@ -0,0 +1 @@
+UPDATE notes SET updated_at = CURRENT_TIMESTAMP WHERE id = ?;
@@ -0,0 +1,6 @@
+UPDATE notes SET updated_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT note_id FROM note_tackles WHERE player = ?1 COLLATE NOCASE AND player != ?2
+    UNION
+    SELECT note_id FROM note_details WHERE type = 'player' AND note = ?1 COLLATE NOCASE AND note != ?2
+);
@@ -0,0 +1 @@
+UPDATE notes SET created_at = ? WHERE id = ?;
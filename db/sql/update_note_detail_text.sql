@@ -0,0 +1 @@
+UPDATE note_details SET note = ? WHERE id = (SELECT MIN(id) FROM note_details WHERE note_id = ?);
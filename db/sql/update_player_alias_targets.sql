@@ -0,0 +1 @@
+UPDATE player_aliases SET player = ?2 WHERE player = ?1 COLLATE NOCASE;
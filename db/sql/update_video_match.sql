@@ -0,0 +1 @@
+UPDATE videos SET match_id = ? WHERE id = ?;
@@ -0,0 +1 @@
+UPDATE videos SET opponent = ?, match_date = ?, venue = ?, result = ?, competition = ? WHERE id = ?;
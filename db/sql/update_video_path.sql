@@ -0,0 +1 @@
+UPDATE videos SET path = ?, filename = ?, extension = ?, filesize = ? WHERE id = ?;
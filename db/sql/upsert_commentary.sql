@@ -0,0 +1,2 @@
+INSERT INTO commentary (video_id, body) VALUES (?, ?)
+ON CONFLICT(video_id) DO UPDATE SET body = excluded.body, updated_at = CURRENT_TIMESTAMP;
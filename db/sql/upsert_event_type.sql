@@ -0,0 +1,4 @@
+INSERT INTO event_types (name, fields, default_duration) VALUES (?1, ?2, ?3)
+ON CONFLICT(name) DO UPDATE SET
+    fields = excluded.fields,
+    default_duration = excluded.default_duration;
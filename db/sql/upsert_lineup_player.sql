@@ -0,0 +1,2 @@
+INSERT INTO lineups (video_id, number, player) VALUES (?1, ?2, ?3)
+ON CONFLICT(video_id, number) DO UPDATE SET player = excluded.player;
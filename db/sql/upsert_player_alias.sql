@@ -0,0 +1,2 @@
+INSERT INTO player_aliases (alias, player) VALUES (?1, ?2)
+ON CONFLICT(alias) DO UPDATE SET player = excluded.player, created_at = CURRENT_TIMESTAMP;
@@ -0,0 +1,5 @@
+INSERT INTO players (name, number, position, team) VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT(name) DO UPDATE SET
+    number = COALESCE(excluded.number, number),
+    position = CASE WHEN excluded.position != '' THEN excluded.position ELSE position END,
+    team = CASE WHEN excluded.team != '' THEN excluded.team ELSE team END;
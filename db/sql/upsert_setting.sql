@@ -0,0 +1,4 @@
+INSERT INTO settings (name, value, updated_at) VALUES (?1, ?2, CURRENT_TIMESTAMP)
+ON CONFLICT(name) DO UPDATE SET
+    value = excluded.value,
+    updated_at = excluded.updated_at;
@@ -0,0 +1,2 @@
+INSERT INTO video_waveforms (video_id, seconds_per_peak, peaks) VALUES (?, ?, ?)
+ON CONFLICT(video_id) DO UPDATE SET seconds_per_peak = excluded.seconds_per_peak, peaks = excluded.peaks;
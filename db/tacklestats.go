@@ -0,0 +1,77 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// TackleFilter narrows and groups the tackles counted by SelectTackleBreakdown.
+type TackleFilter struct {
+	// VideoScope limits the count to a video, match or season (zero for all videos)
+	VideoScope
+	// Zone limits the count to tackles in this zone, in any case ("" for all)
+	Zone string
+	// Followed is "yes" for tackles someone followed up, "no" for the rest
+	// and "" for all
+	Followed string
+	// GroupBy is "player" (the default) or "zone"
+	GroupBy string
+}
+
+// Validate checks the filter's Followed and GroupBy values.
+func (f TackleFilter) Validate() error {
+	switch f.Followed {
+	case "", "yes", "no":
+	default:
+		return fmt.Errorf("invalid followed filter: %s (want yes or no)", f.Followed)
+	}
+	switch f.GroupBy {
+	case "", "player", "zone":
+	default:
+		return fmt.Errorf("invalid grouping: %s (want player or zone)", f.GroupBy)
+	}
+	return nil
+}
+
+// SelectTackleBreakdown counts tackles by outcome per player or zone, most
+// tackles first, with how many missed tackles were covered by a follow-up.
+func SelectTackleBreakdown(database *sql.DB, f TackleFilter) ([]TackleBreakdown, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	rows, err := database.Query(SelectTackleBreakdownSQL, f.GroupBy, f.VideoID, f.Zone, f.Followed, f.MatchID, f.From, f.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []TackleBreakdown
+	for rows.Next() {
+		var s TackleBreakdown
+		if err := rows.Scan(&s.Group, &s.Total, &s.Completed, &s.Missed, &s.Possible, &s.Other, &s.Starred, &s.Covered); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// SelectTackleZones returns the zones tackles were recorded in, within scope,
+// alphabetically.
+func SelectTackleZones(database *sql.DB, scope VideoScope) ([]string, error) {
+	rows, err := database.Query(SelectTackleZonesSQL, scope.VideoID, scope.MatchID, scope.From, scope.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var zones []string
+	for rows.Next() {
+		var z string
+		if err := rows.Scan(&z); err != nil {
+			return nil, err
+		}
+		zones = append(zones, z)
+	}
+	return zones, rows.Err()
+}
@@ -0,0 +1,310 @@
+package db
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+)
+
+// TransferFormat is the version of the video notes JSON document; bump it when
+// the document changes in a way older versions can't read.
+const TransferFormat = 1
+
+// VideoFile is a registered video with the size and duration that identify it
+// on another machine, where it may live at a different path.
+type VideoFile struct {
+	ID       int64
+	Path     string
+	Format   string
+	Filesize int64
+	Duration float64
+}
+
+// Fingerprint identifies the video file by its duration and size.
+func (v VideoFile) Fingerprint() string {
+	return VideoFingerprint(v.Duration, v.Filesize)
+}
+
+// VideoFingerprint hashes a video's duration, to the whole second, and its
+// filesize. Empty when either is unknown, as two such videos can't be told
+// apart.
+func VideoFingerprint(duration float64, filesize int64) string {
+	if duration <= 0 || filesize <= 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%d", int64(math.Round(duration)), filesize)))
+	return hex.EncodeToString(sum[:])
+}
+
+// VideoTransfer is the JSON document holding one video's notes and their
+// child rows, for moving them to another database.
+type VideoTransfer struct {
+	Format     int            `json:"format"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Video      TransferVideo  `json:"video"`
+	Notes      []TransferNote `json:"notes"`
+}
+
+// TransferVideo describes the exported notes' video.
+type TransferVideo struct {
+	Path        string  `json:"path"`
+	Filename    string  `json:"filename"`
+	Format      string  `json:"format,omitempty"`
+	Filesize    int64   `json:"filesize,omitempty"`
+	Duration    float64 `json:"duration,omitempty"`
+	Fingerprint string  `json:"fingerprint,omitempty"`
+}
+
+// TransferNote is a note with its child rows, without database IDs.
+type TransferNote struct {
+	Category   string           `json:"category"`
+	CreatedAt  time.Time        `json:"created_at"`
+	Timings    []TransferTiming `json:"timings,omitempty"`
+	Tackles    []TransferTackle `json:"tackles,omitempty"`
+	Zones      []TransferZone   `json:"zones,omitempty"`
+	Details    []TransferDetail `json:"details,omitempty"`
+	Highlights []string         `json:"highlights,omitempty"`
+	Clips      []TransferClip   `json:"clips,omitempty"`
+}
+
+// TransferTiming is a note_timing row.
+type TransferTiming struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// TransferTackle is a note_tackles row.
+type TransferTackle struct {
+	Player    string `json:"player"`
+	Attempt   int    `json:"attempt"`
+	Outcome   string `json:"outcome"`
+	Height    string `json:"height,omitempty"`
+	Technique string `json:"technique,omitempty"`
+}
+
+// TransferZone is a note_zones row.
+type TransferZone struct {
+	Horizontal string `json:"horizontal"`
+	Vertical   string `json:"vertical"`
+}
+
+// TransferDetail is a note_details row.
+type TransferDetail struct {
+	Type string `json:"type"`
+	Note string `json:"note"`
+}
+
+// TransferClip is a note_clips row.
+type TransferClip struct {
+	Folder     string     `json:"folder"`
+	Filename   string     `json:"filename"`
+	Extension  string     `json:"extension,omitempty"`
+	Format     string     `json:"format,omitempty"`
+	Filesize   int64      `json:"filesize,omitempty"`
+	Status     string     `json:"status"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	ErrorAt    *time.Time `json:"error_at,omitempty"`
+	Log        string     `json:"log,omitempty"`
+}
+
+// SelectVideoFile returns the video registered at path.
+// Returns sql.ErrNoRows when the video has not been registered.
+func SelectVideoFile(database *sql.DB, path string) (VideoFile, error) {
+	var v VideoFile
+	err := database.QueryRow(SelectVideoFileSQL, path).Scan(&v.ID, &v.Path, &v.Format, &v.Filesize, &v.Duration)
+	return v, err
+}
+
+// SelectVideoByFingerprint returns the first registered video with the given
+// fingerprint, whatever its path.
+// Returns sql.ErrNoRows when there is none.
+func SelectVideoByFingerprint(database *sql.DB, fingerprint string, filesize int64) (VideoFile, error) {
+	rows, err := database.Query(SelectVideoFilesBySizeSQL, filesize)
+	if err != nil {
+		return VideoFile{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v VideoFile
+		if err := rows.Scan(&v.ID, &v.Path, &v.Format, &v.Filesize, &v.Duration); err != nil {
+			return VideoFile{}, err
+		}
+		if fingerprint != "" && v.Fingerprint() == fingerprint {
+			return v, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return VideoFile{}, err
+	}
+	return VideoFile{}, sql.ErrNoRows
+}
+
+// SelectNotesByVideo returns the video's notes in the order they were added.
+func SelectNotesByVideo(database *sql.DB, videoID int64) ([]Note, error) {
+	rows, err := database.Query(SelectNotesByVideoSQL, videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notes []Note
+	for rows.Next() {
+		var n Note
+		if err := rows.Scan(&n.ID, &n.Category, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
+
+// createdAtLayout is how SQLite's CURRENT_TIMESTAMP writes notes.created_at.
+const createdAtLayout = "2006-01-02 15:04:05"
+
+// SetNoteCreatedAt backdates a note, e.g. one imported from another database.
+func SetNoteCreatedAt(database *sql.DB, noteID int64, createdAt time.Time) error {
+	if _, err := database.Exec(UpdateNoteCreatedAtSQL, createdAt.UTC().Format(createdAtLayout), noteID); err != nil {
+		return fmt.Errorf("update note created_at: %w", err)
+	}
+	return nil
+}
+
+// NoteExistsCreated reports whether the video already has a note of the given
+// category created at createdAt. Used to skip notes without timing that were
+// imported before.
+func NoteExistsCreated(database *sql.DB, videoPath, category string, createdAt time.Time) (bool, error) {
+	var count int
+	if err := database.QueryRow(SelectNoteExistsCreatedSQL, videoPath, category, createdAt.UTC().Format(createdAtLayout)).Scan(&count); err != nil {
+		return false, fmt.Errorf("select note exists: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ExportVideoNotes builds the transfer document for the video registered at
+// path. Returns sql.ErrNoRows when the video has not been registered.
+func ExportVideoNotes(database *sql.DB, path string) (*VideoTransfer, error) {
+	v, err := SelectVideoFile(database, path)
+	if err != nil {
+		return nil, err
+	}
+	doc := &VideoTransfer{
+		Format:     TransferFormat,
+		ExportedAt: time.Now().UTC(),
+		Video: TransferVideo{
+			Path:        v.Path,
+			Filename:    videosrc.Base(v.Path),
+			Format:      v.Format,
+			Filesize:    v.Filesize,
+			Duration:    v.Duration,
+			Fingerprint: v.Fingerprint(),
+		},
+		Notes: []TransferNote{},
+	}
+
+	notes, err := SelectNotesByVideo(database, v.ID)
+	if err != nil {
+		return nil, fmt.Errorf("select notes: %w", err)
+	}
+	for _, n := range notes {
+		tn, err := transferNote(database, n)
+		if err != nil {
+			return nil, err
+		}
+		doc.Notes = append(doc.Notes, tn)
+	}
+	return doc, nil
+}
+
+// transferNote reads the child rows of note n.
+func transferNote(database *sql.DB, n Note) (TransferNote, error) {
+	tn := TransferNote{Category: n.Category, CreatedAt: n.CreatedAt}
+
+	timings, err := SelectNoteTimingByNote(database, n.ID)
+	if err != nil {
+		return tn, fmt.Errorf("select note timing: %w", err)
+	}
+	for _, t := range timings {
+		tn.Timings = append(tn.Timings, TransferTiming{Start: t.Start, End: t.End})
+	}
+	tackles, err := SelectNoteTacklesByNote(database, n.ID)
+	if err != nil {
+		return tn, fmt.Errorf("select note tackles: %w", err)
+	}
+	for _, t := range tackles {
+		tn.Tackles = append(tn.Tackles, TransferTackle{Player: t.Player, Attempt: t.Attempt, Outcome: t.Outcome, Height: t.Height, Technique: t.Technique})
+	}
+	zones, err := SelectNoteZonesByNote(database, n.ID)
+	if err != nil {
+		return tn, fmt.Errorf("select note zones: %w", err)
+	}
+	for _, z := range zones {
+		tn.Zones = append(tn.Zones, TransferZone{Horizontal: z.Horizontal, Vertical: z.Vertical})
+	}
+	details, err := SelectNoteDetailsByNote(database, n.ID)
+	if err != nil {
+		return tn, fmt.Errorf("select note details: %w", err)
+	}
+	for _, d := range details {
+		tn.Details = append(tn.Details, TransferDetail{Type: d.Type, Note: d.Note})
+	}
+	highlights, err := SelectNoteHighlightsByNote(database, n.ID)
+	if err != nil {
+		return tn, fmt.Errorf("select note highlights: %w", err)
+	}
+	for _, h := range highlights {
+		tn.Highlights = append(tn.Highlights, h.Type)
+	}
+	clips, err := SelectNoteClipsByNote(database, n.ID)
+	if err != nil {
+		return tn, fmt.Errorf("select note clips: %w", err)
+	}
+	for _, c := range clips {
+		tn.Clips = append(tn.Clips, TransferClip{
+			Folder: c.Folder, Filename: c.Filename, Extension: c.Extension, Format: c.Format, Filesize: c.Filesize,
+			Status: c.Status, StartedAt: c.StartedAt, FinishedAt: c.FinishedAt, ErrorAt: c.ErrorAt, Log: c.Log,
+		})
+	}
+	return tn, nil
+}
+
+// Children converts the note's rows for InsertNoteWithChildren on video.
+func (tn TransferNote) Children(video NoteVideo) NoteChildren {
+	children := NoteChildren{Videos: []NoteVideo{video}}
+	for _, t := range tn.Timings {
+		children.Timings = append(children.Timings, NoteTiming{Start: t.Start, End: t.End})
+	}
+	for _, t := range tn.Tackles {
+		children.Tackles = append(children.Tackles, NoteTackle{Player: t.Player, Attempt: t.Attempt, Outcome: t.Outcome, Height: t.Height, Technique: t.Technique})
+	}
+	for _, z := range tn.Zones {
+		children.Zones = append(children.Zones, NoteZone{Horizontal: z.Horizontal, Vertical: z.Vertical})
+	}
+	for _, d := range tn.Details {
+		children.Details = append(children.Details, NoteDetail{Type: d.Type, Note: d.Note})
+	}
+	for _, h := range tn.Highlights {
+		children.Highlights = append(children.Highlights, NoteHighlight{Type: h})
+	}
+	for _, c := range tn.Clips {
+		children.Clips = append(children.Clips, NoteClip{
+			Folder: c.Folder, Filename: c.Filename, Extension: c.Extension, Format: c.Format, Filesize: c.Filesize,
+			Status: c.Status, StartedAt: c.StartedAt, FinishedAt: c.FinishedAt, ErrorAt: c.ErrorAt, Log: c.Log,
+		})
+	}
+	return children
+}
+
+// Start is the note's first start time, 0 for a note without timing.
+func (tn TransferNote) Start() float64 {
+	if len(tn.Timings) == 0 {
+		return 0
+	}
+	return tn.Timings[0].Start
+}
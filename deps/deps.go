@@ -10,38 +10,84 @@ const (
 	FfmpegInstallURL = "https://ffmpeg.org/download.html"
 )
 
+// Binary paths set in config.json; empty means look the binary up in PATH
+var (
+	mpvPath     string
+	ffmpegPath  string
+	ffprobePath string
+)
+
+// SetPaths sets explicit mpv, ffmpeg and ffprobe binaries. Empty paths keep
+// looking the binary up in PATH.
+func SetPaths(mpv, ffmpeg, ffprobe string) {
+	mpvPath, ffmpegPath, ffprobePath = mpv, ffmpeg, ffprobe
+}
+
+// Mpv returns the mpv binary to run.
+func Mpv() string {
+	if mpvPath != "" {
+		return mpvPath
+	}
+	return "mpv"
+}
+
+// Ffmpeg returns the ffmpeg binary to run.
+func Ffmpeg() string {
+	if ffmpegPath != "" {
+		return ffmpegPath
+	}
+	return "ffmpeg"
+}
+
+// Ffprobe returns the ffprobe binary to run.
+func Ffprobe() string {
+	if ffprobePath != "" {
+		return ffprobePath
+	}
+	return "ffprobe"
+}
+
 // DependencyError contains information about a missing dependency
 type DependencyError struct {
 	Name       string
 	InstallURL string
+	// Path is the configured binary that was not found, empty when PATH was searched
+	Path string
 }
 
 func (e *DependencyError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("%s not found at %s (set in config.json \"binaries\"). Fix the path or remove it to use PATH", e.Name, e.Path)
+	}
 	return fmt.Sprintf("%s not found. Install from: %s", e.Name, e.InstallURL)
 }
 
-// CheckMpv checks if mpv is installed and available in PATH
+// CheckMpv checks if mpv is installed and available in PATH, or at the
+// configured path
 func CheckMpv() error {
-	_, err := exec.LookPath("mpv")
+	_, err := exec.LookPath(Mpv())
 	if err != nil {
 		return &DependencyError{
 			Name:       "mpv",
 			InstallURL: MpvInstallURL,
+			Path:       mpvPath,
 		}
 	}
 	return nil
 }
 
-// CheckFfmpeg checks if ffmpeg is installed and available in PATH
+// CheckFfmpeg checks if ffmpeg is installed and available in PATH, or at the
+// configured path, and is at least MinFfmpegVersion
 func CheckFfmpeg() error {
-	_, err := exec.LookPath("ffmpeg")
+	_, err := exec.LookPath(Ffmpeg())
 	if err != nil {
 		return &DependencyError{
 			Name:       "ffmpeg",
 			InstallURL: FfmpegInstallURL,
+			Path:       ffmpegPath,
 		}
 	}
-	return nil
+	return checkFfmpegVersion()
 }
 
 // CheckAll checks all dependencies and returns a slice of errors for missing ones
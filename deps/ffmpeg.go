@@ -0,0 +1,133 @@
+package deps
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MinFfmpegVersion is the oldest ffmpeg release known to handle the clip and
+// analysis commands.
+const MinFfmpegVersion = "4.0"
+
+// releaseVersion matches a release number such as "6.1.1" or "n4.4" at the
+// start of ffmpeg's version string. Git builds ("N-112233-gabc") don't match
+// and are assumed to be recent.
+var releaseVersion = regexp.MustCompile(`^n?(\d+)\.(\d+)`)
+
+// FfmpegVersion returns the version string ffmpeg reports, e.g. "6.1.1-3ubuntu5".
+func FfmpegVersion() (string, error) {
+	out, err := exec.Command(Ffmpeg(), "-hide_banner", "-version").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s -version: %w", Ffmpeg(), err)
+	}
+	line, _, _ := strings.Cut(string(out), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 3 || fields[0] != "ffmpeg" || fields[1] != "version" {
+		return "", fmt.Errorf("unrecognised ffmpeg version output: %q", line)
+	}
+	return fields[2], nil
+}
+
+// checkFfmpegVersion returns an error when ffmpeg is older than MinFfmpegVersion.
+func checkFfmpegVersion() error {
+	version, err := FfmpegVersion()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) || errors.Is(err, exec.ErrNotFound) {
+			return err
+		}
+		// An unusual version banner can't be gated on
+		return nil
+	}
+	if !versionAtLeast(version, MinFfmpegVersion) {
+		return fmt.Errorf("ffmpeg %s is too old; %s or newer is needed. Install from: %s", version, MinFfmpegVersion, FfmpegInstallURL)
+	}
+	return nil
+}
+
+// versionAtLeast reports whether version is min or newer, comparing major and
+// minor release numbers. Versions that aren't release numbers pass.
+func versionAtLeast(version, min string) bool {
+	v := releaseVersion.FindStringSubmatch(version)
+	m := releaseVersion.FindStringSubmatch(min)
+	if v == nil || m == nil {
+		return true
+	}
+	vMajor, _ := strconv.Atoi(v[1])
+	vMinor, _ := strconv.Atoi(v[2])
+	mMajor, _ := strconv.Atoi(m[1])
+	mMinor, _ := strconv.Atoi(m[2])
+	return vMajor > mMajor || vMajor == mMajor && vMinor >= mMinor
+}
+
+// CheckFfmpegEncoders returns an error naming the encoders (e.g. "libx264")
+// this ffmpeg build lacks; need says what they are needed for.
+func CheckFfmpegEncoders(need string, encoders ...string) error {
+	return checkFfmpegList("-encoders", "encoder", need, encoders)
+}
+
+// CheckFfmpegFilters returns an error naming the filters (e.g. "drawtext")
+// this ffmpeg build lacks; need says what they are needed for.
+func CheckFfmpegFilters(need string, filters ...string) error {
+	return checkFfmpegList("-filters", "filter", need, filters)
+}
+
+// MissingFfmpegEncoders returns the encoders this ffmpeg build lacks.
+func MissingFfmpegEncoders(encoders ...string) ([]string, error) {
+	return missingFfmpegList("-encoders", encoders)
+}
+
+// MissingFfmpegFilters returns the filters this ffmpeg build lacks.
+func MissingFfmpegFilters(filters ...string) ([]string, error) {
+	return missingFfmpegList("-filters", filters)
+}
+
+func checkFfmpegList(flag, kind, need string, names []string) error {
+	missing, err := missingFfmpegList(flag, names)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	s := ""
+	if len(missing) > 1 {
+		s = "s"
+	}
+	name := "ffmpeg"
+	if ffmpegPath != "" {
+		name = "ffmpeg at " + ffmpegPath
+	}
+	return fmt.Errorf("%s lacks the %s %s%s needed for %s. Install a full build from %s or set \"binaries\".\"ffmpeg\" in config.json",
+		name, strings.Join(missing, ", "), kind, s, need, FfmpegInstallURL)
+}
+
+// missingFfmpegList lists ffmpeg's encoders or filters and returns the names
+// not among them. Both listings put the name in the second column.
+func missingFfmpegList(flag string, names []string) ([]string, error) {
+	out, err := exec.Command(Ffmpeg(), "-hide_banner", flag).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s %s: %w", Ffmpeg(), flag, err)
+	}
+	available := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 {
+			available[fields[1]] = true
+		}
+	}
+	var missing []string
+	for _, name := range names {
+		if !available[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,249 @@
+// Package importer converts event markers exported by cloud tagging platforms
+// (Veo, Hudl) into a neutral Marker list that can be stored as notes.
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+)
+
+// Marker is a single event marker read from an export file.
+type Marker struct {
+	Start  float64
+	End    float64
+	Label  string
+	Team   string
+	Player string
+	Note   string
+}
+
+// Source describes how to read one platform's export: the accepted column/key
+// names for each field, matched case-insensitively.
+type Source struct {
+	Name     string
+	Start    []string
+	End      []string
+	Duration []string
+	Label    []string
+	Team     []string
+	Player   []string
+	Note     []string
+	// MillisKeys are start/end/duration keys whose values are in milliseconds
+	MillisKeys []string
+}
+
+// Parse reads markers from r. JSON (an array, or an object wrapping an array) and
+// CSV with a header row are both accepted; the format is detected from the content.
+func Parse(src Source, r io.Reader) ([]Marker, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read export: %w", err)
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("export file is empty")
+	}
+
+	var records []map[string]string
+	if trimmed[0] == '[' || trimmed[0] == '{' {
+		records, err = jsonRecords(trimmed)
+	} else {
+		records, err = csvRecords(trimmed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var markers []Marker
+	for i, rec := range records {
+		m, ok, err := src.marker(rec)
+		if err != nil {
+			return nil, fmt.Errorf("%s record %d: %w", src.Name, i+1, err)
+		}
+		if ok {
+			markers = append(markers, m)
+		}
+	}
+	return markers, nil
+}
+
+// marker builds a Marker from a record. ok is false for records with no start time.
+func (src Source) marker(rec map[string]string) (Marker, bool, error) {
+	startKey, startStr := lookup(rec, src.Start)
+	if startStr == "" {
+		return Marker{}, false, nil
+	}
+	start, err := src.parseTime(startKey, startStr)
+	if err != nil {
+		return Marker{}, false, err
+	}
+
+	m := Marker{Start: start, End: start}
+	if key, v := lookup(rec, src.End); v != "" {
+		end, err := src.parseTime(key, v)
+		if err != nil {
+			return Marker{}, false, err
+		}
+		m.End = end
+	} else if key, v := lookup(rec, src.Duration); v != "" {
+		d, err := src.parseTime(key, v)
+		if err != nil {
+			return Marker{}, false, err
+		}
+		m.End = start + d
+	}
+	if m.End < m.Start {
+		m.End = m.Start
+	}
+
+	_, m.Label = lookup(rec, src.Label)
+	_, m.Team = lookup(rec, src.Team)
+	_, m.Player = lookup(rec, src.Player)
+	_, m.Note = lookup(rec, src.Note)
+	return m, true, nil
+}
+
+// parseTime parses a timestamp value as seconds, H:MM:SS or MM:SS.
+// Values under a milliseconds key are divided by 1000.
+func (src Source) parseTime(key, value string) (float64, error) {
+	for _, k := range src.MillisKeys {
+		if key == k {
+			ms, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s: %q", key, value)
+			}
+			return ms / 1000, nil
+		}
+	}
+	secs, err := timeutil.ParseTimeToSeconds(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return secs, nil
+}
+
+// lookup returns the first non-empty value for any of the keys, with the matching key.
+func lookup(rec map[string]string, keys []string) (string, string) {
+	for _, k := range keys {
+		if v := strings.TrimSpace(rec[k]); v != "" {
+			return k, v
+		}
+	}
+	return "", ""
+}
+
+// normalizeKey lowercases a column/key name and folds separators to single spaces.
+func normalizeKey(k string) string {
+	k = strings.ToLower(strings.TrimSpace(k))
+	k = strings.NewReplacer("_", " ", "-", " ").Replace(k)
+	return strings.Join(strings.Fields(k), " ")
+}
+
+// markerArrayKeys are the keys exports wrap their marker array under, in the
+// order they are looked for.
+var markerArrayKeys = []string{"events", "highlights", "markers", "clips"}
+
+// jsonRecords flattens a JSON export into string records.
+// Objects wrapping the marker array are unwrapped: the array under one of
+// markerArrayKeys, or else the object's only array.
+func jsonRecords(data []byte) ([]map[string]string, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse JSON: %w", err)
+	}
+
+	items, ok := raw.([]interface{})
+	if !ok {
+		var err error
+		if items, err = markerArray(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	var records []map[string]string
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rec := make(map[string]string)
+		for k, v := range obj {
+			switch val := v.(type) {
+			case string:
+				rec[normalizeKey(k)] = val
+			case float64:
+				rec[normalizeKey(k)] = strconv.FormatFloat(val, 'f', -1, 64)
+			case map[string]interface{}:
+				// Nested objects such as {"team": {"name": "Home"}}
+				if name, ok := val["name"].(string); ok {
+					rec[normalizeKey(k)] = name
+				}
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// markerArray returns the marker array of a JSON export wrapped in an object.
+func markerArray(raw interface{}) ([]interface{}, error) {
+	obj, _ := raw.(map[string]interface{})
+	for _, key := range markerArrayKeys {
+		if arr, ok := obj[key].([]interface{}); ok {
+			return arr, nil
+		}
+	}
+
+	var keys []string
+	for k, v := range obj {
+		if _, ok := v.([]interface{}); ok {
+			keys = append(keys, k)
+		}
+	}
+	switch len(keys) {
+	case 0:
+		return nil, fmt.Errorf("no marker array found in JSON export")
+	case 1:
+		return obj[keys[0]].([]interface{}), nil
+	}
+	sort.Strings(keys)
+	return nil, fmt.Errorf("JSON export has several arrays (%s): can't tell which holds the markers, expected events, highlights, markers or clips", strings.Join(keys, ", "))
+}
+
+// csvRecords reads a CSV export with a header row into string records.
+func csvRecords(data []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse CSV: %w", err)
+	}
+	if len(rows) < 2 {
+		return nil, nil
+	}
+
+	header := make([]string, len(rows[0]))
+	for i, h := range rows[0] {
+		header[i] = normalizeKey(strings.TrimPrefix(h, "\ufeff"))
+	}
+
+	var records []map[string]string
+	for _, row := range rows[1:] {
+		rec := make(map[string]string)
+		for i, v := range row {
+			if i < len(header) {
+				rec[header[i]] = v
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
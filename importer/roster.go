@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RosterPlayer is one player read from a club roster.
+type RosterPlayer struct {
+	Name string
+	// Number is the squad number, 0 when the roster has none
+	Number   int
+	Position string
+	Team     string
+}
+
+// Accepted roster column names, matched case-insensitively. A roster without a
+// name column may split it into first and last names instead.
+var (
+	rosterName     = []string{"name", "player", "player name", "full name"}
+	rosterFirst    = []string{"first name", "firstname", "forename", "given name"}
+	rosterLast     = []string{"last name", "lastname", "surname", "family name"}
+	rosterNumber   = []string{"number", "no", "no.", "#", "shirt", "shirt number", "squad number", "jersey"}
+	rosterPosition = []string{"position", "pos", "positions"}
+	rosterTeam     = []string{"team", "squad", "side"}
+)
+
+// ParseRoster reads a roster CSV with a header row, such as a club's
+// registration spreadsheet saved as CSV. Columns other than the name, number,
+// position and team are ignored, as are rows without a name.
+func ParseRoster(r io.Reader) ([]RosterPlayer, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read roster: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("roster file is empty")
+	}
+	records, err := csvRecords(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var players []RosterPlayer
+	for i, rec := range records {
+		_, name := lookup(rec, rosterName)
+		if name == "" {
+			_, first := lookup(rec, rosterFirst)
+			_, last := lookup(rec, rosterLast)
+			name = strings.TrimSpace(first + " " + last)
+		}
+		if name == "" {
+			continue
+		}
+		p := RosterPlayer{Name: strings.Join(strings.Fields(name), " ")}
+		if key, v := lookup(rec, rosterNumber); v != "" {
+			n, err := strconv.Atoi(strings.TrimPrefix(v, "#"))
+			if err != nil || n < 1 || n > 99 {
+				return nil, fmt.Errorf("roster row %d: invalid %s: %q (want 1-99)", i+2, key, v)
+			}
+			p.Number = n
+		}
+		_, p.Position = lookup(rec, rosterPosition)
+		_, p.Team = lookup(rec, rosterTeam)
+		players = append(players, p)
+	}
+	if len(players) == 0 {
+		return nil, fmt.Errorf("no players found: the roster needs a header row with a name column (or first and last name)")
+	}
+	return players, nil
+}
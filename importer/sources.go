@@ -0,0 +1,33 @@
+package importer
+
+// Veo reads Veo highlight exports (JSON or CSV).
+var Veo = Source{
+	Name:       "veo",
+	Start:      []string{"start", "start time", "start seconds", "timestamp", "time", "offset", "start ms"},
+	End:        []string{"end", "end time", "end seconds", "stop", "end ms"},
+	Duration:   []string{"duration", "duration ms"},
+	Label:      []string{"tag", "type", "event", "event type", "name", "label", "title"},
+	Team:       []string{"team", "team name"},
+	Player:     []string{"player", "player name"},
+	Note:       []string{"comment", "description", "note", "notes"},
+	MillisKeys: []string{"start ms", "end ms", "duration ms"},
+}
+
+// Hudl reads Hudl (and Sportscode-style) clip exports (JSON or CSV).
+var Hudl = Source{
+	Name:       "hudl",
+	Start:      []string{"clip start", "start", "start time", "in", "timestamp", "time"},
+	End:        []string{"clip end", "end", "end time", "out"},
+	Duration:   []string{"duration", "clip duration"},
+	Label:      []string{"code", "row", "play type", "label", "name", "title"},
+	Team:       []string{"team", "odk"},
+	Player:     []string{"player", "players", "jersey"},
+	Note:       []string{"notes", "note", "comment", "comments", "description"},
+	MillisKeys: nil,
+}
+
+// Sources lists the supported import sources by name.
+var Sources = map[string]Source{
+	Veo.Name:  Veo,
+	Hudl.Name: Hudl,
+}
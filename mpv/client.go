@@ -22,8 +22,24 @@ var (
 	ErrSocketNotFound = errors.New("mpv: socket not found - is mpv running with --input-ipc-server?")
 	// requestID is a global counter for generating unique request IDs.
 	requestID uint64
+	// socketOverride replaces DefaultSocketPath when set ("mpv_socket" in config.json).
+	socketOverride string
 )
 
+// SetSocketPath sets the IPC socket mpv is launched with and clients connect
+// to. An empty path restores DefaultSocketPath.
+func SetSocketPath(path string) {
+	socketOverride = path
+}
+
+// SocketPath returns the IPC socket mpv is launched with and clients connect to.
+func SocketPath() string {
+	if socketOverride != "" {
+		return socketOverride
+	}
+	return DefaultSocketPath
+}
+
 // ipcRequest represents a JSON IPC request to mpv.
 type ipcRequest struct {
 	Command   []interface{} `json:"command"`
@@ -46,10 +62,10 @@ type Client struct {
 }
 
 // NewClient creates a new mpv IPC client.
-// If socketPath is empty, DefaultSocketPath is used.
+// If socketPath is empty, SocketPath() is used.
 func NewClient(socketPath string) *Client {
 	if socketPath == "" {
-		socketPath = DefaultSocketPath
+		socketPath = SocketPath()
 	}
 	return &Client{
 		socketPath: socketPath,
@@ -199,6 +215,16 @@ func (c *Client) GetSpeed() (float64, error) {
 	return toFloat64(result)
 }
 
+// SetPlayDirection sets the playback direction. Backward playback is
+// supported by mpv 0.33 and later and is slower to start than forward.
+func (c *Client) SetPlayDirection(backward bool) error {
+	direction := "forward"
+	if backward {
+		direction = "backward"
+	}
+	return c.SetProperty("play-direction", direction)
+}
+
 // FrameStep advances playback by one frame and pauses.
 func (c *Client) FrameStep() error {
 	_, err := c.sendCommand("frame-step")
@@ -229,6 +255,27 @@ func (c *Client) GetMute() (bool, error) {
 	return muted, nil
 }
 
+// LoadFile replaces the playing file with path, starting at start seconds.
+// Pause state, speed and mute carry over to the new file.
+func (c *Client) LoadFile(path string, start float64) error {
+	// The start option applies to the next file loaded
+	if err := c.SetProperty("start", fmt.Sprintf("%.3f", start)); err != nil {
+		return err
+	}
+	_, err := c.sendCommand("loadfile", path, "replace")
+	return err
+}
+
+// SetChapters replaces the chapter list of the playing file, so mpv's own
+// chapter keys (PgUp/PgDn) and OSC navigate them.
+func (c *Client) SetChapters(chapters []Chapter) error {
+	list := make([]map[string]interface{}, len(chapters))
+	for i, ch := range chapters {
+		list[i] = map[string]interface{}{"title": ch.Title, "time": ch.Time}
+	}
+	return c.SetProperty("chapter-list", list)
+}
+
 // SetABLoop sets the A-B loop points for looping playback between start and end times.
 // Both start and end are in seconds.
 func (c *Client) SetABLoop(start, end float64) error {
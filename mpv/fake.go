@@ -0,0 +1,283 @@
+package mpv
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is an in-process Player that keeps playback state without mpv, for
+// tests and for tagging offline. It records every control call in Calls.
+//
+// With Clock nil the position only moves on seeks, frame steps and Advance,
+// so tests are deterministic. With Clock set (e.g. time.Now) the position
+// advances in real time while playing.
+type Fake struct {
+	mu sync.Mutex
+
+	TimePos   float64
+	Duration  float64
+	Paused    bool
+	Speed     float64
+	Muted     bool
+	Backward  bool
+	Overlay   string
+	LoopStart float64
+	LoopEnd   float64
+	// Path is the file last passed to LoadFile
+	Path string
+	// Chapters is the chapter list last set
+	Chapters []Chapter
+
+	// FrameDuration is the step used by FrameStep/FrameBackStep
+	FrameDuration float64
+	// Clock, when set, drives playback in real time
+	Clock func() time.Time
+	// Calls lists the control calls made, in order
+	Calls []Call
+
+	lastTick time.Time
+}
+
+// NewFake returns a paused player at 0:00 for a video of duration seconds
+// (0 for unknown, which leaves seeking unbounded).
+func NewFake(duration float64) *Fake {
+	return &Fake{Duration: duration, Paused: true, Speed: 1, FrameDuration: 1.0 / 25}
+}
+
+// sync advances the position by the wall time since the last call when a
+// Clock is set and the player is playing. Callers hold mu.
+func (f *Fake) sync() {
+	if f.Clock == nil {
+		return
+	}
+	now := f.Clock()
+	if !f.lastTick.IsZero() && !f.Paused {
+		f.move(now.Sub(f.lastTick).Seconds())
+	}
+	f.lastTick = now
+}
+
+// move plays for seconds of wall time at the current speed and direction.
+// Callers hold mu.
+func (f *Fake) move(seconds float64) {
+	delta := seconds * f.Speed
+	if f.Backward {
+		delta = -delta
+	}
+	f.seekTo(f.TimePos + delta)
+}
+
+// seekTo clamps t to the video and moves there. Callers hold mu.
+func (f *Fake) seekTo(t float64) {
+	if t < 0 {
+		t = 0
+	}
+	if f.Duration > 0 && t > f.Duration {
+		t = f.Duration
+	}
+	f.TimePos = t
+}
+
+// record syncs the clock and appends a call. Callers hold mu.
+func (f *Fake) record(method string, args ...float64) {
+	f.sync()
+	f.Calls = append(f.Calls, Call{Pos: f.TimePos, Method: method, Args: args})
+}
+
+// Advance plays for seconds as if that much time had passed, at the current
+// speed and direction. It does nothing while paused.
+func (f *Fake) Advance(seconds float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sync()
+	if !f.Paused {
+		f.move(seconds)
+	}
+}
+
+// IsConnected always reports true.
+func (f *Fake) IsConnected() bool { return true }
+
+// GetTimePos returns the playback position.
+func (f *Fake) GetTimePos() (float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sync()
+	return f.TimePos, nil
+}
+
+// GetDuration returns the video length.
+func (f *Fake) GetDuration() (float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.Duration, nil
+}
+
+// GetPaused returns the pause state.
+func (f *Fake) GetPaused() (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.Paused, nil
+}
+
+// GetSpeed returns the playback speed.
+func (f *Fake) GetSpeed() (float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.Speed, nil
+}
+
+// GetMute returns the mute state.
+func (f *Fake) GetMute() (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.Muted, nil
+}
+
+// Play resumes playback.
+func (f *Fake) Play() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("Play")
+	f.Paused = false
+	return nil
+}
+
+// Pause pauses playback.
+func (f *Fake) Pause() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("Pause")
+	f.Paused = true
+	return nil
+}
+
+// TogglePause toggles between playing and paused.
+func (f *Fake) TogglePause() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("TogglePause")
+	f.Paused = !f.Paused
+	return nil
+}
+
+// Seek moves to an absolute position.
+func (f *Fake) Seek(seconds float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("Seek", seconds)
+	f.seekTo(seconds)
+	return nil
+}
+
+// SeekRelative moves by seconds from the current position.
+func (f *Fake) SeekRelative(seconds float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("SeekRelative", seconds)
+	f.seekTo(f.TimePos + seconds)
+	return nil
+}
+
+// SetSpeed sets the playback speed.
+func (f *Fake) SetSpeed(multiplier float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("SetSpeed", multiplier)
+	f.Speed = multiplier
+	return nil
+}
+
+// SetPlayDirection sets forward or backward playback.
+func (f *Fake) SetPlayDirection(backward bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("SetPlayDirection", boolArg(backward))
+	f.Backward = backward
+	return nil
+}
+
+// FrameStep pauses and steps one frame forward.
+func (f *Fake) FrameStep() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("FrameStep")
+	f.Paused = true
+	f.seekTo(f.TimePos + f.FrameDuration)
+	return nil
+}
+
+// FrameBackStep pauses and steps one frame backward.
+func (f *Fake) FrameBackStep() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("FrameBackStep")
+	f.Paused = true
+	f.seekTo(f.TimePos - f.FrameDuration)
+	return nil
+}
+
+// SetMute sets the mute state.
+func (f *Fake) SetMute(muted bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("SetMute", boolArg(muted))
+	f.Muted = muted
+	return nil
+}
+
+// LoadFile switches to path at start seconds, keeping the duration.
+func (f *Fake) LoadFile(path string, start float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("LoadFile", start)
+	f.Calls[len(f.Calls)-1].Text = path
+	f.Path = path
+	f.seekTo(start)
+	return nil
+}
+
+// SetChapters keeps the chapter list.
+func (f *Fake) SetChapters(chapters []Chapter) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	title, times := chapterArgs(chapters)
+	f.record("SetChapters", times...)
+	f.Calls[len(f.Calls)-1].Text = title
+	f.Chapters = append([]Chapter(nil), chapters...)
+	return nil
+}
+
+// SetABLoop sets the A-B loop points.
+func (f *Fake) SetABLoop(start, end float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("SetABLoop", start, end)
+	f.LoopStart, f.LoopEnd = start, end
+	return nil
+}
+
+// ClearABLoop clears the A-B loop points.
+func (f *Fake) ClearABLoop() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("ClearABLoop")
+	f.LoopStart, f.LoopEnd = 0, 0
+	return nil
+}
+
+// ShowOverlay keeps the overlay text. Overlay refreshes are not recorded.
+func (f *Fake) ShowOverlay(overlayID int, text string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Overlay = text
+	return nil
+}
+
+// HideOverlay clears the overlay text.
+func (f *Fake) HideOverlay(overlayID int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Overlay = ""
+	return nil
+}
@@ -16,8 +16,8 @@ func LaunchMpv(videoPath string) (*exec.Cmd, error) {
 	}
 
 	// Launch mpv with IPC socket flag
-	cmd := exec.Command("mpv",
-		"--input-ipc-server="+DefaultSocketPath,
+	cmd := exec.Command(deps.Mpv(),
+		"--input-ipc-server="+SocketPath(),
 		videoPath,
 	)
 
@@ -0,0 +1,36 @@
+package mpv
+
+// OffsetPlayer wraps a Player showing a file whose timeline runs Offset seconds
+// ahead of the reference video (e.g. a second camera angle that started
+// recording earlier). Positions read and written through it are in reference
+// time, so notes, seeks and loops line up whichever file is playing.
+type OffsetPlayer struct {
+	Player
+	// Offset is added to reference time to get the playing file's time
+	Offset float64
+}
+
+// GetTimePos returns the position in reference time.
+func (o *OffsetPlayer) GetTimePos() (float64, error) {
+	pos, err := o.Player.GetTimePos()
+	return pos - o.Offset, err
+}
+
+// Seek seeks to a position given in reference time.
+func (o *OffsetPlayer) Seek(seconds float64) error {
+	return o.Player.Seek(seconds + o.Offset)
+}
+
+// SetABLoop sets loop points given in reference time.
+func (o *OffsetPlayer) SetABLoop(start, end float64) error {
+	return o.Player.SetABLoop(start+o.Offset, end+o.Offset)
+}
+
+// SetChapters sets chapters given in reference time.
+func (o *OffsetPlayer) SetChapters(chapters []Chapter) error {
+	shifted := make([]Chapter, len(chapters))
+	for i, ch := range chapters {
+		shifted[i] = Chapter{Title: ch.Title, Time: ch.Time + o.Offset}
+	}
+	return o.Player.SetChapters(shifted)
+}
@@ -0,0 +1,41 @@
+package mpv
+
+// Player is the set of mpv controls the TUI uses. *Client implements it over
+// IPC; Fake implements it in process for tests and offline tagging, and
+// Recorder wraps any Player to log the calls made to it.
+type Player interface {
+	IsConnected() bool
+
+	GetTimePos() (float64, error)
+	GetDuration() (float64, error)
+	GetPaused() (bool, error)
+	GetSpeed() (float64, error)
+	GetMute() (bool, error)
+
+	Play() error
+	Pause() error
+	TogglePause() error
+	Seek(seconds float64) error
+	SeekRelative(seconds float64) error
+	SetSpeed(multiplier float64) error
+	SetPlayDirection(backward bool) error
+	FrameStep() error
+	FrameBackStep() error
+	SetMute(muted bool) error
+	LoadFile(path string, start float64) error
+	SetChapters(chapters []Chapter) error
+
+	SetABLoop(start, end float64) error
+	ClearABLoop() error
+	ShowOverlay(overlayID int, text string) error
+	HideOverlay(overlayID int) error
+}
+
+// Chapter is a named point in the timeline, navigable with mpv's chapter keys.
+type Chapter struct {
+	Title string
+	Time  float64
+}
+
+// Compile-time check that the IPC client satisfies Player.
+var _ Player = (*Client)(nil)
@@ -0,0 +1,264 @@
+package mpv
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Call is one control call made to a Player, e.g. {Method: "Seek", Args: [125]}.
+// Getters are not recorded.
+type Call struct {
+	// Pos is the playback position when the call was made
+	Pos    float64   `json:"pos"`
+	Method string    `json:"method"`
+	Args   []float64 `json:"args,omitempty"`
+	// Text is the overlay text for ShowOverlay, the path for LoadFile, or the
+	// newline-separated titles for SetChapters (whose Args are the times)
+	Text string `json:"text,omitempty"`
+}
+
+// String formats the call as "Seek 125".
+func (c Call) String() string {
+	s := c.Method
+	for _, a := range c.Args {
+		s += fmt.Sprintf(" %g", a)
+	}
+	return s
+}
+
+// Recorder wraps a Player and records every control call made through it,
+// optionally streaming them to W as JSON lines (see ReadCalls).
+type Recorder struct {
+	Player
+	// W receives each call as a JSON line (nil to only keep Calls)
+	W io.Writer
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewRecorder returns a recorder around p that writes calls to w (may be nil).
+func NewRecorder(p Player, w io.Writer) *Recorder {
+	return &Recorder{Player: p, W: w}
+}
+
+// Calls returns the calls recorded so far.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+func (r *Recorder) record(method string, text string, args ...float64) {
+	pos, _ := r.Player.GetTimePos()
+	c := Call{Pos: pos, Method: method, Args: args, Text: text}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, c)
+	if r.W != nil {
+		if data, err := json.Marshal(c); err == nil {
+			r.W.Write(append(data, '\n'))
+		}
+	}
+}
+
+// chapterArgs flattens chapters into a Call's Text and Args.
+func chapterArgs(chapters []Chapter) (string, []float64) {
+	titles := make([]string, len(chapters))
+	times := make([]float64, len(chapters))
+	for i, ch := range chapters {
+		titles[i] = ch.Title
+		times[i] = ch.Time
+	}
+	return strings.Join(titles, "\n"), times
+}
+
+func boolArg(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Play records and forwards the call.
+func (r *Recorder) Play() error { r.record("Play", ""); return r.Player.Play() }
+
+// Pause records and forwards the call.
+func (r *Recorder) Pause() error { r.record("Pause", ""); return r.Player.Pause() }
+
+// TogglePause records and forwards the call.
+func (r *Recorder) TogglePause() error { r.record("TogglePause", ""); return r.Player.TogglePause() }
+
+// Seek records and forwards the call.
+func (r *Recorder) Seek(seconds float64) error {
+	r.record("Seek", "", seconds)
+	return r.Player.Seek(seconds)
+}
+
+// SeekRelative records and forwards the call.
+func (r *Recorder) SeekRelative(seconds float64) error {
+	r.record("SeekRelative", "", seconds)
+	return r.Player.SeekRelative(seconds)
+}
+
+// SetSpeed records and forwards the call.
+func (r *Recorder) SetSpeed(multiplier float64) error {
+	r.record("SetSpeed", "", multiplier)
+	return r.Player.SetSpeed(multiplier)
+}
+
+// SetPlayDirection records and forwards the call.
+func (r *Recorder) SetPlayDirection(backward bool) error {
+	r.record("SetPlayDirection", "", boolArg(backward))
+	return r.Player.SetPlayDirection(backward)
+}
+
+// FrameStep records and forwards the call.
+func (r *Recorder) FrameStep() error { r.record("FrameStep", ""); return r.Player.FrameStep() }
+
+// FrameBackStep records and forwards the call.
+func (r *Recorder) FrameBackStep() error {
+	r.record("FrameBackStep", "")
+	return r.Player.FrameBackStep()
+}
+
+// SetMute records and forwards the call.
+func (r *Recorder) SetMute(muted bool) error {
+	r.record("SetMute", "", boolArg(muted))
+	return r.Player.SetMute(muted)
+}
+
+// LoadFile records and forwards the call.
+func (r *Recorder) LoadFile(path string, start float64) error {
+	r.record("LoadFile", path, start)
+	return r.Player.LoadFile(path, start)
+}
+
+// SetChapters records and forwards the call.
+func (r *Recorder) SetChapters(chapters []Chapter) error {
+	title, times := chapterArgs(chapters)
+	r.record("SetChapters", title, times...)
+	return r.Player.SetChapters(chapters)
+}
+
+// SetABLoop records and forwards the call.
+func (r *Recorder) SetABLoop(start, end float64) error {
+	r.record("SetABLoop", "", start, end)
+	return r.Player.SetABLoop(start, end)
+}
+
+// ClearABLoop records and forwards the call.
+func (r *Recorder) ClearABLoop() error { r.record("ClearABLoop", ""); return r.Player.ClearABLoop() }
+
+// ShowOverlay records and forwards the call.
+func (r *Recorder) ShowOverlay(overlayID int, text string) error {
+	r.record("ShowOverlay", text, float64(overlayID))
+	return r.Player.ShowOverlay(overlayID, text)
+}
+
+// HideOverlay records and forwards the call.
+func (r *Recorder) HideOverlay(overlayID int) error {
+	r.record("HideOverlay", "", float64(overlayID))
+	return r.Player.HideOverlay(overlayID)
+}
+
+// ReadCalls reads calls written as JSON lines by a Recorder.
+func ReadCalls(rd io.Reader) ([]Call, error) {
+	var calls []Call
+	sc := bufio.NewScanner(rd)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var c Call
+		if err := json.Unmarshal(sc.Bytes(), &c); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		calls = append(calls, c)
+	}
+	return calls, sc.Err()
+}
+
+// Replay re-issues recorded calls against p in order, e.g. to rebuild the end
+// state of a session on a Fake or to repeat it on a real player.
+func Replay(p Player, calls []Call) error {
+	arg := func(c Call, i int) (float64, error) {
+		if i >= len(c.Args) {
+			return 0, fmt.Errorf("%s: missing argument %d", c.Method, i+1)
+		}
+		return c.Args[i], nil
+	}
+	for _, c := range calls {
+		var err error
+		switch c.Method {
+		case "Play":
+			err = p.Play()
+		case "Pause":
+			err = p.Pause()
+		case "TogglePause":
+			err = p.TogglePause()
+		case "FrameStep":
+			err = p.FrameStep()
+		case "FrameBackStep":
+			err = p.FrameBackStep()
+		case "ClearABLoop":
+			err = p.ClearABLoop()
+		case "Seek", "SeekRelative", "SetSpeed", "SetPlayDirection", "SetMute", "ShowOverlay", "HideOverlay", "LoadFile":
+			var a float64
+			if a, err = arg(c, 0); err != nil {
+				return err
+			}
+			switch c.Method {
+			case "Seek":
+				err = p.Seek(a)
+			case "SeekRelative":
+				err = p.SeekRelative(a)
+			case "SetSpeed":
+				err = p.SetSpeed(a)
+			case "SetPlayDirection":
+				err = p.SetPlayDirection(a != 0)
+			case "SetMute":
+				err = p.SetMute(a != 0)
+			case "LoadFile":
+				err = p.LoadFile(c.Text, a)
+			case "ShowOverlay":
+				err = p.ShowOverlay(int(a), c.Text)
+			case "HideOverlay":
+				err = p.HideOverlay(int(a))
+			}
+		case "SetChapters":
+			var titles []string
+			if c.Text != "" || len(c.Args) > 0 {
+				titles = strings.Split(c.Text, "\n")
+			}
+			if len(titles) != len(c.Args) {
+				return fmt.Errorf("%s: %d titles for %d times", c.Method, len(titles), len(c.Args))
+			}
+			chapters := make([]Chapter, len(titles))
+			for i := range titles {
+				chapters[i] = Chapter{Title: titles[i], Time: c.Args[i]}
+			}
+			err = p.SetChapters(chapters)
+		case "SetABLoop":
+			var start, end float64
+			if start, err = arg(c, 0); err != nil {
+				return err
+			}
+			if end, err = arg(c, 1); err != nil {
+				return err
+			}
+			err = p.SetABLoop(start, end)
+		default:
+			return fmt.Errorf("unknown call: %s", c.Method)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", c, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package catpath handles hierarchical note categories written as paths,
+// e.g. "set-piece/lineout/steal", so events can be counted and filtered at
+// any level of the path.
+package catpath
+
+import (
+	"sort"
+	"strings"
+)
+
+// Separator divides the levels of a category path.
+const Separator = "/"
+
+// Normalize tidies a category path as typed: spaces around levels and empty
+// levels are dropped, so " set-piece / lineout/" becomes "set-piece/lineout".
+func Normalize(path string) string {
+	var levels []string
+	for _, level := range strings.Split(path, Separator) {
+		if level = strings.TrimSpace(level); level != "" {
+			levels = append(levels, level)
+		}
+	}
+	return strings.Join(levels, Separator)
+}
+
+// Levels returns the levels of path, e.g. ["set-piece", "lineout", "steal"].
+func Levels(path string) []string {
+	path = Normalize(path)
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, Separator)
+}
+
+// Depth returns the number of levels in path: 0 for "", 1 for "try".
+func Depth(path string) int {
+	return len(Levels(path))
+}
+
+// Truncate rolls path up to its first depth levels: Truncate("set-piece/lineout/steal", 1)
+// is "set-piece". A depth of 0 or less, or beyond the path, returns the whole path.
+func Truncate(path string, depth int) string {
+	levels := Levels(path)
+	if depth > 0 && depth < len(levels) {
+		levels = levels[:depth]
+	}
+	return strings.Join(levels, Separator)
+}
+
+// Under reports whether path is parent or one of its subcategories, in any
+// case: "set-piece/lineout" is under "set-piece" but "set-pieces" is not.
+func Under(path, parent string) bool {
+	path, parent = strings.ToLower(Normalize(path)), strings.ToLower(Normalize(parent))
+	if parent == "" {
+		return true
+	}
+	return path == parent || strings.HasPrefix(path, parent+Separator)
+}
+
+// Ancestors returns path and each parent above it, from the top level down:
+// "set-piece", "set-piece/lineout", "set-piece/lineout/steal".
+func Ancestors(path string) []string {
+	levels := Levels(path)
+	paths := make([]string, len(levels))
+	for i := range levels {
+		paths[i] = strings.Join(levels[:i+1], Separator)
+	}
+	return paths
+}
+
+// Abbrev fits path into width characters by shortening its parent levels to
+// their first letter, e.g. "s/l/steal", keeping the most specific level readable.
+// It returns path unchanged when it fits, and "" for an empty path.
+func Abbrev(path string, width int) string {
+	if len(path) <= width {
+		return path
+	}
+	levels := Levels(path)
+	for i := 0; i < len(levels)-1 && len(strings.Join(levels, Separator)) > width; i++ {
+		levels[i] = string([]rune(levels[i])[:1])
+	}
+	return strings.Join(levels, Separator)
+}
+
+// Count is a category path with a number of events.
+type Count struct {
+	Path  string
+	Count int
+}
+
+// RollUp sums counts into their paths truncated to depth levels (0 keeps whole
+// paths). Paths differing only in case are merged under the first spelling
+// given. The result is sorted by count, highest first, then by path.
+func RollUp(counts []Count, depth int) []Count {
+	var out []Count
+	index := make(map[string]int)
+	for _, c := range counts {
+		path := Truncate(c.Path, depth)
+		if path == "" {
+			continue
+		}
+		key := strings.ToLower(path)
+		if i, ok := index[key]; ok {
+			out[i].Count += c.Count
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, Count{Path: path, Count: c.Count})
+	}
+	sortCounts(out)
+	return out
+}
+
+// Tree returns every level of counts down to depth levels (0 for all) in tree
+// order: each path is followed by its subcategories, siblings sorted by count.
+// A row's count includes its subcategories.
+func Tree(counts []Count, depth int) []Count {
+	maxDepth := 0
+	for _, c := range counts {
+		maxDepth = max(maxDepth, Depth(c.Path))
+	}
+	if depth <= 0 || depth > maxDepth {
+		depth = maxDepth
+	}
+	children := make(map[string][]Count)
+	for d := 1; d <= depth; d++ {
+		for _, c := range RollUp(counts, d) {
+			if Depth(c.Path) != d {
+				continue
+			}
+			parent := strings.ToLower(Truncate(c.Path, d-1))
+			if d == 1 {
+				parent = ""
+			}
+			children[parent] = append(children[parent], c)
+		}
+	}
+	var out []Count
+	var walk func(parent string)
+	walk = func(parent string) {
+		for _, c := range children[parent] {
+			out = append(out, c)
+			walk(strings.ToLower(c.Path))
+		}
+	}
+	walk("")
+	return out
+}
+
+func sortCounts(counts []Count) {
+	sort.SliceStable(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Path < counts[j].Path
+	})
+}
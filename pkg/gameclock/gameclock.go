@@ -0,0 +1,75 @@
+// Package gameclock converts video timestamps into match clock times using the
+// match periods (halves) recorded for a video.
+package gameclock
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultPeriodLength is the nominal length of a rugby half in seconds.
+const DefaultPeriodLength = 40 * 60
+
+// Period is a span of play in video time.
+type Period struct {
+	Name  string
+	Start float64
+	End   float64
+}
+
+// Clock maps video time to game time. The clock starts at 0:00 at the start of the
+// first period and each later period restarts at its nominal offset (40:00 for the
+// second half), so stoppages in the footage do not drift the clock.
+type Clock struct {
+	Periods []Period
+	// PeriodLength is the nominal period length in seconds (DefaultPeriodLength if 0)
+	PeriodLength float64
+}
+
+// New returns a clock for the given periods, sorted by start time.
+func New(periods []Period) Clock {
+	sorted := append([]Period(nil), periods...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	return Clock{Periods: sorted, PeriodLength: DefaultPeriodLength}
+}
+
+// HasPeriods reports whether the clock has any periods to work from.
+func (c Clock) HasPeriods() bool {
+	return len(c.Periods) > 0
+}
+
+// Format returns the game clock for video time t, e.g. "23:14", "40+2:05" (added time),
+// "HT" between periods, "Pre" before kick-off and "FT" after the last period.
+// Returns "" when no periods are recorded.
+func (c Clock) Format(t float64) string {
+	if len(c.Periods) == 0 {
+		return ""
+	}
+	length := c.PeriodLength
+	if length <= 0 {
+		length = DefaultPeriodLength
+	}
+	if t < c.Periods[0].Start {
+		return "Pre"
+	}
+	for i, p := range c.Periods {
+		if t < p.Start {
+			return "HT"
+		}
+		if t > p.End {
+			continue
+		}
+		elapsed := t - p.Start
+		if elapsed > length {
+			return fmt.Sprintf("%d+%s", int(float64(i+1)*length)/60, minSec(elapsed-length))
+		}
+		return minSec(float64(i)*length + elapsed)
+	}
+	return "FT"
+}
+
+// minSec formats seconds as M:SS with unbounded minutes.
+func minSec(seconds float64) string {
+	total := int(seconds)
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
@@ -0,0 +1,83 @@
+package gameclock
+
+import "fmt"
+
+// Window is a span of match time that events are counted in, e.g. minutes
+// 10-20 of the first half.
+type Window struct {
+	// Period is the index of the period the window is in
+	Period int
+	// PeriodName is the period's name, e.g. "1st half" ("Video" without periods)
+	PeriodName string
+	// Label is the span in game minutes, e.g. "10-20", or "40+" for added time
+	Label string
+	// Start and End are the span in video time
+	Start float64
+	End   float64
+}
+
+// Windows splits the match into windows of size seconds of game time, per
+// period, with a final window for any added time of a period. Without periods
+// the video is split from 0 up to end, labelled in video minutes.
+func (c Clock) Windows(size, end float64) []Window {
+	if size <= 0 {
+		return nil
+	}
+	if len(c.Periods) == 0 {
+		var windows []Window
+		for s := 0.0; s < end || s == 0; s += size {
+			windows = append(windows, Window{
+				PeriodName: "Video",
+				Label:      fmt.Sprintf("%d-%d", int(s)/60, int(s+size)/60),
+				Start:      s,
+				End:        s + size,
+			})
+		}
+		return windows
+	}
+
+	length := c.PeriodLength
+	if length <= 0 {
+		length = DefaultPeriodLength
+	}
+	var windows []Window
+	for i, p := range c.Periods {
+		offset := float64(i) * length
+		for s := 0.0; s < length && p.Start+s < p.End; s += size {
+			e := s + size
+			if e > length {
+				e = length
+			}
+			windows = append(windows, Window{
+				Period:     i,
+				PeriodName: p.Name,
+				Label:      fmt.Sprintf("%d-%d", int(offset+s)/60, int(offset+e)/60),
+				Start:      p.Start + s,
+				End:        min(p.Start+e, p.End),
+			})
+		}
+		if p.End-p.Start > length {
+			windows = append(windows, Window{
+				Period:     i,
+				PeriodName: p.Name,
+				Label:      fmt.Sprintf("%d+", int(offset+length)/60),
+				Start:      p.Start + length,
+				End:        p.End,
+			})
+		}
+	}
+	return windows
+}
+
+// WindowAt returns the index of the window containing video time t, or -1
+// when t falls outside them (before kick-off, at half time or after the final
+// whistle). A period's last window includes its end.
+func WindowAt(windows []Window, t float64) int {
+	for i, w := range windows {
+		last := i == len(windows)-1 || windows[i+1].Period != w.Period
+		if t >= w.Start && (t < w.End || (last && t == w.End)) {
+			return i
+		}
+	}
+	return -1
+}
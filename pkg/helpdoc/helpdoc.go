@@ -0,0 +1,113 @@
+// Package helpdoc holds the extended help: the TUI keybindings, the command
+// mode commands and the data model. The TUI help overlay, 'help <topic>' and
+// the man pages all render these definitions, so they can't drift apart.
+package helpdoc
+
+import (
+	"strings"
+)
+
+// maxNameWidth is the widest entry name Plain aligns descriptions after.
+const maxNameWidth = 32
+
+// Entry is one line of a help section: a key, command or table and what it
+// does.
+type Entry struct {
+	Name string
+	Desc string
+}
+
+// Section is a titled group of entries, optionally introduced by a paragraph.
+type Section struct {
+	Title   string
+	Text    string
+	Entries []Entry
+}
+
+// Topic is an extended help page.
+type Topic struct {
+	// Name is the topic's word in 'help <name>' and its man page name
+	Name  string
+	Short string
+	// Text introduces the topic, one paragraph per blank-line-separated block
+	Text     string
+	Sections []Section
+}
+
+// Topics returns the help topics in the order they are listed.
+func Topics() []Topic {
+	return []Topic{Keybindings, CommandMode, DataModel}
+}
+
+// Plain renders the topic as plain text for the terminal, each section's
+// entries in two aligned columns.
+func (t Topic) Plain() string {
+	var b strings.Builder
+	if t.Text != "" {
+		b.WriteString(t.Text)
+		b.WriteString("\n")
+	}
+	for _, s := range t.Sections {
+		b.WriteString("\n" + s.Title + ":\n")
+		if s.Text != "" {
+			b.WriteString(indent(s.Text, "  ") + "\n")
+		}
+		// Names too long to align with the rest get their own line
+		width := 0
+		for _, e := range s.Entries {
+			if len(e.Name) <= maxNameWidth {
+				width = max(width, len(e.Name))
+			}
+		}
+		for _, e := range s.Entries {
+			if len(e.Name) > width {
+				b.WriteString("  " + e.Name + "\n" + strings.Repeat(" ", width+5) + e.Desc + "\n")
+				continue
+			}
+			b.WriteString("  " + e.Name + strings.Repeat(" ", width-len(e.Name)+3) + e.Desc + "\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// indent prefixes each non-empty line of s.
+func indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CommandNames returns the command mode commands by name, without their
+// arguments or aliases, in the order they are documented.
+func CommandNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, s := range CommandMode.Sections {
+		for _, e := range s.Entries {
+			name := strings.Fields(e.Name)[0]
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// CommandAliases returns the short aliases of the command mode commands, the
+// words after " / " (e.g. "p" for pause), in the order they are documented.
+func CommandAliases() []string {
+	var aliases []string
+	for _, s := range CommandMode.Sections {
+		for _, e := range s.Entries {
+			if i := strings.LastIndex(e.Name, " / "); i >= 0 {
+				aliases = append(aliases, strings.TrimSpace(e.Name[i+3:]))
+			}
+		}
+	}
+	return aliases
+}
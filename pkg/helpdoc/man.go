@@ -0,0 +1,124 @@
+package helpdoc
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ManPage is a man page, written as roff by Roff.
+type ManPage struct {
+	// Name is the page's name, e.g. tagging-rugby-cli-note-add
+	Name string
+	// Section is the manual section: 1 for commands, 7 for topics
+	Section int
+	Short   string
+	// Source names the program and version in the page footer
+	Source   string
+	Synopsis string
+	// Text is the description, blank-line-separated paragraphs; a paragraph
+	// whose lines are all indented is kept as is, like an example
+	Text     string
+	Sections []Section
+	SeeAlso  []string
+}
+
+// ManPage returns the topic's man page, named after prog.
+func (t Topic) ManPage(prog, source string) ManPage {
+	return ManPage{
+		Name:     prog + "-" + t.Name,
+		Section:  7,
+		Short:    t.Short,
+		Source:   source,
+		Text:     t.Text,
+		Sections: t.Sections,
+		SeeAlso:  []string{prog + "(1)"},
+	}
+}
+
+// Filename is the page's file name, e.g. tagging-rugby-cli-note-add.1.
+func (p ManPage) Filename() string {
+	return p.Name + "." + strconv.Itoa(p.Section)
+}
+
+// Roff renders the page in the man macro package.
+func (p ManPage) Roff() string {
+	var b strings.Builder
+	section := strconv.Itoa(p.Section)
+	b.WriteString(".TH " + quote(strings.ToUpper(p.Name)) + " " + section + ` "" ` + quote(p.Source) + "\n")
+	b.WriteString(".SH NAME\n")
+	b.WriteString(escape(p.Name) + ` \- ` + escape(p.Short) + "\n")
+	if p.Synopsis != "" {
+		b.WriteString(".SH SYNOPSIS\n")
+		b.WriteString(`.B ` + escape(p.Synopsis) + "\n")
+	}
+	if p.Text != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		writeParagraphs(&b, p.Text)
+	}
+	for _, s := range p.Sections {
+		b.WriteString(".SH " + quote(strings.ToUpper(s.Title)) + "\n")
+		if s.Text != "" {
+			writeParagraphs(&b, s.Text)
+		}
+		for _, e := range s.Entries {
+			b.WriteString(".TP\n")
+			b.WriteString(`\fB` + escape(e.Name) + `\fR` + "\n")
+			b.WriteString(escape(e.Desc) + "\n")
+		}
+	}
+	if len(p.SeeAlso) > 0 {
+		b.WriteString(".SH \"SEE ALSO\"\n")
+		refs := make([]string, len(p.SeeAlso))
+		for i, ref := range p.SeeAlso {
+			// name(1) is set as a bold name and its section
+			if open := strings.LastIndex(ref, "("); open > 0 {
+				refs[i] = `\fB` + escape(ref[:open]) + `\fR` + escape(ref[open:])
+			} else {
+				refs[i] = escape(ref)
+			}
+		}
+		b.WriteString(strings.Join(refs, ",\n") + "\n")
+	}
+	return b.String()
+}
+
+// writeParagraphs writes text's blank-line-separated paragraphs, filled, or
+// unfilled and indented when all their lines are indented.
+func writeParagraphs(b *strings.Builder, text string) {
+	for _, para := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		lines := strings.Split(strings.Trim(para, "\n"), "\n")
+		literal := true
+		for _, l := range lines {
+			if !strings.HasPrefix(l, "  ") && !strings.HasPrefix(l, "\t") {
+				literal = false
+				break
+			}
+		}
+		if literal {
+			b.WriteString(".PP\n.RS 4\n.nf\n")
+			for _, l := range lines {
+				b.WriteString(escape(strings.TrimSpace(l)) + "\n")
+			}
+			b.WriteString(".fi\n.RE\n")
+			continue
+		}
+		b.WriteString(".PP\n")
+		for _, l := range lines {
+			b.WriteString(escape(strings.TrimSpace(l)) + "\n")
+		}
+	}
+}
+
+// escape makes text safe on a roff text line.
+func escape(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+// quote makes s one roff macro argument.
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(escape(s), `"`, `""`) + `"`
+}
@@ -0,0 +1,276 @@
+package helpdoc
+
+// Keybindings are the TUI's keys, grouped by function as in the help overlay
+// (?).
+var Keybindings = Topic{
+	Name:  "keybindings",
+	Short: "Keys of the interactive TUI",
+	Text: `Keys of the TUI opened with 'open <video> --tui'. Press ? in the TUI to show
+them, and : to enter command mode (see 'help command-mode').
+
+These are the default keys; "keys" in config.json remaps them, and
+'config keys' lists the keys in use. The seek steps , and . cycle through
+are set with 'config set step_sizes'.`,
+	Sections: []Section{
+		{
+			Title: "Playback",
+			Entries: []Entry{
+				{"Space", "Toggle play/pause"},
+				{"M", "Toggle mute"},
+				{"H / Left", "Step backward (by step size)"},
+				{"L / Right", "Step forward (by step size)"},
+				{"Ctrl+H", "Frame step backward (hold to jog)"},
+				{"Ctrl+L", "Frame step forward (hold to jog)"},
+				{", / <", "Decrease step size"},
+				{". / >", "Increase step size"},
+				{"Ctrl+S", "Toggle shuttle mode (j back, k pause, l forward)"},
+				{"a", "Switch camera angle (when angles are linked)"},
+			},
+		},
+		{
+			Title: "Possession",
+			Entries: []Entry{
+				{"p", "Hand possession to other team"},
+				{"P", "End possession (stoppage)"},
+				{"Y", "Flip territory (ball half)"},
+			},
+		},
+		{
+			Title: "Bookmarks",
+			Entries: []Entry{
+				{"b", "Bookmark current position"},
+				{"B", "Jump to next bookmark"},
+				{"' 1-9", "Jump to bookmark by number"},
+			},
+		},
+		{
+			Title: "Scratch Notes",
+			Entries: []Entry{
+				{"w", "Add an unsaved scratch note"},
+				{"+", "Keep selected scratch as a note"},
+				{"x", "Discard selected scratch"},
+			},
+		},
+		{
+			Title: "Navigation",
+			Entries: []Entry{
+				{"J / Up", "Select previous item"},
+				{"K / Down", "Select next item"},
+				{"Enter", "Jump to selected item"},
+				{"E", "Edit selected tackle"},
+				{"i", "Quick-edit selected note text"},
+				{"X", "Delete selected item (press twice)"},
+				{"p / P", "Pin / unpin selected item"},
+				{"f", "Filter by selected event's player"},
+				{"r / 2r", "Jump to the event #n the selected note refers to"},
+				{":cat <path>", "Filter by category and subcategories"},
+			},
+		},
+		{
+			Title: "Views",
+			Entries: []Entry{
+				{"?", "Show/hide this help"},
+				{"S", "Open stats view"},
+				{"O", "Toggle overlay on video"},
+				{"N", "Quick add note"},
+				{"T", "Quick add tackle"},
+				{":ev <type>", "Tag a lineout, scrum, try... with its fields"},
+				{"C", "Edit match commentary"},
+				{"Ctrl+E", "Export clips of the selected player, or of starred events"},
+				{"Backspace", "Return to main view"},
+				{"/ (stats)", "Search players by name/initials"},
+				{"<n>G (stats)", "Jump to row n (gg / G first / last)"},
+				{"Esc (stats)", "Clear player filters"},
+				{"V (stats)", "Cycle video / match / season / all videos"},
+			},
+		},
+		{
+			Title: "Stats Panel",
+			Entries: []Entry{
+				{"Tab", "Focus the stats panel (wide layout)"},
+				{"O", "Cycle sort column"},
+				{"/", "Filter table by player"},
+				{"Enter", "Filter notes list by player"},
+				{"Esc", "Clear filter / leave panel"},
+			},
+		},
+		{
+			Title: "Suggestions",
+			Entries: []Entry{
+				{":suggest", "Open suggestion review queue"},
+				{"Enter / A", "Accept suggestion as note"},
+				{"X / R", "Reject suggestion"},
+				{"Space", "Seek to suggestion"},
+			},
+		},
+		{
+			Title: "Clip Export",
+			Entries: []Entry{
+				{"Ctrl+E", "Queue clips and open the export view"},
+				{"R", "Retry the selected failed clip"},
+				{"Shift+R", "Retry every failed clip"},
+				{"Space", "Seek to event"},
+			},
+		},
+		{
+			Title: "Commands",
+			Entries: []Entry{
+				{":", "Enter command mode"},
+				{"Esc", "Cancel command mode"},
+				{"Ctrl+C", "Quit application"},
+			},
+		},
+		{
+			Title: "Shorthand Commands",
+			Entries: []Entry{
+				{":nn", "Quick note (or :nn <text>)"},
+				{":nt", "Quick tackle (or :nt <p> <t> <a> <o>)"},
+				{":cs", "Clip start"},
+				{":ce <desc>", "Clip end with description"},
+			},
+		},
+	},
+}
+
+// CommandMode are the commands typed after : in the TUI. The first word of
+// each entry is the command, a second after / its alias.
+var CommandMode = Topic{
+	Name:  "command-mode",
+	Short: "Commands typed after : in the TUI",
+	Text: `Press : in the TUI to type a command, Enter to run it and Esc to cancel.
+Results and errors show in the footer, and are kept in the :messages history.
+
+Pressing : captures the playback position: notes and tackles added by the
+command use it, however long the command takes to type.
+
+"command_aliases" in config.json adds words standing for a command and its
+leading arguments, e.g. {"g": "goto"}; 'config keys' lists them.`,
+	Sections: []Section{
+		{
+			Title: "Notes and Tackles",
+			Entries: []Entry{
+				{"note add <text>", "Add note at current timestamp"},
+				{"note list", "Reload notes list"},
+				{"note goto <id|#n>", "Jump to note timestamp, by note ID or event number"},
+				{"goto <#n|id>", "Jump to the video's nth event (e.g. goto #17), or a note by ID"},
+				{"nn [<text>]", "Quick note form, or add a note with the text"},
+				{"tackle add -p <player> -t <team> -a <num> -o <outcome>", "Add tackle"},
+				{"tackle list", "Reload tackles list"},
+				{"nt [<player> <team> <attempt> <outcome>]", "Quick tackle form, or add the tackle"},
+				{"event [<type>] / ev", "List event types, or open the note form for an event of one"},
+				{"snippet [name] [values...] / sn", "List note snippets, or add a note from one"},
+				{"scratch [<text>|keep|clear] / sc", "Add an unsaved scratch note, or keep or discard them all"},
+				{"default [team|zone|followed [value]] / def", "Show, set or clear the session's pre-filled form values"},
+				{"sticky", "Toggle keeping the last player and outcome in the tackle form"},
+			},
+		},
+		{
+			Title: "Clips",
+			Entries: []Entry{
+				{"clip start / cs", "Mark clip start"},
+				{"clip end <description> / ce", "Mark clip end and save"},
+				{"clip list", "Show clip count"},
+				{"clip play <id>", "Play clip with A-B loop"},
+				{"clip stop", "Clear A-B loop"},
+			},
+		},
+		{
+			Title: "Playback",
+			Entries: []Entry{
+				{"pause / p", "Pause playback"},
+				{"play", "Resume playback"},
+				{"mute / m", "Toggle mute"},
+				{"seek <time>", "Seek to time (MM:SS or seconds)"},
+				{"speed <multiplier>", "Set playback speed"},
+				{"shuttle", "Toggle JKL shuttle mode"},
+				{"bookmark [add|delete <n>|clear|<n>] / bm", "Add, delete, clear or jump to bookmarks"},
+				{"replay [session-id|stop]", "Replay the video's events in the order they were tagged"},
+			},
+		},
+		{
+			Title: "Match",
+			Entries: []Entry{
+				{"possession [home|away|end]", "Set or end possession"},
+				{"territory [home|away]", "Set which half the ball is in"},
+				{"lineup [<number> <player>|remove <number>]", "Show or hide the lineup panel, or edit the lineup"},
+				{"commentary", "Edit the match commentary"},
+			},
+		},
+		{
+			Title: "Views",
+			Entries: []Entry{
+				{"category [<path>|clear] / cat", "Filter the notes list to a category, or count events below the filter"},
+				{"crossfilter / xf", "Toggle filtering the notes list as the stats tables' selection moves"},
+				{"gameclock / gc", "Toggle the game clock column in the notes list"},
+				{"waveform / wf", "Show or hide the audio waveform under the timeline"},
+				{"changes", "List the events added or edited since the last session"},
+				{"messages / msg", "Show the history of result, warning and error messages"},
+				{"suggest [run|clear]", "Review suggested events, re-run the suggester, or discard the queue"},
+				{"plugin [name] [args]", "List plugins, or run one with the current video/timestamp/selected note"},
+			},
+		},
+		{
+			Title: "Application",
+			Entries: []Entry{
+				{"help / h", "Show available commands"},
+				{"tutorial [stop]", "Walk through tagging a note and a tackle, jumping to it and viewing stats"},
+				{"quit / q", "Exit application"},
+			},
+		},
+	},
+}
+
+// DataModel describes the SQLite tables, for 'db query' and other tools
+// reading the database.
+var DataModel = Topic{
+	Name:  "data-model",
+	Short: "Tables of the SQLite database",
+	Text: `Everything is stored in one SQLite database (--db, by default
+~/.local/share/tagging-rugby-cli/data.db). 'db schema' prints the full schema
+and 'db query' runs read-only SQL against it.
+
+A note is one tagged event of a video. What it records is split over child
+tables keyed by note_id, deleted with the note. Its category is a path such as
+set-piece/lineout, so events can be counted at any level.`,
+	Sections: []Section{
+		{
+			Title: "Videos and Matches",
+			Entries: []Entry{
+				{"videos", "A video file or stream URL, with its match details"},
+				{"video_timings", "A video's duration and where playback last stopped"},
+				{"video_angles", "Other camera angles of a video, with their time offset"},
+				{"video_waveforms", "Audio peak levels drawn under the TUI timeline"},
+				{"matches", "A game grouping its videos (halves, angles), with the result"},
+				{"periods", "Halves and extra time, for the game clock"},
+				{"possessions", "Intervals one team had the ball, and in which half"},
+				{"lineups", "A video's shirt numbers and their players"},
+				{"bookmarks", "Positions to come back to, kept out of stats"},
+				{"commentary", "A video's free-form Markdown commentary"},
+				{"sessions", "Each TUI tagging session, with the events it added"},
+			},
+		},
+		{
+			Title: "Notes",
+			Entries: []Entry{
+				{"notes", "An event: its video, category path and when it was made or edited"},
+				{"note_timing", "The event's start and end in the video, in seconds"},
+				{"note_tackles", "Tackler, attempt, outcome, height and technique"},
+				{"note_zones", "Where on the field: horizontal and vertical zone"},
+				{"note_details", "Typed values: text, player, team and event type fields"},
+				{"note_highlights", "Marks such as star"},
+				{"note_refs", "The events a note text's #17 references point to"},
+				{"note_clips", "The clip exported for the event and its export status"},
+			},
+		},
+		{
+			Title: "Players and Event Types",
+			Entries: []Entry{
+				{"players", "The club roster, with squad numbers and positions"},
+				{"player_aliases", "Other spellings of a player's name, from 'player merge'"},
+				{"event_types", "Structured events (lineout, scrum...) and their fields"},
+				{"settings", "App settings kept with the data, such as the bundle from 'settings export --embed'"},
+				{"schema_migrations", "The migrations applied to the database"},
+			},
+		},
+	},
+}
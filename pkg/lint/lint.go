@@ -0,0 +1,275 @@
+// Package lint checks note text against the club's terminology list and finds
+// inconsistent spellings of the same term or player across notes.
+package lint
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Finding is a word in a note that differs from a dictionary term.
+type Finding struct {
+	Word string
+	// Term is the dictionary spelling the word appears to mean
+	Term string
+}
+
+// Dictionary is the club's list of preferred terms.
+type Dictionary struct {
+	terms []string
+	// byKey maps a term's normalized form to its spelling
+	byKey map[string]string
+	// exact holds the lowercased terms, which are never flagged
+	exact map[string]bool
+}
+
+// NewDictionary builds a dictionary from a list of terms. Terms may contain
+// spaces or hyphens (e.g. "pick and go", "line-out").
+func NewDictionary(terms []string) *Dictionary {
+	d := &Dictionary{byKey: make(map[string]string), exact: make(map[string]bool)}
+	for _, t := range terms {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		d.terms = append(d.terms, t)
+		d.byKey[Normalize(t)] = t
+		d.exact[strings.ToLower(t)] = true
+	}
+	return d
+}
+
+// Empty reports whether the dictionary has no terms, in which case Check finds nothing.
+func (d *Dictionary) Empty() bool {
+	return d == nil || len(d.terms) == 0
+}
+
+// Check returns the words in text that look like a dictionary term spelled
+// differently: the same letters with other spacing or hyphenation ("line out"
+// for "lineout"), or a near miss ("rukc" for "ruck").
+func (d *Dictionary) Check(text string) []Finding {
+	if d.Empty() {
+		return nil
+	}
+	var findings []Finding
+	words := Words(text)
+	for i := 0; i < len(words); i++ {
+		w := strings.ToLower(words[i])
+		// Two words written where the term is one ("line out")
+		if i+1 < len(words) {
+			pair := w + " " + strings.ToLower(words[i+1])
+			if term, ok := d.byKey[Normalize(pair)]; ok && !d.exact[pair] {
+				findings = append(findings, Finding{Word: words[i] + " " + words[i+1], Term: term})
+				i++
+				continue
+			}
+		}
+		if d.exact[w] {
+			continue
+		}
+		if term, ok := d.byKey[Normalize(w)]; ok {
+			findings = append(findings, Finding{Word: words[i], Term: term})
+			continue
+		}
+		if term := d.nearest(w); term != "" {
+			findings = append(findings, Finding{Word: words[i], Term: term})
+		}
+	}
+	return findings
+}
+
+// nearest returns the single-word term within typo distance of w, or "".
+// Short words are skipped: too many ordinary words are one letter from a term.
+func (d *Dictionary) nearest(w string) string {
+	if len(w) < 4 {
+		return ""
+	}
+	best, bestDist := "", maxTypoDistance(w)+1
+	for _, t := range d.terms {
+		lt := strings.ToLower(t)
+		if strings.ContainsAny(lt, " -") || len(lt) < 4 {
+			continue
+		}
+		if dist := Distance(w, lt); dist < bestDist {
+			best, bestDist = t, dist
+		}
+	}
+	return best
+}
+
+// maxTypoDistance allows one edit in short words and two in longer ones.
+func maxTypoDistance(w string) int {
+	if len(w) >= 8 {
+		return 2
+	}
+	return 1
+}
+
+// Words splits text into words, keeping apostrophes and hyphens inside a word.
+func Words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '-'
+	})
+}
+
+// Normalize lowercases s and drops everything but letters and digits, so
+// "Line-out", "line out" and "lineout" compare equal.
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Distance returns the Levenshtein edit distance between a and b, counting a
+// swap of adjacent letters as one edit.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+// Variant is one spelling of a term and how many times it was used.
+type Variant struct {
+	Spelling string
+	Count    int
+}
+
+// Variants finds terms spelled more than one way across texts, e.g. "lineout",
+// "line-out" and "line out". Case differences are ignored. Each group is sorted
+// most used first; groups are sorted by their most used spelling.
+func Variants(texts []string) [][]Variant {
+	counts := make(map[string]map[string]int)
+	add := func(spelling string) {
+		key := Normalize(spelling)
+		if len(key) < 4 {
+			return
+		}
+		if counts[key] == nil {
+			counts[key] = make(map[string]int)
+		}
+		counts[key][spelling]++
+	}
+	for _, text := range texts {
+		words := Words(strings.ToLower(text))
+		for i, w := range words {
+			add(w)
+			if i+1 < len(words) {
+				add(w + " " + words[i+1])
+			}
+		}
+	}
+
+	var groups [][]Variant
+	for _, spellings := range counts {
+		// A two-word phrase only conflicts with a single-word spelling; on its
+		// own it is just two words that happen to be adjacent
+		single := 0
+		for s := range spellings {
+			if !strings.Contains(s, " ") {
+				single++
+			}
+		}
+		if len(spellings) < 2 || single == 0 {
+			continue
+		}
+		var group []Variant
+		for s, n := range spellings {
+			group = append(group, Variant{Spelling: s, Count: n})
+		}
+		sortVariants(group)
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0].Spelling < groups[j][0].Spelling })
+	return groups
+}
+
+// SimilarNames groups names that are probably the same player written
+// differently: equal once case and punctuation are ignored ("J. Smith" and
+// "j smith"), or one typo apart ("Smith" and "Smyth").
+func SimilarNames(names []Variant) [][]Variant {
+	parent := make([]int, len(names))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range names {
+		for j := i + 1; j < len(names); j++ {
+			a, b := Normalize(names[i].Spelling), Normalize(names[j].Spelling)
+			if a == b || len(a) >= 4 && len(b) >= 4 && Distance(a, b) <= 1 {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	byRoot := make(map[int][]Variant)
+	var roots []int
+	for i, n := range names {
+		r := find(i)
+		if _, ok := byRoot[r]; !ok {
+			roots = append(roots, r)
+		}
+		byRoot[r] = append(byRoot[r], n)
+	}
+	var groups [][]Variant
+	for _, r := range roots {
+		if len(byRoot[r]) > 1 {
+			group := byRoot[r]
+			sortVariants(group)
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// ClosestName returns the name in names nearest to name, or "" if none is within
+// typo distance.
+func ClosestName(name string, names []string) string {
+	key := Normalize(name)
+	best, bestDist := "", maxTypoDistance(key)+1
+	for _, n := range names {
+		if dist := Distance(key, Normalize(n)); dist < bestDist {
+			best, bestDist = n, dist
+		}
+	}
+	return best
+}
+
+// sortVariants orders spellings most used first, then alphabetically.
+func sortVariants(group []Variant) {
+	sort.Slice(group, func(i, j int) bool {
+		if group[i].Count != group[j].Count {
+			return group[i].Count > group[j].Count
+		}
+		return group[i].Spelling < group[j].Spelling
+	})
+}
@@ -0,0 +1,90 @@
+// Package noteref finds references to other events in note text: "#17" is
+// the 17th event of the note's video in time order, the number the notes list
+// shows and ':goto #17' jumps to.
+package noteref
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// refRe matches #n not glued to a word, so "C#7" and "&#39;" aren't references.
+var refRe = regexp.MustCompile(`(?:^|[^\w#&/])(#([0-9]+))\b`)
+
+// Ref is a reference found in note text.
+type Ref struct {
+	// Number is the event referred to, 1..N in time order
+	Number int
+	// Start and End are the byte offsets of "#n" in the text
+	Start, End int
+}
+
+// Find returns the references in text, in order.
+func Find(text string) []Ref {
+	var refs []Ref
+	for _, m := range refRe.FindAllStringSubmatchIndex(text, -1) {
+		n, err := strconv.Atoi(text[m[4]:m[5]])
+		if err != nil {
+			continue
+		}
+		refs = append(refs, Ref{Number: n, Start: m[2], End: m[3]})
+	}
+	return refs
+}
+
+// Numbers returns the distinct event numbers text refers to, in order of
+// first appearance.
+func Numbers(text string) []int {
+	var numbers []int
+	seen := make(map[int]bool)
+	for _, r := range Find(text) {
+		if !seen[r.Number] {
+			seen[r.Number] = true
+			numbers = append(numbers, r.Number)
+		}
+	}
+	return numbers
+}
+
+// Check returns an error naming the references in text to no event of a
+// video with events events.
+func Check(text string, events int) error {
+	var unknown []string
+	for _, n := range Numbers(text) {
+		if n < 1 || n > events {
+			unknown = append(unknown, fmt.Sprintf("#%d", n))
+		}
+	}
+	switch {
+	case len(unknown) == 0:
+		return nil
+	case events == 0:
+		return fmt.Errorf("no event %s: the video has no events yet", strings.Join(unknown, ", "))
+	default:
+		return fmt.Errorf("no event %s: the video's events are #1-#%d", strings.Join(unknown, ", "), events)
+	}
+}
+
+// Renumber returns text with the references in numbers, by their position
+// among Find's from 0, changed to those numbers: the numbers the events they
+// were saved pointing to have now.
+func Renumber(text string, numbers map[int]int) string {
+	if len(numbers) == 0 {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for i, r := range Find(text) {
+		n, ok := numbers[i]
+		if !ok {
+			continue
+		}
+		b.WriteString(text[last:r.Start])
+		fmt.Fprintf(&b, "#%d", n)
+		last = r.End
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,75 @@
+// Package season works out rugby seasons, which span two calendar years:
+// with the default August start, season 2025 runs from 2025-08-01 to
+// 2026-07-31 and is labelled 2025/26.
+package season
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultStartMonth is the month seasons start in unless configured otherwise.
+const DefaultStartMonth = 8
+
+// dateLayout is the YYYY-MM-DD form of match dates.
+const dateLayout = "2006-01-02"
+
+// Season is the span [From, To) of one season.
+type Season struct {
+	From time.Time
+	To   time.Time
+}
+
+// New returns the season starting in startMonth of year. An invalid month
+// falls back to DefaultStartMonth.
+func New(year, startMonth int) Season {
+	if startMonth < 1 || startMonth > 12 {
+		startMonth = DefaultStartMonth
+	}
+	from := time.Date(year, time.Month(startMonth), 1, 0, 0, 0, 0, time.UTC)
+	return Season{From: from, To: from.AddDate(1, 0, 0)}
+}
+
+// Parse reads a season written as its first year, e.g. "2023", "2023/24" or
+// "2023-24".
+func Parse(s string, startMonth int) (Season, error) {
+	yearStr := s
+	if i := strings.IndexAny(s, "/-"); i >= 0 {
+		yearStr = s[:i]
+	}
+	year, err := strconv.Atoi(yearStr)
+	if err != nil || year < 1900 {
+		return Season{}, fmt.Errorf("invalid season: %s (want a year, e.g. 2023)", s)
+	}
+	return New(year, startMonth), nil
+}
+
+// Of returns the season a YYYY-MM-DD date falls in.
+func Of(date string, startMonth int) (Season, error) {
+	t, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return Season{}, fmt.Errorf("invalid date: %s (want YYYY-MM-DD)", date)
+	}
+	s := New(t.Year(), startMonth)
+	if t.Before(s.From) {
+		s = New(t.Year()-1, startMonth)
+	}
+	return s, nil
+}
+
+// Bounds returns the season as YYYY-MM-DD dates [from, to).
+func (s Season) Bounds() (from, to string) {
+	return s.From.Format(dateLayout), s.To.Format(dateLayout)
+}
+
+// Label names the season by its years, e.g. "2025/26", or "2025" for a
+// season starting in January.
+func (s Season) Label() string {
+	last := s.To.AddDate(0, 0, -1)
+	if last.Year() == s.From.Year() {
+		return strconv.Itoa(s.From.Year())
+	}
+	return fmt.Sprintf("%d/%02d", s.From.Year(), last.Year()%100)
+}
@@ -0,0 +1,65 @@
+// Package snippet expands reusable note text templates such as
+// "line speed slow on {player}'s side", so analysts share terminology.
+package snippet
+
+import (
+	"regexp"
+	"strings"
+)
+
+var placeholderRe = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Placeholders returns the distinct placeholder names in tmpl, in order of first appearance.
+func Placeholders(tmpl string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range placeholderRe.FindAllStringSubmatch(tmpl, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// Expand replaces each {name} in tmpl with values[name] (names match
+// case-insensitively). Placeholders without a non-empty value are left as they
+// are and returned in missing.
+func Expand(tmpl string, values map[string]string) (text string, missing []string) {
+	lower := make(map[string]string, len(values))
+	for k, v := range values {
+		lower[strings.ToLower(k)] = v
+	}
+	seen := make(map[string]bool)
+	text = placeholderRe.ReplaceAllStringFunc(tmpl, func(ph string) string {
+		name := ph[1 : len(ph)-1]
+		if v := lower[strings.ToLower(name)]; v != "" {
+			return v
+		}
+		if !seen[name] {
+			seen[name] = true
+			missing = append(missing, name)
+		}
+		return ph
+	})
+	return text, missing
+}
+
+// Fill expands tmpl from positional args: each placeholder takes one argument
+// in order of first appearance, and the last takes all remaining arguments so
+// multi-word values need no quoting.
+func Fill(tmpl string, args []string) (text string, missing []string) {
+	names := Placeholders(tmpl)
+	values := make(map[string]string, len(names))
+	for i, name := range names {
+		if i >= len(args) {
+			break
+		}
+		if i == len(names)-1 {
+			values[name] = strings.Join(args[i:], " ")
+		} else {
+			values[name] = args[i]
+		}
+	}
+	return Expand(tmpl, values)
+}
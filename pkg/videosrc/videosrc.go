@@ -0,0 +1,44 @@
+// Package videosrc distinguishes local video files from mpv-playable stream URLs
+// (YouTube, Veo, HLS playlists, ...), which are stored in the videos table as-is.
+package videosrc
+
+import (
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IsURL reports whether the given video source is a URL rather than a local file path.
+// Single-letter schemes are rejected so Windows drive letters (C:\...) are not mistaken for URLs.
+func IsURL(source string) bool {
+	u, err := url.Parse(source)
+	if err != nil {
+		return false
+	}
+	return len(u.Scheme) > 1 && (u.Host != "" || u.Opaque != "")
+}
+
+// Base returns the last element of the source for display: the file name of a
+// local path, or the last path segment (falling back to the host) of a URL.
+func Base(source string) string {
+	if !IsURL(source) {
+		return filepath.Base(source)
+	}
+	u, _ := url.Parse(source)
+	base := path.Base(u.Path)
+	if base == "/" || base == "." || base == "" {
+		return u.Host
+	}
+	return base
+}
+
+// Ext returns the file extension of the source without the leading dot.
+// Query strings and fragments are ignored for URLs.
+func Ext(source string) string {
+	if !IsURL(source) {
+		return strings.TrimPrefix(filepath.Ext(source), ".")
+	}
+	u, _ := url.Parse(source)
+	return strings.TrimPrefix(path.Ext(u.Path), ".")
+}
@@ -0,0 +1,175 @@
+// Package plugin runs external plugin executables. A plugin receives a JSON
+// Request on stdin and prints a JSON Response (a list of actions) on stdout.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/config"
+)
+
+// Action types a plugin may return.
+const (
+	// ActionInsertNote inserts a note (Category, Text, Time; Time defaults to the request timestamp)
+	ActionInsertNote = "insert_note"
+	// ActionSeek seeks the player to Time
+	ActionSeek = "seek"
+	// ActionMessage shows Message to the user
+	ActionMessage = "message"
+)
+
+// Plugin is an executable found in the plugins directory.
+type Plugin struct {
+	// Name is the file name without extension, used as the command name
+	Name string
+	// Path is the absolute path to the executable
+	Path string
+}
+
+// Note describes the selected note passed to a plugin.
+type Note struct {
+	ID       int64   `json:"id"`
+	Category string  `json:"category"`
+	Time     float64 `json:"time"`
+	Text     string  `json:"text,omitempty"`
+	Player   string  `json:"player,omitempty"`
+	Team     string  `json:"team,omitempty"`
+}
+
+// Request is written to the plugin's stdin.
+type Request struct {
+	// Video is the current video path or URL
+	Video string `json:"video"`
+	// Timestamp is the current playback position in seconds
+	Timestamp float64 `json:"timestamp"`
+	// Note is the selected note, if any
+	Note *Note `json:"note,omitempty"`
+	// Args are any extra arguments given after the plugin name
+	Args []string `json:"args"`
+}
+
+// Action is a single instruction returned by a plugin.
+type Action struct {
+	Type     string   `json:"type"`
+	Category string   `json:"category,omitempty"`
+	Text     string   `json:"text,omitempty"`
+	Time     *float64 `json:"time,omitempty"`
+	Message  string   `json:"message,omitempty"`
+}
+
+// Response is read from the plugin's stdout.
+type Response struct {
+	Actions []Action `json:"actions"`
+}
+
+// Dir returns the plugins directory, ~/.config/tagging-rugby/plugins.
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plugins"), nil
+}
+
+// List returns the executables in the plugins directory, sorted by name.
+// A missing directory is not an error.
+func List() ([]Plugin, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read plugins dir: %w", err)
+	}
+
+	var plugins []Plugin
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || info.Mode()&0111 == 0 {
+			continue
+		}
+		name := strings.TrimSuffix(e.Name(), filepath.Ext(e.Name()))
+		plugins = append(plugins, Plugin{Name: name, Path: filepath.Join(dir, e.Name())})
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins, nil
+}
+
+// Find returns the plugin with the given name.
+func Find(name string) (Plugin, error) {
+	plugins, err := List()
+	if err != nil {
+		return Plugin{}, err
+	}
+	for _, p := range plugins {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Plugin{}, fmt.Errorf("plugin not found: %s", name)
+}
+
+// Run executes the plugin with req on stdin and returns the actions it printed.
+// Empty output means no actions.
+func (p Plugin) Run(ctx context.Context, req Request) ([]Action, error) {
+	if req.Args == nil {
+		req.Args = []string{}
+	}
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode plugin request: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, p.Path, req.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			return nil, fmt.Errorf("plugin %s: %w: %s", p.Name, err, msg)
+		}
+		return nil, fmt.Errorf("plugin %s: %w", p.Name, err)
+	}
+
+	out := bytes.TrimSpace(stdout.Bytes())
+	if len(out) == 0 {
+		return nil, nil
+	}
+	var resp Response
+	if out[0] == '[' {
+		err = json.Unmarshal(out, &resp.Actions)
+	} else {
+		err = json.Unmarshal(out, &resp)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: invalid response: %w", p.Name, err)
+	}
+	for _, a := range resp.Actions {
+		switch a.Type {
+		case ActionInsertNote, ActionSeek, ActionMessage:
+		default:
+			return nil, fmt.Errorf("plugin %s: unknown action type %q", p.Name, a.Type)
+		}
+		if a.Type == ActionSeek && a.Time == nil {
+			return nil, fmt.Errorf("plugin %s: seek action without time", p.Name)
+		}
+	}
+	return resp.Actions, nil
+}
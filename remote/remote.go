@@ -0,0 +1,140 @@
+// Package remote serves a small HTTP endpoint that accepts pre-defined TUI
+// actions, so button boxes such as an Elgato Stream Deck can drive tagging
+// with labelled buttons.
+package remote
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/user/tagging-rugby-cli/controller"
+)
+
+// replyTimeout bounds how long a request waits for the TUI to act on it.
+const replyTimeout = 5 * time.Second
+
+// Actions are the remote-only actions, on top of the controller actions, with
+// the query parameters they take.
+var Actions = map[string]string{
+	"tag":  "Add a note now: category=<category>, text=<text>",
+	"seek": "Seek: to=<H:MM:SS or seconds>, or by=<+/- seconds>",
+	"star": "Star the most recent event",
+}
+
+// Request is an action received over HTTP, answered with Reply.
+type Request struct {
+	Action string
+	Params url.Values
+	reply  chan reply
+}
+
+type reply struct {
+	message string
+	err     error
+}
+
+// Reply answers the request. An error is returned to the caller as 400.
+func (r Request) Reply(message string, err error) {
+	r.reply <- reply{message: message, err: err}
+}
+
+// Server accepts actions at /<action> and passes them on as Requests.
+type Server struct {
+	srv      *http.Server
+	token    string
+	requests chan Request
+}
+
+// Listen starts serving on addr (e.g. "127.0.0.1:7373"). Requests must carry
+// token as ?token= or an X-Token header, so web pages open in the analyst's
+// browser can't fire actions at the endpoint.
+func Listen(addr, token string) (*Server, error) {
+	if token == "" {
+		return nil, errors.New("the remote endpoint needs a token")
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{token: token, requests: make(chan Request)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/{action}", s.handle)
+	s.srv = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go s.srv.Serve(ln)
+	return s, nil
+}
+
+// Requests returns the channel actions arrive on.
+func (s *Server) Requests() <-chan Request {
+	return s.requests
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.srv.Close()
+}
+
+// handle validates an action request and waits for the TUI's reply.
+// GET and POST are both accepted, since button apps differ in what they send.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !s.validToken(r.Form.Get("token")) && !s.validToken(r.Header.Get("X-Token")) {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	action := r.PathValue("action")
+	if _, ok := controller.Actions[action]; !ok {
+		if _, ok := Actions[action]; !ok {
+			http.Error(w, fmt.Sprintf("unknown action %q", action), http.StatusNotFound)
+			return
+		}
+	}
+
+	req := Request{Action: action, Params: r.Form, reply: make(chan reply, 1)}
+	timeout := time.After(replyTimeout)
+	select {
+	case s.requests <- req:
+	case <-timeout:
+		http.Error(w, "TUI busy", http.StatusServiceUnavailable)
+		return
+	}
+	var res reply
+	select {
+	case res = <-req.reply:
+	case <-timeout:
+		res.err = errors.New("no reply from TUI")
+	}
+	if res.err != nil {
+		http.Error(w, res.err.Error(), http.StatusBadRequest)
+		return
+	}
+	fmt.Fprintln(w, res.message)
+}
+
+// validToken reports whether token is the server's, in constant time.
+func (s *Server) validToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// NewToken returns a random token for the endpoint.
+func NewToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
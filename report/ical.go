@@ -0,0 +1,76 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/user/tagging-rugby-cli/db"
+)
+
+// icalTimeLayout is the UTC date-time form used by iCalendar (RFC 5545).
+const icalTimeLayout = "20060102T150405Z"
+
+// WriteSessionsICal writes tagging sessions as an iCalendar file, one event per
+// session, so review workload shows up alongside training in a calendar app.
+// Sessions that were never closed are written as zero-length events.
+func WriteSessionsICal(w io.Writer, sessions []db.Session, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		bw.WriteString(foldICalLine(s))
+		bw.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//tagging-rugby-cli//sessions//EN")
+	line("CALSCALE:GREGORIAN")
+	for _, s := range sessions {
+		end := s.StartedAt
+		if s.EndedAt.Valid {
+			end = s.EndedAt.Time
+		}
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:session-%d@tagging-rugby-cli", s.ID))
+		line("DTSTAMP:" + now.UTC().Format(icalTimeLayout))
+		line("DTSTART:" + s.StartedAt.UTC().Format(icalTimeLayout))
+		line("DTEND:" + end.UTC().Format(icalTimeLayout))
+		line("SUMMARY:" + escapeICal("Tagging: "+s.Filename))
+		line("DESCRIPTION:" + escapeICal(fmt.Sprintf("%d event(s) added\n%s", s.EventsAdded, s.Path)))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// escapeICal escapes text property values per RFC 5545 section 3.3.11.
+func escapeICal(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// foldICalLine folds content lines longer than 75 octets, continuing each
+// with a leading space. Splits never fall inside a UTF-8 sequence.
+func foldICalLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines lose one octet to the leading space
+		width = limit - 1
+	}
+	b.WriteString(s)
+	return b.String()
+}
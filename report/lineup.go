@@ -0,0 +1,37 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/db"
+)
+
+// lineupRoles joins a lineup into its starting XV and bench as
+// "1 Smith · 2 Jones · ...", either "" when empty.
+func lineupRoles(lineup []db.LineupPlayer) (starting, bench string) {
+	var s, b []string
+	for _, p := range lineup {
+		entry := fmt.Sprintf("%d %s", p.Number, p.Player)
+		if p.Starting() {
+			s = append(s, entry)
+		} else {
+			b = append(b, entry)
+		}
+	}
+	return strings.Join(s, " · "), strings.Join(b, " · ")
+}
+
+// writeLineupMarkdown writes a lineup as a starting XV line and a bench line.
+func writeLineupMarkdown(b *strings.Builder, lineup []db.LineupPlayer) {
+	starting, bench := lineupRoles(lineup)
+	if starting != "" {
+		b.WriteString("**Starting XV:** " + escapeMarkdown(starting) + "\n")
+	}
+	if bench != "" {
+		if starting != "" {
+			b.WriteString("\n")
+		}
+		b.WriteString("**Bench:** " + escapeMarkdown(bench) + "\n")
+	}
+}
@@ -0,0 +1,153 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/gameclock"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+)
+
+// NotesDoc is a single video's notes prepared for Markdown export.
+type NotesDoc struct {
+	// Match is the display name of the video
+	Match string
+	// Info is the video's recorded match details, shown under the title
+	Info db.MatchInfo
+	// Commentary is the free-form match commentary (Markdown), or ""
+	Commentary string
+	// Lineup is the video's lineup by shirt number, or nil
+	Lineup []db.LineupPlayer
+	Notes  []db.ExportNote
+	// GroupBy is "category" or "player"
+	GroupBy string
+	// Clock converts timestamps to game time; video time is used when it has no periods
+	Clock gameclock.Clock
+}
+
+// WriteNotesMarkdown writes the notes grouped into sections, one bullet per note.
+func WriteNotesMarkdown(w io.Writer, doc NotesDoc) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Match Notes: %s\n\n", escapeMarkdown(doc.Match))
+	if details := matchDetails(doc.Info); details != "" {
+		b.WriteString(escapeMarkdown(details) + "\n\n")
+	}
+	fmt.Fprintf(&b, "_Exported %s · %d note(s)", time.Now().Format("2006-01-02"), len(doc.Notes))
+	if doc.Clock.HasPeriods() {
+		b.WriteString(" · times are game clock (video time in brackets)")
+	}
+	b.WriteString("_\n")
+
+	if body := strings.TrimSpace(doc.Commentary); body != "" {
+		b.WriteString("\n## Commentary\n\n" + body + "\n")
+	}
+	if len(doc.Lineup) > 0 {
+		b.WriteString("\n## Lineup\n\n")
+		writeLineupMarkdown(&b, doc.Lineup)
+	}
+
+	groups, order := groupNotes(doc.Notes, doc.GroupBy)
+	for _, key := range order {
+		notes := groups[key]
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", escapeMarkdown(key), len(notes))
+		for _, n := range notes {
+			b.WriteString("- " + noteLine(n, doc.GroupBy, doc.Clock) + "\n")
+		}
+	}
+	if len(doc.Notes) == 0 {
+		b.WriteString("\nNo notes recorded.\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// matchDetails joins the recorded match details as "2025-03-01 · Home Park · W 24-17".
+func matchDetails(m db.MatchInfo) string {
+	var parts []string
+	for _, p := range []string{m.Date, m.Venue, m.Result} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// groupNotes splits notes into sections keyed by category or player.
+// Sections are ordered by size (largest first), then name; notes without a player go last.
+func groupNotes(notes []db.ExportNote, groupBy string) (map[string][]db.ExportNote, []string) {
+	const unassigned = "Unassigned"
+	groups := make(map[string][]db.ExportNote)
+	for _, n := range notes {
+		key := titleCase(n.Category)
+		if groupBy == "player" {
+			key = n.Player
+		}
+		if key == "" {
+			key = unassigned
+		}
+		groups[key] = append(groups[key], n)
+	}
+
+	order := make([]string, 0, len(groups))
+	for key := range groups {
+		order = append(order, key)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if (a == unassigned) != (b == unassigned) {
+			return b == unassigned
+		}
+		if len(groups[a]) != len(groups[b]) {
+			return len(groups[a]) > len(groups[b])
+		}
+		return a < b
+	})
+	return groups, order
+}
+
+// noteLine renders one note, e.g. "**23:14** (0:25:40) — Smith · completed ★ — great line speed".
+func noteLine(n db.ExportNote, groupBy string, clock gameclock.Clock) string {
+	var b strings.Builder
+	if clock.HasPeriods() {
+		fmt.Fprintf(&b, "**%s** (%s)", clock.Format(n.Start), timeutil.FormatTime(n.Start))
+	} else {
+		fmt.Fprintf(&b, "**%s**", timeutil.FormatTime(n.Start))
+	}
+
+	var parts []string
+	if groupBy == "player" {
+		parts = append(parts, n.Category)
+	} else if n.Player != "" {
+		parts = append(parts, n.Player)
+	}
+	if n.Team != "" {
+		parts = append(parts, n.Team)
+	}
+	if n.Outcome != "" {
+		parts = append(parts, n.Outcome)
+	}
+	if len(parts) > 0 {
+		b.WriteString(" — " + escapeMarkdown(strings.Join(parts, " · ")))
+	}
+	if n.Starred {
+		b.WriteString(" ★")
+	}
+	if n.Text != "" {
+		b.WriteString(" — " + escapeMarkdown(n.Text))
+	}
+	fmt.Fprintf(&b, " `#%d`", n.ID)
+	return b.String()
+}
+
+// titleCase capitalises the first letter of a category for section headings.
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
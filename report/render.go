@@ -0,0 +1,181 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+)
+
+// WriteMarkdown writes the weekly report as Markdown.
+func WriteMarkdown(w io.Writer, r Weekly) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Weekly Summary: %s to %s\n\n", r.From, r.To)
+
+	b.WriteString("## Matches\n\n")
+	if len(r.Matches) == 0 {
+		b.WriteString("No matches tagged in this period.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	b.WriteString("| # | Match | Date | Result | Notes | Tackles |\n")
+	b.WriteString("|---|-------|------|--------|-------|---------|\n")
+	for i, m := range r.Matches {
+		fmt.Fprintf(&b, "| %d | %s | %s | %s | %d | %d |\n", i+1, escapeMarkdown(m.Name), m.Date, escapeMarkdown(m.Result), m.Notes, m.Tackles)
+	}
+
+	writeCommentaryMarkdown(&b, r.Matches)
+	writeLineupsMarkdown(&b, r.Matches)
+
+	b.WriteString("\n## Player Tackling\n\n")
+	if len(r.Players) == 0 {
+		b.WriteString("No tackles recorded.\n")
+	} else {
+		b.WriteString("Completed / attempted (completion %) per match, in match order.\n\n")
+		b.WriteString("| Player |")
+		for i := range r.Matches {
+			fmt.Fprintf(&b, " M%d |", i+1)
+		}
+		b.WriteString(" Total | Trend |\n|--------|")
+		for range r.Matches {
+			b.WriteString("----|")
+		}
+		b.WriteString("-------|-------|\n")
+		for _, p := range r.Players {
+			fmt.Fprintf(&b, "| %s |", escapeMarkdown(p.Player))
+			for _, c := range p.Cells {
+				fmt.Fprintf(&b, " %s |", c)
+			}
+			fmt.Fprintf(&b, " %s | %s |\n", p.Total, p.Trend)
+		}
+	}
+
+	b.WriteString("\n## Flagged Clips\n\n")
+	if len(r.Flagged) == 0 {
+		b.WriteString("No starred events.\n")
+	} else {
+		for _, f := range r.Flagged {
+			fmt.Fprintf(&b, "- **%s** @ %s — %s", escapeMarkdown(f.Match), timeutil.FormatTime(f.Start), escapeMarkdown(f.Category))
+			if f.Player != "" {
+				fmt.Fprintf(&b, ", %s", escapeMarkdown(f.Player))
+			}
+			if f.Text != "" {
+				fmt.Fprintf(&b, ": %s", escapeMarkdown(f.Text))
+			}
+			if f.ClipPath != "" {
+				fmt.Fprintf(&b, " ([clip](<%s>))", f.ClipPath)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeCommentaryMarkdown writes a section with each match's commentary, if any match has one.
+// Commentary is already Markdown, so it is copied as written.
+func writeCommentaryMarkdown(b *strings.Builder, matches []Match) {
+	first := true
+	for _, m := range matches {
+		body := strings.TrimSpace(m.Commentary)
+		if body == "" {
+			continue
+		}
+		if first {
+			b.WriteString("\n## Match Commentary\n")
+			first = false
+		}
+		fmt.Fprintf(b, "\n### %s (%s)\n\n%s\n", escapeMarkdown(m.Name), m.Date, body)
+	}
+}
+
+// writeLineupsMarkdown writes a section with each match's lineup, if any match has one.
+func writeLineupsMarkdown(b *strings.Builder, matches []Match) {
+	first := true
+	for _, m := range matches {
+		if len(m.Lineup) == 0 {
+			continue
+		}
+		if first {
+			b.WriteString("\n## Lineups\n")
+			first = false
+		}
+		fmt.Fprintf(b, "\n### %s (%s)\n\n", escapeMarkdown(m.Name), m.Date)
+		writeLineupMarkdown(b, m.Lineup)
+	}
+}
+
+// escapeMarkdown escapes characters that would break table cells or emphasis.
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", "\\|", "*", "\\*", "_", "\\_", "\n", " ").Replace(s)
+}
+
+var htmlTemplate = template.Must(template.New("weekly").Funcs(template.FuncMap{
+	"time": timeutil.FormatTime,
+	"inc":  func(i int) int { return i + 1 },
+	"starting": func(lineup []db.LineupPlayer) string {
+		starting, _ := lineupRoles(lineup)
+		return starting
+	},
+	"bench": func(lineup []db.LineupPlayer) string {
+		_, bench := lineupRoles(lineup)
+		return bench
+	},
+	// fileURL marks local clip links as safe; html/template rewrites non-http URLs otherwise
+	"fileURL": func(path string) template.URL {
+		return template.URL((&url.URL{Scheme: "file", Path: path}).String())
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Weekly Summary: {{.From}} to {{.To}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #222; max-width: 60em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #f0eef6; }
+.up { color: #1a7f37; } .down { color: #cf222e; }
+.commentary { white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Weekly Summary: {{.From}} to {{.To}}</h1>
+<h2>Matches</h2>
+{{if not .Matches}}<p>No matches tagged in this period.</p>{{else}}
+<table>
+<tr><th>#</th><th>Match</th><th>Date</th><th>Result</th><th>Notes</th><th>Tackles</th></tr>
+{{range $i, $m := .Matches}}<tr><td>{{inc $i}}</td><td>{{$m.Name}}</td><td>{{$m.Date}}</td><td>{{$m.Result}}</td><td>{{$m.Notes}}</td><td>{{$m.Tackles}}</td></tr>
+{{end}}</table>
+{{range .Matches}}{{if .Commentary}}<h3>{{.Name}} ({{.Date}})</h3>
+<div class="commentary">{{.Commentary}}</div>
+{{end}}{{end}}{{range .Matches}}{{if .Lineup}}<h3>Lineup: {{.Name}} ({{.Date}})</h3>
+{{with starting .Lineup}}<p><strong>Starting XV:</strong> {{.}}</p>
+{{end}}{{with bench .Lineup}}<p><strong>Bench:</strong> {{.}}</p>
+{{end}}{{end}}{{end}}<h2>Player Tackling</h2>
+{{if not .Players}}<p>No tackles recorded.</p>{{else}}
+<p>Completed / attempted (completion %) per match, in match order.</p>
+<table>
+<tr><th>Player</th>{{range $i, $m := .Matches}}<th>M{{inc $i}}</th>{{end}}<th>Total</th><th>Trend</th></tr>
+{{range .Players}}<tr><td>{{.Player}}</td>{{range .Cells}}<td>{{.}}</td>{{end}}<td>{{.Total}}</td><td class="{{if eq .Trend "↑"}}up{{else if eq .Trend "↓"}}down{{end}}">{{.Trend}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>Flagged Clips</h2>
+{{if not .Flagged}}<p>No starred events.</p>{{else}}
+<ul>
+{{range .Flagged}}<li><strong>{{.Match}}</strong> @ {{time .Start}} — {{.Category}}{{if .Player}}, {{.Player}}{{end}}{{if .Text}}: {{.Text}}{{end}}{{if .ClipPath}} (<a href="{{fileURL .ClipPath}}">clip</a>){{end}}</li>
+{{end}}</ul>
+{{end}}{{end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the weekly report as a standalone HTML page.
+func WriteHTML(w io.Writer, r Weekly) error {
+	return htmlTemplate.Execute(w, r)
+}
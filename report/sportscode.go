@@ -0,0 +1,171 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/db"
+)
+
+// sportscodeMinLength is the length given to notes without an end, as
+// Sportscode drops instances that end where they start.
+const sportscodeMinLength = 5.0
+
+// sportscodeColours are the row colours, as 16-bit RGB, given to codes in
+// the order they first appear.
+var sportscodeColours = [][3]int{
+	{0xFFFF, 0x5555, 0x5555}, // red
+	{0x5555, 0xAAAA, 0xFFFF}, // blue
+	{0x5555, 0xDDDD, 0x5555}, // green
+	{0xFFFF, 0xAAAA, 0x0000}, // orange
+	{0xAAAA, 0x5555, 0xFFFF}, // purple
+	{0x0000, 0xCCCC, 0xCCCC}, // teal
+	{0xFFFF, 0x6666, 0xCCCC}, // pink
+	{0xCCCC, 0xCCCC, 0x3333}, // olive
+}
+
+type sportscodeFile struct {
+	XMLName   xml.Name             `xml:"file"`
+	Instances []sportscodeInstance `xml:"ALL_INSTANCES>instance"`
+	Rows      []sportscodeRow      `xml:"ROWS>row"`
+}
+
+type sportscodeInstance struct {
+	ID       int               `xml:"ID"`
+	Start    string            `xml:"start"`
+	End      string            `xml:"end"`
+	Code     string            `xml:"code"`
+	FreeText string            `xml:"free_text,omitempty"`
+	Labels   []sportscodeLabel `xml:"label"`
+}
+
+type sportscodeLabel struct {
+	Group string `xml:"group,omitempty"`
+	Text  string `xml:"text"`
+}
+
+type sportscodeRow struct {
+	Code string `xml:"code"`
+	R    int    `xml:"R"`
+	G    int    `xml:"G"`
+	B    int    `xml:"B"`
+}
+
+// WriteSportscodeXML writes a video's notes as a Sportscode XML timeline, which
+// Hudl Sportscode and Nacsport open as a coded timeline. Each note with a
+// timing becomes an instance coded by its category, its text as free text and
+// its tackle, zone, highlight and other details as grouped labels; each code
+// gets a timeline row. Notes without a timing can't be placed and are left out.
+// Returns the number of instances written.
+func WriteSportscodeXML(w io.Writer, doc *db.VideoTransfer) (int, error) {
+	notes := make([]db.TransferNote, 0, len(doc.Notes))
+	for _, n := range doc.Notes {
+		if len(n.Timings) > 0 {
+			notes = append(notes, n)
+		}
+	}
+	sort.SliceStable(notes, func(i, j int) bool { return notes[i].Start() < notes[j].Start() })
+
+	var f sportscodeFile
+	seen := make(map[string]bool)
+	for i, n := range notes {
+		t := n.Timings[0]
+		end := t.End
+		if end <= t.Start {
+			end = t.Start + sportscodeMinLength
+		}
+		code := n.Category
+		if code == "" {
+			code = "note"
+		}
+		in := sportscodeInstance{
+			ID:     i + 1,
+			Start:  sportscodeTime(t.Start),
+			End:    sportscodeTime(end),
+			Code:   code,
+			Labels: sportscodeLabels(n),
+		}
+		var text []string
+		for _, d := range n.Details {
+			if (d.Type == "text" || d.Type == "notes") && d.Note != "" {
+				text = append(text, d.Note)
+			}
+		}
+		in.FreeText = strings.Join(text, "\n")
+		f.Instances = append(f.Instances, in)
+
+		if !seen[code] {
+			seen[code] = true
+			c := sportscodeColours[len(f.Rows)%len(sportscodeColours)]
+			f.Rows = append(f.Rows, sportscodeRow{Code: code, R: c[0], G: c[1], B: c[2]})
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return 0, err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		return 0, fmt.Errorf("encode sportscode xml: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return len(f.Instances), err
+}
+
+// sportscodeLabels returns the note's labels: its tackles, zones, highlights
+// and every detail but its text, grouped by what they are.
+func sportscodeLabels(n db.TransferNote) []sportscodeLabel {
+	var labels []sportscodeLabel
+	add := func(group, text string) {
+		if text != "" {
+			labels = append(labels, sportscodeLabel{Group: group, Text: text})
+		}
+	}
+	for _, t := range n.Tackles {
+		add("Player", t.Player)
+		add("Outcome", t.Outcome)
+		if t.Attempt > 0 {
+			add("Attempt", strconv.Itoa(t.Attempt))
+		}
+		add("Height", t.Height)
+		add("Technique", t.Technique)
+	}
+	for _, z := range n.Zones {
+		add("Zone", z.Horizontal)
+		add("Vertical zone", z.Vertical)
+	}
+	for _, d := range n.Details {
+		switch d.Type {
+		case "text", "notes":
+		case "player":
+			add("Player", d.Note)
+		case "team":
+			add("Team", d.Note)
+		default:
+			add(labelGroup(d.Type), d.Note)
+		}
+	}
+	for _, h := range n.Highlights {
+		add("Highlight", h)
+	}
+	return labels
+}
+
+// labelGroup turns a detail type such as won_by into a label group, Won by.
+func labelGroup(detail string) string {
+	s := strings.ReplaceAll(detail, "_", " ")
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// sportscodeTime formats seconds as Sportscode does, to the hundredth.
+func sportscodeTime(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 2, 64)
+}
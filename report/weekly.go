@@ -0,0 +1,187 @@
+// Package report builds summary documents across several tagged matches.
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/user/tagging-rugby-cli/db"
+)
+
+// Weekly is the aggregated summary for all matches tagged in a date range.
+type Weekly struct {
+	// From and To are the inclusive date range (YYYY-MM-DD)
+	From    string
+	To      string
+	Matches []Match
+	Players []PlayerProgress
+	Flagged []Flagged
+}
+
+// Match is one tagged video in the report.
+type Match struct {
+	VideoID int64
+	// Name is "vs <opponent>" when recorded, otherwise the video filename
+	Name string
+	// Date is the match date, or the day the match was first tagged when not recorded
+	Date    string
+	Result  string
+	Notes   int
+	Tackles int
+	// Commentary is the free-form match commentary (Markdown), or ""
+	Commentary string
+	// Lineup is the match lineup by shirt number, or nil
+	Lineup []db.LineupPlayer
+}
+
+// Cell is a player's tackle counts in one match.
+type Cell struct {
+	Played    bool
+	Total     int
+	Completed int
+	Missed    int
+}
+
+// Pct returns the completion percentage (completed / (completed + missed)), or -1 when undefined.
+func (c Cell) Pct() float64 {
+	if c.Completed+c.Missed == 0 {
+		return -1
+	}
+	return float64(c.Completed) / float64(c.Completed+c.Missed) * 100
+}
+
+// String renders the cell as "7/9 78%", or "-" when the player has no tackles in the match.
+func (c Cell) String() string {
+	if !c.Played {
+		return "-"
+	}
+	if pct := c.Pct(); pct >= 0 {
+		return fmt.Sprintf("%d/%d %.0f%%", c.Completed, c.Completed+c.Missed, pct)
+	}
+	return fmt.Sprintf("%d", c.Total)
+}
+
+// PlayerProgress is a player's tackling across the report's matches, in match order.
+type PlayerProgress struct {
+	Player string
+	Cells  []Cell
+	Total  Cell
+	// Trend compares the first and last matches with a completion rate: "↑", "↓", "→" or ""
+	Trend string
+}
+
+// Flagged is a starred note listed in the report.
+type Flagged struct {
+	NoteID   int64
+	Match    string
+	Category string
+	Start    float64
+	Player   string
+	Text     string
+	// ClipPath is the exported clip file, or "" when no clip has been rendered
+	ClipPath string
+}
+
+// trendThreshold is the change in completion percentage needed to show ↑ or ↓.
+const trendThreshold = 5.0
+
+// BuildWeekly assembles the report from the rows selected for the date range.
+func BuildWeekly(from, to string, matches []db.ReportMatch, tackles []db.ReportPlayerTackles, flagged []db.FlaggedClip) Weekly {
+	r := Weekly{From: from, To: to}
+
+	index := make(map[int64]int, len(matches))
+	for i, m := range matches {
+		name := m.Match.Title()
+		if name == "" {
+			name = m.Filename
+		}
+		if name == "" {
+			name = filepath.Base(m.Path)
+		}
+		date := m.Match.Date
+		if date == "" {
+			date = m.FirstTagged
+		}
+		if len(date) >= 10 {
+			date = date[:10]
+		}
+		r.Matches = append(r.Matches, Match{VideoID: m.VideoID, Name: name, Date: date, Result: m.Match.Result, Notes: m.Notes, Commentary: m.Commentary})
+		index[m.VideoID] = i
+	}
+
+	players := make(map[string]*PlayerProgress)
+	for _, t := range tackles {
+		i, ok := index[t.VideoID]
+		if !ok {
+			continue
+		}
+		p := players[t.Player]
+		if p == nil {
+			p = &PlayerProgress{Player: t.Player, Cells: make([]Cell, len(matches))}
+			players[t.Player] = p
+		}
+		p.Cells[i] = Cell{Played: true, Total: t.Total, Completed: t.Completed, Missed: t.Missed}
+		p.Total.Played = true
+		p.Total.Total += t.Total
+		p.Total.Completed += t.Completed
+		p.Total.Missed += t.Missed
+		r.Matches[i].Tackles += t.Total
+	}
+	for _, p := range players {
+		p.Trend = trend(p.Cells)
+		r.Players = append(r.Players, *p)
+	}
+	sort.Slice(r.Players, func(i, j int) bool {
+		if r.Players[i].Total.Total != r.Players[j].Total.Total {
+			return r.Players[i].Total.Total > r.Players[j].Total.Total
+		}
+		return r.Players[i].Player < r.Players[j].Player
+	})
+
+	for _, f := range flagged {
+		item := Flagged{
+			NoteID:   f.NoteID,
+			Category: f.Category,
+			Start:    f.Start,
+			Player:   f.Player,
+			Text:     f.Text,
+		}
+		i, ok := index[f.VideoID]
+		if !ok {
+			continue
+		}
+		item.Match = r.Matches[i].Name
+		if f.ClipStatus == "completed" && f.ClipFile != "" {
+			item.ClipPath = filepath.Join(f.ClipFolder, f.ClipFile)
+		}
+		r.Flagged = append(r.Flagged, item)
+	}
+	return r
+}
+
+// trend compares the completion rate of the first and last matches that have one.
+func trend(cells []Cell) string {
+	first, last := -1.0, -1.0
+	n := 0
+	for _, c := range cells {
+		if pct := c.Pct(); pct >= 0 {
+			if first < 0 {
+				first = pct
+			}
+			last = pct
+			n++
+		}
+	}
+	if n < 2 {
+		return ""
+	}
+	switch {
+	case last-first >= trendThreshold:
+		return "↑"
+	case first-last >= trendThreshold:
+		return "↓"
+	default:
+		return "→"
+	}
+}
@@ -0,0 +1,245 @@
+// Package script embeds a Lua interpreter so users can define custom TUI
+// commands and key handlers in ~/.config/tagging-rugby/scripts/*.lua.
+//
+// Scripts use the global `rugby` table:
+//
+//	rugby.command(name, fn)      register :name; fn receives the arguments as a table
+//	rugby.key(key, fn)           bind a key (bubbletea key name, e.g. "ctrl+g", "f5")
+//	rugby.time()                 current playback position in seconds
+//	rugby.seek(seconds)          seek to an absolute position
+//	rugby.seek_relative(delta)   seek forward/backward
+//	rugby.insert(category, text [, seconds])  insert a note, returns its ID
+//	rugby.stats()                {notes=, tackles=, players={[name]={total=, completed=, missed=}}}
+//	rugby.message(text)          show text in the TUI footer
+package script
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/config"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// PlayerStats holds per-player tackle counts exposed to scripts.
+type PlayerStats struct {
+	Total     int
+	Completed int
+	Missed    int
+}
+
+// Stats is the summary returned by rugby.stats().
+type Stats struct {
+	Notes   int
+	Tackles int
+	Players map[string]PlayerStats
+}
+
+// Host is implemented by the TUI to give scripts access to playback and the database.
+type Host interface {
+	TimePos() (float64, error)
+	Seek(seconds float64) error
+	SeekRelative(seconds float64) error
+	InsertNote(category, text string, at float64) (int64, error)
+	Stats() (Stats, error)
+	Message(text string)
+}
+
+// Engine holds a Lua state and the commands and keys registered by scripts.
+type Engine struct {
+	state    *lua.LState
+	host     Host
+	commands map[string]*lua.LFunction
+	keys     map[string]*lua.LFunction
+}
+
+// Dir returns the scripts directory, ~/.config/tagging-rugby/scripts.
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "scripts"), nil
+}
+
+// New creates an engine bound to host with the rugby API installed.
+func New(host Host) *Engine {
+	e := &Engine{
+		state:    lua.NewState(),
+		host:     host,
+		commands: make(map[string]*lua.LFunction),
+		keys:     make(map[string]*lua.LFunction),
+	}
+	e.installAPI()
+	return e
+}
+
+// Close releases the Lua state.
+func (e *Engine) Close() {
+	e.state.Close()
+}
+
+// LoadDir runs every .lua file in dir in name order. A missing directory is not an error.
+func (e *Engine) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read scripts dir: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".lua") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := e.state.DoFile(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("load %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Commands returns the names of script-defined commands, sorted.
+func (e *Engine) Commands() []string {
+	names := make([]string, 0, len(e.commands))
+	for name := range e.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasCommand reports whether a script registered the command.
+func (e *Engine) HasCommand(name string) bool {
+	_, ok := e.commands[name]
+	return ok
+}
+
+// RunCommand calls the command's handler. A string returned by the handler becomes the result.
+func (e *Engine) RunCommand(name string, args []string) (string, error) {
+	fn, ok := e.commands[name]
+	if !ok {
+		return "", fmt.Errorf("unknown script command: %s", name)
+	}
+	argTable := e.state.NewTable()
+	for _, a := range args {
+		argTable.Append(lua.LString(a))
+	}
+	return e.call(fn, argTable)
+}
+
+// HasKey reports whether a script bound the key.
+func (e *Engine) HasKey(key string) bool {
+	_, ok := e.keys[key]
+	return ok
+}
+
+// RunKey calls the key's handler.
+func (e *Engine) RunKey(key string) (string, error) {
+	fn, ok := e.keys[key]
+	if !ok {
+		return "", fmt.Errorf("unbound key: %s", key)
+	}
+	return e.call(fn)
+}
+
+// call invokes fn and converts a string return value into the result.
+func (e *Engine) call(fn *lua.LFunction, args ...lua.LValue) (string, error) {
+	if err := e.state.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, args...); err != nil {
+		return "", err
+	}
+	ret := e.state.Get(-1)
+	e.state.Pop(1)
+	if ret == lua.LNil {
+		return "", nil
+	}
+	return ret.String(), nil
+}
+
+// installAPI registers the global rugby table.
+func (e *Engine) installAPI() {
+	L := e.state
+	api := L.NewTable()
+	L.SetFuncs(api, map[string]lua.LGFunction{
+		"command": func(L *lua.LState) int {
+			e.commands[L.CheckString(1)] = L.CheckFunction(2)
+			return 0
+		},
+		"key": func(L *lua.LState) int {
+			e.keys[L.CheckString(1)] = L.CheckFunction(2)
+			return 0
+		},
+		"time": func(L *lua.LState) int {
+			t, err := e.host.TimePos()
+			if err != nil {
+				L.RaiseError("time: %v", err)
+			}
+			L.Push(lua.LNumber(t))
+			return 1
+		},
+		"seek": func(L *lua.LState) int {
+			if err := e.host.Seek(float64(L.CheckNumber(1))); err != nil {
+				L.RaiseError("seek: %v", err)
+			}
+			return 0
+		},
+		"seek_relative": func(L *lua.LState) int {
+			if err := e.host.SeekRelative(float64(L.CheckNumber(1))); err != nil {
+				L.RaiseError("seek_relative: %v", err)
+			}
+			return 0
+		},
+		"insert": func(L *lua.LState) int {
+			category := L.CheckString(1)
+			text := L.OptString(2, "")
+			var at float64
+			if L.GetTop() >= 3 {
+				at = float64(L.CheckNumber(3))
+			} else {
+				t, err := e.host.TimePos()
+				if err != nil {
+					L.RaiseError("insert: %v", err)
+				}
+				at = t
+			}
+			id, err := e.host.InsertNote(category, text, at)
+			if err != nil {
+				L.RaiseError("insert: %v", err)
+			}
+			L.Push(lua.LNumber(id))
+			return 1
+		},
+		"stats": func(L *lua.LState) int {
+			stats, err := e.host.Stats()
+			if err != nil {
+				L.RaiseError("stats: %v", err)
+			}
+			t := L.NewTable()
+			t.RawSetString("notes", lua.LNumber(stats.Notes))
+			t.RawSetString("tackles", lua.LNumber(stats.Tackles))
+			players := L.NewTable()
+			for name, ps := range stats.Players {
+				p := L.NewTable()
+				p.RawSetString("total", lua.LNumber(ps.Total))
+				p.RawSetString("completed", lua.LNumber(ps.Completed))
+				p.RawSetString("missed", lua.LNumber(ps.Missed))
+				players.RawSetString(name, p)
+			}
+			t.RawSetString("players", players)
+			L.Push(t)
+			return 1
+		},
+		"message": func(L *lua.LState) int {
+			e.host.Message(L.CheckString(1))
+			return 0
+		},
+	})
+	L.SetGlobal("rugby", api)
+}
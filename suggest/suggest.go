@@ -0,0 +1,113 @@
+// Package suggest defines the event suggester hook: an external process that
+// inspects a video and proposes candidate events for the user to review.
+package suggest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// Suggestion is a candidate event proposed by a suggester.
+type Suggestion struct {
+	// Time is the video timestamp in seconds
+	Time float64 `json:"time"`
+	// Label is a short description shown in the review queue (e.g. "try", "lineout")
+	Label string `json:"label"`
+	// Category is the note category to use when accepted (defaults to Label)
+	Category string `json:"category,omitempty"`
+	// Confidence is an optional score between 0 and 1
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// Suggester produces candidate events for a video.
+type Suggester interface {
+	Suggest(ctx context.Context, videoPath string) ([]Suggestion, error)
+}
+
+// Command is a Suggester backed by an external process. The video path is appended
+// as the final argument; the process prints suggestions on stdout, either as a JSON
+// array or as one JSON object per line.
+type Command struct {
+	// Line is the command line, split on whitespace (e.g. "python3 detect.py --model tries")
+	Line string
+}
+
+// Suggest runs the command and parses its output.
+func (c Command) Suggest(ctx context.Context, videoPath string) ([]Suggestion, error) {
+	args := strings.Fields(c.Line)
+	if len(args) == 0 {
+		return nil, errors.New("empty suggest command")
+	}
+	args = append(args, videoPath)
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", args[0], err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", args[0], err)
+	}
+	return Parse(stdout.Bytes())
+}
+
+// Parse decodes suggester output (a JSON array or JSON lines), fills in missing
+// categories and returns the suggestions sorted by time.
+func Parse(out []byte) ([]Suggestion, error) {
+	var suggestions []Suggestion
+	trimmed := bytes.TrimSpace(out)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	if trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &suggestions); err != nil {
+			return nil, fmt.Errorf("parse suggestions: %w", err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+		line := 0
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+			var s Suggestion
+			if err := json.Unmarshal([]byte(text), &s); err != nil {
+				return nil, fmt.Errorf("parse suggestion on line %d: %w", line, err)
+			}
+			suggestions = append(suggestions, s)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("read suggestions: %w", err)
+		}
+	}
+
+	valid := suggestions[:0]
+	for _, s := range suggestions {
+		if s.Time < 0 {
+			continue
+		}
+		s.Label = strings.TrimSpace(s.Label)
+		s.Category = strings.TrimSpace(s.Category)
+		if s.Category == "" {
+			s.Category = s.Label
+		}
+		if s.Category == "" {
+			s.Category = "note"
+		}
+		valid = append(valid, s)
+	}
+	sort.SliceStable(valid, func(i, j int) bool { return valid[i].Time < valid[j].Time })
+	return valid, nil
+}
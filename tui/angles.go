@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/mpv"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+)
+
+// loadAngles reloads the camera angles linked to the current video.
+func (m *Model) loadAngles() {
+	if m.db == nil || m.videoID <= 0 {
+		m.angles = nil
+		return
+	}
+	angles, err := db.SelectVideoAngles(m.db, m.videoID)
+	if err != nil {
+		return
+	}
+	m.angles = angles
+}
+
+// nextAngle switches mpv to the next camera angle (wrapping back to the main
+// video) at the same moment of the match. While an angle plays, m.client is
+// wrapped so positions stay in the main video's time.
+func (m *Model) nextAngle() string {
+	if len(m.angles) == 0 {
+		return "No camera angles linked (video angle add)"
+	}
+	if m.client == nil || !m.client.IsConnected() {
+		return "mpv not connected"
+	}
+	pos, err := m.client.GetTimePos()
+	if err != nil {
+		return "Angle switch failed: " + err.Error()
+	}
+	if m.basePlayer == nil {
+		m.basePlayer = m.client
+	}
+
+	next := (m.angleIndex + 1) % (len(m.angles) + 1)
+	path, label, offset := m.videoPath, "", 0.0
+	if next > 0 {
+		a := m.angles[next-1]
+		path, label, offset = a.Path, a.Label, a.Offset
+	}
+	if err := m.basePlayer.LoadFile(path, pos+offset); err != nil {
+		return "Angle switch failed: " + err.Error()
+	}
+
+	m.angleIndex = next
+	m.statusBar.Angle = label
+	// The new file has its own chapters; push ours again on the next tick
+	m.chaptersPushed = len(m.chapters) == 0
+	if next == 0 {
+		m.client = m.basePlayer
+		return "Main angle at " + timeutil.FormatTime(pos)
+	}
+	m.client = &mpv.OffsetPlayer{Player: m.basePlayer, Offset: offset}
+	return fmt.Sprintf("Angle %s at %s", label, timeutil.FormatTime(pos))
+}
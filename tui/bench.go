@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/user/tagging-rugby-cli/mpv"
+)
+
+// BenchResult is the timing of one measured TUI operation.
+type BenchResult struct {
+	Name           string
+	Runs           int
+	Mean, Min, Max time.Duration
+}
+
+// Bench times the work the TUI repeats while running, against the events
+// stored for videoPath: reloading the notes list, the stats panel query, a
+// whole tick and a full screen render. Each is run iterations times.
+func Bench(database *sql.DB, videoPath string, iterations int) ([]BenchResult, error) {
+	m, err := NewTestModel(mpv.NewFake(5400), database, videoPath)
+	if err != nil {
+		return nil, err
+	}
+	if iterations < 1 {
+		iterations = 1
+	}
+	measure := func(name string, fn func()) BenchResult {
+		r := BenchResult{Name: name, Runs: iterations}
+		var total time.Duration
+		for i := 0; i < iterations; i++ {
+			start := time.Now()
+			fn()
+			d := time.Since(start)
+			total += d
+			if i == 0 || d < r.Min {
+				r.Min = d
+			}
+			if d > r.Max {
+				r.Max = d
+			}
+		}
+		r.Mean = total / time.Duration(iterations)
+		return r
+	}
+	return []BenchResult{
+		measure("list", m.loadNotesAndTackles),
+		measure("stats", m.loadTackleStatsForPanel),
+		measure("tick", func() { m.Update(tickMsg(time.Now())) }),
+		measure("render", func() { m.render() }),
+	}, nil
+}
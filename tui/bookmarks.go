@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// loadBookmarks reloads the bookmarks for the current video.
+func (m *Model) loadBookmarks() {
+	if m.db == nil || m.videoID <= 0 {
+		m.bookmarks = nil
+		return
+	}
+	bookmarks, err := db.SelectBookmarksByVideo(m.db, m.videoID)
+	if err != nil {
+		return
+	}
+	m.bookmarks = bookmarks
+}
+
+// bookmarkTimes returns the bookmark positions for rendering.
+func (m *Model) bookmarkTimes() []float64 {
+	times := make([]float64, len(m.bookmarks))
+	for i, b := range m.bookmarks {
+		times[i] = b.Time
+	}
+	return times
+}
+
+// addBookmark drops a bookmark at the current playback position.
+func (m *Model) addBookmark() string {
+	if m.videoID <= 0 {
+		return "Bookmarks need a registered video"
+	}
+	if m.client == nil || !m.client.IsConnected() {
+		return "Bookmark failed: mpv not connected"
+	}
+	timePos, err := m.client.GetTimePos()
+	if err != nil {
+		return "Bookmark failed: " + err.Error()
+	}
+	if _, err := db.InsertBookmark(m.db, m.videoID, timePos); err != nil {
+		return "Bookmark failed: " + err.Error()
+	}
+	m.loadBookmarks()
+	return "Bookmarked " + timeutil.FormatTime(timePos)
+}
+
+// jumpToBookmark seeks to the nth bookmark (1-based).
+func (m *Model) jumpToBookmark(n int) string {
+	if n < 1 || n > len(m.bookmarks) {
+		return fmt.Sprintf("No bookmark %d", n)
+	}
+	if m.client == nil || !m.client.IsConnected() {
+		return "mpv not connected"
+	}
+	t := m.bookmarks[n-1].Time
+	if err := m.client.Seek(t); err != nil {
+		return "Seek failed: " + err.Error()
+	}
+	return fmt.Sprintf("Bookmark %d: %s", n, timeutil.FormatTime(t))
+}
+
+// jumpToNextBookmark seeks to the first bookmark after the playhead, wrapping
+// to the first bookmark at the end.
+func (m *Model) jumpToNextBookmark() string {
+	if len(m.bookmarks) == 0 {
+		return "No bookmarks"
+	}
+	// Small margin so repeated presses move past the bookmark just jumped to
+	next := 1
+	for i, b := range m.bookmarks {
+		if b.Time > m.statusBar.TimePos+0.5 {
+			next = i + 1
+			break
+		}
+	}
+	return m.jumpToBookmark(next)
+}
+
+// executeBookmarkCommand handles :bookmark [add|delete <n>|clear|<n>].
+func (m *Model) executeBookmarkCommand(args []string) (string, error) {
+	if len(args) == 0 || args[0] == "add" {
+		return m.addBookmark(), nil
+	}
+	switch args[0] {
+	case "delete", "rm":
+		if len(args) < 2 {
+			return "", fmt.Errorf("usage: bookmark delete <n>")
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 || n > len(m.bookmarks) {
+			return "", fmt.Errorf("no bookmark %s", args[1])
+		}
+		if err := db.DeleteBookmark(m.db, m.bookmarks[n-1].ID); err != nil {
+			return "", err
+		}
+		m.loadBookmarks()
+		return fmt.Sprintf("Deleted bookmark %d", n), nil
+	case "clear":
+		if m.videoID <= 0 {
+			return "No bookmarks", nil
+		}
+		if err := db.DeleteBookmarksByVideo(m.db, m.videoID); err != nil {
+			return "", err
+		}
+		m.loadBookmarks()
+		return "Cleared bookmarks", nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return "", fmt.Errorf("usage: bookmark [add|delete <n>|clear|<n>]")
+	}
+	return m.jumpToBookmark(n), nil
+}
+
+// bookmarkResult shows a bookmark action's message in the footer.
+func (m *Model) bookmarkResult(msg string) (tea.Model, tea.Cmd) {
+	return m.showStatus(msg)
+}
+
+// showStatus shows msg in the footer for a few seconds.
+func (m *Model) showStatus(msg string) (tea.Model, tea.Cmd) {
+	m.statusMsg = msg
+	m.notify(components.LevelInfo, msg)
+	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+		return clearStatusMsg{}
+	})
+}
+
+// renderBookmarks returns the bookmarks panel, or "" when there are none.
+func (m *Model) renderBookmarks(width int) string {
+	if len(m.bookmarks) == 0 && !m.bookmarkPending {
+		return ""
+	}
+	return components.BookmarksBox(m.bookmarkTimes(), m.statusBar.TimePos, m.bookmarkPending, width)
+}
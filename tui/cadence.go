@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// updateSinceEvent shows in the status bar how much video has played since
+// the last event before the playhead, so long untagged spells stand out. Past
+// "event_gap_warning" in config.json it turns to a warning, and notifies once
+// while playing until an event closes the gap.
+func (m *Model) updateSinceEvent() {
+	timePos := m.statusBar.TimePos
+	// The first event after the playhead; the one before it is the last
+	i := sort.Search(len(m.eventTimes), func(i int) bool { return m.eventTimes[i] > timePos })
+	if i == 0 {
+		m.statusBar.SinceEvent = ""
+		m.statusBar.SinceEventWarn = false
+		m.gapWarned = false
+		return
+	}
+	gap := timePos - m.eventTimes[i-1]
+	m.statusBar.SinceEvent = formatGap(gap)
+
+	warn := m.config != nil && m.config.EventGapWarning > 0 && gap >= m.config.EventGapWarning
+	m.statusBar.SinceEventWarn = warn
+	switch {
+	case !warn:
+		m.gapWarned = false
+	case !m.gapWarned && !m.statusBar.Paused:
+		m.gapWarned = true
+		m.notify(components.LevelWarning, fmt.Sprintf("No event tagged for %s of play", formatGap(gap)))
+	}
+}
+
+// formatGap formats seconds as M:SS, or H:MM:SS from an hour.
+func formatGap(seconds float64) string {
+	total := int(seconds)
+	if total >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", total/3600, total%3600/60, total%60)
+	}
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// captureCommandTime records the playback position as command mode opens, so a
+// note or tackle typed at the prompt is stamped at the moment ':' was pressed
+// rather than when Enter is. The position is shown next to the prompt.
+func (m *Model) captureCommandTime() {
+	m.commandTime = nil
+	m.commandInput.Timestamp = ""
+	if m.client == nil || !m.client.IsConnected() {
+		return
+	}
+	if pos, err := m.client.GetTimePos(); err == nil {
+		m.commandTime = &pos
+		m.commandInput.Timestamp = timeutil.FormatTime(pos)
+	}
+}
+
+// eventTimestamp returns the timestamp for a new event: the position captured
+// when command mode opened, or the current position for a direct keypress.
+func (m *Model) eventTimestamp() (float64, error) {
+	if m.commandTime != nil {
+		return *m.commandTime, nil
+	}
+	return m.client.GetTimePos()
+}
+
+// recaptureFormTime replaces an open form's timestamp with the current mpv
+// position, for when the form was opened a moment too early or late.
+func (m *Model) recaptureFormTime(timestamp *float64) {
+	pos, err := m.client.GetTimePos()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Time not re-captured: %v", err)
+		m.notify(components.LevelWarning, m.statusMsg)
+		return
+	}
+	*timestamp = pos
+	if m.videoID > 0 {
+		_ = db.UpdateVideoTimingStopped(m.db, m.videoID, pos)
+	}
+}
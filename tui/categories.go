@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// categorySuggestions returns the category paths used before, in any video,
+// for completion in the note form: each path and its parents, most used
+// first, followed by template categories not used yet.
+func (m *Model) categorySuggestions() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if key := strings.ToLower(path); path != "" && !seen[key] {
+			seen[key] = true
+			paths = append(paths, path)
+		}
+	}
+	if m.db != nil {
+		counts, _ := db.SelectCategoryCounts(m.db, db.VideoScope{})
+		for _, c := range catpath.RollUp(categoryCounts(counts), 0) {
+			for _, p := range catpath.Ancestors(c.Path) {
+				add(p)
+			}
+		}
+	}
+	for _, t := range m.noteTemplates() {
+		add(catpath.Normalize(t.Category))
+	}
+	return paths
+}
+
+// categoryCounts converts category counts from the database for catpath.
+func categoryCounts(counts []db.CategoryCount) []catpath.Count {
+	out := make([]catpath.Count, len(counts))
+	for i, c := range counts {
+		out[i] = catpath.Count{Path: c.Category, Count: c.Uses}
+	}
+	return out
+}
+
+// categoryLevel is the category depth the event distribution counts at: one
+// level below the category filter, so filtering drills down the tree.
+func (m *Model) categoryLevel() int {
+	return catpath.Depth(m.categoryFilter) + 1
+}
+
+// filterItemsByCategory returns the items in category or its subcategories.
+func filterItemsByCategory(items []components.ListItem, category string) []components.ListItem {
+	var out []components.ListItem
+	for _, item := range items {
+		if catpath.Under(item.Category, category) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// setCategoryFilter limits the notes list to a category and its subcategories,
+// "" for all.
+func (m *Model) setCategoryFilter(category string) {
+	m.categoryFilter = catpath.Normalize(category)
+	m.notesList.SelectedIndex = 0
+	m.notesList.ScrollOffset = 0
+	m.loadNotesAndTackles()
+}
+
+// clearCategoryFilter clears the category filter (Esc in the notes list).
+func (m *Model) clearCategoryFilter() (tea.Model, tea.Cmd) {
+	m.setCategoryFilter("")
+	return m.showStatus("Category filter cleared")
+}
+
+// executeCategoryCommand handles :category [<path>|clear]. A path filters the
+// notes list to it and its subcategories; on its own the command lists the
+// event counts one level below the current filter.
+func (m *Model) executeCategoryCommand(args []string) (string, error) {
+	path := catpath.Normalize(strings.Join(args, " "))
+	switch path {
+	case "":
+		return m.categoryBreakdown()
+	case "clear":
+		m.setCategoryFilter("")
+		return "Category filter cleared", nil
+	}
+	m.setCategoryFilter(path)
+	if len(m.notesList.Items) == 0 {
+		return fmt.Sprintf("No events in %s (:category clear shows all)", path), nil
+	}
+	return fmt.Sprintf("%d event(s) in %s (Esc or :category clear shows all)", len(m.notesList.Items), path), nil
+}
+
+// categoryBreakdown describes the video's event counts one level below the
+// category filter, e.g. "set-piece: lineout 8, scrum 4".
+func (m *Model) categoryBreakdown() (string, error) {
+	counts, err := db.SelectCategoryCounts(m.db, db.VideoScope{VideoID: m.videoID})
+	if err != nil {
+		return "", fmt.Errorf("failed to count categories: %w", err)
+	}
+	var under []catpath.Count
+	for _, c := range categoryCounts(counts) {
+		if catpath.Under(c.Path, m.categoryFilter) {
+			under = append(under, c)
+		}
+	}
+	rolled := catpath.RollUp(under, m.categoryLevel())
+	if len(rolled) == 0 {
+		return "No categorised events", nil
+	}
+	parts := make([]string, len(rolled))
+	for i, c := range rolled {
+		// Named by their last level, as in the event distribution
+		levels := catpath.Levels(c.Path)
+		parts[i] = fmt.Sprintf("%s %d", levels[len(levels)-1], c.Count)
+	}
+	prefix := "Categories"
+	if m.categoryFilter != "" {
+		prefix = m.categoryFilter
+	}
+	return prefix + ": " + strings.Join(parts, ", "), nil
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// changesState holds the events added or edited since the previous session on
+// this video, read once at startup so this session's own edits don't count.
+type changesState struct {
+	// Session is the previous finished session (ID 0 when there is none)
+	Session db.Session
+	Changes []db.NoteChange
+}
+
+// loadChanges reads what changed since the previous session and summarises it
+// in the footer. Must run before startSession records the new session.
+func (m *Model) loadChanges() {
+	if m.db == nil || m.videoID <= 0 {
+		return
+	}
+	session, err := db.SelectPreviousSession(m.db, m.videoID)
+	if err != nil {
+		return
+	}
+	changes, err := db.SelectNoteChanges(m.db, m.videoID, session.ID)
+	if err != nil {
+		return
+	}
+	m.changes = changesState{Session: session, Changes: changes}
+	if len(changes) == 0 {
+		return
+	}
+	added, edited := m.changes.counts()
+	summary := fmt.Sprintf("Since last session (%s): %d added, %d edited — :changes to list",
+		session.StartedAt.Local().Format("2006-01-02 15:04"), added, edited)
+	m.notify(components.LevelInfo, summary)
+	// Don't hide a startup error already in the footer
+	if m.statusMsg == "" {
+		m.statusMsg = summary
+	}
+}
+
+// counts returns how many changes are new events and how many are edits.
+func (c changesState) counts() (added, edited int) {
+	for _, ch := range c.Changes {
+		if ch.Added {
+			added++
+		}
+	}
+	return added, len(c.Changes) - added
+}
+
+// executeChangesCommand handles :changes, listing the changes in the message
+// history and opening it.
+func (m *Model) executeChangesCommand() (string, error) {
+	if m.changes.Session.ID == 0 {
+		return "No earlier session on this video", nil
+	}
+	since := m.changes.Session.StartedAt.Local().Format("2006-01-02 15:04")
+	if len(m.changes.Changes) == 0 {
+		return fmt.Sprintf("No events added or edited since last session (%s)", since), nil
+	}
+	added, edited := m.changes.counts()
+	m.notify(components.LevelInfo, fmt.Sprintf("Since last session (%s): %d added, %d edited", since, added, edited))
+	for _, c := range m.changes.Changes {
+		change, when := "added", c.CreatedAt
+		if !c.Added {
+			change, when = "edited", c.UpdatedAt.Time
+		}
+		text := fmt.Sprintf("#%d %s @ %s %s %s", c.NoteID, c.Category, timeutil.FormatTime(c.Time), change, when.Local().Format("15:04"))
+		if c.Player != "" {
+			text += " — " + c.Player
+		}
+		m.notify(components.LevelInfo, text)
+	}
+	m.showMessages = true
+	m.commandInput.Unread = 0
+	return "", nil
+}
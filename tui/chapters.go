@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/mpv"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// buildChapters returns the match structure as chapters: each period with the
+// breaks around it, plus a chapter at every try. periods must be sorted.
+func (m *Model) buildChapters(items []components.ListItem) []mpv.Chapter {
+	var chapters []mpv.Chapter
+	periods := m.gameClock.Periods
+	for i, p := range periods {
+		if i == 0 && p.Start > 0 {
+			chapters = append(chapters, mpv.Chapter{Title: "Pre-match", Time: 0})
+		}
+		chapters = append(chapters, mpv.Chapter{Title: p.Name, Time: p.Start})
+		switch {
+		case i == len(periods)-1:
+			chapters = append(chapters, mpv.Chapter{Title: "Full time", Time: p.End})
+		case periods[i+1].Start > p.End:
+			chapters = append(chapters, mpv.Chapter{Title: "Half-time", Time: p.End})
+		}
+	}
+	for _, item := range items {
+		if strings.EqualFold(item.Category, "try") {
+			chapters = append(chapters, mpv.Chapter{Title: "Try", Time: item.TimestampSeconds})
+		}
+	}
+	sort.SliceStable(chapters, func(i, j int) bool { return chapters[i].Time < chapters[j].Time })
+	return chapters
+}
+
+// syncChapters rebuilds the chapters from the periods and tries on the video and
+// pushes them to mpv when they change. A failed push is retried on the next call.
+// Nothing is pushed when there are no chapters, leaving the file's own alone.
+func (m *Model) syncChapters(items []components.ListItem) {
+	chapters := m.buildChapters(items)
+	if !slices.Equal(chapters, m.chapters) {
+		m.chapters = chapters
+		m.chaptersPushed = len(chapters) == 0
+	}
+	if m.chaptersPushed || m.client == nil || !m.client.IsConnected() {
+		return
+	}
+	if err := m.client.SetChapters(m.chapters); err == nil {
+		m.chaptersPushed = true
+	}
+}
+
+// currentChapter returns the title of the chapter playing at t, or "".
+func (m *Model) currentChapter(t float64) string {
+	title := ""
+	for _, ch := range m.chapters {
+		if ch.Time > t {
+			break
+		}
+		title = ch.Title
+	}
+	return title
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/clip"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// openClipExport handles Ctrl+E: it queues clips for every event of the
+// selected event's player, or for every starred event when it has none, and
+// opens the clip export view to follow the background worker through them.
+// Clips already done or on their way are left alone; failed ones are retried.
+func (m *Model) openClipExport() (tea.Model, tea.Cmd) {
+	if m.videoID <= 0 {
+		return m.showStatus("Clip export needs a registered video")
+	}
+	if videosrc.IsURL(m.videoPath) {
+		return m.showStatus("Clip export unavailable for streamed video (use 'video relink')")
+	}
+	clips, err := db.SelectVideoClips(m.db, m.videoID)
+	if err != nil {
+		return m.showStatus("Error: " + err.Error())
+	}
+
+	var player string
+	if item := m.notesList.GetSelectedItem(); item != nil {
+		player = item.Player
+	}
+	scope := player
+	if scope == "" {
+		scope = "starred events"
+	}
+
+	view := components.ClipsViewState{Active: true, Scope: scope}
+	queued := 0
+	for _, c := range clips {
+		if player != "" && !strings.EqualFold(c.Player, player) || player == "" && !c.Starred {
+			continue
+		}
+		if c.Status == "" || c.Status == "error" {
+			if err := m.queueClip(c); err != nil {
+				return m.showStatus("Error: " + err.Error())
+			}
+			queued++
+		}
+		view.Items = append(view.Items, components.ClipItem{NoteID: c.NoteID, Time: c.Start, Category: c.Category, Player: c.Player})
+	}
+	m.clipsView = view
+	m.refreshClipsView()
+
+	if len(view.Items) == 0 {
+		m.setResult("No events to export for "+scope, false)
+	} else {
+		m.setResult(fmt.Sprintf("Queued %d clip(s) for %s", queued, scope), false)
+	}
+	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+		return clearResultMsg{}
+	})
+}
+
+// queueClip queues the clip of an event for the background worker, named
+// after its tackle when it has one.
+func (m *Model) queueClip(c db.VideoClip) error {
+	folder, filename := clip.ClipPaths(m.videoPath, c.Category, c.Player, c.Attempt, c.Outcome, c.Start)
+	if c.Player == "" {
+		folder, filename = clip.EventClipPaths(m.videoPath, c.Category, c.NoteID, c.Start)
+	}
+	if err := db.UpsertNoteClipPending(m.db, c.NoteID, folder, filename); err != nil {
+		return fmt.Errorf("queue clip for note %d: %w", c.NoteID, err)
+	}
+	return nil
+}
+
+// refreshClipsView reloads the export status of the view's events, and moves
+// the spinner on while any is processing.
+func (m *Model) refreshClipsView() {
+	if !m.clipsView.Active || m.db == nil {
+		return
+	}
+	clips, err := db.SelectVideoClips(m.db, m.videoID)
+	if err != nil {
+		return
+	}
+	byNote := make(map[int64]db.VideoClip, len(clips))
+	for _, c := range clips {
+		byNote[c.NoteID] = c
+	}
+
+	// A new slice, so the tick's before and after snapshots can differ
+	items := make([]components.ClipItem, len(m.clipsView.Items))
+	for i, item := range m.clipsView.Items {
+		c := byNote[item.NoteID]
+		item.Status = c.Status
+		item.Log = lastLine(c.Log)
+		items[i] = item
+	}
+	m.clipsView.Items = items
+	if _, processing, _, _ := m.clipsView.Counts(); processing > 0 {
+		m.clipsView.Frame++
+	}
+}
+
+// retryClips re-queues the failed clips of the export view: the selected one,
+// or all of them.
+func (m *Model) retryClips(all bool) (tea.Model, tea.Cmd) {
+	clips, err := db.SelectVideoClips(m.db, m.videoID)
+	if err != nil {
+		return m.showStatus("Error: " + err.Error())
+	}
+	retry := make(map[int64]bool)
+	if all {
+		for _, item := range m.clipsView.Items {
+			retry[item.NoteID] = item.Status == "error"
+		}
+	} else if item := m.clipsView.Selected(); item != nil && item.Status == "error" {
+		retry[item.NoteID] = true
+	}
+
+	n := 0
+	for _, c := range clips {
+		if !retry[c.NoteID] || c.Status != "error" {
+			continue
+		}
+		if err := m.queueClip(c); err != nil {
+			return m.showStatus("Error: " + err.Error())
+		}
+		n++
+	}
+	m.refreshClipsView()
+	if n == 0 {
+		return m.showStatus("No failed clip to retry")
+	}
+	return m.showStatus(fmt.Sprintf("Retrying %d clip(s)", n))
+}
+
+// handleClipsViewKeys handles key events while the clip export view is open.
+func (m *Model) handleClipsViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "backspace":
+		m.clipsView.Active = false
+	case "j", "J", "up":
+		m.clipsView.MoveUp()
+	case "k", "K", "down":
+		m.clipsView.MoveDown()
+	case " ":
+		if item := m.clipsView.Selected(); item != nil && m.client != nil && m.client.IsConnected() {
+			_ = m.client.Seek(item.Time)
+		}
+	case "r":
+		return m.retryClips(false)
+	case "R":
+		return m.retryClips(true)
+	case "ctrl+c":
+		m.quitting = true
+		if timePos, tpErr := m.client.GetTimePos(); tpErr == nil && m.videoID > 0 {
+			_ = db.UpdateVideoTimingStopped(m.db, m.videoID, timePos)
+		}
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// lastLine returns the last non-empty line of s: where ffmpeg says why it
+// failed.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
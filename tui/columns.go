@@ -26,6 +26,8 @@ func (m *Model) renderColumn1(width, height int) string {
 		focusName = "Video"
 	case FocusSearch:
 		focusName = "Search"
+	case FocusStats:
+		focusName = "Stats"
 	}
 	mode := "Normal"
 	if m.searchInput.Mode == "command" {
@@ -55,6 +57,16 @@ func (m *Model) renderColumn1(width, height int) string {
 	summaryBox := components.RenderInfoBox("Summary", summaryLines, width, false)
 	lines = append(lines, strings.Split(summaryBox, "\n")...)
 
+	// Bookmarks panel (only when the video has bookmarks)
+	if bookmarksBox := m.renderBookmarks(width); bookmarksBox != "" {
+		lines = append(lines, strings.Split(bookmarksBox, "\n")...)
+	}
+
+	// Lineup panel (toggled with :lineup)
+	if lineupBox := m.renderLineup(width); lineupBox != "" {
+		lines = append(lines, strings.Split(lineupBox, "\n")...)
+	}
+
 	// Current tag detail card (selected item) — bordered box
 	item := m.notesList.GetSelectedItem()
 	if item != nil {
@@ -67,9 +79,15 @@ func (m *Model) renderColumn1(width, height int) string {
 			innerW = 10
 		}
 
-		typeStr := "Note"
-		if item.Type == components.ItemTypeTackle {
-			typeStr = "Tackle"
+		// Saved events show their number, as references to them use it
+		idStr := fmt.Sprintf("#%d", item.Number)
+		typeStr := fmt.Sprintf("Note (id %d)", item.ID)
+		switch item.Type {
+		case components.ItemTypeTackle:
+			typeStr = fmt.Sprintf("Tackle (id %d)", item.ID)
+		case components.ItemTypeScratch:
+			idStr = fmt.Sprintf("~%d", -item.ID)
+			typeStr = "Scratch (unsaved)"
 		}
 		starStr := ""
 		if item.Starred {
@@ -77,7 +95,7 @@ func (m *Model) renderColumn1(width, height int) string {
 		}
 
 		var contentLines []string
-		contentLines = append(contentLines, detailStyle.Render(fmt.Sprintf(" #%d %s%s", item.ID, typeStr, starStr)))
+		contentLines = append(contentLines, detailStyle.Render(fmt.Sprintf(" %s %s%s", idStr, typeStr, starStr)))
 		contentLines = append(contentLines, dimStyle.Render(fmt.Sprintf(" @ %s", timeutil.FormatTime(item.TimestampSeconds))))
 		if item.Category != "" {
 			contentLines = append(contentLines, dimStyle.Render(fmt.Sprintf(" [%s]", item.Category)))
@@ -89,11 +107,9 @@ func (m *Model) renderColumn1(width, height int) string {
 			contentLines = append(contentLines, dimStyle.Render(fmt.Sprintf(" Team: %s", item.Team)))
 		}
 		if item.Text != "" {
-			text := item.Text
-			if len(text) > innerW {
-				text = text[:innerW-3] + "..."
-			}
-			contentLines = append(contentLines, detailStyle.Render(" "+text))
+			linkStyle := lipgloss.NewStyle().Foreground(styles.Cyan).Underline(true)
+			contentLines = append(contentLines, detailStyle.Render(" ")+refText(item.Text, innerW, detailStyle, linkStyle))
+			contentLines = append(contentLines, m.refLines(item, innerW)...)
 		}
 
 		infoBox := components.RenderInfoBox("Selected Tag", contentLines, width, false)
@@ -119,12 +135,24 @@ func (m *Model) renderColumn2(width, height int) string {
 	if m.tackleForm != nil {
 		return layout.Container{Width: width, Height: height}.Render(m.tackleForm.View())
 	}
+	if m.commentaryForm != nil {
+		return layout.Container{Width: width, Height: height}.Render(m.commentaryForm.View())
+	}
 	if m.showHelp {
-		return layout.Container{Width: width, Height: height}.Render(components.HelpOverlay(width, height))
+		return layout.Container{Width: width, Height: height}.Render(components.HelpOverlay(width, height, m.remappedHelp()))
+	}
+	if m.showMessages {
+		return layout.Container{Width: width, Height: height}.Render(components.MessagesOverlay(m.messages, width, height))
 	}
 	if m.statsView.Active {
 		return layout.Container{Width: width, Height: height}.Render(components.StatsView(m.statsView, width, height))
 	}
+	if m.suggestions.Active {
+		return layout.Container{Width: width, Height: height}.Render(components.SuggestionQueue(m.suggestions, width, height))
+	}
+	if m.clipsView.Active {
+		return layout.Container{Width: width, Height: height}.Render(components.ClipsView(m.clipsView, width, height))
+	}
 
 	// Search box takes 3 lines (InfoBox top border + content + bottom border)
 	searchBoxHeight := 3
@@ -146,11 +174,44 @@ func (m *Model) renderColumn2(width, height int) string {
 	notesOutput := components.NotesList(m.notesList, width-2, innerHeight, m.statusBar.TimePos, m.searchInput.Matches, m.searchInput.CurrentMatch, m.searchInput.Input)
 	notesLines := strings.Split(notesOutput, "\n")
 
-	infoBox := components.RenderInfoBox("Notes", notesLines, width, m.focus == FocusNotes)
+	infoBox := components.RenderInfoBox(m.notesTitle(), notesLines, width, m.focus == FocusNotes)
 	combined := searchBox + "\n" + infoBox
 	return layout.Container{Width: width, Height: height}.Render(combined)
 }
 
+// notesTitle returns the notes box title, naming the player and category when
+// the list is filtered.
+func (m *Model) notesTitle() string {
+	var filters []string
+	if m.playerFilter != "" {
+		filters = append(filters, m.playerFilter)
+	}
+	if m.categoryFilter != "" {
+		filters = append(filters, m.categoryFilter)
+	}
+	if len(filters) == 0 {
+		return "Notes"
+	}
+	return "Notes: " + strings.Join(filters, " · ")
+}
+
+// renderMiniPlayer renders the single-column layout used when the terminal is too
+// narrow for column 2: the video card with a resize warning and the notes list.
+func (m *Model) renderMiniPlayer(width, height int) string {
+	videoBox := components.RenderVideoBox(m.statusBar, width, true, m.focus == FocusVideo)
+	videoHeight := len(strings.Split(videoBox, "\n"))
+
+	// Notes list gets remaining height, less the InfoBox top+bottom border lines
+	innerHeight := height - videoHeight - 2
+	if innerHeight < 3 {
+		innerHeight = 3
+	}
+
+	notesOutput := components.NotesList(m.notesList, width-2, innerHeight, m.statusBar.TimePos, m.searchInput.Matches, m.searchInput.CurrentMatch, m.searchInput.Input)
+	notesBox := components.RenderInfoBox(m.notesTitle(), strings.Split(notesOutput, "\n"), width, m.focus == FocusNotes)
+	return layout.Container{Width: width, Height: height}.Render(videoBox + "\n" + notesBox)
+}
+
 // renderColumn3 renders Column 3: Live stats summary, bar graph, top players leaderboard.
 // When overlayActive is true, returns an empty container (column is hidden by layout anyway).
 func (m *Model) renderColumn3(width, height int, overlayActive bool) string {
@@ -158,14 +219,15 @@ func (m *Model) renderColumn3(width, height int, overlayActive bool) string {
 		return layout.Container{Width: width, Height: height}.Render("")
 	}
 	return layout.Container{Width: width, Height: height}.Render(
-		components.StatsPanel(m.statsView.Stats, m.notesList.Items, width, height))
+		components.StatsPanel(m.statsPanel, m.statsView.Stats, m.playerTrends(), m.notesList.Items, m.categoryLevel(), m.possession, width, height, m.focus == FocusStats))
 }
 
-// renderColumn4 renders Column 4: Keybinding control groups (Playback, Navigation, Views).
+// renderColumn4 renders Column 4: the keybinding control groups valid for the
+// current focus or mode.
 func (m *Model) renderColumn4(width, height int) string {
 	var lines []string
 
-	groups := components.GetControlGroups()
+	groups := m.controlGroups()
 	for i, group := range groups {
 		contentLines := components.ControlGroupLines(group, width-4)
 		box := components.RenderInfoBox(group.Name, contentLines, width, false)
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+	"github.com/user/tagging-rugby-cli/tui/forms"
+)
+
+// openCommentary opens the match commentary editor for the current video.
+func (m *Model) openCommentary() (tea.Model, tea.Cmd) {
+	if m.width < 61 {
+		return m, nil
+	}
+	if m.videoID <= 0 {
+		return m.showStatus("Commentary needs a registered video")
+	}
+	body, err := db.SelectCommentary(m.db, m.videoID)
+	if err != nil {
+		return m.showStatus("Failed to load commentary: " + err.Error())
+	}
+	m.commentaryText = body
+	m.commentaryForm = forms.NewCommentaryForm(videosrc.Base(m.videoPath), &m.commentaryText)
+	return m, m.commentaryForm.Init()
+}
+
+// handleCommentaryFormUpdate delegates messages to the commentary form.
+// Enter saves, Esc closes without saving.
+func (m *Model) handleCommentaryFormUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "esc" {
+		m.commentaryForm = nil
+		return m.showStatus("Commentary not saved")
+	}
+
+	form, cmd := m.commentaryForm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.commentaryForm = f
+	}
+
+	switch m.commentaryForm.State {
+	case huh.StateCompleted:
+		m.commentaryForm = nil
+		if err := db.UpsertCommentary(m.db, m.videoID, m.commentaryText); err != nil {
+			return m.showStatus("Failed to save commentary: " + err.Error())
+		}
+		return m.showStatus("Commentary saved")
+	case huh.StateAborted:
+		m.commentaryForm = nil
+		return m, nil
+	}
+	return m, cmd
+}
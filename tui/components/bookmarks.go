@@ -0,0 +1,48 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// MaxBookmarkJumps is the number of bookmarks reachable with ' + digit.
+const MaxBookmarkJumps = 9
+
+// BookmarksBox renders the bookmarks panel: numbered positions for ' + digit
+// jumps, with the bookmark nearest before the playhead highlighted.
+// pending marks that ' was pressed and a digit is awaited.
+func BookmarksBox(times []float64, timePos float64, pending bool, width int) string {
+	numStyle := lipgloss.NewStyle().Foreground(styles.Purple)
+	timeStyle := lipgloss.NewStyle().Foreground(styles.LightLavender)
+	currentStyle := lipgloss.NewStyle().Foreground(styles.Cyan).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(styles.Lavender).Italic(true)
+
+	current := -1
+	for i, t := range times {
+		if t <= timePos {
+			current = i
+		}
+	}
+
+	var lines []string
+	for i, t := range times {
+		if i >= MaxBookmarkJumps {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf(" +%d more", len(times)-MaxBookmarkJumps)))
+			break
+		}
+		style := timeStyle
+		if i == current {
+			style = currentStyle
+		}
+		lines = append(lines, " "+numStyle.Render(fmt.Sprintf("%d", i+1))+" "+style.Render(timeutil.FormatTime(t)))
+	}
+
+	title := "Bookmarks"
+	if pending {
+		title = "Bookmarks — jump to 1-9"
+	}
+	return RenderInfoBox(title, lines, width, pending)
+}
@@ -0,0 +1,174 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// spinnerFrames animate clips being rendered.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// ClipItem is one event in the clip export view.
+type ClipItem struct {
+	NoteID   int64
+	Time     float64
+	Category string
+	Player   string
+	// Status is the export status: "pending", "processing", "completed" or "error"
+	Status string
+	// Log is the last line of ffmpeg's output when the export failed
+	Log string
+}
+
+// ClipsViewState holds the state for the clip export view.
+type ClipsViewState struct {
+	// Active indicates if the clip export view is currently displayed
+	Active bool
+	// Scope names the events being exported, e.g. "Smith" or "starred events"
+	Scope string
+	// Items are the events being exported, in time order
+	Items []ClipItem
+	// SelectedIndex is the highlighted event
+	SelectedIndex int
+	// Frame is the spinner frame, advanced while clips are processing
+	Frame int
+}
+
+// Selected returns the highlighted event, or nil if there are none.
+func (s *ClipsViewState) Selected() *ClipItem {
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(s.Items) {
+		return nil
+	}
+	return &s.Items[s.SelectedIndex]
+}
+
+// MoveUp moves the selection up one row.
+func (s *ClipsViewState) MoveUp() {
+	if s.SelectedIndex > 0 {
+		s.SelectedIndex--
+	}
+}
+
+// MoveDown moves the selection down one row.
+func (s *ClipsViewState) MoveDown() {
+	if s.SelectedIndex < len(s.Items)-1 {
+		s.SelectedIndex++
+	}
+}
+
+// Counts returns how many clips are in each state.
+func (s ClipsViewState) Counts() (pending, processing, done, failed int) {
+	for _, item := range s.Items {
+		switch item.Status {
+		case "processing":
+			processing++
+		case "completed":
+			done++
+		case "error":
+			failed++
+		default:
+			pending++
+		}
+	}
+	return pending, processing, done, failed
+}
+
+// ClipsView renders the clip export view: overall progress, then each event
+// with its export status.
+func ClipsView(state ClipsViewState, width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.Cyan).
+		Bold(true).
+		Padding(0, 1)
+	subtitleStyle := lipgloss.NewStyle().
+		Foreground(styles.Lavender).
+		Italic(true).
+		Padding(0, 1)
+	rowStyle := lipgloss.NewStyle().Foreground(styles.LightLavender)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.Cyan).Bold(true)
+	statusStyles := map[string]lipgloss.Style{
+		"pending":    lipgloss.NewStyle().Foreground(styles.Lavender),
+		"processing": lipgloss.NewStyle().Foreground(styles.Amber),
+		"completed":  lipgloss.NewStyle().Foreground(styles.Green),
+		"error":      lipgloss.NewStyle().Foreground(styles.Red),
+	}
+
+	pending, processing, done, failed := state.Counts()
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("Clip Export: %s (%d/%d done)", state.Scope, done, len(state.Items))))
+	lines = append(lines, subtitleStyle.Render(fmt.Sprintf("%d pending · %d processing · %d failed", pending, processing, failed)))
+	lines = append(lines, " "+progressBar(done, failed, len(state.Items), width-2))
+	lines = append(lines, subtitleStyle.Render("R retry | Shift+R retry all failed | Space seek | J/K move | Esc to exit"))
+	lines = append(lines, "")
+
+	if len(state.Items) == 0 {
+		lines = append(lines, subtitleStyle.Render("No events to export"))
+		return centerContent(strings.Join(lines, "\n"), width, height)
+	}
+
+	// Keep the selected row visible: 5 header lines above the list
+	visible := height - 6
+	if visible < 1 {
+		visible = 1
+	}
+	offset := 0
+	if state.SelectedIndex >= visible {
+		offset = state.SelectedIndex - visible + 1
+	}
+
+	for i := offset; i < len(state.Items) && i < offset+visible; i++ {
+		item := state.Items[i]
+		label := item.Category
+		if item.Player != "" {
+			label += " " + item.Player
+		}
+		status := clipStatusLabel(item, state.Frame)
+		row := fmt.Sprintf("  %s  #%-4d %-24s ", timeutil.FormatTime(item.Time), item.NoteID, truncateString(label, 24))
+		style := rowStyle
+		prefix := row
+		if i == state.SelectedIndex {
+			style = selectedStyle
+			prefix = "▶" + row[1:]
+		}
+		lines = append(lines, style.Render(prefix)+statusStyles[item.Status].Render(status))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// clipStatusLabel is the status column of an event: a spinner while it
+// renders, and why it failed.
+func clipStatusLabel(item ClipItem, frame int) string {
+	switch item.Status {
+	case "processing":
+		return spinnerFrames[frame%len(spinnerFrames)] + " processing"
+	case "completed":
+		return "✓ done"
+	case "error":
+		if item.Log != "" {
+			return "✗ error: " + item.Log
+		}
+		return "✗ error"
+	default:
+		return "· pending"
+	}
+}
+
+// progressBar draws done and failed clips out of total as a bar of width.
+func progressBar(done, failed, total, width int) string {
+	if width < 1 {
+		return ""
+	}
+	if total == 0 {
+		return lipgloss.NewStyle().Foreground(styles.Purple).Render(strings.Repeat("░", width))
+	}
+	doneW := done * width / total
+	failedW := failed * width / total
+	return lipgloss.NewStyle().Foreground(styles.Green).Render(strings.Repeat("█", doneW)) +
+		lipgloss.NewStyle().Foreground(styles.Red).Render(strings.Repeat("█", failedW)) +
+		lipgloss.NewStyle().Foreground(styles.Purple).Render(strings.Repeat("░", width-doneW-failedW))
+}
@@ -2,6 +2,8 @@
 package components
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/user/tagging-rugby-cli/tui/styles"
 )
@@ -18,6 +20,12 @@ type CommandInputState struct {
 	Result string
 	// IsError indicates if the result is an error message
 	IsError bool
+	// Timestamp is the playback position captured when command mode opened,
+	// shown at the right of the prompt
+	Timestamp string
+	// Unread is the number of warnings and errors not yet seen in :messages,
+	// shown as a badge at the right of the result or hint
+	Unread int
 }
 
 // CommandInput renders the command input component.
@@ -47,6 +55,12 @@ func CommandInput(state CommandInputState, width int) string {
 		}
 
 		content := promptStyle.Render(":") + inputStyle.Render(displayInput)
+		if state.Timestamp != "" {
+			stamp := promptStyle.Render("@ "+state.Timestamp) + " "
+			if gap := width - lipgloss.Width(content) - lipgloss.Width(stamp); gap > 0 {
+				content += strings.Repeat(" ", gap) + stamp
+			}
+		}
 
 		// Apply background to full width
 		lineStyle := lipgloss.NewStyle().
@@ -73,7 +87,7 @@ func CommandInput(state CommandInputState, width int) string {
 			Background(styles.DarkPurple).
 			Width(width)
 
-		return lineStyle.Render(" " + resultStyle.Render(state.Result))
+		return lineStyle.Render(withBadge(" "+resultStyle.Render(state.Result), state.Unread, width))
 	}
 
 	// Default: show help hint
@@ -85,7 +99,20 @@ func CommandInput(state CommandInputState, width int) string {
 		Background(styles.DarkPurple).
 		Width(width)
 
-	return lineStyle.Render(" " + hintStyle.Render("Press : to enter command mode, ? for help, q to quit"))
+	return lineStyle.Render(withBadge(" "+hintStyle.Render("Press : to enter command mode, ? for help, q to quit"), state.Unread, width))
+}
+
+// withBadge right-aligns the unread badge after content when it fits.
+func withBadge(content string, unread, width int) string {
+	badge := UnreadBadge(unread)
+	if badge == "" {
+		return content
+	}
+	gap := width - lipgloss.Width(content) - lipgloss.Width(badge) - 1
+	if gap < 1 {
+		return content
+	}
+	return content + strings.Repeat(" ", gap) + badge + " "
 }
 
 // InsertChar inserts a character at the current cursor position.
@@ -136,6 +163,7 @@ func (s *CommandInputState) Clear() {
 	s.Input = ""
 	s.CursorPos = 0
 	s.Active = false
+	s.Timestamp = ""
 }
 
 // GetCommand returns the current command and clears the input.
@@ -24,8 +24,196 @@ type ControlGroup struct {
 	SubGroups [][]Control
 }
 
-// GetControlGroups returns the control groups for display.
-func GetControlGroups() []ControlGroup {
+// ControlContext identifies what currently takes key presses, so the controls
+// display lists only the keys that do something there.
+type ControlContext int
+
+const (
+	// ControlsVideo is the video panel focus.
+	ControlsVideo ControlContext = iota
+	// ControlsNotes is the notes list focus.
+	ControlsNotes
+	// ControlsSearch is the search input focus.
+	ControlsSearch
+	// ControlsCommand is a command being typed after ':'.
+	ControlsCommand
+	// ControlsStats is the column 3 stats panel focus.
+	ControlsStats
+	// ControlsShuttle is shuttle mode, which takes j/k/l in any panel.
+	ControlsShuttle
+	// ControlsForm is an open form; the model supplies its keys from the
+	// focused field, GetControlGroups only has the generic ones.
+	ControlsForm
+	// ControlsOverlay is the help, messages, stats or suggestion view.
+	ControlsOverlay
+)
+
+// Shared groups: tagging and view keys work from every panel.
+var (
+	taggingControls = ControlGroup{
+		Name: "Tagging",
+		SubGroups: [][]Control{
+			{
+				{Name: "Note", Shortcut: "n"},
+				{Name: "Tackle", Shortcut: "t"},
+				{Name: "Commentary", Shortcut: "c"},
+			},
+			{
+				{Name: "Tackle #n", Shortcut: "F1-F12"},
+				{Name: "Missed #n", Shortcut: "Shift+Fn"},
+			},
+			{
+				{Name: "Bookmark", Shortcut: "b"},
+				{Name: "Next mark", Shortcut: "B"},
+				{Name: "Scratch", Shortcut: "w"},
+				{Name: "Shuttle", Shortcut: "Ctrl+s"},
+			},
+		},
+	}
+	viewControls = ControlGroup{
+		Name: "Views",
+		SubGroups: [][]Control{
+			{
+				{Name: "Focus", Shortcut: "Tab"},
+				{Name: "Stats", Shortcut: "s"},
+				{Name: "Help", Shortcut: "?"},
+				{Name: "Quit", Shortcut: "Ctrl+c"},
+			},
+		},
+	}
+)
+
+// GetControlGroups returns the control groups for display in the given context.
+func GetControlGroups(ctx ControlContext) []ControlGroup {
+	switch ctx {
+	case ControlsNotes:
+		return []ControlGroup{
+			{
+				Name: "Navigation",
+				SubGroups: [][]Control{
+					{
+						{Name: "Prev", Shortcut: "j / \u2191"},
+						{Name: "Next", Shortcut: "k / \u2193"},
+						{Name: "Jump", Shortcut: "Enter"},
+					},
+					{
+						{Name: "First", Shortcut: "gg"},
+						{Name: "Last", Shortcut: "G"},
+						{Name: "Row", Shortcut: "<n>G"},
+					},
+				},
+			},
+			{
+				Name: "Edit",
+				SubGroups: [][]Control{
+					{
+						{Name: "Edit", Shortcut: "e"},
+						{Name: "Inline", Shortcut: "i"},
+						{Name: "Delete", Shortcut: "x"},
+						{Name: "Pin", Shortcut: "p / P"},
+						{Name: "Player", Shortcut: "f"},
+						{Name: "Keep", Shortcut: "+"},
+						{Name: "Re-clip", Shortcut: "Ctrl+r"},
+						{Name: "Export", Shortcut: "Ctrl+e"},
+						{Name: "Command", Shortcut: ":"},
+					},
+				},
+			},
+			taggingControls,
+			viewControls,
+		}
+	case ControlsSearch:
+		return []ControlGroup{
+			{
+				Name: "Search",
+				SubGroups: [][]Control{
+					{
+						{Name: "Match +", Shortcut: "Tab"},
+						{Name: "Match -", Shortcut: "Shift+Tab"},
+						{Name: "Command", Shortcut: ":"},
+						{Name: "Close", Shortcut: "Esc"},
+					},
+				},
+			},
+		}
+	case ControlsCommand:
+		return []ControlGroup{
+			{
+				Name: "Command",
+				SubGroups: [][]Control{
+					{
+						{Name: "Run", Shortcut: "Enter"},
+						{Name: "Cancel", Shortcut: "Esc"},
+					},
+				},
+			},
+		}
+	case ControlsStats:
+		return []ControlGroup{
+			{
+				Name: "Players",
+				SubGroups: [][]Control{
+					{
+						{Name: "Prev", Shortcut: "j / \u2191"},
+						{Name: "Next", Shortcut: "k / \u2193"},
+						{Name: "Sort", Shortcut: "o"},
+					},
+					{
+						{Name: "Find", Shortcut: "/"},
+						{Name: "Filter notes", Shortcut: "Enter"},
+						{Name: "Clear", Shortcut: "Esc"},
+					},
+				},
+			},
+			taggingControls,
+			viewControls,
+		}
+	case ControlsShuttle:
+		return []ControlGroup{
+			{
+				Name: "Shuttle",
+				SubGroups: [][]Control{
+					{
+						{Name: "Reverse", Shortcut: "j"},
+						{Name: "Stop", Shortcut: "k"},
+						{Name: "Forward", Shortcut: "l"},
+						{Name: "Exit", Shortcut: "Ctrl+s"},
+					},
+				},
+			},
+			taggingControls,
+			viewControls,
+		}
+	case ControlsForm:
+		return []ControlGroup{
+			{
+				Name: "Form",
+				SubGroups: [][]Control{
+					{
+						{Name: "Next field", Shortcut: "Enter"},
+						{Name: "Prev field", Shortcut: "Shift+Tab"},
+						{Name: "Cancel", Shortcut: "Ctrl+c"},
+					},
+				},
+			},
+		}
+	case ControlsOverlay:
+		return []ControlGroup{
+			{
+				Name: "Overlay",
+				SubGroups: [][]Control{
+					{
+						{Name: "Prev", Shortcut: "j"},
+						{Name: "Next", Shortcut: "k"},
+						{Name: "Close", Shortcut: "Esc"},
+						{Name: "Quit", Shortcut: "Ctrl+c"},
+					},
+				},
+			},
+		}
+	}
+
+	// ControlsVideo
 	return []ControlGroup{
 		// Playback controls — three sub-groups separated by dividers
 		{
@@ -33,8 +221,8 @@ func GetControlGroups() []ControlGroup {
 			SubGroups: [][]Control{
 				{
 					{Name: "Play", Shortcut: "Space"},
-					{Name: "Back", Shortcut: "H"},
-					{Name: "Fwd", Shortcut: "L"},
+					{Name: "Back", Shortcut: "h"},
+					{Name: "Fwd", Shortcut: "l"},
 				},
 				{
 					{Name: "Step -", Shortcut: ", / <"},
@@ -45,36 +233,25 @@ func GetControlGroups() []ControlGroup {
 					{Name: "Frame +", Shortcut: "Ctrl+l"},
 				},
 				{
-					{Name: "Speed -", Shortcut: "[ / {"},
-					{Name: "Speed +", Shortcut: "] / }"},
-					{Name: "Speed 1x", Shortcut: "\\"},
+					{Name: "Mute", Shortcut: "m"},
+					{Name: "Overlay", Shortcut: "o"},
+					{Name: "Angle", Shortcut: "a"},
 				},
 			},
 		},
-		// Navigation controls — single sub-group, no dividers
+		// Possession tracker controls
 		{
-			Name: "Navigation",
+			Name: "Possession",
 			SubGroups: [][]Control{
 				{
-					{Name: "Prev", Shortcut: "J / \u2191"},
-					{Name: "Next", Shortcut: "K / \u2193"},
-					{Name: "Mute", Shortcut: "M"},
-					{Name: "Overlay", Shortcut: "O"},
-				},
-			},
-		},
-		// View controls — single sub-group, no dividers
-		{
-			Name: "Views",
-			SubGroups: [][]Control{
-				{
-					{Name: "Stats", Shortcut: "S"},
-					{Name: "Sort", Shortcut: "X"},
-					{Name: "Help", Shortcut: "?"},
-					{Name: "Quit", Shortcut: "Ctrl+C"},
+					{Name: "Switch", Shortcut: "p"},
+					{Name: "End", Shortcut: "P"},
+					{Name: "Territory", Shortcut: "y"},
 				},
 			},
 		},
+		taggingControls,
+		viewControls,
 	}
 }
 
@@ -297,6 +474,10 @@ func RenderVideoBox(state StatusBarState, width int, showWarning bool, focused b
 		playState = "⏸ Paused"
 	}
 
+	if state.Shuttle != "" {
+		playState = state.Shuttle
+	}
+
 	stepStr := formatStepSize(state.StepSize)
 	leftPart := " " + playState
 	rightPart := "Step: " + stepStr
@@ -326,14 +507,34 @@ func RenderVideoBox(state StatusBarState, width int, showWarning bool, focused b
 	videoLine := " Video: Closed"
 	if state.VideoOpen {
 		videoLine = " Video: Open"
+		if state.Angle != "" {
+			videoLine += " (" + state.Angle + ")"
+		}
 	}
 
 	contentLines := []string{
 		textStyle.Render(statusLine),
 		textStyle.Render(timeLine),
+	}
+	if state.Chapter != "" {
+		contentLines = append(contentLines, textStyle.Render(" Chapter: "+state.Chapter))
+	}
+	if state.Tally != "" {
+		tallyStyle := lipgloss.NewStyle().Foreground(styles.Cyan)
+		contentLines = append(contentLines, tallyStyle.Render(" "+state.Tally))
+	}
+	if state.SinceEvent != "" {
+		if state.SinceEventWarn {
+			warnStyle := lipgloss.NewStyle().Foreground(styles.Red).Bold(true)
+			contentLines = append(contentLines, warnStyle.Render(" ⚠ Since event: "+state.SinceEvent))
+		} else {
+			contentLines = append(contentLines, textStyle.Render(" Since event: "+state.SinceEvent))
+		}
+	}
+	contentLines = append(contentLines,
 		textStyle.Render(overlayLine),
 		textStyle.Render(videoLine),
-	}
+	)
 
 	card := RenderInfoBox("Video", contentLines, width, focused)
 
@@ -352,10 +553,10 @@ func RenderVideoBox(state StatusBarState, width int, showWarning bool, focused b
 	return card + "\n" + strings.Repeat(" ", warnPad) + warning
 }
 
-// ControlsDisplay renders the controls display component as a horizontal bar.
-// It shows all available controls grouped by function with Name [Shortcut] format.
-func ControlsDisplay(width int) string {
-	groups := GetControlGroups()
+// ControlsDisplay renders control groups as a one-line footer bar, grouped by
+// function with Name [Shortcut] format. Groups that do not fit the width are
+// dropped from the end, so the most specific keys always show.
+func ControlsDisplay(groups []ControlGroup, width int) string {
 
 	shortcutStyle := lipgloss.NewStyle().
 		Foreground(styles.Cyan).
@@ -376,9 +577,15 @@ func ControlsDisplay(width int) string {
 		}
 		groupStrings = append(groupStrings, strings.Join(controlStrs, "  "))
 	}
+	for len(groupStrings) > 1 && lipgloss.Width(strings.Join(groupStrings, "   ")) > width {
+		groupStrings = groupStrings[:len(groupStrings)-1]
+	}
 
 	// Join all groups with separator
 	allControls := strings.Join(groupStrings, "   ")
+	if lipgloss.Width(allControls) > width {
+		allControls = ansi.Truncate(allControls, width, "")
+	}
 
 	// Center the controls
 	controlsWidth := lipgloss.Width(allControls)
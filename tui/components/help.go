@@ -5,90 +5,15 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/pkg/helpdoc"
 	"github.com/user/tagging-rugby-cli/tui/styles"
 )
 
 // HelpOverlay renders the help overlay showing all keybindings.
-// The overlay is styled with the palette colors and grouped by function.
-func HelpOverlay(width, height int) string {
-	// Define keybinding groups
-	groups := []struct {
-		title    string
-		bindings []struct {
-			key  string
-			desc string
-		}
-	}{
-		{
-			title: "Playback",
-			bindings: []struct {
-				key  string
-				desc string
-			}{
-				{"Space", "Toggle play/pause"},
-				{"M", "Toggle mute"},
-				{"H / Left", "Step backward (by step size)"},
-				{"L / Right", "Step forward (by step size)"},
-				{"Ctrl+H", "Frame step backward"},
-				{"Ctrl+L", "Frame step forward"},
-				{", / <", "Decrease step size"},
-				{". / >", "Increase step size"},
-			},
-		},
-		{
-			title: "Navigation",
-			bindings: []struct {
-				key  string
-				desc string
-			}{
-				{"J / Up", "Select previous item"},
-				{"K / Down", "Select next item"},
-				{"Enter", "Jump to selected item"},
-				{"E", "Edit selected tackle"},
-				{"X", "Delete selected item"},
-			},
-		},
-		{
-			title: "Views",
-			bindings: []struct {
-				key  string
-				desc string
-			}{
-				{"?", "Show/hide this help"},
-				{"S", "Open stats view"},
-				{"O", "Toggle overlay on video"},
-				{"N", "Quick add note"},
-				{"T", "Quick add tackle"},
-				{"Backspace", "Return to main view"},
-				{"/ (stats)", "Filter players by name/initials"},
-				{"Esc (stats)", "Clear player filters"},
-			},
-		},
-		{
-			title: "Commands",
-			bindings: []struct {
-				key  string
-				desc string
-			}{
-				{":", "Enter command mode"},
-				{"Esc", "Cancel command mode"},
-				{"Ctrl+C", "Quit application"},
-			},
-		},
-		{
-			title: "Shorthand Commands",
-			bindings: []struct {
-				key  string
-				desc string
-			}{
-				{":nn", "Quick note (or :nn <text>)"},
-				{":nt", "Quick tackle (or :nt <p> <t> <a> <o>)"},
-				{":cs", "Clip start"},
-				{":ce <desc>", "Clip end with description"},
-			},
-		},
-	}
-
+// The overlay is styled with the palette colors and grouped by function, as
+// defined in helpdoc.Keybindings. Keys remapped in config.json are listed
+// first, under their own header.
+func HelpOverlay(width, height int, remapped []helpdoc.Entry) string {
 	// Title style
 	titleStyle := lipgloss.NewStyle().
 		Foreground(styles.Cyan).
@@ -119,10 +44,14 @@ func HelpOverlay(width, height int) string {
 	lines = append(lines, "")
 
 	// Render each group
+	groups := helpdoc.Keybindings.Sections
+	if len(remapped) > 0 {
+		groups = append([]helpdoc.Section{{Title: "Remapped (config.json)", Entries: remapped}}, groups...)
+	}
 	for _, group := range groups {
-		lines = append(lines, groupHeaderStyle.Render(group.title))
-		for _, binding := range group.bindings {
-			line := "  " + keyStyle.Render(binding.key) + descStyle.Render(binding.desc)
+		lines = append(lines, groupHeaderStyle.Render(group.Title))
+		for _, binding := range group.Entries {
+			line := "  " + keyStyle.Render(binding.Name) + descStyle.Render(binding.Desc)
 			lines = append(lines, line)
 		}
 	}
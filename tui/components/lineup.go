@@ -0,0 +1,53 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// LineupEntry is a shirt number on the lineup panel and the player wearing it.
+type LineupEntry struct {
+	Number int
+	Player string
+	// Bench is true for replacements
+	Bench bool
+}
+
+// LineupBox renders the lineup panel: the starting XV, then the bench under a
+// divider. The player named selected (the selected event's) is highlighted.
+func LineupBox(lineup []LineupEntry, selected string, width int) string {
+	numStyle := lipgloss.NewStyle().Foreground(styles.Purple)
+	nameStyle := lipgloss.NewStyle().Foreground(styles.LightLavender)
+	currentStyle := lipgloss.NewStyle().Foreground(styles.Cyan).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(styles.Lavender).Italic(true)
+
+	// Inner width = column width - 2 borders, minus " 23 " for the number
+	nameWidth := width - 6
+	if nameWidth < 1 {
+		nameWidth = 1
+	}
+
+	var lines []string
+	starters, bench := 0, 0
+	for i, p := range lineup {
+		if p.Bench {
+			if i == 0 || !lineup[i-1].Bench {
+				lines = append(lines, dimStyle.Render(" Bench"))
+			}
+			bench++
+		} else {
+			starters++
+		}
+		name := truncateStr(p.Player, nameWidth)
+		style := nameStyle
+		if selected != "" && strings.EqualFold(p.Player, selected) {
+			style = currentStyle
+		}
+		lines = append(lines, numStyle.Render(fmt.Sprintf(" %2d", p.Number))+" "+style.Render(name))
+	}
+
+	return RenderInfoBox(fmt.Sprintf("Lineup (%d+%d)", starters, bench), lines, width, false)
+}
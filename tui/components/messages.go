@@ -0,0 +1,78 @@
+// Package components provides reusable TUI components.
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// Severity levels for notifications.
+const (
+	LevelInfo = iota
+	LevelWarning
+	LevelError
+)
+
+// Notification is one message shown in the footer and kept in the history.
+type Notification struct {
+	Time  time.Time
+	Level int
+	Text  string
+}
+
+// MessagesOverlay renders the notification history, newest first.
+func MessagesOverlay(messages []Notification, width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.Cyan).
+		Bold(true).
+		Padding(0, 1)
+	subtitleStyle := lipgloss.NewStyle().
+		Foreground(styles.Lavender).
+		Italic(true).
+		Padding(0, 1)
+	timeStyle := lipgloss.NewStyle().Foreground(styles.Lavender)
+	levelStyles := map[int]lipgloss.Style{
+		LevelInfo:    lipgloss.NewStyle().Foreground(styles.LightLavender),
+		LevelWarning: lipgloss.NewStyle().Foreground(styles.Amber),
+		LevelError:   lipgloss.NewStyle().Foreground(styles.Pink).Bold(true),
+	}
+	levelTags := map[int]string{LevelInfo: "   ", LevelWarning: "!  ", LevelError: "✗  "}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("Messages (%d)", len(messages))))
+	lines = append(lines, subtitleStyle.Render("Newest first | Esc to close"))
+	lines = append(lines, "")
+
+	if len(messages) == 0 {
+		lines = append(lines, subtitleStyle.Render("No messages yet"))
+		return centerContent(strings.Join(lines, "\n"), width, height)
+	}
+
+	visible := height - 3
+	textWidth := width - 14
+	if textWidth < 10 {
+		textWidth = 10
+	}
+	for i := len(messages) - 1; i >= 0 && len(lines) < 3+visible; i-- {
+		msg := messages[i]
+		text := msg.Text
+		if len(text) > textWidth {
+			text = text[:textWidth-3] + "..."
+		}
+		lines = append(lines, " "+timeStyle.Render(msg.Time.Format("15:04:05"))+" "+levelStyles[msg.Level].Render(levelTags[msg.Level]+text))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// UnreadBadge renders the count of unseen warnings and errors, or "" for none.
+func UnreadBadge(unread int) string {
+	if unread == 0 {
+		return ""
+	}
+	style := lipgloss.NewStyle().Foreground(styles.Amber).Bold(true)
+	return style.Render(fmt.Sprintf("● %d new (:messages)", unread))
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
 	"github.com/user/tagging-rugby-cli/pkg/timeutil"
 	"github.com/user/tagging-rugby-cli/tui/styles"
 )
@@ -19,12 +20,18 @@ const (
 	ItemTypeNote ListItemType = iota
 	// ItemTypeTackle represents a tackle item.
 	ItemTypeTackle
+	// ItemTypeScratch represents an unsaved scratch note; its ID is minus its
+	// session number.
+	ItemTypeScratch
 )
 
 // ListItem represents a note or tackle in the list.
 type ListItem struct {
 	// ID is the database ID of the item
 	ID int64
+	// Number is the item's place among the video's events, 1..N in time
+	// order, as used by ':goto #17' (0 for scratch notes)
+	Number int
 	// Type is either note or tackle
 	Type ListItemType
 	// TimestampSeconds is the position in the video
@@ -33,18 +40,33 @@ type ListItem struct {
 	Text string
 	// Starred indicates if this is a starred item (tackles only)
 	Starred bool
+	// Pinned keeps the item at the top of the notes list
+	Pinned bool
 	// Category is the optional category
 	Category string
 	// Player is the optional player name
 	Player string
 	// Team is the optional team name
 	Team string
+	// Outcome is the tackle outcome (tackles only)
+	Outcome string
+	// GameClock is the match time of the item (e.g. "23:14"), set when the game clock column is shown
+	GameClock string
 	// ClipStatus is the export status of the note's clip record (empty, 'pending', 'processing', 'completed', 'error')
 	ClipStatus string
 	// ClipFinishedAt is the time the clip export finished, or nil if not finished
 	ClipFinishedAt *time.Time
 }
 
+// clipFlashDuration is how long a finished clip shows the [f] badge.
+const clipFlashDuration = 5 * time.Second
+
+// ClipJustFinished reports whether the item's clip finished recently enough
+// to show the [f] badge at now.
+func (item ListItem) ClipJustFinished(now time.Time) bool {
+	return item.ClipStatus == "completed" && item.ClipFinishedAt != nil && now.Sub(*item.ClipFinishedAt) <= clipFlashDuration
+}
+
 // NotesListState holds the state for the notes list component.
 type NotesListState struct {
 	// Items is the list of notes and tackles
@@ -53,10 +75,44 @@ type NotesListState struct {
 	SelectedIndex int
 	// ScrollOffset is the scroll position
 	ScrollOffset int
+	// ShowGameClock adds a Game column with each item's match time
+	ShowGameClock bool
+	// Edit is the inline text editor shown over the selected row
+	Edit InlineEdit
+	// Total is the number of events before filtering, for the footer count
+	Total int
+	// Filters describe the active filters, e.g. "player=Smith", for the footer
+	Filters []string
+}
+
+// InlineEdit holds the state of the single-line editor that replaces the
+// selected row's text field while quick-editing a note.
+type InlineEdit struct {
+	// Active indicates if the editor is open
+	Active bool
+	// NoteID is the note being edited
+	NoteID int64
+	// Input holds the edit buffer and cursor
+	Input SearchInputState
+}
+
+// editWindow returns the visible part of the edit buffer with a "_" cursor,
+// scrolled so the cursor stays within width.
+func editWindow(input string, cursor, width int) string {
+	display := input[:cursor] + "_" + input[cursor:]
+	start := 0
+	if cursor >= width {
+		start = cursor - width + 1
+	}
+	end := start + width
+	if end > len(display) {
+		end = len(display)
+	}
+	return display[start:end]
 }
 
 // NotesList renders the notes list component as a dynamically-sized table.
-// It displays notes and tackles sorted by timestamp.
+// It displays notes and tackles sorted by timestamp, pinned items first.
 // The visible row count is derived from the height parameter (height - 1 for the header row).
 // The currentTimePos parameter is used to auto-scroll to show notes near the current video timestamp.
 func NotesList(state NotesListState, width, height int, currentTimePos float64, matches []int, currentMatch int, query string) string {
@@ -75,39 +131,84 @@ func NotesList(state NotesListState, width, height int, currentTimePos float64,
 		Bold(true).
 		Underline(true)
 
-	// Column widths (#: 5, ID: 6, Timestamp: 9 for H:MM:SS, Category: 12, Text: rest)
+	// Column widths (Row: 5, #: 4, ID: 6, Timestamp: 9 for H:MM:SS, Category: 12, Text: rest)
 	rowWidth := 5
+	numWidth := 4
 	idWidth := 6
 	timeWidth := 9
 	catWidth := 12
-	textWidth := width - rowWidth - idWidth - timeWidth - catWidth - 10 // 10 for spacing/borders
+	// Game clock (optional): 8 for "40+12:05"
+	gameWidth := 0
+	if state.ShowGameClock {
+		gameWidth = 8
+	}
+	textWidth := width - rowWidth - numWidth - idWidth - timeWidth - catWidth - 11 // 11 for spacing/borders
+	if gameWidth > 0 {
+		textWidth -= gameWidth + 1
+	}
 	if textWidth < 10 {
 		textWidth = 10
 	}
 
 	// Build header row
-	header := fmt.Sprintf(" %*s %-*s %-*s %-*s %-*s",
+	header := fmt.Sprintf(" %*s %*s %-*s %-*s ",
 		rowWidth, "Row",
+		numWidth, "#",
 		idWidth, "ID",
-		timeWidth, "Time",
+		timeWidth, "Time")
+	if gameWidth > 0 {
+		header += fmt.Sprintf("%-*s ", gameWidth, "Game")
+	}
+	header += fmt.Sprintf("%-*s %-*s",
 		catWidth, "Category",
 		textWidth, "Text")
 	lines = append(lines, headerStyle.Render(header))
 
+	// The footer counts the events shown and names the filters hiding the rest
+	footer := visibleRows > 1
+	rowsHeight := height
+	if footer {
+		visibleRows--
+		rowsHeight--
+	}
+
 	if len(state.Items) == 0 {
 		// Empty state - show placeholder rows
 		emptyStyle := lipgloss.NewStyle().
 			Foreground(styles.Purple).
 			Italic(true)
-		emptyRow := emptyStyle.Render(fmt.Sprintf(" %-*s", width-2, "No notes or tackles for this video"))
+		empty := "No notes or tackles for this video"
+		if len(state.Filters) > 0 {
+			empty = "No events match the filter"
+		}
+		emptyRow := emptyStyle.Render(fmt.Sprintf(" %-*s", width-2, empty))
 		lines = append(lines, emptyRow)
 		// Fill remaining rows with empty space
 		for i := 1; i < visibleRows; i++ {
 			lines = append(lines, "")
 		}
+		if footer {
+			lines = append(lines, listFooter(state, width))
+		}
 		return strings.Join(lines, "\n")
 	}
 
+	// Pinned items lead the list under their own section header, with a second
+	// header before the rest; rows for both are reserved so scrolling keeps the
+	// selected item in view
+	pinned := state.PinnedCount()
+	itemRows := visibleRows
+	if pinned > 0 {
+		itemRows--
+		if pinned < len(state.Items) {
+			itemRows--
+		}
+		if itemRows < 1 {
+			itemRows = 1
+		}
+	}
+	visibleRows = itemRows
+
 	// Auto-scroll to show notes near current video timestamp
 	state.scrollToCurrentTime(currentTimePos, visibleRows)
 
@@ -142,34 +243,129 @@ func NotesList(state NotesListState, width, height int, currentTimePos float64,
 
 	now := time.Now()
 
-	// Render visible rows
-	for row := 0; row < visibleRows; row++ {
+	sectionStyle := lipgloss.NewStyle().
+		Foreground(styles.Purple).
+		Italic(true)
+
+	// Render visible rows, with section headers where they fall in view
+	for row := 0; len(lines) < rowsHeight; row++ {
 		itemIndex := state.ScrollOffset + row
+		if pinned > 0 && itemIndex == 0 {
+			lines = append(lines, sectionStyle.Render(fmt.Sprintf(" Pinned (%d)", pinned)))
+		}
+		if pinned > 0 && itemIndex == pinned && itemIndex < len(state.Items) && len(lines) < rowsHeight {
+			lines = append(lines, sectionStyle.Render(" All events"))
+		}
+		if len(lines) >= rowsHeight {
+			break
+		}
 		if itemIndex < len(state.Items) {
 			item := state.Items[itemIndex]
 			isSelected := itemIndex == state.SelectedIndex
 			rowNum := itemIndex + 1
 			isMatch := matchSet[itemIndex]
 			isCurrentMatch := itemIndex == currentMatchIdx
-			lines = append(lines, renderTableRow(item, isSelected, isMatch, isCurrentMatch, rowNum, rowWidth, idWidth, timeWidth, catWidth, textWidth, width, query, now))
+			if isSelected && state.Edit.Active {
+				item.Text = editWindow(state.Edit.Input.Input, state.Edit.Input.CursorPos, textWidth)
+				item.ClipStatus = ""
+			}
+			lines = append(lines, renderTableRow(item, isSelected, isMatch, isCurrentMatch, rowNum, rowWidth, numWidth, idWidth, timeWidth, gameWidth, catWidth, textWidth, width, query, now))
 		} else {
 			// Empty row
 			lines = append(lines, "")
 		}
 	}
+	if footer {
+		lines = append(lines, listFooter(state, width))
+	}
 
 	return strings.Join(lines, "\n")
 }
 
+// listFooter renders the line under the table, e.g. "showing 42/128 events —
+// filter: player=Smith", followed by the outcome legend when the list holds
+// tackles and the line has room for it.
+func listFooter(state NotesListState, width int) string {
+	total := state.Total
+	if total < len(state.Items) {
+		total = len(state.Items)
+	}
+	text := fmt.Sprintf(" showing %d/%d events", len(state.Items), total)
+	style := lipgloss.NewStyle().Foreground(styles.Purple)
+	if len(state.Filters) > 0 {
+		// A filter hiding events is easy to forget, so it stands out
+		text += " — filter: " + strings.Join(state.Filters, ", ")
+		style = lipgloss.NewStyle().Foreground(styles.Amber)
+	}
+	if lipgloss.Width(text) > width {
+		text = truncateStr(text, width)
+	}
+	line := style.Render(text)
+
+	if hasTackles(state.Items) {
+		legend := outcomeLegend()
+		if gap := width - lipgloss.Width(text) - lipgloss.Width(legend); gap >= 2 {
+			line += strings.Repeat(" ", gap) + legend
+		}
+	}
+	return line
+}
+
+// outcomeColors are the foreground colours of tackle rows by outcome, in
+// legend order. Other outcomes use the default text colour.
+var outcomeColors = []struct {
+	outcome string
+	color   lipgloss.Color
+}{
+	{"completed", styles.Green},
+	{"missed", styles.Red},
+	{"possible", styles.Yellow},
+}
+
+// outcomeColor returns the row colour for a tackle outcome, and false for
+// outcomes without one.
+func outcomeColor(outcome string) (lipgloss.Color, bool) {
+	for _, c := range outcomeColors {
+		if c.outcome == outcome {
+			return c.color, true
+		}
+	}
+	return "", false
+}
+
+// outcomeLegend names the outcome colours, e.g. "■ completed  ■ missed  ■ possible".
+func outcomeLegend() string {
+	dim := lipgloss.NewStyle().Foreground(styles.Purple)
+	var parts []string
+	for _, c := range outcomeColors {
+		parts = append(parts, lipgloss.NewStyle().Foreground(c.color).Render("■")+dim.Render(" "+c.outcome))
+	}
+	return strings.Join(parts, dim.Render("  ")) + " "
+}
+
+// hasTackles reports whether any of the items is a tackle.
+func hasTackles(items []ListItem) bool {
+	for _, item := range items {
+		if item.Type == ItemTypeTackle {
+			return true
+		}
+	}
+	return false
+}
+
 // scrollToCurrentTime adjusts the scroll offset to show notes near the current timestamp.
 func (s *NotesListState) scrollToCurrentTime(currentTimePos float64, visibleRows int) {
 	if len(s.Items) == 0 {
 		return
 	}
 
-	// Find the first item that is at or after the current time
+	// Find the first item that is at or after the current time, past the
+	// pinned items, which aren't in time order with the rest
 	nearestIndex := 0
 	for i, item := range s.Items {
+		if item.Pinned {
+			continue
+		}
 		if item.TimestampSeconds >= currentTimePos {
 			nearestIndex = i
 			break
@@ -198,21 +394,31 @@ func (s *NotesListState) scrollToCurrentTime(currentTimePos float64, visibleRows
 // renderTableRow renders a single table row.
 // When query is non-empty and the row is a match, the matching substring is highlighted
 // inline rather than coloring the whole row. Matched rows get a subtle background.
-func renderTableRow(item ListItem, selected, isMatch, isCurrentMatch bool, rowNum, rowWidth, idWidth, timeWidth, catWidth, textWidth, fullWidth int, query string, now time.Time) string {
+// A gameWidth of 0 hides the game clock column.
+func renderTableRow(item ListItem, selected, isMatch, isCurrentMatch bool, rowNum, rowWidth, numWidth, idWidth, timeWidth, gameWidth, catWidth, textWidth, fullWidth int, query string, now time.Time) string {
 	// Format row number: right-aligned, no # prefix (e.g., "  1", " 12", "123")
 	rowStr := fmt.Sprintf("%*d", rowWidth, rowNum)
 
+	// Format event number: right-aligned with # prefix, blank for scratch notes
+	numStr := ""
+	if item.Number > 0 {
+		numStr = fmt.Sprintf("%*s", numWidth, fmt.Sprintf("#%d", item.Number))
+	}
+
 	// Format ID with star symbol if starred
 	idStr := fmt.Sprintf("%d", item.ID)
 	if item.Starred {
 		idStr = "★" + idStr
 	}
+	if item.Type == ItemTypeScratch {
+		idStr = fmt.Sprintf("~%d", -item.ID)
+	}
 
 	// Format timestamp
 	timeStr := timeutil.FormatTime(item.TimestampSeconds)
 
 	// Get category (or type badge for tackles)
-	catStr := item.Category
+	catStr := catpath.Abbrev(item.Category, catWidth)
 	if item.Type == ItemTypeTackle && catStr == "" {
 		catStr = "tackle"
 	}
@@ -228,7 +434,7 @@ func renderTableRow(item ListItem, selected, isMatch, isCurrentMatch bool, rowNu
 	case "error":
 		badgeLetter, badgeColor = "e", styles.Red
 	case "completed":
-		if item.ClipFinishedAt != nil && now.Sub(*item.ClipFinishedAt) <= 5*time.Second {
+		if item.ClipJustFinished(now) {
 			badgeLetter, badgeColor = "f", styles.Green
 		}
 	}
@@ -266,6 +472,19 @@ func renderTableRow(item ListItem, selected, isMatch, isCurrentMatch bool, rowNu
 		baseStyle = lipgloss.NewStyle().
 			Foreground(styles.LightLavender)
 	}
+	// Unselected tackle rows take their outcome's colour
+	if !selected || isMatch || isCurrentMatch {
+		if color, ok := outcomeColor(item.Outcome); ok && item.Type == ItemTypeTackle {
+			baseStyle = baseStyle.Foreground(color)
+		}
+	}
+	// Scratch notes aren't saved, so they look provisional
+	if item.Type == ItemTypeScratch {
+		baseStyle = baseStyle.Italic(true)
+		if !selected {
+			baseStyle = baseStyle.Foreground(styles.Lavender)
+		}
+	}
 
 	// Helper to render a field with inline query highlighting
 	renderField := func(s string, fieldWidth int) string {
@@ -298,9 +517,13 @@ func renderTableRow(item ListItem, selected, isMatch, isCurrentMatch bool, rowNu
 	space := baseStyle.Render(" ")
 	row := space +
 		renderField(rowStr, rowWidth) + space +
+		renderField(numStr, numWidth) + space +
 		renderField(idStr, idWidth) + space +
-		renderField(timeStr, timeWidth) + space +
-		renderField(catStr, catWidth) + space +
+		renderField(timeStr, timeWidth) + space
+	if gameWidth > 0 {
+		row += renderField(item.GameClock, gameWidth) + space
+	}
+	row += renderField(catStr, catWidth) + space +
 		textFieldRendered
 
 	// Pad to full width
@@ -360,6 +583,15 @@ func truncateStr(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// PinnedCount returns the number of pinned items, which lead the list.
+func (s *NotesListState) PinnedCount() int {
+	n := 0
+	for n < len(s.Items) && s.Items[n].Pinned {
+		n++
+	}
+	return n
+}
+
 // MoveUp moves the selection up in the list.
 func (s *NotesListState) MoveUp() {
 	if s.SelectedIndex > 0 {
@@ -0,0 +1,85 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// PossessionState holds the live possession tracker state and derived percentages.
+type PossessionState struct {
+	// Team currently in possession: "home", "away", or "" when no interval is open
+	Team string
+	// Territory is the half the ball is in: "home" or "away"
+	Territory string
+	// HasData is true once any possession interval has been recorded
+	HasData bool
+	// Possession percentages derived from interval timestamps
+	HomePct float64
+	AwayPct float64
+	// Territory percentages (share of time in the opposition half)
+	HomeTerritoryPct float64
+	AwayTerritoryPct float64
+}
+
+// PossessionBox renders the possession/territory summary box for the stats panel.
+func PossessionBox(state PossessionState, width int) string {
+	if width < 4 {
+		return ""
+	}
+	innerWidth := width - 4
+
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Lavender)
+	homeStyle := lipgloss.NewStyle().Foreground(styles.Cyan)
+	awayStyle := lipgloss.NewStyle().Foreground(styles.Pink)
+	dimStyle := lipgloss.NewStyle().Foreground(styles.Purple).Italic(true)
+
+	var lines []string
+
+	// Current holder
+	ball := "-"
+	if state.Team != "" {
+		ball = fmt.Sprintf("%s (%s half)", teamLabel(state.Team), state.Territory)
+	}
+	lines = append(lines, " "+labelStyle.Render("Ball: ")+lipgloss.NewStyle().Foreground(styles.LightLavender).Render(ball))
+
+	if !state.HasData {
+		lines = append(lines, dimStyle.Render(" No possession data"))
+		return RenderInfoBox("Possession", lines, width, false)
+	}
+
+	barWidth := innerWidth - 15 // label (5) + two percentages (5 each)
+	if barWidth < 4 {
+		barWidth = 4
+	}
+	splitLine := func(label string, home, away float64) string {
+		homeLen := int(home / 100 * float64(barWidth))
+		if homeLen > barWidth {
+			homeLen = barWidth
+		}
+		bar := homeStyle.Render(strings.Repeat("█", homeLen)) + awayStyle.Render(strings.Repeat("█", barWidth-homeLen))
+		return fmt.Sprintf(" %s%s %s %s",
+			labelStyle.Render(fmt.Sprintf("%-5s", label)),
+			homeStyle.Render(fmt.Sprintf("%3.0f%%", home)),
+			bar,
+			awayStyle.Render(fmt.Sprintf("%3.0f%%", away)),
+		)
+	}
+	lines = append(lines, splitLine("Poss", state.HomePct, state.AwayPct))
+	lines = append(lines, splitLine("Terr", state.HomeTerritoryPct, state.AwayTerritoryPct))
+
+	return RenderInfoBox("Possession", lines, width, false)
+}
+
+// teamLabel capitalises a team key for display.
+func teamLabel(team string) string {
+	switch team {
+	case "home":
+		return "Home"
+	case "away":
+		return "Away"
+	}
+	return team
+}
@@ -0,0 +1,104 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// sparkBlocks are the sparkline levels, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values from 0 to max as one block character each, e.g.
+// "▃▅▆█". Negative values (no data) render as a dim "·".
+func Sparkline(values []float64, max float64) string {
+	sparkStyle := lipgloss.NewStyle().Foreground(styles.Cyan)
+	dimStyle := lipgloss.NewStyle().Foreground(styles.Purple)
+
+	var b strings.Builder
+	for _, v := range values {
+		if v < 0 || max <= 0 {
+			b.WriteString(dimStyle.Render("·"))
+			continue
+		}
+		level := int(v/max*float64(len(sparkBlocks)-1) + 0.5)
+		if level >= len(sparkBlocks) {
+			level = len(sparkBlocks) - 1
+		}
+		b.WriteString(sparkStyle.Render(string(sparkBlocks[level])))
+	}
+	return b.String()
+}
+
+// BarSegment is one coloured part of a stacked bar.
+type BarSegment struct {
+	Count int
+	Color lipgloss.Color
+}
+
+// StackedBar renders segments side by side as a bar width cells wide, each
+// sized by its share of the total. Every non-empty segment gets at least one
+// cell when the bar is wide enough.
+func StackedBar(segments []BarSegment, width int) string {
+	total := 0
+	nonEmpty := 0
+	for _, s := range segments {
+		total += s.Count
+		if s.Count > 0 {
+			nonEmpty++
+		}
+	}
+	if total == 0 || width < 1 {
+		return ""
+	}
+
+	// Largest remainder, after one cell for each non-empty segment
+	cells := make([]int, len(segments))
+	spare := width
+	if width >= nonEmpty {
+		for i, s := range segments {
+			if s.Count > 0 {
+				cells[i] = 1
+			}
+		}
+		spare -= nonEmpty
+	}
+	remainders := make([]int, len(segments))
+	used := 0
+	for i, s := range segments {
+		share := s.Count * spare
+		cells[i] += share / total
+		remainders[i] = share % total
+		used += share / total
+	}
+	for ; used < spare; used++ {
+		best := 0
+		for i := range remainders {
+			if remainders[i] > remainders[best] {
+				best = i
+			}
+		}
+		cells[best]++
+		remainders[best] = -1
+	}
+
+	var b strings.Builder
+	for i, s := range segments {
+		if cells[i] > 0 {
+			b.WriteString(lipgloss.NewStyle().Foreground(s.Color).Render(strings.Repeat("█", cells[i])))
+		}
+	}
+	return b.String()
+}
+
+// outcomeSegments splits tackle totals into stacked bar segments in legend
+// order, with other outcomes last.
+func outcomeSegments(p PlayerStats) []BarSegment {
+	counts := map[string]int{"completed": p.Completed, "missed": p.Missed, "possible": p.Possible}
+	var segments []BarSegment
+	for _, c := range outcomeColors {
+		segments = append(segments, BarSegment{Count: counts[c.outcome], Color: c.color})
+	}
+	return append(segments, BarSegment{Count: p.Other, Color: styles.Lavender})
+}
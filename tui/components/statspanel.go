@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
 	"github.com/user/tagging-rugby-cli/tui/styles"
 )
 
@@ -16,9 +17,105 @@ type CategoryCount struct {
 	Count int
 }
 
+// panelSortColumns is the sort cycle for the column 3 tackle table.
+var panelSortColumns = []SortColumn{SortByTotal, SortByCompleted, SortByMissed, SortByPercentage, SortByPlayer}
+
+// panelSortNames labels each entry of panelSortColumns in the table title.
+var panelSortNames = []string{"Tot", "Comp", "Miss", "%", "Player"}
+
+// StatsPanelState holds the interactive state of the column 3 tackle table.
+type StatsPanelState struct {
+	// SortIndex indexes panelSortColumns (0 = total tackles)
+	SortIndex int
+	// SelectedIndex is the selected row among Rows()
+	SelectedIndex int
+	// FilterMode indicates if the player filter is being typed
+	FilterMode bool
+	// FilterInput narrows the table to players whose name or initials match
+	FilterInput string
+}
+
+// NextSort cycles the table's sort column.
+func (s *StatsPanelState) NextSort() {
+	s.SortIndex = (s.SortIndex + 1) % len(panelSortColumns)
+	s.SelectedIndex = 0
+}
+
+// MoveUp moves the selection up in the table.
+func (s *StatsPanelState) MoveUp() {
+	if s.SelectedIndex > 0 {
+		s.SelectedIndex--
+	}
+}
+
+// MoveDown moves the selection down in the table, given the number of rows.
+func (s *StatsPanelState) MoveDown(rows int) {
+	if s.SelectedIndex < rows-1 {
+		s.SelectedIndex++
+	}
+}
+
+// Rows returns the stats filtered by FilterInput and sorted by the current column,
+// with alphabetical player name as tiebreaker.
+func (s StatsPanelState) Rows(stats []PlayerStats) []PlayerStats {
+	filter := strings.ToLower(strings.TrimSpace(s.FilterInput))
+	var rows []PlayerStats
+	for _, p := range stats {
+		if filter == "" || strings.Contains(strings.ToLower(p.Player), filter) || matchesInitials(p.Player, filter) {
+			rows = append(rows, p)
+		}
+	}
+
+	col := panelSortColumns[s.SortIndex%len(panelSortColumns)]
+	key := func(p PlayerStats) float64 {
+		switch col {
+		case SortByCompleted:
+			return float64(p.Completed)
+		case SortByMissed:
+			return float64(p.Missed)
+		case SortByPercentage:
+			return p.Percentage
+		}
+		return float64(p.Total)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if col != SortByPlayer {
+			if ki, kj := key(rows[i]), key(rows[j]); ki != kj {
+				return ki > kj
+			}
+		}
+		return rows[i].Player < rows[j].Player
+	})
+	return rows
+}
+
+// Selected returns the selected player, or "" when the table is empty.
+func (s StatsPanelState) Selected(stats []PlayerStats) string {
+	rows := s.Rows(stats)
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(rows) {
+		return ""
+	}
+	return rows[s.SelectedIndex].Player
+}
+
+// Select moves the selection to player, if the table shows them.
+func (s *StatsPanelState) Select(stats []PlayerStats, player string) {
+	for i, p := range s.Rows(stats) {
+		if p.Player == player {
+			s.SelectedIndex = i
+			return
+		}
+	}
+}
+
 // StatsPanel renders a live stats panel for column 3 of the three-column layout.
-// It shows: stats summary, bar graph of event distribution, and tackle stats table.
-func StatsPanel(tackleStats []PlayerStats, items []ListItem, width, height int) string {
+// It shows: possession/territory summary, bar graph of event distribution, and tackle stats table.
+// The distribution rolls category paths up to categoryLevel levels and labels
+// each bar with its last level, e.g. "lineout" for set-piece/lineout/steal at level 2.
+// When focused, the tackle table shows its sort column, filter and selected row.
+// trends holds each player's completion % per recent match, keyed by player,
+// shown as a sparkline column when the panel is wide enough.
+func StatsPanel(state StatsPanelState, tackleStats []PlayerStats, trends map[string][]float64, items []ListItem, categoryLevel int, possession PossessionState, width, height int, focused bool) string {
 	if width < 5 {
 		return ""
 	}
@@ -36,6 +133,9 @@ func StatsPanel(tackleStats []PlayerStats, items []ListItem, width, height int)
 		if item.Type == ItemTypeTackle {
 			cat = "tackle"
 		}
+		if levels := catpath.Levels(catpath.Truncate(cat, categoryLevel)); len(levels) > 0 {
+			cat = levels[len(levels)-1]
+		}
 		if cat == "" {
 			cat = "other"
 		}
@@ -98,19 +198,20 @@ func StatsPanel(tackleStats []PlayerStats, items []ListItem, width, height int)
 	// --- Tackle Stats Table ---
 	var tackleLines []string
 
-	if len(tackleStats) == 0 {
+	if state.FilterMode || state.FilterInput != "" {
+		filterStyle := lipgloss.NewStyle().Foreground(styles.Cyan)
+		filterLine := " / " + state.FilterInput
+		if state.FilterMode {
+			filterLine += "█"
+		}
+		tackleLines = append(tackleLines, filterStyle.Render(filterLine))
+	}
+
+	sorted := state.Rows(tackleStats)
+	if len(sorted) == 0 {
 		dimStyle := lipgloss.NewStyle().Foreground(styles.Purple).Italic(true)
 		tackleLines = append(tackleLines, dimStyle.Render(" No tackle data"))
 	} else {
-		// Sort by total tackles descending, alphabetical name as tiebreaker
-		sorted := make([]PlayerStats, len(tackleStats))
-		copy(sorted, tackleStats)
-		sort.Slice(sorted, func(i, j int) bool {
-			if sorted[i].Total != sorted[j].Total {
-				return sorted[i].Total > sorted[j].Total
-			}
-			return sorted[i].Player < sorted[j].Player
-		})
 
 		// Column widths: Total(5) + Comp(5) + Miss(5) + %(5) + spacing(4) = 24
 		nameWidth := innerWidth - 24
@@ -118,53 +219,98 @@ func StatsPanel(tackleStats []PlayerStats, items []ListItem, width, height int)
 			nameWidth = 6
 		}
 
+		// Trend column (one block per match) when it leaves the names room
+		trendWidth := 0
+		for _, t := range trends {
+			if len(t) > trendWidth {
+				trendWidth = len(t)
+			}
+		}
+		if trendWidth < len("Trend") {
+			trendWidth = len("Trend")
+		}
+		if len(trends) == 0 || nameWidth-trendWidth-1 < 6 {
+			trendWidth = 0
+		} else {
+			nameWidth -= trendWidth + 1
+		}
+		trendCell := func(player string) string {
+			if trendWidth == 0 {
+				return ""
+			}
+			t := trends[player]
+			return " " + strings.Repeat(" ", trendWidth-len(t)) + Sparkline(t, 100)
+		}
+
 		headerStyle := lipgloss.NewStyle().Foreground(styles.Pink).Bold(true)
-		tackleLines = append(tackleLines, fmt.Sprintf(" %s %s %s %s %s",
+		header := fmt.Sprintf(" %s %s %s %s %s",
 			headerStyle.Render(fmt.Sprintf("%-*s", nameWidth, "Player")),
 			headerStyle.Render(fmt.Sprintf("%4s", "Tot")),
 			headerStyle.Render(fmt.Sprintf("%4s", "Comp")),
 			headerStyle.Render(fmt.Sprintf("%4s", "Miss")),
 			headerStyle.Render(fmt.Sprintf("%4s", "%")),
-		))
+		)
+		if trendWidth > 0 {
+			header += " " + headerStyle.Render(fmt.Sprintf("%*s", trendWidth, "Trend"))
+		}
+		tackleLines = append(tackleLines, header)
 
 		// TOTAL row (pinned after header so it stays visible when Container truncates)
 		totalsStyle := lipgloss.NewStyle().Foreground(styles.Cyan)
-		var sumTotal, sumComp, sumMiss int
+		var sum PlayerStats
 		for _, p := range sorted {
-			sumTotal += p.Total
-			sumComp += p.Completed
-			sumMiss += p.Missed
+			sum.Total += p.Total
+			sum.Completed += p.Completed
+			sum.Missed += p.Missed
+			sum.Possible += p.Possible
+			sum.Other += p.Other
 		}
 		totalPctStr := "-"
-		if sumComp+sumMiss > 0 {
-			totalPctStr = fmt.Sprintf("%.0f", float64(sumComp)/float64(sumComp+sumMiss)*100)
+		if sum.Completed+sum.Missed > 0 {
+			totalPctStr = fmt.Sprintf("%.0f", float64(sum.Completed)/float64(sum.Completed+sum.Missed)*100)
 		}
 		tackleLines = append(tackleLines, totalsStyle.Render(fmt.Sprintf(" %-*s %4d %4d %4d %4s",
-			nameWidth, "TOTAL", sumTotal, sumComp, sumMiss, totalPctStr,
+			nameWidth, "TOTAL", sum.Total, sum.Completed, sum.Missed, totalPctStr,
 		)))
+		// Outcome split of the totals, coloured like the notes list
+		if bar := StackedBar(outcomeSegments(sum), innerWidth-2); bar != "" {
+			tackleLines = append(tackleLines, " "+bar)
+		}
 
 		// Player rows
 		nameStyle := lipgloss.NewStyle().Foreground(styles.LightLavender)
 		numStyle := lipgloss.NewStyle().Foreground(styles.LightLavender)
 		pctStyle := lipgloss.NewStyle().Foreground(styles.Lavender)
+		selectedStyle := lipgloss.NewStyle().Foreground(styles.Pink).Bold(true)
 
-		for _, p := range sorted {
+		for i, p := range sorted {
 			name := truncateStr(p.Player, nameWidth)
 			pctStr := "-"
 			if p.Completed+p.Missed > 0 {
 				pctStr = fmt.Sprintf("%.0f", p.Percentage)
 			}
+			if focused && i == state.SelectedIndex {
+				tackleLines = append(tackleLines, selectedStyle.Render(fmt.Sprintf("▸%-*s %4d %4d %4d %4s",
+					nameWidth, name, p.Total, p.Completed, p.Missed, pctStr))+trendCell(p.Player))
+				continue
+			}
 			tackleLines = append(tackleLines, fmt.Sprintf(" %s %s %s %s %s",
 				nameStyle.Render(fmt.Sprintf("%-*s", nameWidth, name)),
 				numStyle.Render(fmt.Sprintf("%4d", p.Total)),
 				numStyle.Render(fmt.Sprintf("%4d", p.Completed)),
 				numStyle.Render(fmt.Sprintf("%4d", p.Missed)),
 				pctStyle.Render(fmt.Sprintf("%4s", pctStr)),
-			))
+			)+trendCell(p.Player))
 		}
 	}
 
-	tackleBox := RenderInfoBox("Tackle Stats", tackleLines, width, false)
+	tackleTitle := "Tackle Stats"
+	if focused {
+		tackleTitle += " by " + panelSortNames[state.SortIndex%len(panelSortNames)]
+	}
+	tackleBox := RenderInfoBox(tackleTitle, tackleLines, width, focused)
+
+	possessionBox := PossessionBox(possession, width)
 
-	return eventBox + "\n\n" + tackleBox
+	return possessionBox + "\n\n" + eventBox + "\n\n" + tackleBox
 }
@@ -46,6 +46,8 @@ type PlayerStats struct {
 	Other int
 	// Starred is the number of starred tackles
 	Starred int
+	// Covered is the number of missed tackles someone followed up
+	Covered int
 	// Percentage is the completion percentage (Completed / (Completed + Missed) * 100)
 	Percentage float64
 }
@@ -58,8 +60,9 @@ type StatsViewState struct {
 	Stats []PlayerStats
 	// SortColumn is the current sort column
 	SortColumn SortColumn
-	// AllVideos indicates if showing stats for all videos (true) or current video only (false)
-	AllVideos bool
+	// Scope names the videos the stats count, e.g. "Current Video" or
+	// "Season 2025/26" ("" for the current video)
+	Scope string
 	// SelectedIndex is the currently selected row
 	SelectedIndex int
 	// ScrollOffset is the scroll position
@@ -70,6 +73,61 @@ type StatsViewState struct {
 	FilterInput string
 	// FilteredPlayers is a set of player names that are currently filtered (highlighted)
 	FilteredPlayers map[string]bool
+	// ZoneFilter limits the table to tackles in one zone ("" for all)
+	ZoneFilter string
+	// FollowedFilter limits the table to tackles followed up ("yes") or not ("no"), "" for all
+	FollowedFilter string
+	// Timeline indicates if the timeline chart page is shown instead of the table
+	Timeline bool
+	// TimelineWindows are the current video's events per window of game time
+	TimelineWindows []TimelineWindow
+	// TimelineNote describes the chart's time base
+	TimelineNote string
+}
+
+// NextZone cycles the zone filter through all zones, then each of zones.
+func (s *StatsViewState) NextZone(zones []string) {
+	if s.ZoneFilter == "" {
+		if len(zones) > 0 {
+			s.ZoneFilter = zones[0]
+		}
+		return
+	}
+	next := ""
+	for i, z := range zones {
+		if strings.EqualFold(z, s.ZoneFilter) && i+1 < len(zones) {
+			next = zones[i+1]
+		}
+	}
+	s.ZoneFilter = next
+}
+
+// NextFollowed cycles the followed-up filter: all, followed up, not followed up.
+func (s *StatsViewState) NextFollowed() {
+	switch s.FollowedFilter {
+	case "":
+		s.FollowedFilter = "yes"
+	case "yes":
+		s.FollowedFilter = "no"
+	default:
+		s.FollowedFilter = ""
+	}
+}
+
+// tackleFilters describes the zone and followed-up filters, e.g.
+// "zone=22, followed up", or "" when neither is set.
+func (s StatsViewState) tackleFilters() string {
+	var parts []string
+	if s.ZoneFilter != "" {
+		parts = append(parts, "zone="+s.ZoneFilter)
+	}
+	switch s.FollowedFilter {
+	case "yes":
+		parts = append(parts, "followed up")
+	case "no":
+		parts = append(parts, "not followed up")
+	}
+	return strings.Join(parts, ", ")
 }
 
 // SortStats sorts the stats by the current sort column.
@@ -126,6 +184,61 @@ func (s *StatsViewState) MoveDown() {
 	}
 }
 
+// Selected returns the selected player, or "" when the table is empty.
+func (s *StatsViewState) Selected() string {
+	stats := s.GetSortedStats()
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(stats) {
+		return ""
+	}
+	return stats[s.SelectedIndex].Player
+}
+
+// Select moves the selection to player, if the table has them.
+func (s *StatsViewState) Select(player string) {
+	for i, stat := range s.GetSortedStats() {
+		if stat.Player == player {
+			s.SelectedIndex = i
+			return
+		}
+	}
+}
+
+// JumpTo selects row i (0-based) of the table, clamped to its rows.
+func (s *StatsViewState) JumpTo(i int) {
+	if i >= len(s.Stats) {
+		i = len(s.Stats) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	s.SelectedIndex = i
+}
+
+// Search selects the first row, from the top, whose player matches query by
+// name or initials. It returns false, leaving the selection, when none does.
+func (s *StatsViewState) Search(query string) bool {
+	i := s.matchIndex(query)
+	if i < 0 {
+		return false
+	}
+	s.SelectedIndex = i
+	return true
+}
+
+// matchIndex returns the first row matching query, or -1.
+func (s *StatsViewState) matchIndex(query string) int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return -1
+	}
+	for i, stat := range s.GetSortedStats() {
+		if matchesPlayer(stat.Player, query) {
+			return i
+		}
+	}
+	return -1
+}
+
 // ToggleFilter toggles the filter state for a player by name or initials.
 // Returns true if a player was matched and toggled.
 func (s *StatsViewState) ToggleFilter(input string) bool {
@@ -141,12 +254,7 @@ func (s *StatsViewState) ToggleFilter(input string) bool {
 	// Find players matching the input (name contains or initials match)
 	var matched []string
 	for _, stat := range s.Stats {
-		playerLower := strings.ToLower(stat.Player)
-		// Check if input is contained in player name
-		if strings.Contains(playerLower, input) {
-			matched = append(matched, stat.Player)
-		} else if matchesInitials(stat.Player, input) {
-			// Check if input matches initials
+		if matchesPlayer(stat.Player, input) {
 			matched = append(matched, stat.Player)
 		}
 	}
@@ -186,6 +294,12 @@ func (s *StatsViewState) ToggleFilter(input string) bool {
 	return false
 }
 
+// matchesPlayer checks if lowercase input is contained in a player name or
+// matches its initials.
+func matchesPlayer(playerName, input string) bool {
+	return strings.Contains(strings.ToLower(playerName), input) || matchesInitials(playerName, input)
+}
+
 // matchesInitials checks if the input matches the initials of a player name.
 // For example, "jd" matches "John Doe", "js" matches "John Smith".
 func matchesInitials(playerName, input string) bool {
@@ -243,8 +357,12 @@ func (s *StatsViewState) GetSortedStats() []PlayerStats {
 }
 
 // StatsView renders the stats view component.
-// It displays a table of player tackle statistics.
+// It displays a table of player tackle statistics, or the timeline chart.
 func StatsView(state StatsViewState, width, height int) string {
+	if state.Timeline {
+		return TimelineChart(state.TimelineWindows, state.TimelineNote, width, height)
+	}
+
 	// Title style
 	titleStyle := lipgloss.NewStyle().
 		Foreground(styles.Cyan).
@@ -261,18 +379,23 @@ func StatsView(state StatsViewState, width, height int) string {
 	var lines []string
 
 	// Title
-	title := "Tackle Statistics"
-	if state.AllVideos {
-		title += " (All Videos)"
-	} else {
-		title += " (Current Video)"
+	scope := state.Scope
+	if scope == "" {
+		scope = "Current Video"
 	}
+	title := "Tackle Statistics (" + scope + ")"
 	lines = append(lines, titleStyle.Render(title))
 
 	// Subtitle with sort indicator
 	sortNames := []string{"Player", "Total", "Completed", "Missed", "Possible", "%", "Starred"}
-	subtitle := fmt.Sprintf("Sorted by: %s | Tab to change | V to toggle videos | / to filter | Backspace to exit", sortNames[state.SortColumn])
+	subtitle := fmt.Sprintf("Sorted by: %s | Tab to change | V to change videos | / to filter | T for timeline | Backspace to exit", sortNames[state.SortColumn])
 	lines = append(lines, subtitleStyle.Render(subtitle))
+	if filters := state.tackleFilters(); filters != "" {
+		tackleFilterStyle := lipgloss.NewStyle().Foreground(styles.Amber).Padding(0, 1)
+		lines = append(lines, tackleFilterStyle.Render("Tackles: "+filters+" | Z zone | F followed"))
+	} else {
+		lines = append(lines, subtitleStyle.Render("Tackles: all | Z to filter by zone | F by followed up"))
+	}
 
 	// Filter mode indicator
 	if state.FilterMode {
@@ -281,6 +404,9 @@ func StatsView(state StatsViewState, width, height int) string {
 			Bold(true).
 			Padding(0, 1)
 		filterPrompt := fmt.Sprintf("Filter: %s_", state.FilterInput)
+		if state.FilterInput != "" && state.matchIndex(state.FilterInput) < 0 {
+			filterPrompt += " (no match)"
+		}
 		lines = append(lines, filterStyle.Render(filterPrompt))
 	} else if state.HasFilters() {
 		// Show filter count when not in filter mode
@@ -303,10 +429,11 @@ func StatsView(state StatsViewState, width, height int) string {
 	}
 
 	// Column widths
+	colRow := 3
 	colPlayer := 15
 	colNum := 6
 	colPct := 6
-	colTotal := colPlayer + colNum*5 + colPct + colNum + 8 // 8 for separators
+	colTotal := colRow + colPlayer + colNum*6 + colPct + colNum + 10 // 10 for separators
 
 	// Header row style
 	headerStyle := lipgloss.NewStyle().
@@ -314,7 +441,8 @@ func StatsView(state StatsViewState, width, height int) string {
 		Bold(true)
 
 	// Highlight current sort column in header
-	headerParts := []string{"Player", "Total", "Comp", "Miss", "Poss", "%", "Star"}
+	// Cov (missed tackles followed up) is not a sort column
+	headerParts := []string{"Player", "Total", "Comp", "Miss", "Poss", "%", "Star", "Cov"}
 	highlightedHeader := ""
 	for i, part := range headerParts {
 		var partWidth int
@@ -344,7 +472,7 @@ func StatsView(state StatsViewState, width, height int) string {
 			highlightedHeader += " "
 		}
 	}
-	lines = append(lines, " "+highlightedHeader)
+	lines = append(lines, " "+headerStyle.Render(fmt.Sprintf("%*s", colRow, "#"))+" "+highlightedHeader)
 
 	// Separator
 	separator := strings.Repeat("-", colTotal)
@@ -380,14 +508,22 @@ func StatsView(state StatsViewState, width, height int) string {
 			pctStr = fmt.Sprintf("%.0f", stat.Percentage)
 		}
 
-		row := fmt.Sprintf("%-*s %*d %*d %*d %*d %*s %*d",
+		covStr := "-"
+		if stat.Missed > 0 {
+			covStr = fmt.Sprintf("%d/%d", stat.Covered, stat.Missed)
+		}
+
+		// Row numbers are what nG jumps to
+		row := fmt.Sprintf("%*d %-*s %*d %*d %*d %*d %*s %*d %*s",
+			colRow, i+1,
 			colPlayer, truncateString(stat.Player, colPlayer),
 			colNum, stat.Total,
 			colNum, stat.Completed,
 			colNum, stat.Missed,
 			colNum, stat.Possible,
 			colPct, pctStr,
-			colNum, stat.Starred)
+			colNum, stat.Starred,
+			colNum, covStr)
 
 		var rowStyle lipgloss.Style
 		if isSelected {
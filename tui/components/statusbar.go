@@ -25,6 +25,19 @@ type StatusBarState struct {
 	OverlayEnabled bool
 	// VideoOpen indicates if the mpv video player is connected
 	VideoOpen bool
+	// Tally is the compact live event count (e.g. "T:14 ✓:11 ✗:3 N:7")
+	Tally string
+	// Chapter is the title of the chapter playing (e.g. "2nd half"), or "" without chapters
+	Chapter string
+	// Angle is the label of the camera angle playing, or "" for the main video
+	Angle string
+	// Shuttle is the JKL shuttle indicator (e.g. "◀◀ 4x"), or "" when shuttle mode is off
+	Shuttle string
+	// SinceEvent is the video time since the last event before the playhead
+	// (e.g. "1:42"), or "" when there is none
+	SinceEvent string
+	// SinceEventWarn is set when SinceEvent is past the warning threshold
+	SinceEventWarn bool
 }
 
 // StatusBar renders the status bar component.
@@ -60,7 +73,25 @@ func StatusBar(state StatusBarState, width int) string {
 
 	// Build the status bar content
 	leftContent := fmt.Sprintf(" %s %s / %s", playIcon, timeStr, durationStr)
-	rightContent := fmt.Sprintf("Step: %s%s%s ", stepStr, muteIcon, overlayIcon)
+	if state.Chapter != "" {
+		leftContent += "  " + state.Chapter
+	}
+	if state.Tally != "" {
+		leftContent += "  " + state.Tally
+	}
+	if state.SinceEvent != "" {
+		since := "+" + state.SinceEvent
+		if state.SinceEventWarn {
+			since = lipgloss.NewStyle().Foreground(styles.Red).Render("⚠ " + since)
+		}
+		leftContent += "  " + since
+	}
+	// Shuttle indicator (only shown in shuttle mode)
+	var shuttleStr string
+	if state.Shuttle != "" {
+		shuttleStr = state.Shuttle + "  "
+	}
+	rightContent := fmt.Sprintf("%sStep: %s%s%s ", shuttleStr, stepStr, muteIcon, overlayIcon)
 
 	// Calculate padding between left and right content
 	leftWidth := lipgloss.Width(leftContent)
@@ -89,6 +120,26 @@ func StatusBar(state StatusBarState, width int) string {
 	return statusBarStyle.Render(content)
 }
 
+// Tally summarizes the list as tackles (T), completed (✓), missed (✗) and notes (N),
+// e.g. "T:14 ✓:11 ✗:3 N:7".
+func Tally(items []ListItem) string {
+	var tackles, completed, missed, notes int
+	for _, item := range items {
+		if item.Type != ItemTypeTackle {
+			notes++
+			continue
+		}
+		tackles++
+		switch item.Outcome {
+		case "completed":
+			completed++
+		case "missed":
+			missed++
+		}
+	}
+	return fmt.Sprintf("T:%d ✓:%d ✗:%d N:%d", tackles, completed, missed, notes)
+}
+
 // formatStepSize formats the step size for display.
 // Shows decimal for values less than 1, otherwise whole number.
 func formatStepSize(stepSize float64) string {
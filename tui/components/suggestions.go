@@ -0,0 +1,120 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// SuggestionItem is a candidate event waiting in the review queue.
+type SuggestionItem struct {
+	Time       float64
+	Label      string
+	Category   string
+	Confidence float64
+}
+
+// SuggestionQueueState holds the state for the suggestion review queue.
+type SuggestionQueueState struct {
+	// Active indicates if the review queue is currently displayed
+	Active bool
+	// Loading is true while the suggester process is running
+	Loading bool
+	// Items are the pending suggestions, sorted by time
+	Items []SuggestionItem
+	// SelectedIndex is the highlighted suggestion
+	SelectedIndex int
+	// Accepted and Rejected count decisions made this session
+	Accepted int
+	Rejected int
+}
+
+// Selected returns the highlighted suggestion, or nil if the queue is empty.
+func (s *SuggestionQueueState) Selected() *SuggestionItem {
+	if s.SelectedIndex < 0 || s.SelectedIndex >= len(s.Items) {
+		return nil
+	}
+	return &s.Items[s.SelectedIndex]
+}
+
+// Remove drops the highlighted suggestion and keeps the selection in range.
+func (s *SuggestionQueueState) Remove() {
+	if s.Selected() == nil {
+		return
+	}
+	s.Items = append(s.Items[:s.SelectedIndex], s.Items[s.SelectedIndex+1:]...)
+	if s.SelectedIndex >= len(s.Items) && s.SelectedIndex > 0 {
+		s.SelectedIndex--
+	}
+}
+
+// MoveUp moves the selection up one row.
+func (s *SuggestionQueueState) MoveUp() {
+	if s.SelectedIndex > 0 {
+		s.SelectedIndex--
+	}
+}
+
+// MoveDown moves the selection down one row.
+func (s *SuggestionQueueState) MoveDown() {
+	if s.SelectedIndex < len(s.Items)-1 {
+		s.SelectedIndex++
+	}
+}
+
+// SuggestionQueue renders the review queue overlay.
+func SuggestionQueue(state SuggestionQueueState, width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(styles.Cyan).
+		Bold(true).
+		Padding(0, 1)
+	subtitleStyle := lipgloss.NewStyle().
+		Foreground(styles.Lavender).
+		Italic(true).
+		Padding(0, 1)
+	rowStyle := lipgloss.NewStyle().Foreground(styles.LightLavender)
+	selectedStyle := lipgloss.NewStyle().Foreground(styles.Cyan).Bold(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("Suggested Events (%d pending, %d accepted, %d rejected)", len(state.Items), state.Accepted, state.Rejected)))
+	lines = append(lines, subtitleStyle.Render("Enter/A accept | X/R reject | Space seek | J/K move | Esc to exit"))
+	lines = append(lines, "")
+
+	if state.Loading {
+		lines = append(lines, subtitleStyle.Render("Running suggester..."))
+		return centerContent(strings.Join(lines, "\n"), width, height)
+	}
+	if len(state.Items) == 0 {
+		lines = append(lines, subtitleStyle.Render("No suggestions to review"))
+		return centerContent(strings.Join(lines, "\n"), width, height)
+	}
+
+	// Keep the selected row visible: 3 header lines above the list
+	visible := height - 4
+	if visible < 1 {
+		visible = 1
+	}
+	offset := 0
+	if state.SelectedIndex >= visible {
+		offset = state.SelectedIndex - visible + 1
+	}
+
+	for i := offset; i < len(state.Items) && i < offset+visible; i++ {
+		item := state.Items[i]
+		conf := ""
+		if item.Confidence > 0 {
+			conf = fmt.Sprintf(" %3.0f%%", item.Confidence*100)
+		}
+		row := fmt.Sprintf("  %s  %-12s %s%s", timeutil.FormatTime(item.Time), item.Category, item.Label, conf)
+		if i == state.SelectedIndex {
+			lines = append(lines, selectedStyle.Render("▶"+row[1:]))
+		} else {
+			lines = append(lines, rowStyle.Render(row))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
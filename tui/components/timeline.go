@@ -10,34 +10,22 @@ import (
 	"github.com/user/tagging-rugby-cli/tui/styles"
 )
 
-// Timeline renders a progress bar with event markers spanning full terminal width.
-// It shows playback position, timestamps, and note/tackle markers.
-func Timeline(timePos, duration float64, items []ListItem, width int) string {
-	if width < 20 {
-		return ""
-	}
-
-	// Styles
-	filledStyle := lipgloss.NewStyle().Foreground(styles.BrightPurple)
-	unfilledStyle := lipgloss.NewStyle().Foreground(styles.Purple)
-	timeStyle := lipgloss.NewStyle().Foreground(styles.LightLavender).Bold(true)
-	markerStyle := lipgloss.NewStyle().Foreground(styles.Cyan)
-	posStyle := lipgloss.NewStyle().Foreground(styles.Pink).Bold(true)
-
+// timelineLayout returns the time display, bar width and fill position shared by
+// the timeline and the waveform strip drawn under it.
+func timelineLayout(timePos, duration float64, width int) (timeDisplay string, barWidth, fillPos int) {
 	// Format timestamps
 	currentStr := timeutil.FormatTime(timePos)
 	totalStr := timeutil.FormatTime(duration)
-	timeDisplay := fmt.Sprintf(" %s / %s", currentStr, totalStr)
+	timeDisplay = fmt.Sprintf(" %s / %s", currentStr, totalStr)
 	timeDisplayWidth := lipgloss.Width(timeDisplay)
 
 	// Bar width = total width minus time display and spacing
-	barWidth := width - timeDisplayWidth - 2 // 1 space left margin + 1 space before time
+	barWidth = width - timeDisplayWidth - 2 // 1 space left margin + 1 space before time
 	if barWidth < 10 {
 		barWidth = 10
 	}
 
 	// Calculate fill position
-	var fillPos int
 	if duration > 0 {
 		fillPos = int(math.Round(float64(barWidth) * timePos / duration))
 	}
@@ -47,6 +35,24 @@ func Timeline(timePos, duration float64, items []ListItem, width int) string {
 	if fillPos > barWidth {
 		fillPos = barWidth
 	}
+	return timeDisplay, barWidth, fillPos
+}
+
+// Timeline renders a progress bar with event markers spanning full terminal width.
+// It shows playback position, timestamps, and note/tackle markers.
+func Timeline(timePos, duration float64, items []ListItem, width int) string {
+	if width < 20 {
+		return ""
+	}
+
+	// Styles
+	filledStyle := lipgloss.NewStyle().Foreground(styles.BrightPurple)
+	unfilledStyle := lipgloss.NewStyle().Foreground(styles.Purple)
+	timeStyle := lipgloss.NewStyle().Foreground(styles.LightLavender).Bold(true)
+	markerStyle := lipgloss.NewStyle().Foreground(styles.Cyan)
+	posStyle := lipgloss.NewStyle().Foreground(styles.Pink).Bold(true)
+
+	timeDisplay, barWidth, fillPos := timelineLayout(timePos, duration, width)
 
 	// Build the bar with event markers
 	barChars := make([]rune, barWidth)
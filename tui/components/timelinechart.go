@@ -0,0 +1,85 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// TimelineWindow is one window of the stats view's timeline chart: the
+// events counted in a span of game time.
+type TimelineWindow struct {
+	// Period names the half the window is in, e.g. "1st half"
+	Period string
+	// Label is the span in game minutes, e.g. "10-20" or "40+"
+	Label     string
+	Events    int
+	Tackles   int
+	Completed int
+	Missed    int
+}
+
+// completion formats the tackle completion %, or "-" with no completed or missed tackles.
+func (w TimelineWindow) completion() string {
+	if w.Completed+w.Missed == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", float64(w.Completed)/float64(w.Completed+w.Missed)*100)
+}
+
+// TimelineChart renders the stats view's timeline page: a bar of events per
+// window, grouped by half, with the window's tackles, missed tackles and
+// completion beside it. note explains the time base (game clock or video time).
+func TimelineChart(windows []TimelineWindow, note string, width, height int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(styles.Cyan).Bold(true).Padding(0, 1)
+	subtitleStyle := lipgloss.NewStyle().Foreground(styles.Lavender).Italic(true).Padding(0, 1)
+	periodStyle := lipgloss.NewStyle().Foreground(styles.Pink).Bold(true).Padding(0, 1)
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Lavender)
+	barStyle := lipgloss.NewStyle().Foreground(styles.BrightPurple)
+	countStyle := lipgloss.NewStyle().Foreground(styles.Cyan)
+	missedStyle := lipgloss.NewStyle().Foreground(styles.Red)
+	dimStyle := lipgloss.NewStyle().Foreground(styles.Purple)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Events per Window"))
+	lines = append(lines, subtitleStyle.Render(note+" | T for player stats | Backspace to exit"))
+	lines = append(lines, "")
+
+	if len(windows) == 0 {
+		emptyStyle := lipgloss.NewStyle().Foreground(styles.Lavender).Italic(true).Padding(1, 2)
+		lines = append(lines, emptyStyle.Render("No events recorded"))
+		return centerContent(strings.Join(lines, "\n"), width, height)
+	}
+
+	most := 1
+	for _, w := range windows {
+		most = max(most, w.Events)
+	}
+	// Label (6) + count (4) + tackle columns (20) + spacing and padding (8)
+	barWidth := min(40, width-38)
+	if barWidth < 5 {
+		barWidth = 5
+	}
+
+	for i, w := range windows {
+		if i == 0 || w.Period != windows[i-1].Period {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, periodStyle.Render(w.Period))
+		}
+		bar := strings.Repeat("█", w.Events*barWidth/most)
+		lines = append(lines, fmt.Sprintf("  %s %s%s %s %s %s",
+			labelStyle.Render(fmt.Sprintf("%-6s", w.Label)),
+			barStyle.Render(bar),
+			strings.Repeat(" ", barWidth-lipgloss.Width(bar)),
+			countStyle.Render(fmt.Sprintf("%4d", w.Events)),
+			dimStyle.Render(fmt.Sprintf("T %-3d", w.Tackles))+missedStyle.Render(fmt.Sprintf(" M %-3d", w.Missed)),
+			dimStyle.Render(fmt.Sprintf("%4s", w.completion())),
+		))
+	}
+
+	return centerContent(strings.Join(lines, "\n"), width, height)
+}
@@ -0,0 +1,36 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// TutorialPrompt renders the one-line :tutorial bar: the step number and
+// title, then what to do, truncated to width.
+func TutorialPrompt(step, total int, title, prompt string, width int) string {
+	stepStyle := lipgloss.NewStyle().
+		Foreground(styles.Cyan).
+		Bold(true)
+
+	promptStyle := lipgloss.NewStyle().
+		Foreground(styles.LightLavender)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.Lavender)
+
+	line := " " + stepStyle.Render(fmt.Sprintf("Tutorial %d/%d · %s", step, total, title)) +
+		"  " + promptStyle.Render(prompt) +
+		"  " + hintStyle.Render("(:tutorial stop)")
+	if lipgloss.Width(line) > width {
+		line = ansi.Truncate(line, width, "")
+	}
+
+	containerStyle := lipgloss.NewStyle().
+		Background(styles.DeepPurple).
+		Width(width)
+
+	return containerStyle.Render(line)
+}
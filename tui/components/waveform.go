@@ -0,0 +1,56 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// waveformLevels are the block characters for audio levels, quietest first.
+var waveformLevels = []rune(" ▁▂▃▄▅▆▇█")
+
+// Waveform renders the audio peaks as a one-line strip aligned under the
+// timeline bar, so loud moments (whistles, crowd noise) line up with the
+// event markers. Each cell shows the loudest peak in its slice of the video.
+func Waveform(peaks []byte, secondsPerPeak, timePos, duration float64, width int) string {
+	if width < 20 || len(peaks) == 0 || secondsPerPeak <= 0 || duration <= 0 {
+		return ""
+	}
+
+	playedStyle := lipgloss.NewStyle().Foreground(styles.BrightPurple)
+	unplayedStyle := lipgloss.NewStyle().Foreground(styles.Lavender)
+	posStyle := lipgloss.NewStyle().Foreground(styles.Pink).Bold(true)
+
+	_, barWidth, fillPos := timelineLayout(timePos, duration, width)
+
+	var b strings.Builder
+	b.WriteString(" ")
+	for i := 0; i < barWidth; i++ {
+		from := int(duration * float64(i) / float64(barWidth) / secondsPerPeak)
+		to := int(duration * float64(i+1) / float64(barWidth) / secondsPerPeak)
+		if to <= from {
+			to = from + 1
+		}
+		var level byte
+		for j := from; j < to && j < len(peaks); j++ {
+			if peaks[j] > level {
+				level = peaks[j]
+			}
+		}
+		ch := string(waveformLevels[int(level)*(len(waveformLevels)-1)/255])
+		switch {
+		case i == fillPos:
+			b.WriteString(posStyle.Render(ch))
+		case i < fillPos:
+			b.WriteString(playedStyle.Render(ch))
+		default:
+			b.WriteString(unplayedStyle.Render(ch))
+		}
+	}
+
+	bgStyle := lipgloss.NewStyle().
+		Background(styles.DarkPurple).
+		Width(width)
+	return bgStyle.Render(b.String())
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/pkg/helpdoc"
+	"github.com/user/tagging-rugby-cli/pkg/lint"
+	"github.com/user/tagging-rugby-cli/tui/components"
+	"github.com/user/tagging-rugby-cli/tui/keymap"
+)
+
+// loadConfig reads config.json for the settings the TUI uses. A broken config
+// is reported in the footer and the defaults are used instead.
+func (m *Model) loadConfig() {
+	cfg, err := config.Load()
+	if err != nil {
+		m.statusMsg = "Config error: " + err.Error()
+		m.notify(components.LevelError, m.statusMsg)
+		cfg = &config.Config{}
+	}
+	m.config = cfg
+	m.frameRamp = newFrameRamp(cfg.FrameStep)
+	if !validSteps(cfg.StepSizes) {
+		m.notify(components.LevelWarning, "Config: step_sizes must be seconds above 0 in ascending order; using the defaults")
+		cfg.StepSizes = nil
+	}
+	if cfg.StepSize > 0 {
+		m.statusBar.StepSize = cfg.StepSize
+	}
+	if cfg.Dictionary != nil {
+		m.dictionary = lint.NewDictionary(cfg.Dictionary.Terms)
+	}
+	m.loadKeymap()
+}
+
+// validSteps reports whether config.json's step sizes are usable: positive
+// and ascending, as 'config set step_sizes' stores them.
+func validSteps(steps []float64) bool {
+	for i, step := range steps {
+		if step <= 0 || (i > 0 && step <= steps[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// loadKeymap applies config.json's key remapping and command aliases. Bad
+// entries are reported and skipped; the rest still apply.
+func (m *Model) loadKeymap() {
+	km, err := keymap.New(m.config.Keys)
+	if err != nil {
+		m.notify(components.LevelWarning, "Keys: "+err.Error())
+	}
+	m.keymap = km
+
+	commands := append(helpdoc.CommandNames(), helpdoc.CommandAliases()...)
+	if m.scripts != nil {
+		commands = append(commands, m.scripts.Commands()...)
+	}
+	aliases, err := keymap.Aliases(m.config.CommandAliases, commands)
+	if err != nil {
+		m.notify(components.LevelWarning, "Command aliases: "+err.Error())
+	}
+	m.commandAliases = aliases
+}
+
+// keyScope is the keymap scope of the focused panel.
+func (m *Model) keyScope() keymap.Scope {
+	switch m.focus {
+	case FocusVideo:
+		return keymap.Video
+	case FocusNotes:
+		return keymap.Notes
+	}
+	return keymap.Global
+}
+
+// remappedHelp lists the keys config.json remaps, for the help overlay.
+func (m *Model) remappedHelp() []helpdoc.Entry {
+	if m.keymap == nil {
+		return nil
+	}
+	var entries []helpdoc.Entry
+	for _, b := range m.keymap.Bindings() {
+		if !b.Remapped {
+			continue
+		}
+		keys := make([]string, len(b.Bound))
+		for i, k := range b.Bound {
+			keys[i] = keymap.Display(k)
+		}
+		name := strings.Join(keys, " / ")
+		if name == "" {
+			name = "(none)"
+		}
+		entries = append(entries, helpdoc.Entry{Name: name, Desc: b.Desc})
+	}
+	return entries
+}
@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/config"
+)
+
+// crashLogSize is how many recent messages a crash dump lists.
+const crashLogSize = 50
+
+// crashLog is a ring of the most recent messages, so a crash dump shows what
+// led up to a panic. Ticks are left out; they would push everything else out.
+type crashLog struct {
+	entries []string
+	next    int
+}
+
+// record adds msg to the log.
+func (l *crashLog) record(msg tea.Msg) {
+	switch msg.(type) {
+	case tickMsg, slowTickMsg:
+		return
+	}
+	entry := time.Now().Format("15:04:05.000") + " " + describeMsg(msg)
+	if len(l.entries) < crashLogSize {
+		l.entries = append(l.entries, entry)
+		return
+	}
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % crashLogSize
+}
+
+// lines returns the logged messages, oldest first.
+func (l *crashLog) lines() []string {
+	return append(append([]string(nil), l.entries[l.next:]...), l.entries[:l.next]...)
+}
+
+// describeMsg formats a message for the crash log.
+func describeMsg(msg tea.Msg) string {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		return "key " + key.String()
+	}
+	s := fmt.Sprintf("%T %+v", msg, msg)
+	if len(s) > 200 {
+		s = s[:200] + "..."
+	}
+	return s
+}
+
+// crash handles a panic while updating or rendering: it writes a state dump
+// and quits, so Bubble Tea restores the terminal the usual way. Run reports
+// the dump's location once the terminal is back.
+func (m *Model) crash(r any) {
+	if m.crashed != nil {
+		return
+	}
+	m.crashed = &crashReport{Panic: fmt.Sprint(r)}
+	path, err := m.writeCrashDump(r, debug.Stack())
+	if err != nil {
+		m.crashed.Err = err
+		return
+	}
+	m.crashed.Path = path
+}
+
+// crashReport records a recovered panic for Run to report.
+type crashReport struct {
+	Panic string
+	// Path is the state dump, or "" when it could not be written (Err)
+	Path string
+	Err  error
+}
+
+// error returns the error Run returns for the crash.
+func (c *crashReport) error() error {
+	if c.Path == "" {
+		return fmt.Errorf("TUI crashed: %s (state dump not written: %v)", c.Panic, c.Err)
+	}
+	return fmt.Errorf("TUI crashed: %s\nState dump written to %s", c.Panic, c.Path)
+}
+
+// writeCrashDump writes the panic, a snapshot of the model, the recent
+// messages and the stack to a new file in the crashes directory.
+func (m *Model) writeCrashDump(r any, stack []byte) (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	dir = filepath.Join(dir, "crashes")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	path := filepath.Join(dir, "crash-"+now.Format("20060102-150405")+".txt")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "tagging-rugby-cli TUI crash at %s\n\npanic: %v\n\n", now.Format(time.RFC3339), r)
+	b.WriteString("== State ==\n")
+	m.writeCrashState(&b)
+	b.WriteString("\n== Recent messages ==\n")
+	for _, line := range m.crashLog.lines() {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n== Stack ==\n")
+	b.Write(stack)
+
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// writeCrashState writes the parts of the model useful for reproducing a
+// crash. The database and player handles are left out.
+func (m *Model) writeCrashState(b *strings.Builder) {
+	fmt.Fprintf(b, "video: %s (id %d, angle %d)\n", m.videoPath, m.videoID, m.angleIndex)
+	fmt.Fprintf(b, "terminal: %dx%d\n", m.width, m.height)
+	fmt.Fprintf(b, "focus: %d\n", m.focus)
+	fmt.Fprintf(b, "status bar: %+v\n", m.statusBar)
+	fmt.Fprintf(b, "status: %q\n", m.statusMsg)
+	fmt.Fprintf(b, "forms: note=%t tackle=%t confirm=%t commentary=%t\n",
+		m.noteForm != nil, m.tackleForm != nil, m.confirmDiscardForm != nil, m.commentaryForm != nil)
+	fmt.Fprintf(b, "overlays: help=%t messages=%t stats=%t suggestions=%t clips=%t\n",
+		m.showHelp, m.showMessages, m.statsView.Active, m.suggestions.Active, m.clipsView.Active)
+	fmt.Fprintf(b, "command: active=%t input=%q\n", m.commandInput.Active, m.commandInput.Input)
+	fmt.Fprintf(b, "search: %q (%d matches)\n", m.searchInput.Input, len(m.searchInput.Matches))
+	fmt.Fprintf(b, "notes: %d items, selected %d, scroll %d, editing=%t\n",
+		len(m.notesList.Items), m.notesList.SelectedIndex, m.notesList.ScrollOffset, m.notesList.Edit.Active)
+	fmt.Fprintf(b, "player filter: %q\n", m.playerFilter)
+	fmt.Fprintf(b, "category filter: %q\n", m.categoryFilter)
+	fmt.Fprintf(b, "shuttle: %+v\n", m.shuttle)
+	fmt.Fprintf(b, "replay: active=%t next=%d of %d\n", m.replay.Active, m.replay.Next, len(m.replay.Events))
+}
@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/config"
+)
+
+// crossFilterOn reports whether moving through the stats tables filters the
+// notes list live.
+func (m *Model) crossFilterOn() bool {
+	return m.config != nil && m.config.CrossFilter
+}
+
+// toggleCrossFilter turns the live cross-filter on or off and saves the choice
+// to config.json.
+func (m *Model) toggleCrossFilter() (string, error) {
+	if m.config == nil {
+		m.config = &config.Config{}
+	}
+	m.config.CrossFilter = !m.config.CrossFilter
+
+	// Re-read before saving so edits made to config.json since startup are kept
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("cross-filter not saved: %w", err)
+	}
+	cfg.CrossFilter = m.config.CrossFilter
+	if err := config.Save(cfg); err != nil {
+		return "", fmt.Errorf("cross-filter not saved: %w", err)
+	}
+
+	if !m.config.CrossFilter {
+		return "Cross-filter off: Enter in the stats filters the notes list", nil
+	}
+	return "Cross-filter on: the notes list and timeline follow the player selected in the stats", nil
+}
+
+// applyPlayerFilter limits the notes list (and so the timeline markers) to
+// player's events, "" for all, and selects the player in both stats tables so
+// the three stay on the same player.
+func (m *Model) applyPlayerFilter(player string) {
+	m.playerFilter = player
+	m.notesList.SelectedIndex = 0
+	m.notesList.ScrollOffset = 0
+	m.loadNotesAndTackles()
+	if player != "" {
+		m.statsPanel.Select(m.statsView.Stats, player)
+		m.statsView.Select(player)
+	}
+}
+
+// followStatsSelection filters the notes list to the player now selected in a
+// stats table, when the cross-filter is on.
+func (m *Model) followStatsSelection(player string) {
+	if !m.crossFilterOn() || player == "" || player == m.playerFilter {
+		return
+	}
+	m.applyPlayerFilter(player)
+}
+
+// filterBySelectedPlayer filters the notes list by the selected event's player
+// (f in the notes list), or clears the filter when it is already on them.
+func (m *Model) filterBySelectedPlayer() (tea.Model, tea.Cmd) {
+	item := m.notesList.GetSelectedItem()
+	if item == nil || item.Player == "" {
+		return m.showStatus("Selected event has no player")
+	}
+	if item.Player == m.playerFilter {
+		return m.setPlayerFilter("")
+	}
+	return m.setPlayerFilter(item.Player)
+}
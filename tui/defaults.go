@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/tui/forms"
+)
+
+// formDefaults are values pre-filled into new note and tackle forms for the rest
+// of the session, so the operator only changes what differs between events.
+type formDefaults struct {
+	Team     string
+	Zone     string
+	Followed string
+}
+
+// defaultFields lists the fields :default can set, in display order.
+var defaultFields = []string{"team", "zone", "followed"}
+
+// field returns a pointer to the named default, or nil for an unknown name.
+func (d *formDefaults) field(name string) *string {
+	switch name {
+	case "team":
+		return &d.Team
+	case "zone":
+		return &d.Zone
+	case "followed":
+		return &d.Followed
+	}
+	return nil
+}
+
+// noteForm returns the values a new note form opens with.
+func (d formDefaults) noteForm() forms.NoteFormResult {
+	return forms.NoteFormResult{Team: d.Team}
+}
+
+// tackleForm returns the values a new tackle form opens with.
+func (d formDefaults) tackleForm() forms.TackleFormResult {
+	return forms.TackleFormResult{Zone: d.Zone, Followed: d.Followed}
+}
+
+// noteFormPrefill returns the values a new note form opens with: an event
+// type's category and duration when :event opened it.
+func (m *Model) noteFormPrefill() forms.NoteFormResult {
+	prefill := m.defaults.noteForm()
+	prefill.Duration = m.defaultEventDuration()
+	if m.noteFormEvent != nil {
+		prefill.Category = m.noteFormEvent.Name
+		if m.noteFormEvent.Duration > 0 {
+			prefill.Duration = forms.FormatDuration(m.noteFormEvent.Duration)
+		}
+	}
+	return prefill
+}
+
+// defaultEventDuration returns the configured event duration for the forms'
+// duration field, or "" when none is set.
+func (m *Model) defaultEventDuration() string {
+	if m.config == nil {
+		return ""
+	}
+	return forms.FormatDuration(m.config.EventDuration)
+}
+
+// executeDefaultCommand handles :default [field [value]]. With no arguments it
+// lists the defaults; a field with no value clears it.
+func (m *Model) executeDefaultCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		var parts []string
+		for _, name := range defaultFields {
+			if v := *m.defaults.field(name); v != "" {
+				parts = append(parts, fmt.Sprintf("%s %s", name, v))
+			}
+		}
+		if len(parts) == 0 {
+			return "No defaults set (usage: default team|zone|followed [value])", nil
+		}
+		return "Defaults: " + strings.Join(parts, ", "), nil
+	}
+
+	name := strings.ToLower(args[0])
+	if name == "clear" {
+		m.defaults = formDefaults{}
+		return "Defaults cleared", nil
+	}
+	f := m.defaults.field(name)
+	if f == nil {
+		return "", fmt.Errorf("unknown default: %s (want %s)", args[0], strings.Join(defaultFields, ", "))
+	}
+	*f = strings.Join(args[1:], " ")
+	if *f == "" {
+		return fmt.Sprintf("Default %s cleared", name), nil
+	}
+	return fmt.Sprintf("Default %s set to %s", name, *f), nil
+}
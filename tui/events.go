@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+)
+
+// executeEventCommand handles :event [<type>]. A type opens the note form
+// with its category and default duration filled in, so the form goes on to
+// the type's fields; on its own the command lists the event types.
+func (m *Model) executeEventCommand(args []string) (string, error) {
+	name := catpath.Normalize(strings.Join(args, " "))
+	if name == "" {
+		types, err := db.SelectEventTypes(m.db)
+		if err != nil {
+			return "", fmt.Errorf("failed to load event types: %w", err)
+		}
+		if len(types) == 0 {
+			return "No event types (add them with 'event type add')", nil
+		}
+		names := make([]string, len(types))
+		for i, t := range types {
+			names[i] = t.Name
+		}
+		return "Event types: " + strings.Join(names, ", "), nil
+	}
+
+	t, err := db.SelectEventType(m.db, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("no event type %q (:event lists them)", name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load event type: %w", err)
+	}
+	m.nextNoteEvent = &t
+	return "OPEN_NOTE_INPUT", nil
+}
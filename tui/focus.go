@@ -1,5 +1,7 @@
 package tui
 
+import "github.com/user/tagging-rugby-cli/tui/components"
+
 // FocusTarget represents which panel currently has focus.
 type FocusTarget int
 
@@ -10,12 +12,16 @@ const (
 	FocusSearch
 	// FocusNotes focuses the notes list.
 	FocusNotes
+	// FocusStats focuses the column 3 stats panel.
+	FocusStats
 )
 
 // cycleFocus cycles focus between panels.
-// forward=true: Video -> Search -> Notes -> Video
-// forward=false: Video -> Notes -> Search -> Video
+// forward=true: Video -> Search -> Notes -> Stats -> Video
+// forward=false: Video -> Stats -> Notes -> Search -> Video
+// Stats is skipped when column 3 is hidden.
 func (m *Model) cycleFocus(forward bool) {
+	stats := m.statsPanelVisible()
 	if forward {
 		switch m.focus {
 		case FocusVideo:
@@ -24,15 +30,47 @@ func (m *Model) cycleFocus(forward bool) {
 			m.focus = FocusNotes
 		case FocusNotes:
 			m.focus = FocusVideo
+			if stats {
+				m.focus = FocusStats
+			}
+		case FocusStats:
+			m.focus = FocusVideo
 		}
 	} else {
 		switch m.focus {
 		case FocusVideo:
 			m.focus = FocusNotes
+			if stats {
+				m.focus = FocusStats
+			}
 		case FocusSearch:
 			m.focus = FocusVideo
 		case FocusNotes:
 			m.focus = FocusSearch
+		case FocusStats:
+			m.focus = FocusNotes
 		}
 	}
 }
+
+// controlContext returns what currently takes key presses, for the controls
+// display. Open forms and overlays win over panel focus.
+func (m *Model) controlContext() components.ControlContext {
+	switch {
+	case m.noteForm != nil || m.tackleForm != nil || m.confirmDiscardForm != nil || m.commentaryForm != nil:
+		return components.ControlsForm
+	case m.showHelp || m.showMessages || m.statsView.Active || m.suggestions.Active || m.clipsView.Active:
+		return components.ControlsOverlay
+	case m.commandInput.Active || m.focus == FocusSearch && m.searchInput.Mode == "command":
+		return components.ControlsCommand
+	case m.focus == FocusSearch:
+		return components.ControlsSearch
+	case m.shuttle.Active:
+		return components.ControlsShuttle
+	case m.focus == FocusNotes:
+		return components.ControlsNotes
+	case m.focus == FocusStats:
+		return components.ControlsStats
+	}
+	return components.ControlsVideo
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/user/tagging-rugby-cli/tui/components"
+	"github.com/user/tagging-rugby-cli/tui/forms"
+)
+
+// formKeyNames renames huh's terse binding descriptions for the controls display.
+var formKeyNames = map[string]string{
+	"next":   "Next field",
+	"back":   "Prev field",
+	"submit": "Save",
+}
+
+// controlGroups returns the control groups for the current focus or mode.
+func (m *Model) controlGroups() []components.ControlGroup {
+	ctx := m.controlContext()
+	if ctx == components.ControlsForm {
+		if groups := m.formControlGroups(); groups != nil {
+			return groups
+		}
+	}
+	return components.GetControlGroups(ctx)
+}
+
+// formControlGroups returns the keys of the open form: the bindings huh enables
+// for the focused field (so Save only shows on the last field, and Prev field
+// not on the first), followed by the app's own keys for that form.
+func (m *Model) formControlGroups() []components.ControlGroup {
+	var form *huh.Form
+	var name string
+	var extra []components.Control
+	switch {
+	case m.confirmDiscardForm != nil:
+		form, name = m.confirmDiscardForm, "Discard?"
+		extra = append(extra, components.Control{Name: "Go back", Shortcut: "Ctrl+c"})
+	case m.noteForm != nil:
+		form, name = m.noteForm, "Note"
+		extra = append(extra,
+			components.Control{Name: "Re-capture", Shortcut: "Ctrl+t"},
+			components.Control{Name: "Cancel", Shortcut: "Ctrl+c"})
+	case m.tackleForm != nil:
+		form, name = m.tackleForm, "Tackle"
+		if m.editingNoteID == 0 {
+			if m.hasStickyTackle() {
+				extra = append(extra, components.Control{Name: "Clear sticky", Shortcut: "Ctrl+x"})
+			}
+			if len(m.recentPlayers) > 0 && forms.PlayerFocused(m.tackleForm) {
+				extra = append(extra, components.Control{Name: "Recent player", Shortcut: fmt.Sprintf("Alt+1-%d", len(m.recentPlayers))})
+			}
+			extra = append(extra, components.Control{Name: "Re-capture", Shortcut: "Ctrl+t"})
+		}
+		extra = append(extra, components.Control{Name: "Cancel", Shortcut: "Ctrl+c"})
+	case m.commentaryForm != nil:
+		form, name = m.commentaryForm, "Commentary"
+		extra = append(extra, components.Control{Name: "Cancel", Shortcut: "Esc"})
+	default:
+		return nil
+	}
+
+	var field []components.Control
+	for _, b := range form.KeyBinds() {
+		help := b.Help()
+		if !b.Enabled() || help.Key == "" {
+			continue
+		}
+		desc := help.Desc
+		if desc == "submit" && form == m.confirmDiscardForm {
+			desc = "Confirm"
+		} else if n, ok := formKeyNames[desc]; ok {
+			desc = n
+		} else if desc != "" {
+			desc = strings.ToUpper(desc[:1]) + desc[1:]
+		}
+		field = append(field, components.Control{Name: desc, Shortcut: help.Key})
+	}
+	return []components.ControlGroup{{Name: name, SubGroups: [][]components.Control{field, extra}}}
+}
@@ -0,0 +1,22 @@
+package forms
+
+import (
+	"github.com/charmbracelet/huh"
+)
+
+// NewCommentaryForm creates a huh form with a multi-line text area for a video's
+// free-form match commentary. The body pointer starts with the saved text.
+func NewCommentaryForm(title string, body *string) *huh.Form {
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title("Match Commentary — " + title).
+				Description("Markdown. Alt+Enter / Ctrl+J for a new line, Enter to save, Esc to cancel").
+				Lines(14).
+				CharLimit(0).
+				Value(body),
+		),
+	).WithTheme(Theme())
+
+	return form
+}
@@ -2,6 +2,10 @@
 package forms
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/charmbracelet/huh"
 )
 
@@ -19,3 +23,81 @@ func NewConfirmDiscardForm(discard *bool) *huh.Form {
 		),
 	).WithTheme(Theme())
 }
+
+// Suggestions are the values entered before, offered as completions while a
+// field is typed: "jo" offers "Jones". Each list is most used first.
+type Suggestions struct {
+	Players    []string
+	Categories []string
+	Zones      []string
+	// RecentPlayers are the last players tagged, most recent first, which the
+	// tackle form numbers for picking without typing
+	RecentPlayers []string
+	// Events is how many events the video has: the #n references in note text
+	// are checked against it
+	Events int
+}
+
+// completes appends the completion hint to a field description when there is
+// something to complete.
+func completes(description string, suggestions []string) string {
+	if len(suggestions) == 0 {
+		return description
+	}
+	return description + " · ctrl+e completes"
+}
+
+// Spelled returns value as a suggestion spells it when the two differ only in
+// case: a completion keeps the case typed, so "jo" completes to "jones".
+func Spelled(value string, suggestions []string) string {
+	for _, s := range suggestions {
+		if strings.EqualFold(s, value) {
+			return s
+		}
+	}
+	return value
+}
+
+// markGroupStart relabels a field's Shift+Tab help as "prev group", for the
+// first field of a later step, where Shift+Tab goes back to the step before.
+func markGroupStart(form *huh.Form, field *huh.Input) {
+	keymap := huh.NewDefaultKeyMap()
+	keymap.Input.Prev.SetHelp("shift+tab", "prev group")
+	field.WithKeyMap(keymap)
+	form.UpdateFieldPositions()
+}
+
+// FormatDuration formats an event duration in seconds for a form field ("" for none).
+func FormatDuration(seconds float64) string {
+	if seconds <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(seconds, 'f', -1, 64)
+}
+
+// ParseDuration parses an optional duration field. Empty means 0.
+func ParseDuration(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	val, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("must be a number")
+	}
+	if val < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return val, nil
+}
+
+// newDurationInput creates the optional duration field of the create forms.
+func newDurationInput(value *string) *huh.Input {
+	return huh.NewInput().
+		Title("Duration (seconds)").
+		Description("Optional - how long the event lasts, for clip export").
+		Value(value).
+		Validate(func(s string) error {
+			_, err := ParseDuration(s)
+			return err
+		})
+}
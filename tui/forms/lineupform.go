@@ -0,0 +1,69 @@
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// LineupSize is the number of shirts on the lineup form: the starting XV and
+// a bench of eight.
+const LineupSize = 23
+
+// lineupStarters is how many of the form's shirts start; the rest are the bench.
+const lineupStarters = 15
+
+// positions names the starting XV's positions by shirt number.
+var positions = [lineupStarters]string{
+	"Loosehead prop", "Hooker", "Tighthead prop", "Lock", "Lock",
+	"Blindside flanker", "Openside flanker", "Number 8", "Scrum-half", "Fly-half",
+	"Left wing", "Inside centre", "Outside centre", "Right wing", "Full-back",
+}
+
+// LineupFormResult holds the player names by shirt number (index 0 is #1).
+// Empty names are shirts not on the lineup.
+type LineupFormResult struct {
+	Players [LineupSize]string
+}
+
+// NewLineupForm creates a huh form for a match lineup: one step for the
+// starting XV and one for the bench. Fields start with the names already in
+// result and complete from players (ctrl+e).
+func NewLineupForm(title string, result *LineupFormResult, players []string) *huh.Form {
+	validate := func(s string) error {
+		if _, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(s), "#")); err == nil {
+			return fmt.Errorf("enter the player's name, not a number")
+		}
+		return nil
+	}
+	field := func(i int) huh.Field {
+		label := fmt.Sprintf("%2d", i+1)
+		if i < lineupStarters {
+			label += " " + positions[i]
+		}
+		return huh.NewInput().
+			Title(label).
+			Inline(true).
+			Suggestions(players).
+			Value(&result.Players[i]).
+			Validate(validate)
+	}
+
+	starting := []huh.Field{huh.NewNote().Title("Starting XV").Description(title)}
+	for i := 0; i < lineupStarters; i++ {
+		starting = append(starting, field(i))
+	}
+	bench := []huh.Field{huh.NewNote().Title("Bench").Description("Replacements, optional")}
+	for i := lineupStarters; i < LineupSize; i++ {
+		bench = append(bench, field(i))
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(starting...),
+		huh.NewGroup(bench...),
+	).WithTheme(Theme())
+	markGroupStart(form, bench[1].(*huh.Input))
+	return form
+}
@@ -0,0 +1,63 @@
+package forms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/huh"
+)
+
+// MatchFormResult holds the data returned by a completed match details form.
+type MatchFormResult struct {
+	Opponent    string
+	Date        string
+	Venue       string
+	Result      string
+	Competition string
+}
+
+// NewMatchForm creates a huh form for a video's match details (opponent, date,
+// venue, result, competition). Fields start with the values already in result.
+func NewMatchForm(title string, result *MatchFormResult) *huh.Form {
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().Title("Match Details").Description(title),
+
+			huh.NewInput().
+				Title("Opponent").
+				Description("Optional").
+				Value(&result.Opponent),
+
+			huh.NewInput().
+				Title("Date").
+				Description("YYYY-MM-DD, optional").
+				Value(&result.Date).
+				Validate(func(s string) error {
+					if s == "" {
+						return nil
+					}
+					if _, err := time.Parse("2006-01-02", s); err != nil {
+						return fmt.Errorf("date must be YYYY-MM-DD")
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title("Venue").
+				Description("Optional").
+				Value(&result.Venue),
+
+			huh.NewInput().
+				Title("Result").
+				Description("e.g. W 24-17, optional").
+				Value(&result.Result),
+
+			huh.NewInput().
+				Title("Competition").
+				Description("Optional").
+				Value(&result.Competition),
+		),
+	).WithTheme(Theme())
+
+	return form
+}
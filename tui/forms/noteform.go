@@ -2,8 +2,12 @@ package forms
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/huh"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+	"github.com/user/tagging-rugby-cli/pkg/noteref"
 	"github.com/user/tagging-rugby-cli/pkg/timeutil"
 )
 
@@ -13,50 +17,217 @@ type NoteFormResult struct {
 	Category string
 	Player   string
 	Team     string
+	// Duration is the optional length of the event in seconds
+	Duration string
+	// Fields are the values of the category template's fields, in template order
+	Fields [MaxTemplateFields]string
+}
+
+// MaxTemplateFields is the most fields a note template adds to the note form.
+const MaxTemplateFields = 8
+
+// NoteTemplate is the extra fields the note form asks for one category.
+type NoteTemplate struct {
+	Category string
+	Fields   []TemplateField
+}
+
+// TemplateField is one field of a note template.
+type TemplateField struct {
+	Name  string
+	Title string
+	// Type is "number" for a numeric field or "player" for one completing
+	// from the players; anything else is free text
+	Type string
+	// Options makes the field a select instead of free text
+	Options  []string
+	Required bool
 }
 
 // HasData returns true if any field in the note form result has a non-empty value.
 func (r *NoteFormResult) HasData() bool {
-	return r.Text != "" || r.Category != "" || r.Player != "" || r.Team != ""
+	return r.Text != "" || r.Category != "" || r.Player != "" || r.Team != "" || r.Duration != "" || r.Fields != [MaxTemplateFields]string{}
 }
 
-// NewNoteForm creates a huh form for note input with the given timestamp.
-// The timestamp is displayed as a header in H:MM:SS format.
+// NewNoteForm creates a huh form for note input at *timestamp.
+// The timestamp is displayed as a header in H:MM:SS format and follows changes
+// to *timestamp, so it can be re-captured while the form is open.
 // The result pointer is bound to the form fields and will be populated on submit.
-func NewNoteForm(timestamp float64, result *NoteFormResult) *huh.Form {
-	header := fmt.Sprintf("Add Note @ %s", timeutil.FormatTime(timestamp))
+// Snippets are offered as completions for the text; their {placeholders} are
+// filled in from the other fields when the note is saved. If check is set, it is
+// run on the text as it is typed and any warning replaces the field description.
+// Typing a category with a template adds a step asking for its fields, and
+// makes the text optional when the form opens with it filled in. The
+// category and player fields, and player template fields, complete from suggest.
+// References to other events in the text ("see #17") must be to one of the
+// suggest.Events events of the video.
+func NewNoteForm(timestamp *float64, result *NoteFormResult, snippets []string, check func(string) string, templates []NoteTemplate, suggest Suggestions) *huh.Form {
+	header := func() string {
+		return fmt.Sprintf("Add Note @ %s", timeutil.FormatTime(*timestamp))
+	}
 
-	form := huh.NewForm(
+	categoryDesc := completes("Optional · a/b for subcategories", suggest.Categories)
+	if len(templates) > 0 {
+		var names []string
+		for _, t := range templates {
+			names = append(names, t.Category)
+		}
+		categoryDesc += " · with fields: " + strings.Join(names, ", ")
+	}
+
+	// Events of a category with fields may be tagged by the fields alone
+	hasTemplate := func(category string) bool {
+		for _, t := range templates {
+			if strings.EqualFold(catpath.Normalize(t.Category), catpath.Normalize(category)) {
+				return true
+			}
+		}
+		return false
+	}
+	textDesc := "Required"
+	if category := catpath.Normalize(result.Category); category != "" && hasTemplate(category) {
+		textDesc = "Optional for " + category
+	}
+	textDesc += " · #17 refers to event 17"
+	if len(snippets) > 0 {
+		textDesc += " · ctrl+e completes a snippet"
+	}
+
+	groups := []*huh.Group{
 		huh.NewGroup(
-			huh.NewNote().Title(header),
+			huh.NewNote().TitleFunc(header, timestamp).Description("ctrl+t re-captures the time"),
 
 			huh.NewInput().
 				Title("Text").
-				Description("Required").
+				DescriptionFunc(func() string {
+					if check != nil {
+						if warning := check(result.Text); warning != "" {
+							return warning
+						}
+					}
+					return textDesc
+				}, &result.Text).
+				Suggestions(snippets).
 				Value(&result.Text).
 				Validate(func(s string) error {
-					if s == "" {
+					if s == "" && !hasTemplate(result.Category) {
 						return fmt.Errorf("text is required")
 					}
-					return nil
+					return noteref.Check(s, suggest.Events)
 				}),
 
 			huh.NewInput().
 				Title("Category").
-				Description("Optional").
+				Description(categoryDesc).
+				Suggestions(suggest.Categories).
 				Value(&result.Category),
 
 			huh.NewInput().
 				Title("Player").
-				Description("Optional").
+				Description(completes("Optional", suggest.Players)).
+				Suggestions(suggest.Players).
 				Value(&result.Player),
 
 			huh.NewInput().
 				Title("Team").
 				Description("Optional").
 				Value(&result.Team),
-		),
-	).WithTheme(Theme())
 
+			newDurationInput(&result.Duration),
+		),
+	}
+	built := false
+	for _, t := range templates {
+		groups = append(groups, templateGroup(t, header, timestamp, result, suggest.Players, &built))
+	}
+	form := huh.NewForm(groups...).WithTheme(Theme())
+	built = true
 	return form
 }
+
+// templateAccessor binds a template's choice to its slot of result.Fields.
+// The slots are shared by every template and a select sets its first option
+// as it is built, so only the template of the category entered writes to
+// them, and only once the form is built.
+type templateAccessor struct {
+	result   *NoteFormResult
+	i        int
+	category string
+	built    *bool
+}
+
+func (a templateAccessor) Get() string {
+	return a.result.Fields[a.i]
+}
+
+func (a templateAccessor) Set(value string) {
+	if *a.built && strings.EqualFold(catpath.Normalize(a.result.Category), catpath.Normalize(a.category)) {
+		a.result.Fields[a.i] = value
+	}
+}
+
+// templateGroup is the step asking for a template's fields, shown while the
+// category field names the template's category.
+func templateGroup(t NoteTemplate, header func() string, timestamp *float64, result *NoteFormResult, players []string, built *bool) *huh.Group {
+	fields := []huh.Field{
+		huh.NewNote().TitleFunc(header, timestamp).Description(t.Category + " details"),
+	}
+	for i, f := range t.Fields {
+		if i == MaxTemplateFields {
+			break
+		}
+		title := f.Title
+		if title == "" {
+			title = f.Name
+		}
+		desc := "Optional"
+		if f.Required {
+			desc = "Required"
+		}
+		value := &result.Fields[i]
+		if len(f.Options) > 0 {
+			var options []huh.Option[string]
+			if !f.Required {
+				options = append(options, huh.NewOption("None", ""))
+			}
+			for _, o := range f.Options {
+				options = append(options, huh.NewOption(o, o))
+			}
+			fields = append(fields, huh.NewSelect[string]().
+				Title(title).
+				Description(desc).
+				Options(options...).
+				Accessor(templateAccessor{result: result, i: i, category: t.Category, built: built}))
+			continue
+		}
+		input := huh.NewInput().
+			Title(title).
+			Value(value)
+		switch f.Type {
+		case "player":
+			input = input.Description(completes(desc, players)).Suggestions(players)
+		case "number":
+			input = input.Description(desc + " · a number")
+		default:
+			input = input.Description(desc)
+		}
+		required, number := f.Required, f.Type == "number"
+		input = input.Validate(func(s string) error {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				if required {
+					return fmt.Errorf("%s is required", strings.ToLower(title))
+				}
+				return nil
+			}
+			if _, err := strconv.ParseFloat(s, 64); number && err != nil {
+				return fmt.Errorf("%s must be a number", strings.ToLower(title))
+			}
+			return nil
+		})
+		fields = append(fields, input)
+	}
+	return huh.NewGroup(fields...).WithHideFunc(func() bool {
+		return !strings.EqualFold(catpath.Normalize(result.Category), catpath.Normalize(t.Category))
+	})
+}
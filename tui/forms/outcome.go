@@ -0,0 +1,69 @@
+package forms
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// outcomeOptions are the tackle outcomes in display order, with the letter that
+// picks each one. Digits 1-4 pick them by position.
+var outcomeOptions = []struct {
+	Label, Value, Key string
+}{
+	{"Completed", "completed", "c"},
+	{"Missed", "missed", "m"},
+	{"Possible", "possible", "p"},
+	{"Other", "other", "o"},
+}
+
+// outcomeSelect is the tackle outcome Select with single-key accelerators: a
+// letter or digit selects the outcome and moves straight to the next field,
+// so no arrow-key navigation is needed.
+type outcomeSelect struct {
+	*huh.Select[string]
+	value *string
+	keys  map[string]string
+	pick  key.Binding
+}
+
+// newOutcomeSelect creates the outcome Select bound to value.
+func newOutcomeSelect(value *string) *outcomeSelect {
+	s := &outcomeSelect{value: value, keys: make(map[string]string)}
+	var options []huh.Option[string]
+	for i, o := range outcomeOptions {
+		options = append(options, huh.NewOption(o.Label+" ("+o.Key+")", o.Value))
+		s.keys[o.Key] = o.Value
+		s.keys[string(rune('1'+i))] = o.Value
+	}
+	var keys []string
+	for k := range s.keys {
+		keys = append(keys, k)
+	}
+	s.pick = key.NewBinding(key.WithKeys(keys...), key.WithHelp("c/m/p/o", "pick"))
+	s.Select = huh.NewSelect[string]().
+		Title("Outcome").
+		Description("Required - c/m/p/o or 1-4 picks and moves on").
+		Options(options...).
+		Value(value)
+	return s
+}
+
+// KeyBinds adds the accelerators to the Select's bindings, so help shows them.
+func (s *outcomeSelect) KeyBinds() []key.Binding {
+	return append([]key.Binding{s.pick}, s.Select.KeyBinds()...)
+}
+
+// Update picks an outcome on its accelerator key, otherwise defers to the Select.
+func (s *outcomeSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && !s.GetFiltering() {
+		if v, ok := s.keys[keyMsg.String()]; ok {
+			*s.value = v
+			// Rebinding the value moves the cursor onto the chosen option
+			s.Select.Value(s.value)
+			return s, huh.NextField
+		}
+	}
+	_, cmd := s.Select.Update(msg)
+	return s, cmd
+}
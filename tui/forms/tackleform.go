@@ -3,8 +3,10 @@ package forms
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/huh"
+	"github.com/user/tagging-rugby-cli/pkg/noteref"
 	"github.com/user/tagging-rugby-cli/pkg/timeutil"
 )
 
@@ -22,6 +24,7 @@ type TackleFormResult struct {
 	Height    string // maps to note_tackles.height (optional: high/mid/low)
 	Technique string // maps to note_tackles.technique (optional, free text)
 	Star      bool   // maps to note_highlights type="star"
+	Duration  string // maps to note_timing end - start (create form only; edit uses EndSeconds)
 }
 
 // HasData returns true if any user-entered field in the tackle form has data.
@@ -31,6 +34,14 @@ func (r *TackleFormResult) HasData() bool {
 		r.Followed != "" || r.Notes != "" || r.Zone != ""
 }
 
+// ChangedFrom reports whether any user-entered field differs from prefill, the
+// values the form was opened with. Like HasData, Outcome and Star are ignored.
+func (r *TackleFormResult) ChangedFrom(prefill TackleFormResult) bool {
+	return r.Player != prefill.Player || r.Attempt != prefill.Attempt ||
+		r.Followed != prefill.Followed || r.Notes != prefill.Notes || r.Zone != prefill.Zone ||
+		r.Duration != prefill.Duration
+}
+
 // EditTackleFormResult extends TackleFormResult with editable timestamp and end seconds.
 type EditTackleFormResult struct {
 	TackleFormResult
@@ -41,71 +52,109 @@ type EditTackleFormResult struct {
 	EndSeconds string
 }
 
-// NewTackleForm creates a multi-step huh wizard form for tackle input.
-// The timestamp is displayed as a header in H:MM:SS format.
-// The result pointer is bound to the form fields and will be populated on submit.
-func NewTackleForm(timestamp float64, result *TackleFormResult) *huh.Form {
-	header := fmt.Sprintf("Add Tackle @ %s", timeutil.FormatTime(timestamp))
+// MaxRecentPlayers is how many recently tagged players the tackle form offers
+// for picking with alt+1 onwards.
+const MaxRecentPlayers = 5
 
-	form := huh.NewForm(
-		// Step 1: Tackle fields (maps to note_tackles)
-		huh.NewGroup(
-			huh.NewNote().Title(header).Description("Step 1 of 2: Tackle Details"),
+// playerKey identifies the tackle form's player field.
+const playerKey = "player"
 
-			huh.NewInput().
-				Title("Player").
-				Description("Required").
-				Value(&result.Player).
-				Validate(func(s string) error {
-					if s == "" {
-						return fmt.Errorf("player is required")
-					}
-					return nil
-				}),
+// PlayerFocused reports whether the focused field of a tackle form is the player.
+func PlayerFocused(form *huh.Form) bool {
+	field := form.GetFocusedField()
+	return field != nil && field.GetKey() == playerKey
+}
 
-			huh.NewInput().
-				Title("Attempt").
-				Description("Required - number only").
-				Value(&result.Attempt).
-				Validate(func(s string) error {
-					if s == "" {
-						return fmt.Errorf("attempt is required")
-					}
-					if _, err := strconv.Atoi(s); err != nil {
-						return fmt.Errorf("attempt must be a number")
-					}
-					return nil
-				}),
+// recentPlayersNote numbers the recent players above the player field, e.g.
+// "alt+1 Smith   alt+2 Jones".
+func recentPlayersNote(players []string) *huh.Note {
+	picks := make([]string, len(players))
+	for i, p := range players {
+		picks[i] = fmt.Sprintf("alt+%d %s", i+1, p)
+	}
+	return huh.NewNote().Title("Recent players").Description(strings.Join(picks, "   "))
+}
 
-			huh.NewSelect[string]().
-				Title("Outcome").
-				Description("Required").
-				Options(
-					huh.NewOption("Completed", "completed"),
-					huh.NewOption("Missed", "missed"),
-					huh.NewOption("Possible", "possible"),
-					huh.NewOption("Other", "other"),
-				).
-				Value(&result.Outcome),
-		),
+// NewTackleForm creates a multi-step huh wizard form for tackle input at *timestamp.
+// The timestamp is displayed as a header in H:MM:SS format and follows changes
+// to *timestamp, so it can be re-captured while the form is open.
+// The result pointer is bound to the form fields and will be populated on submit.
+// When sticky is set, the player and outcome were carried over from the last
+// tackle and the first step says how to clear them. The player, followed and
+// zone fields complete from suggest, and its recent players are listed above
+// the player field.
+func NewTackleForm(timestamp *float64, result *TackleFormResult, sticky bool, suggest Suggestions) *huh.Form {
+	header := func() string {
+		return fmt.Sprintf("Add Tackle @ %s", timeutil.FormatTime(*timestamp))
+	}
+	step1 := "Step 1 of 2: Tackle Details (ctrl+t re-captures the time)"
+	if sticky {
+		step1 = "Step 1 of 2: Tackle Details (last player kept, ctrl+x clears; ctrl+t re-captures the time)"
+	}
+
+	followed := huh.NewInput().
+		Title("Followed").
+		Description(completes("Optional - who followed up", suggest.Players)).
+		Suggestions(suggest.Players).
+		Value(&result.Followed)
+
+	details := []huh.Field{huh.NewNote().TitleFunc(header, timestamp).Description(step1)}
+	if len(suggest.RecentPlayers) > 0 {
+		details = append(details, recentPlayersNote(suggest.RecentPlayers))
+	}
+	details = append(details,
+		huh.NewInput().
+			Key(playerKey).
+			Title("Player").
+			Description(completes("Required: name, or shirt number on the lineup", suggest.Players)).
+			Suggestions(suggest.Players).
+			Value(&result.Player).
+			Validate(func(s string) error {
+				if s == "" {
+					return fmt.Errorf("player is required")
+				}
+				return nil
+			}),
+
+		huh.NewInput().
+			Title("Attempt").
+			Description("Required - number only").
+			Value(&result.Attempt).
+			Validate(func(s string) error {
+				if s == "" {
+					return fmt.Errorf("attempt is required")
+				}
+				if _, err := strconv.Atoi(s); err != nil {
+					return fmt.Errorf("attempt must be a number")
+				}
+				return nil
+			}),
+
+		newOutcomeSelect(&result.Outcome),
+	)
+
+	form := huh.NewForm(
+		// Step 1: Tackle fields (maps to note_tackles)
+		huh.NewGroup(details...),
 
 		// Step 2: Optional fields (maps to note_details, note_zones, note_highlights)
 		huh.NewGroup(
-			huh.NewNote().Title(header).Description("Step 2 of 2: Optional Details"),
+			huh.NewNote().TitleFunc(header, timestamp).Description("Step 2 of 2: Optional Details"),
 
-			huh.NewInput().
-				Title("Followed").
-				Description("Optional - who followed up").
-				Value(&result.Followed),
+			followed,
 
 			huh.NewInput().
 				Title("Notes").
-				Description("Optional - additional notes").
-				Value(&result.Notes),
+				Description("Optional - additional notes, #17 refers to event 17").
+				Value(&result.Notes).
+				Validate(func(s string) error {
+					return noteref.Check(s, suggest.Events)
+				}),
 
 			huh.NewInput().
 				Title("Zone").
-				Description("Optional - field zone").
+				Description(completes("Optional - field zone", suggest.Zones)).
+				Suggestions(suggest.Zones).
 				Value(&result.Zone),
 
 			huh.NewSelect[string]().
@@ -124,12 +173,15 @@ func NewTackleForm(timestamp float64, result *TackleFormResult) *huh.Form {
 				Description("Optional - e.g. shoulder, choke, ankle").
 				Value(&result.Technique),
 
+			newDurationInput(&result.Duration),
+
 			huh.NewConfirm().
 				Title("Star").
 				Description("Mark as highlighted").
 				Value(&result.Star),
 		),
 	).WithTheme(Theme())
+	markGroupStart(form, followed)
 
 	return form
 }
@@ -137,13 +189,20 @@ func NewTackleForm(timestamp float64, result *TackleFormResult) *huh.Form {
 // NewEditTackleForm creates a multi-step huh wizard form for editing an existing tackle.
 // The form is pre-filled with values from the result, and includes editable Timestamp and End seconds fields.
 // The editResult pointer is bound to the form fields and will be populated on submit.
-func NewEditTackleForm(timestamp float64, endSeconds float64, result *EditTackleFormResult) *huh.Form {
+// The player, followed and zone fields complete from suggest.
+func NewEditTackleForm(timestamp float64, endSeconds float64, result *EditTackleFormResult, suggest Suggestions) *huh.Form {
 	// Pre-fill timestamp and end seconds as strings for the form inputs
 	result.Timestamp = fmt.Sprintf("%g", timestamp)
 	result.EndSeconds = fmt.Sprintf("%g", endSeconds)
 
 	header := fmt.Sprintf("Edit Tackle @ %s", timeutil.FormatTime(timestamp))
 
+	followed := huh.NewInput().
+		Title("Followed").
+		Description(completes("Optional - who followed up", suggest.Players)).
+		Suggestions(suggest.Players).
+		Value(&result.Followed)
+
 	form := huh.NewForm(
 		// Step 1: Tackle fields + timing (maps to note_tackles + note_timing)
 		huh.NewGroup(
@@ -183,7 +242,8 @@ func NewEditTackleForm(timestamp float64, endSeconds float64, result *EditTackle
 
 			huh.NewInput().
 				Title("Player").
-				Description("Required").
+				Description(completes("Required: name, or shirt number on the lineup", suggest.Players)).
+				Suggestions(suggest.Players).
 				Value(&result.Player).
 				Validate(func(s string) error {
 					if s == "" {
@@ -206,35 +266,27 @@ func NewEditTackleForm(timestamp float64, endSeconds float64, result *EditTackle
 					return nil
 				}),
 
-			huh.NewSelect[string]().
-				Title("Outcome").
-				Description("Required").
-				Options(
-					huh.NewOption("Completed", "completed"),
-					huh.NewOption("Missed", "missed"),
-					huh.NewOption("Possible", "possible"),
-					huh.NewOption("Other", "other"),
-				).
-				Value(&result.Outcome),
+			newOutcomeSelect(&result.Outcome),
 		),
 
 		// Step 2: Optional fields (maps to note_details, note_zones, note_highlights)
 		huh.NewGroup(
 			huh.NewNote().Title(header).Description("Step 2 of 2: Optional Details"),
 
-			huh.NewInput().
-				Title("Followed").
-				Description("Optional - who followed up").
-				Value(&result.Followed),
+			followed,
 
 			huh.NewInput().
 				Title("Notes").
-				Description("Optional - additional notes").
-				Value(&result.Notes),
+				Description("Optional - additional notes, #17 refers to event 17").
+				Value(&result.Notes).
+				Validate(func(s string) error {
+					return noteref.Check(s, suggest.Events)
+				}),
 
 			huh.NewInput().
 				Title("Zone").
-				Description("Optional - field zone").
+				Description(completes("Optional - field zone", suggest.Zones)).
+				Suggestions(suggest.Zones).
 				Value(&result.Zone),
 
 			huh.NewSelect[string]().
@@ -259,6 +311,7 @@ func NewEditTackleForm(timestamp float64, endSeconds float64, result *EditTackle
 				Value(&result.Star),
 		),
 	).WithTheme(Theme())
+	markGroupStart(form, followed)
 
 	return form
 }
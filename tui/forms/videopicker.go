@@ -0,0 +1,43 @@
+package forms
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+)
+
+// VideoChoice is one video offered by the video picker.
+type VideoChoice struct {
+	Path string
+	// Label names the video in the list, e.g. its file name and match
+	Label string
+}
+
+// maxPickerHeight is the most rows the video picker shows before scrolling.
+const maxPickerHeight = 15
+
+// NewVideoPickerForm creates a huh form choosing one of videos, which sets
+// *path. "/" filters the list by label.
+func NewVideoPickerForm(videos []VideoChoice, path *string) *huh.Form {
+	options := make([]huh.Option[string], len(videos))
+	for i, v := range videos {
+		options[i] = huh.NewOption(v.Label, v.Path)
+	}
+	height := len(videos) + 2
+	if height > maxPickerHeight {
+		height = maxPickerHeight
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Open Video").
+				Description(fmt.Sprintf("%d videos · / filters, Enter opens, Esc cancels", len(videos))).
+				Options(options...).
+				Height(height).
+				Value(path),
+		),
+	).WithTheme(Theme())
+
+	return form
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"sort"
+	"time"
+
+	"github.com/user/tagging-rugby-cli/config"
+)
+
+// defaultHoldGap is the longest gap between key repeats treated as a held key.
+// It has to cover the terminal's initial key-repeat delay.
+const defaultHoldGap = 600 * time.Millisecond
+
+// defaultFrameRamp steps single frames for a short hold, then seeks in
+// growing increments the longer Ctrl+H / Ctrl+L is held.
+var defaultFrameRamp = []config.RampStage{
+	{After: 0, Seconds: 0},
+	{After: 8, Seconds: 0.1},
+	{After: 20, Seconds: 0.5},
+	{After: 40, Seconds: 2},
+}
+
+// frameRamp turns repeated frame-step key presses into an accelerating jog.
+type frameRamp struct {
+	curve   []config.RampStage
+	holdGap time.Duration
+	key     string
+	last    time.Time
+	repeats int
+}
+
+// newFrameRamp builds a ramp from config, falling back to the defaults.
+func newFrameRamp(cfg *config.FrameStep) frameRamp {
+	r := frameRamp{curve: defaultFrameRamp, holdGap: defaultHoldGap}
+	if cfg == nil {
+		return r
+	}
+	if cfg.HoldGapMS > 0 {
+		r.holdGap = time.Duration(cfg.HoldGapMS) * time.Millisecond
+	}
+	if len(cfg.Ramp) > 0 {
+		curve := append([]config.RampStage(nil), cfg.Ramp...)
+		sort.Slice(curve, func(i, j int) bool { return curve[i].After < curve[j].After })
+		r.curve = curve
+	}
+	return r
+}
+
+// step records a press of key at now and returns the seek size in seconds
+// for it, or 0 for a single frame step.
+func (r *frameRamp) step(key string, now time.Time) float64 {
+	if key == r.key && now.Sub(r.last) <= r.holdGap {
+		r.repeats++
+	} else {
+		r.repeats = 0
+	}
+	r.key = key
+	r.last = now
+
+	seconds := 0.0
+	for _, stage := range r.curve {
+		if r.repeats < stage.After {
+			break
+		}
+		seconds = stage.Seconds
+	}
+	return seconds
+}
+
+// frameStep steps one frame (or seeks, once the key has been held long enough)
+// in the given direction (-1 back, 1 forward).
+func (m *Model) frameStep(key string, direction float64) {
+	if m.client == nil || !m.client.IsConnected() {
+		return
+	}
+	seconds := m.frameRamp.step(key, time.Now())
+	switch {
+	case seconds > 0:
+		_ = m.client.SeekRelative(direction * seconds)
+	case direction < 0:
+		_ = m.client.FrameBackStep()
+	default:
+		_ = m.client.FrameStep()
+	}
+}
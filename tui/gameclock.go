@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/gameclock"
+)
+
+// loadGameClock builds the game clock from the current video's match periods.
+// The clock is empty (no column) until periods are recorded.
+func (m *Model) loadGameClock() {
+	m.gameClock = gameclock.Clock{}
+	if m.db == nil || m.videoID == 0 {
+		return
+	}
+	periods, err := db.SelectPeriodsByVideo(m.db, m.videoID)
+	if err != nil {
+		return
+	}
+	clockPeriods := make([]gameclock.Period, len(periods))
+	for i, p := range periods {
+		clockPeriods[i] = gameclock.Period{Name: p.Name, Start: p.Start, End: p.End}
+	}
+	m.gameClock = gameclock.New(clockPeriods)
+}
+
+// showGameClock reports whether the notes list shows the game clock column.
+func (m *Model) showGameClock() bool {
+	return m.config != nil && m.config.GameClock && m.gameClock.HasPeriods()
+}
+
+// toggleGameClock turns the game clock column on or off and saves the choice
+// to config.json so it persists across sessions.
+func (m *Model) toggleGameClock() (string, error) {
+	if m.config == nil {
+		m.config = &config.Config{}
+	}
+	m.config.GameClock = !m.config.GameClock
+	m.loadGameClock()
+
+	// Re-read before saving so edits made to config.json since startup are kept
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("game clock not saved: %w", err)
+	}
+	cfg.GameClock = m.config.GameClock
+	if err := config.Save(cfg); err != nil {
+		return "", fmt.Errorf("game clock not saved: %w", err)
+	}
+
+	if !m.config.GameClock {
+		return "Game clock column off", nil
+	}
+	if !m.gameClock.HasPeriods() {
+		return "Game clock column on (shown once match periods are set with 'analyze halves')", nil
+	}
+	return "Game clock column on", nil
+}
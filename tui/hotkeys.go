@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// playerHotkey parses a function key used for one-key tackle logging. F1-F12
+// log a completed tackle and Shift+F1-F12, which terminals report as F13-F24,
+// a missed one. Returns the key number 1-12.
+func playerHotkey(key string) (number int, outcome string, ok bool) {
+	if !strings.HasPrefix(key, "f") {
+		return 0, "", false
+	}
+	n, err := strconv.Atoi(key[1:])
+	if err != nil || n < 1 || n > 24 {
+		return 0, "", false
+	}
+	if n > 12 {
+		return n - 12, "missed", true
+	}
+	return n, "completed", true
+}
+
+// hotkeyPlayer returns the player a function key logs for: the player_keys
+// mapping from config.json, or the shirt number, which the video's lineup
+// resolves to a name when the tackle is saved.
+func (m *Model) hotkeyPlayer(number int) string {
+	key := strconv.Itoa(number)
+	if m.config != nil {
+		if player := m.config.PlayerKeys[key]; player != "" {
+			return player
+		}
+	}
+	return key
+}
+
+// logHotkeyTackle records a tackle for the function key's player at the
+// current time, without opening the tackle form.
+func (m *Model) logHotkeyTackle(number int, outcome string) (tea.Model, tea.Cmd) {
+	result, err := m.addTackle(m.hotkeyPlayer(number), m.defaults.Team, 1, outcome)
+	if err != nil {
+		return m.showStatus("Error: " + err.Error())
+	}
+	return m.showStatus(result)
+}
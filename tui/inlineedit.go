@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/db"
+)
+
+// openInlineEdit starts quick-editing the selected note's text in place.
+func (m *Model) openInlineEdit() (tea.Model, tea.Cmd) {
+	item := m.notesList.GetSelectedItem()
+	if item == nil {
+		return m, nil
+	}
+	text := ""
+	if details, err := db.SelectNoteDetailsByNote(m.db, item.ID); err == nil && len(details) > 0 {
+		text = m.detailText(details[0])
+	}
+	edit := &m.notesList.Edit
+	edit.Active = true
+	edit.NoteID = item.ID
+	edit.Input.Clear()
+	edit.Input.Input = text
+	edit.Input.CursorPos = len(text)
+	return m.showStatus("Editing note text: Enter to save, Esc to cancel")
+}
+
+// handleInlineEditKeys handles key events while the inline editor is open.
+func (m *Model) handleInlineEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	edit := &m.notesList.Edit
+	switch msg.String() {
+	case "esc":
+		edit.Active = false
+		m.statusMsg = ""
+		return m, nil
+	case "enter":
+		// A bad reference keeps the editor open to fix it
+		if err := m.checkNoteRefs(edit.Input.Input); err != nil {
+			m.setResult("Error: "+err.Error(), true)
+			return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+				return clearResultMsg{}
+			})
+		}
+		edit.Active = false
+		targets := m.noteRefTargets(edit.Input.Input)
+		if err := db.UpdateNoteText(m.db, edit.NoteID, edit.Input.Input); err != nil {
+			m.setResult("Error: "+err.Error(), true)
+			return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+				return clearResultMsg{}
+			})
+		}
+		m.saveNoteRefs(edit.NoteID, edit.Input.Input, targets)
+		m.loadNotesAndTackles()
+		return m.showStatus(fmt.Sprintf("Note %d updated", edit.NoteID))
+	case "backspace":
+		edit.Input.Backspace()
+	case "left":
+		edit.Input.MoveCursorLeft()
+	case "right":
+		edit.Input.MoveCursorRight()
+	case "home", "ctrl+a":
+		edit.Input.CursorPos = 0
+	case "end", "ctrl+e":
+		edit.Input.CursorPos = len(edit.Input.Input)
+	default:
+		// Insert character if it's a printable rune
+		if len(msg.String()) == 1 {
+			edit.Input.InsertChar(rune(msg.String()[0]))
+		} else if msg.Type == tea.KeyRunes {
+			for _, r := range msg.Runes {
+				edit.Input.InsertChar(r)
+			}
+		}
+	}
+	return m, nil
+}
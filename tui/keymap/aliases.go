@@ -0,0 +1,47 @@
+package keymap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Aliases checks config.json's "command_aliases": extra command-mode words,
+// each standing for a command and its leading arguments (e.g. "g": "goto").
+// commands are the words command mode already knows, which an alias may not
+// shadow and must expand to. It returns the valid aliases split into words,
+// and every problem found.
+func Aliases(aliases map[string]string, commands []string) (map[string][]string, error) {
+	known := make(map[string]bool, len(commands))
+	for _, c := range commands {
+		known[c] = true
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	expanded := make(map[string][]string)
+	for _, name := range names {
+		words := strings.Fields(aliases[name])
+		switch {
+		case name == "" || strings.ContainsAny(name, " \t"):
+			problems = append(problems, fmt.Sprintf("alias %q must be one word", name))
+		case known[name]:
+			problems = append(problems, fmt.Sprintf("alias %s shadows the %s command", name, name))
+		case len(words) == 0:
+			problems = append(problems, fmt.Sprintf("alias %s is empty", name))
+		case !known[words[0]]:
+			problems = append(problems, fmt.Sprintf("alias %s: unknown command %q", name, words[0]))
+		default:
+			expanded[name] = words
+		}
+	}
+	if len(problems) > 0 {
+		return expanded, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return expanded, nil
+}
@@ -0,0 +1,284 @@
+// Package keymap holds the TUI's remappable keys: the actions of the main
+// view with their default keys, and the remapping set up in config.json,
+// which translates the keys pressed into the default keys the TUI handles.
+package keymap
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Scope is where in the main view an action's keys work.
+type Scope string
+
+const (
+	// Global keys work whichever panel has focus
+	Global Scope = "global"
+	// Video keys work when the video panel has focus
+	Video Scope = "video"
+	// Notes keys work when the notes list has focus
+	Notes Scope = "notes"
+)
+
+// Action is a remappable TUI action and the keys that trigger it by default,
+// as bubbletea names them ("n", "ctrl+e", " " for space).
+type Action struct {
+	Name  string
+	Scope Scope
+	Keys  []string
+	Desc  string
+}
+
+// Actions are the remappable actions, in the order 'config keys' lists them.
+// Keys of overlays (stats view, forms, suggestions) keep their defaults, and
+// the reserved keys can't be bound.
+var Actions = []Action{
+	{"quit", Global, []string{"ctrl+c"}, "Quit"},
+	{"focus-next", Global, []string{"tab"}, "Focus next panel"},
+	{"focus-prev", Global, []string{"shift+tab"}, "Focus previous panel"},
+	{"help", Global, []string{"?"}, "Show/hide the help"},
+	{"stats", Global, []string{"s", "S"}, "Open stats view"},
+	{"note", Global, []string{"n", "N"}, "Quick add note"},
+	{"tackle", Global, []string{"t", "T"}, "Quick add tackle"},
+	{"commentary", Global, []string{"c", "C"}, "Edit match commentary"},
+	{"scratch", Global, []string{"w"}, "Add an unsaved scratch note"},
+	{"export-clips", Global, []string{"ctrl+e"}, "Export clips of the selected player, or of starred events"},
+	{"bookmark", Global, []string{"b"}, "Bookmark current position"},
+	{"next-bookmark", Global, []string{"B"}, "Jump to next bookmark"},
+	{"goto-bookmark", Global, []string{"'"}, "Jump to bookmark by number (then 1-9)"},
+	{"shuttle", Global, []string{"ctrl+s"}, "Toggle shuttle mode"},
+	{"angle", Global, []string{"a"}, "Switch camera angle (when angles are linked)"},
+
+	{"play", Video, []string{" "}, "Toggle play/pause"},
+	{"mute", Video, []string{"m", "M"}, "Toggle mute"},
+	{"back", Video, []string{"h", "H", "left"}, "Step backward (by step size)"},
+	{"forward", Video, []string{"l", "L", "right"}, "Step forward (by step size)"},
+	{"frame-back", Video, []string{"ctrl+h"}, "Frame step backward"},
+	{"frame-forward", Video, []string{"ctrl+l"}, "Frame step forward"},
+	{"step-down", Video, []string{",", "<"}, "Decrease step size"},
+	{"step-up", Video, []string{".", ">"}, "Increase step size"},
+	{"possession", Video, []string{"p"}, "Hand possession to other team"},
+	{"end-possession", Video, []string{"P"}, "End possession (stoppage)"},
+	{"territory", Video, []string{"y", "Y"}, "Flip territory (ball half)"},
+	{"overlay", Video, []string{"o", "O"}, "Toggle overlay on video"},
+
+	{"up", Notes, []string{"j", "J", "up"}, "Select previous item"},
+	{"down", Notes, []string{"k", "K", "down"}, "Select next item"},
+	{"first", Notes, []string{"g"}, "Jump to first item (press twice)"},
+	{"last", Notes, []string{"G", "$"}, "Jump to last item (<n>G to item n)"},
+	{"jump", Notes, []string{"enter"}, "Jump to selected item"},
+	{"edit", Notes, []string{"e", "E"}, "Edit selected tackle"},
+	{"quick-edit", Notes, []string{"i"}, "Quick-edit selected note text"},
+	{"delete", Notes, []string{"x", "X"}, "Delete selected item (press twice)"},
+	{"pin", Notes, []string{"p"}, "Pin selected item"},
+	{"unpin", Notes, []string{"P"}, "Unpin selected item"},
+	{"filter-player", Notes, []string{"f"}, "Filter by selected event's player"},
+	{"command", Notes, []string{":"}, "Enter command mode"},
+	{"regenerate-clip", Notes, []string{"ctrl+r"}, "Render the selected tackle's clip again"},
+	{"keep-scratch", Notes, []string{"+"}, "Keep selected scratch as a note"},
+	{"follow-ref", Notes, []string{"r"}, "Jump to the event the selected note refers to (#17; 2r the second)"},
+}
+
+// Lookup returns the action called name.
+func Lookup(name string) (Action, bool) {
+	for _, a := range Actions {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Action{}, false
+}
+
+// Binding is an action with the keys it is bound to once config.json's
+// remapping is applied.
+type Binding struct {
+	Action
+	// Bound are the keys that trigger the action
+	Bound []string
+	// Remapped is set when config.json binds the action to other keys
+	Remapped bool
+}
+
+// Keymap translates the keys pressed in the main view into the default keys
+// of the actions they are bound to.
+type Keymap struct {
+	bindings []Binding
+	// translate maps, per scope, a pressed key to the key the TUI handles;
+	// "" when the key is unbound
+	translate map[Scope]map[string]string
+}
+
+// New builds the keymap for config.json's "keys": action names to the keys
+// that trigger them instead of their defaults. Problems (unknown actions or
+// keys, and keys bound twice) are returned all at once; the keymap still
+// applies every remapping that has none.
+func New(keys map[string][]string) (*Keymap, error) {
+	km := &Keymap{translate: map[Scope]map[string]string{Global: {}, Video: {}, Notes: {}}}
+	var problems []string
+
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	remap := make(map[string][]string)
+	for _, name := range names {
+		if _, ok := Lookup(name); !ok {
+			problems = append(problems, fmt.Sprintf("unknown action %q", name))
+			continue
+		}
+		var bound []string
+		for _, key := range keys[name] {
+			k, err := Normalize(key)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+				continue
+			}
+			if why := reserved(k); why != "" {
+				problems = append(problems, fmt.Sprintf("%s: %s is reserved for %s", name, Display(k), why))
+				continue
+			}
+			bound = append(bound, k)
+		}
+		remap[name] = bound
+	}
+
+	for _, a := range Actions {
+		b := Binding{Action: a, Bound: a.Keys}
+		if bound, ok := remap[a.Name]; ok {
+			b.Bound, b.Remapped = bound, true
+		}
+		km.bindings = append(km.bindings, b)
+	}
+
+	// Each key triggers one action in each scope, global keys in all of them
+	owner := make(map[Scope]map[string]string)
+	for _, b := range km.bindings {
+		for _, key := range b.Bound {
+			for _, scope := range b.Scope.scopes() {
+				if owner[scope] == nil {
+					owner[scope] = make(map[string]string)
+				}
+				if other, ok := owner[scope][key]; ok && other != b.Name {
+					problems = append(problems, fmt.Sprintf("%s is bound to both %s and %s", Display(key), other, b.Name))
+					break
+				}
+				owner[scope][key] = b.Name
+			}
+		}
+	}
+
+	// Remapped actions give up their default keys, and take their new ones
+	for _, b := range km.bindings {
+		if !b.Remapped {
+			continue
+		}
+		for _, key := range b.Keys {
+			for _, scope := range b.Scope.scopes() {
+				if _, ok := km.translate[scope][key]; !ok {
+					km.translate[scope][key] = ""
+				}
+			}
+		}
+		for _, key := range b.Bound {
+			for _, scope := range b.Scope.scopes() {
+				km.translate[scope][key] = b.Keys[0]
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return km, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return km, nil
+}
+
+// reserved returns what a key that can't be bound is for: the digits count
+// rows in the notes list and pick bookmarks, and the function keys log
+// tackles for players. It returns "" for other keys.
+func reserved(key string) string {
+	if len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+		return "row counts and bookmark numbers"
+	}
+	if number, ok := strings.CutPrefix(key, "f"); ok {
+		if n, err := strconv.Atoi(number); err == nil && n >= 1 && n <= 24 {
+			return "player hotkeys"
+		}
+	}
+	return ""
+}
+
+// scopes returns the scopes a key of the scope works in: global keys work in
+// each panel too.
+func (s Scope) scopes() []Scope {
+	if s == Global {
+		return []Scope{Global, Video, Notes}
+	}
+	return []Scope{s}
+}
+
+// Translate returns the key the TUI handles for key pressed where scope's keys
+// work (Global for panels with only the global keys), and false when the
+// key's default action was remapped away and nothing is bound to it.
+func (km *Keymap) Translate(scope Scope, key string) (string, bool) {
+	if km == nil {
+		return key, true
+	}
+	to, ok := km.translate[scope][key]
+	if !ok {
+		return key, true
+	}
+	return to, to != ""
+}
+
+// Bindings returns every action with the keys it is bound to.
+func (km *Keymap) Bindings() []Binding {
+	return km.bindings
+}
+
+// keyTypes maps bubbletea's key names ("enter", "ctrl+e") to their types.
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for t := tea.KeyType(-128); t < 128; t++ {
+		if name := t.String(); name != "" && t != tea.KeyRunes {
+			types[name] = t
+		}
+	}
+	return types
+}()
+
+// Normalize returns key as bubbletea names it, accepting "space" for " ". It
+// fails for keys bubbletea doesn't report.
+func Normalize(key string) (string, error) {
+	if key == "space" {
+		return " ", nil
+	}
+	name := strings.TrimPrefix(key, "alt+")
+	if _, ok := keyTypes[name]; ok || len([]rune(name)) == 1 {
+		return key, nil
+	}
+	return "", fmt.Errorf("unknown key %q", key)
+}
+
+// Display returns key as 'config keys' and messages show it.
+func Display(key string) string {
+	if key == " " {
+		return "space"
+	}
+	return key
+}
+
+// KeyMsg returns the key message bubbletea sends for key, a name Normalize
+// accepts.
+func KeyMsg(key string) tea.KeyMsg {
+	alt := strings.HasPrefix(key, "alt+")
+	name := strings.TrimPrefix(key, "alt+")
+	if t, ok := keyTypes[name]; ok {
+		return tea.KeyMsg{Type: t, Alt: alt}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name), Alt: alt}
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// resolvePlayer returns the name a player entry is saved under: a shirt number
+// on the video's lineup becomes the player's name, and merged spellings their
+// canonical one. The database applies the same resolution on save; resolving
+// first keeps results, the sticky tackle and webhooks in step with it.
+func (m *Model) resolvePlayer(player string) string {
+	if m.db == nil {
+		return player
+	}
+	name, err := db.ResolvePlayer(m.db, m.videoID, player)
+	if err != nil {
+		return player
+	}
+	return name
+}
+
+// loadLineup reloads the lineup for the current video.
+func (m *Model) loadLineup() {
+	if m.db == nil || m.videoID <= 0 {
+		m.lineup = nil
+		return
+	}
+	lineup, err := db.SelectLineup(m.db, m.videoID)
+	if err != nil {
+		return
+	}
+	m.lineup = lineup
+}
+
+// executeLineupCommand handles :lineup [<number> <player>|remove <number>].
+// With no arguments it shows or hides the lineup panel.
+func (m *Model) executeLineupCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		if len(m.lineup) == 0 && !m.showLineup {
+			return "No lineup for this video: :lineup <number> <player>, or 'tagging-rugby-cli video lineup edit'", nil
+		}
+		m.showLineup = !m.showLineup
+		if m.showLineup {
+			return "Lineup shown", nil
+		}
+		return "Lineup hidden", nil
+	}
+	if m.db == nil || m.videoID <= 0 {
+		return "", fmt.Errorf("lineups need a registered video")
+	}
+
+	if args[0] == "remove" || args[0] == "rm" {
+		if len(args) != 2 {
+			return "", fmt.Errorf("usage: lineup remove <number>")
+		}
+		number, ok := db.ShirtNumber(args[1])
+		if !ok {
+			return "", fmt.Errorf("invalid shirt number: %s", args[1])
+		}
+		removed, err := db.DeleteLineupPlayer(m.db, m.videoID, number)
+		if err != nil {
+			return "", err
+		}
+		if !removed {
+			return "", fmt.Errorf("#%d is not on the lineup", number)
+		}
+		m.loadLineup()
+		return fmt.Sprintf("Removed #%d from the lineup", number), nil
+	}
+
+	number, ok := db.ShirtNumber(args[0])
+	if !ok || len(args) < 2 {
+		return "", fmt.Errorf("usage: lineup [<number> <player>|remove <number>]")
+	}
+	player := strings.Join(args[1:], " ")
+	if _, isNumber := db.ShirtNumber(player); isNumber {
+		return "", fmt.Errorf("player name must not be a number: %s", player)
+	}
+	if err := db.SetLineupPlayer(m.db, m.videoID, number, player); err != nil {
+		return "", err
+	}
+	m.loadLineup()
+	m.showLineup = true
+	return fmt.Sprintf("#%d is %s", number, player), nil
+}
+
+// renderLineup returns the lineup panel, or "" when it is hidden or empty.
+func (m *Model) renderLineup(width int) string {
+	if !m.showLineup || len(m.lineup) == 0 {
+		return ""
+	}
+	entries := make([]components.LineupEntry, len(m.lineup))
+	for i, p := range m.lineup {
+		entries[i] = components.LineupEntry{Number: p.Number, Player: p.Player, Bench: !p.Starting()}
+	}
+	var selected string
+	if item := m.notesList.GetSelectedItem(); item != nil {
+		selected = item.Player
+	}
+	return components.LineupBox(entries, selected, width)
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/controller"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// maxJogTicks caps the ticks handled from one jog event, so a fast spin
+// doesn't queue seconds of frame steps.
+const maxJogTicks = 10
+
+// midiMsg carries an event from the MIDI controller, or the error that ended reading.
+type midiMsg struct {
+	event controller.MIDIEvent
+	err   error
+}
+
+// loadMIDI opens the MIDI controller configured in config.json. A missing or
+// unreadable device is reported and the TUI runs without it.
+func (m *Model) loadMIDI() {
+	if m.config == nil || m.config.MIDI == nil || m.config.MIDI.Device == "" {
+		return
+	}
+	for control, action := range m.config.MIDI.Bindings {
+		_, button := controller.Actions[action]
+		_, wheel := controller.MIDIActions[action]
+		if !button && !wheel {
+			m.notify(components.LevelWarning, fmt.Sprintf("MIDI: unknown action %q for %s", action, control))
+		}
+	}
+	device, err := controller.OpenMIDI(m.config.MIDI.Device)
+	if err != nil {
+		m.statusMsg = "MIDI controller not available: " + err.Error()
+		m.notify(components.LevelWarning, m.statusMsg)
+		return
+	}
+	m.midi = device
+}
+
+// closeMIDI releases the MIDI device, if open.
+func (m *Model) closeMIDI() {
+	if m.midi != nil {
+		m.midi.Close()
+	}
+}
+
+// waitForMIDI returns a command that blocks until the next MIDI event.
+// Returns nil when no MIDI controller is open.
+func (m *Model) waitForMIDI() tea.Cmd {
+	if m.midi == nil {
+		return nil
+	}
+	device := m.midi
+	return func() tea.Msg {
+		event, err := device.Next()
+		return midiMsg{event: event, err: err}
+	}
+}
+
+// handleMIDI applies a MIDI event to its bound action and waits for the next.
+// Wheels and rings use the event's value; other actions run on a press.
+func (m *Model) handleMIDI(msg midiMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.midi = nil
+		return m.showStatus("MIDI controller disconnected: " + msg.err.Error())
+	}
+	next := m.waitForMIDI()
+	ev := msg.event
+
+	var action string
+	if m.config != nil && m.config.MIDI != nil {
+		action = m.config.MIDI.Bindings[ev.Control()]
+	}
+	switch action {
+	case "jog":
+		m.jog(ev.Jog(), false)
+	case "scrub":
+		m.jog(ev.Jog(), true)
+	case "shuttle":
+		m.shuttleRing(int(ev.Value) - 64)
+	default:
+		if ev.Pressed() {
+			model, cmd := m.runControllerAction(action)
+			return model, tea.Batch(cmd, next)
+		}
+	}
+	return m, next
+}
+
+// jog moves ticks frames, or step sizes when scrub is set; negative ticks go back.
+func (m *Model) jog(ticks int, scrub bool) {
+	if m.client == nil || !m.client.IsConnected() || ticks == 0 {
+		return
+	}
+	if scrub {
+		_ = m.client.SeekRelative(float64(ticks) * m.statusBar.StepSize)
+		return
+	}
+	n := min(max(ticks, -ticks), maxJogTicks)
+	for i := 0; i < n; i++ {
+		if ticks < 0 {
+			_ = m.client.FrameBackStep()
+		} else {
+			_ = m.client.FrameStep()
+		}
+	}
+}
+
+// shuttleRing plays at a speed set by how far the ring is turned from its
+// centre: each position out climbs the shuttle speed ladder, and the centre
+// pauses.
+func (m *Model) shuttleRing(offset int) {
+	if offset == 0 {
+		m.applyShuttle(0, 1)
+		return
+	}
+	direction := 1
+	if offset < 0 {
+		direction, offset = -1, -offset
+	}
+	level := min(offset, len(shuttleSpeeds)) - 1
+	m.applyShuttle(direction, shuttleSpeeds[level])
+}
@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/noteref"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/tui/components"
+	"github.com/user/tagging-rugby-cli/tui/styles"
+)
+
+// maxRefLines is the most references the selected tag box lists.
+const maxRefLines = 5
+
+// checkNoteRefs returns an error when text refers to an event the video
+// doesn't have.
+func (m *Model) checkNoteRefs(text string) error {
+	return noteref.Check(text, len(m.events))
+}
+
+// noteRefTargets resolves the references in text to the events they point
+// to, before the text is saved; nil when it has none or they can't be
+// resolved, which leaves them as written.
+func (m *Model) noteRefTargets(text string) []int64 {
+	if len(noteref.Find(text)) == 0 {
+		return nil
+	}
+	targets, err := db.ResolveNoteRefs(m.db, m.videoPath, text)
+	if err != nil {
+		m.notify(components.LevelWarning, "References kept as written: "+err.Error())
+		return nil
+	}
+	return targets
+}
+
+// saveNoteRefs keeps the events a saved note's text refers to, from
+// noteRefTargets, so its references follow them as the video is renumbered.
+func (m *Model) saveNoteRefs(noteID int64, text string, targets []int64) {
+	if text == "" {
+		return
+	}
+	if err := db.SaveNoteRefs(m.db, noteID, text, targets); err != nil {
+		m.notify(components.LevelWarning, fmt.Sprintf("References in note %d kept as written: %v", noteID, err))
+	}
+}
+
+// detailText returns a note detail's text with its references showing the
+// current numbers of the events they were saved pointing to.
+func (m *Model) detailText(d db.NoteDetail) string {
+	return noteref.Renumber(d.Note, m.refNumbers[d.ID])
+}
+
+// followRef seeks to the nth event (1-based) the selected item's text refers
+// to, selecting it in the notes list unless the filters hide it.
+func (m *Model) followRef(n int) (tea.Model, tea.Cmd) {
+	item := m.notesList.GetSelectedItem()
+	if item == nil {
+		return m, nil
+	}
+	numbers := noteref.Numbers(item.Text)
+	switch {
+	case len(numbers) == 0:
+		return m.showStatus("The selected event refers to no other event")
+	case n > len(numbers):
+		return m.showStatus(fmt.Sprintf("The selected event refers to %d event(s)", len(numbers)))
+	}
+	target, ok := m.events[numbers[n-1]]
+	if !ok {
+		return m.showStatus(fmt.Sprintf("No event #%d in this video", numbers[n-1]))
+	}
+	if m.client == nil || !m.client.IsConnected() {
+		return m.showStatus("Not connected to mpv")
+	}
+	if err := m.client.Seek(target.TimestampSeconds); err != nil {
+		return m.showStatus("Seek failed: " + err.Error())
+	}
+
+	msg := fmt.Sprintf("Jumped to #%d", target.Number)
+	if target.Category != "" {
+		msg += " [" + target.Category + "]"
+	}
+	msg += " at " + timeutil.FormatTime(target.TimestampSeconds)
+	if row := m.rowOf(target.ID); row >= 0 {
+		m.jumpToRow(row)
+	} else {
+		msg += " (hidden by the filter)"
+	}
+	return m.showStatus(msg)
+}
+
+// rowOf returns the notes list row of the saved event id, or -1 when the
+// list doesn't show it.
+func (m *Model) rowOf(id int64) int {
+	for i, item := range m.notesList.Items {
+		if item.ID == id && item.Type != components.ItemTypeScratch {
+			return i
+		}
+	}
+	return -1
+}
+
+// refText renders an item's text for the selected tag box, cut to width,
+// with its references to other events highlighted.
+func refText(text string, width int, style, linkStyle lipgloss.Style) string {
+	if len(text) > width {
+		text = text[:width-3] + "..."
+	}
+	var b strings.Builder
+	last := 0
+	for _, r := range noteref.Find(text) {
+		// A reference cut short by the "..." isn't one
+		if r.End > len(text)-3 && strings.HasSuffix(text, "...") {
+			break
+		}
+		b.WriteString(style.Render(text[last:r.Start]))
+		b.WriteString(linkStyle.Render(text[r.Start:r.End]))
+		last = r.End
+	}
+	b.WriteString(style.Render(text[last:]))
+	return b.String()
+}
+
+// refLines lists the events an item's text refers to, each with the keys
+// following it: r for the first, 2r for the second.
+func (m *Model) refLines(item *components.ListItem, width int) []string {
+	numbers := noteref.Numbers(item.Text)
+	if len(numbers) == 0 {
+		return nil
+	}
+	keyStyle := lipgloss.NewStyle().Foreground(styles.Pink)
+	linkStyle := lipgloss.NewStyle().Foreground(styles.Cyan)
+	dimStyle := lipgloss.NewStyle().Foreground(styles.Lavender)
+	warnStyle := lipgloss.NewStyle().Foreground(styles.Red)
+
+	var lines []string
+	for i, n := range numbers {
+		if i == maxRefLines {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("    +%d more", len(numbers)-i)))
+			break
+		}
+		key := "r"
+		if i > 0 {
+			key = strconv.Itoa(i+1) + "r"
+		}
+		line := keyStyle.Render(fmt.Sprintf(" %-3s", key)) + linkStyle.Render(fmt.Sprintf("#%d", n))
+		target, ok := m.events[n]
+		if !ok {
+			lines = append(lines, line+warnStyle.Render(" no such event"))
+			continue
+		}
+		desc := " " + timeutil.FormatTime(target.TimestampSeconds)
+		if target.Category != "" {
+			desc += " " + target.Category
+		}
+		if room := width - 4 - len(fmt.Sprintf("#%d", n)); len(desc) > room && room > 3 {
+			desc = desc[:room-3] + "..."
+		}
+		lines = append(lines, line+dimStyle.Render(desc))
+	}
+	return lines
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// maxMessages caps the notification history kept for :messages.
+const maxMessages = 200
+
+// notify records a message in the notification history. Warnings and errors
+// count as unread until :messages is opened.
+func (m *Model) notify(level int, text string) {
+	if text == "" {
+		return
+	}
+	m.messages = append(m.messages, components.Notification{Time: time.Now(), Level: level, Text: text})
+	if len(m.messages) > maxMessages {
+		m.messages = m.messages[len(m.messages)-maxMessages:]
+	}
+	if level >= components.LevelWarning {
+		m.commandInput.Unread++
+	}
+}
+
+// setResult shows a command result in the footer and records it in the history.
+func (m *Model) setResult(msg string, isError bool) {
+	level := components.LevelInfo
+	if isError {
+		level = components.LevelError
+	}
+	m.notify(level, msg)
+	m.commandInput.SetResult(msg, isError)
+	m.resultSetAt = time.Now()
+}
+
+// clearResult clears the footer result once it has been shown for the full
+// display time, so an older message's timer doesn't cut a newer one short.
+func (m *Model) clearResult() {
+	if time.Since(m.resultSetAt) < resultDisplayDuration {
+		return
+	}
+	m.commandInput.ClearResult()
+}
+
+// toggleMessages shows or hides the notification history and marks it read.
+func (m *Model) toggleMessages() (string, error) {
+	m.showMessages = !m.showMessages
+	m.commandInput.Unread = 0
+	return "", nil
+}
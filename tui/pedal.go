@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/controller"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// pedalMsg carries a button press from the pedal, or the error that ended reading.
+type pedalMsg struct {
+	code uint16
+	err  error
+}
+
+// loadPedal opens the pedal configured in config.json. A missing or
+// unreadable device is reported and the TUI runs without it.
+func (m *Model) loadPedal() {
+	if m.config == nil || m.config.Pedal == nil || m.config.Pedal.Device == "" {
+		return
+	}
+	buttons := make(map[uint16]string)
+	for code, action := range m.config.Pedal.Buttons {
+		n, err := strconv.ParseUint(code, 10, 16)
+		if err != nil {
+			m.notify(components.LevelWarning, fmt.Sprintf("Pedal: invalid button code %q", code))
+			continue
+		}
+		if _, ok := controller.Actions[action]; !ok {
+			m.notify(components.LevelWarning, fmt.Sprintf("Pedal: unknown action %q for button %s", action, code))
+			continue
+		}
+		buttons[uint16(n)] = action
+	}
+	device, err := controller.Open(m.config.Pedal.Device)
+	if err != nil {
+		m.statusMsg = "Pedal not available: " + err.Error()
+		m.notify(components.LevelWarning, m.statusMsg)
+		return
+	}
+	m.pedal = device
+	m.pedalButtons = buttons
+}
+
+// closePedal releases the pedal device, if open.
+func (m *Model) closePedal() {
+	if m.pedal != nil {
+		m.pedal.Close()
+	}
+}
+
+// waitForPedal returns a command that blocks until the next pedal press.
+// Returns nil when no pedal is open.
+func (m *Model) waitForPedal() tea.Cmd {
+	if m.pedal == nil {
+		return nil
+	}
+	device := m.pedal
+	return func() tea.Msg {
+		code, err := device.NextPress()
+		return pedalMsg{code: code, err: err}
+	}
+}
+
+// handlePedal runs the action mapped to a pressed button and waits for the next.
+func (m *Model) handlePedal(msg pedalMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.pedal = nil
+		return m.showStatus("Pedal disconnected: " + msg.err.Error())
+	}
+	model, cmd := m.runControllerAction(m.pedalButtons[msg.code])
+	return model, tea.Batch(cmd, m.waitForPedal())
+}
+
+// runControllerAction runs a pedal, gamepad or MIDI button action. Playback
+// actions work anywhere; the form and bookmark actions are ignored while a
+// form is open.
+func (m *Model) runControllerAction(action string) (tea.Model, tea.Cmd) {
+	formOpen := m.noteForm != nil || m.tackleForm != nil || m.confirmDiscardForm != nil || m.commentaryForm != nil
+	switch action {
+	case "play":
+		m.togglePause()
+	case "back":
+		m.seekStep(-1)
+	case "forward":
+		m.seekStep(1)
+	case "frame-back":
+		m.frameStep("controller", -1)
+	case "frame-forward":
+		m.frameStep("controller", 1)
+	case "note":
+		if !formOpen {
+			return m.openNoteInput()
+		}
+	case "tackle":
+		if !formOpen {
+			return m.openTackleInput()
+		}
+	case "bookmark":
+		if !formOpen {
+			return m.showStatus(m.addBookmark())
+		}
+	}
+	return m, nil
+}
+
+// togglePause toggles playback and remembers where it stopped.
+func (m *Model) togglePause() {
+	if m.client == nil || !m.client.IsConnected() {
+		return
+	}
+	if err := m.client.TogglePause(); err == nil {
+		if timePos, tpErr := m.client.GetTimePos(); tpErr == nil && m.videoID > 0 {
+			_ = db.UpdateVideoTimingStopped(m.db, m.videoID, timePos)
+		}
+	}
+}
+
+// seekStep seeks one step size in direction (-1 back, 1 forward).
+func (m *Model) seekStep(direction float64) {
+	if m.client != nil && m.client.IsConnected() {
+		_ = m.client.SeekRelative(direction * m.statusBar.StepSize)
+	}
+}
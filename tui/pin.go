@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// pinHighlight is the note_highlights type that pins a note to the top of the
+// notes list. Unlike a star it only affects the list, not exports or stats.
+const pinHighlight = "pin"
+
+// pinnedFirst moves pinned items to the top of the list, keeping the time
+// order within the pinned and unpinned sections.
+func pinnedFirst(items []components.ListItem) []components.ListItem {
+	out := make([]components.ListItem, 0, len(items))
+	for _, item := range items {
+		if item.Pinned {
+			out = append(out, item)
+		}
+	}
+	for _, item := range items {
+		if !item.Pinned {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// isPinned reports whether the note is pinned in the notes list.
+func (m *Model) isPinned(noteID int64) bool {
+	for _, item := range m.notesList.Items {
+		if item.ID == noteID {
+			return item.Pinned
+		}
+	}
+	return false
+}
+
+// pinSelectedItem pins (p) or unpins (P) the selected item, keeping it
+// selected as it moves between the pinned and unpinned sections.
+func (m *Model) pinSelectedItem(pin bool) (tea.Model, tea.Cmd) {
+	result := func(msg string, isError bool) (tea.Model, tea.Cmd) {
+		m.setResult(msg, isError)
+		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+			return clearResultMsg{}
+		})
+	}
+
+	item := m.notesList.GetSelectedItem()
+	if item == nil {
+		return result("No item selected", true)
+	}
+	id := item.ID
+	switch {
+	case pin && item.Pinned:
+		return result(fmt.Sprintf("Event %d is already pinned", id), false)
+	case !pin && !item.Pinned:
+		return result(fmt.Sprintf("Event %d is not pinned", id), false)
+	}
+
+	var err error
+	if pin {
+		err = db.InsertNoteHighlight(m.db, id, pinHighlight)
+	} else {
+		err = db.DeleteNoteHighlight(m.db, id, pinHighlight)
+	}
+	if err != nil {
+		return result("Error: "+err.Error(), true)
+	}
+
+	m.loadNotesAndTackles()
+	for i, it := range m.notesList.Items {
+		if it.ID == id {
+			m.notesList.SelectedIndex = i
+			break
+		}
+	}
+	if pin {
+		return result(fmt.Sprintf("Event %d pinned", id), false)
+	}
+	return result(fmt.Sprintf("Event %d unpinned", id), false)
+}
@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/plugin"
+	"github.com/user/tagging-rugby-cli/tui/components"
+	"github.com/user/tagging-rugby-cli/webhook"
+)
+
+// pluginTimeout bounds how long a plugin may run.
+const pluginTimeout = 10 * time.Second
+
+// pluginRun is a plugin started by :plugin <name>, waiting to be run in the
+// background.
+type pluginRun struct {
+	plugin plugin.Plugin
+	req    plugin.Request
+}
+
+// pluginMsg carries the actions of a plugin run.
+type pluginMsg struct {
+	name      string
+	timestamp float64
+	actions   []plugin.Action
+	err       error
+}
+
+// executePluginCommand handles :plugin (list installed plugins) and :plugin <name> [args...].
+func (m *Model) executePluginCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		plugins, err := plugin.List()
+		if err != nil {
+			return "", err
+		}
+		if len(plugins) == 0 {
+			return "No plugins installed", nil
+		}
+		names := make([]string, len(plugins))
+		for i, p := range plugins {
+			names[i] = p.Name
+		}
+		return "Plugins: " + strings.Join(names, ", "), nil
+	}
+
+	p, err := plugin.Find(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	req := plugin.Request{
+		Video:     m.videoPath,
+		Timestamp: m.statusBar.TimePos,
+		Args:      args[1:],
+	}
+	if m.client != nil && m.client.IsConnected() {
+		if timePos, err := m.client.GetTimePos(); err == nil {
+			req.Timestamp = timePos
+		}
+	}
+	if item := m.notesList.GetSelectedItem(); item != nil && item.Type != components.ItemTypeScratch {
+		req.Note = &plugin.Note{
+			ID:       item.ID,
+			Category: item.Category,
+			Time:     item.TimestampSeconds,
+			Text:     item.Text,
+			Player:   item.Player,
+			Team:     item.Team,
+		}
+	}
+
+	m.pendingPlugin = &pluginRun{plugin: p, req: req}
+	return "RUN_PLUGIN", nil
+}
+
+// runPlugin runs the plugin :plugin started in the background, so a slow
+// plugin doesn't hold up the TUI. Returns nil when none is waiting.
+func (m *Model) runPlugin() tea.Cmd {
+	run := m.pendingPlugin
+	m.pendingPlugin = nil
+	if run == nil {
+		return nil
+	}
+	m.setResult(fmt.Sprintf("Running plugin %s...", run.plugin.Name), false)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+		defer cancel()
+		actions, err := run.plugin.Run(ctx, run.req)
+		return pluginMsg{name: run.plugin.Name, timestamp: run.req.Timestamp, actions: actions, err: err}
+	}
+}
+
+// handlePlugin applies the actions of a finished plugin run.
+func (m *Model) handlePlugin(msg pluginMsg) (tea.Model, tea.Cmd) {
+	err := msg.err
+	if err == nil {
+		var result string
+		result, err = m.applyPluginActions(msg.name, msg.timestamp, msg.actions)
+		m.setResult(result, false)
+	}
+	if err != nil {
+		m.setResult("Error: "+err.Error(), true)
+	}
+	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+		return clearResultMsg{}
+	})
+}
+
+// applyPluginActions applies plugin actions in order and summarises them for the result line.
+func (m *Model) applyPluginActions(pluginName string, timestamp float64, actions []plugin.Action) (string, error) {
+	var results []string
+	inserted := false
+	for _, a := range actions {
+		switch a.Type {
+		case plugin.ActionMessage:
+			results = append(results, a.Message)
+		case plugin.ActionSeek:
+			if m.client == nil || !m.client.IsConnected() {
+				return "", fmt.Errorf("not connected to mpv")
+			}
+			if err := m.client.Seek(*a.Time); err != nil {
+				return "", fmt.Errorf("failed to seek: %w", err)
+			}
+			results = append(results, "Seeked to "+timeutil.FormatTime(*a.Time))
+		case plugin.ActionInsertNote:
+			at := timestamp
+			if a.Time != nil {
+				at = *a.Time
+			}
+			category := a.Category
+			if category == "" {
+				category = "note"
+			}
+			duration, _ := m.client.GetDuration()
+			children := db.NoteChildren{
+				Timings: []db.NoteTiming{
+					{Start: at, End: at},
+				},
+				Videos: []db.NoteVideo{
+					newNoteVideo(m.videoPath, duration),
+				},
+				Details: []db.NoteDetail{
+					{Type: "source", Note: "plugin:" + pluginName},
+				},
+			}
+			if a.Text != "" {
+				children.Details = append(children.Details, db.NoteDetail{Type: "text", Note: a.Text})
+			}
+			noteID, err := db.InsertNoteWithChildren(m.db, category, children)
+			if err != nil {
+				return "", fmt.Errorf("failed to insert note: %w", err)
+			}
+			m.notifyWebhooks(webhook.NoteCreated, noteID, category, children)
+			inserted = true
+			results = append(results, fmt.Sprintf("Note %d added at %s", noteID, timeutil.FormatTime(at)))
+		}
+	}
+	if inserted {
+		m.loadNotesAndTackles()
+	}
+	if len(results) == 0 {
+		return fmt.Sprintf("Plugin %s finished", pluginName), nil
+	}
+	return strings.Join(results, "; "), nil
+}
@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+)
+
+// possessionTimePos returns the playback position used to open/close possession intervals.
+// Falls back to the last polled position when mpv cannot be queried.
+func (m *Model) possessionTimePos() float64 {
+	if m.client != nil && m.client.IsConnected() {
+		if timePos, err := m.client.GetTimePos(); err == nil {
+			return timePos
+		}
+	}
+	return m.statusBar.TimePos
+}
+
+// setPossession closes the open interval and starts a new one for team/territory.
+func (m *Model) setPossession(team, territory string) (string, error) {
+	if m.videoID == 0 {
+		return "", fmt.Errorf("video is not registered")
+	}
+	at := m.possessionTimePos()
+	if err := db.StartPossession(m.db, m.videoID, team, territory, at); err != nil {
+		return "", err
+	}
+	m.possession.Team = team
+	m.possession.Territory = territory
+	m.refreshPossession()
+	return fmt.Sprintf("Possession: %s ball in %s half at %s", team, territory, timeutil.FormatTime(at)), nil
+}
+
+// endPossession closes the open possession interval (e.g. at a stoppage or half-time).
+func (m *Model) endPossession() (string, error) {
+	if m.videoID == 0 {
+		return "", fmt.Errorf("video is not registered")
+	}
+	if m.possession.Team == "" {
+		return "", fmt.Errorf("no possession in progress")
+	}
+	at := m.possessionTimePos()
+	if err := db.EndPossession(m.db, m.videoID, at); err != nil {
+		return "", err
+	}
+	m.possession.Team = ""
+	m.refreshPossession()
+	return fmt.Sprintf("Possession ended at %s", timeutil.FormatTime(at)), nil
+}
+
+// togglePossession hands the ball to the other team, or gives it to home when nobody has it.
+// The territory defaults to the receiving team's own half when none has been set yet.
+func (m *Model) togglePossession() (string, error) {
+	team := "home"
+	if m.possession.Team == "home" {
+		team = "away"
+	}
+	territory := m.possession.Territory
+	if territory == "" {
+		territory = team
+	}
+	return m.setPossession(team, territory)
+}
+
+// flipTerritory moves the ball to the other half. If a possession is in progress
+// a new interval is started so territory time is split at this timestamp.
+func (m *Model) flipTerritory() (string, error) {
+	territory := "away"
+	if m.possession.Territory == "away" {
+		territory = "home"
+	}
+	if m.possession.Team == "" {
+		m.possession.Territory = territory
+		return fmt.Sprintf("Territory: ball in %s half", territory), nil
+	}
+	return m.setPossession(m.possession.Team, territory)
+}
+
+// possessionKeyResult runs a possession action and shows its result in the command line.
+func (m *Model) possessionKeyResult(action func() (string, error)) (tea.Model, tea.Cmd) {
+	result, err := action()
+	if err != nil {
+		m.setResult("Error: "+err.Error(), true)
+	} else {
+		m.setResult(result, false)
+	}
+	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+		return clearResultMsg{}
+	})
+}
+
+// executePossessionCommand handles :possession home|away|end and :territory home|away.
+func (m *Model) executePossessionCommand(cmd string, args []string) (string, error) {
+	if len(args) == 0 {
+		if cmd == "territory" {
+			return m.flipTerritory()
+		}
+		return m.togglePossession()
+	}
+	switch args[0] {
+	case "home", "away":
+		if cmd == "territory" {
+			if m.possession.Team == "" {
+				m.possession.Territory = args[0]
+				return fmt.Sprintf("Territory: ball in %s half", args[0]), nil
+			}
+			return m.setPossession(m.possession.Team, args[0])
+		}
+		territory := m.possession.Territory
+		if territory == "" {
+			territory = args[0]
+		}
+		return m.setPossession(args[0], territory)
+	case "end":
+		if cmd == "possession" {
+			return m.endPossession()
+		}
+	}
+	return "", fmt.Errorf("usage: :possession [home|away|end] or :territory [home|away]")
+}
+
+// loadPossessionState restores the in-progress possession from an open interval, if any.
+func (m *Model) loadPossessionState() {
+	if m.db == nil || m.videoID == 0 {
+		return
+	}
+	open, err := db.SelectOpenPossession(m.db, m.videoID)
+	if err == nil && open != nil {
+		m.possession.Team = open.Team
+		m.possession.Territory = open.Territory
+	}
+	m.refreshPossession()
+}
+
+// refreshPossession recomputes possession and territory percentages from the stored intervals.
+func (m *Model) refreshPossession() {
+	if m.db == nil || m.videoID == 0 {
+		return
+	}
+	possessions, err := db.SelectPossessionsByVideo(m.db, m.videoID)
+	if err != nil {
+		return
+	}
+	summary := db.SummarizePossessions(possessions, m.statusBar.TimePos)
+	m.possession.HasData = len(possessions) > 0
+	m.possession.HomePct, m.possession.AwayPct = summary.PossessionPct()
+	m.possession.HomeTerritoryPct, m.possession.AwayTerritoryPct = summary.TerritoryPct()
+}
@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/remote"
+	"github.com/user/tagging-rugby-cli/tui/components"
+	"github.com/user/tagging-rugby-cli/webhook"
+)
+
+// remoteMsg carries an action received by the HTTP endpoint.
+type remoteMsg remote.Request
+
+// loadRemote starts the HTTP action endpoint configured in config.json,
+// generating its token first when none is set. A failure to listen is
+// reported and the TUI runs without it.
+func (m *Model) loadRemote() {
+	if m.config == nil || m.config.Remote == nil || m.config.Remote.Listen == "" {
+		return
+	}
+	if m.config.Remote.Token == "" {
+		if err := m.generateRemoteToken(); err != nil {
+			m.statusMsg = "Remote endpoint not started: " + err.Error()
+			m.notify(components.LevelWarning, m.statusMsg)
+			return
+		}
+	}
+	server, err := remote.Listen(m.config.Remote.Listen, m.config.Remote.Token)
+	if err != nil {
+		m.statusMsg = "Remote endpoint not started: " + err.Error()
+		m.notify(components.LevelWarning, m.statusMsg)
+		return
+	}
+	m.remote = server
+}
+
+// generateRemoteToken saves a new random token for the endpoint to
+// config.json, for the button apps to send.
+func (m *Model) generateRemoteToken() error {
+	token, err := remote.NewToken()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if cfg.Remote == nil {
+		cfg.Remote = &config.Remote{Listen: m.config.Remote.Listen}
+	}
+	cfg.Remote.Token = token
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+	m.config.Remote.Token = token
+	m.notify(components.LevelInfo, "Remote endpoint token generated and saved to config.json: "+token)
+	return nil
+}
+
+// closeRemote stops the HTTP endpoint, if running.
+func (m *Model) closeRemote() {
+	if m.remote != nil {
+		m.remote.Close()
+	}
+}
+
+// waitForRemote returns a command that blocks until the next remote action.
+// Returns nil when the endpoint is not running.
+func (m *Model) waitForRemote() tea.Cmd {
+	if m.remote == nil {
+		return nil
+	}
+	requests := m.remote.Requests()
+	return func() tea.Msg {
+		return remoteMsg(<-requests)
+	}
+}
+
+// handleRemote runs a remote action, replies with its result and waits for the next.
+func (m *Model) handleRemote(msg remoteMsg) (tea.Model, tea.Cmd) {
+	req := remote.Request(msg)
+	next := m.waitForRemote()
+
+	var result string
+	var err error
+	switch req.Action {
+	case "tag":
+		category, text := req.Params.Get("category"), req.Params.Get("text")
+		if category == "" && text == "" {
+			err = fmt.Errorf("tag needs a category or text")
+			break
+		}
+		result, err = m.addNote(text, category, "", "")
+	case "seek":
+		result, err = m.remoteSeek(req.Params.Get("to"), req.Params.Get("by"))
+	case "star":
+		result, err = m.starLatestEvent()
+	default:
+		model, cmd := m.runControllerAction(req.Action)
+		req.Reply("OK", nil)
+		return model, tea.Batch(cmd, next)
+	}
+	req.Reply(result, err)
+
+	if err != nil {
+		m.notify(components.LevelError, "Remote "+req.Action+": "+err.Error())
+		return m, next
+	}
+	model, cmd := m.showStatus(result)
+	return model, tea.Batch(cmd, next)
+}
+
+// remoteSeek seeks to an absolute position or by a relative amount.
+func (m *Model) remoteSeek(to, by string) (string, error) {
+	if m.client == nil || !m.client.IsConnected() {
+		return "", fmt.Errorf("mpv not connected")
+	}
+	switch {
+	case to != "":
+		seconds, err := timeutil.ParseTimeToSeconds(to)
+		if err != nil {
+			return "", fmt.Errorf("invalid time: %s", to)
+		}
+		if err := m.client.Seek(seconds); err != nil {
+			return "", err
+		}
+		return "Seeked to " + timeutil.FormatTime(seconds), nil
+	case by != "":
+		seconds, err := strconv.ParseFloat(by, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid offset: %s", by)
+		}
+		if err := m.client.SeekRelative(seconds); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Seeked %+gs", seconds), nil
+	}
+	return "", fmt.Errorf("seek needs to= or by=")
+}
+
+// starLatestEvent stars the most recently added event in the notes list.
+func (m *Model) starLatestEvent() (string, error) {
+	var latest *components.ListItem
+	for i := range m.notesList.Items {
+		if latest == nil || m.notesList.Items[i].ID > latest.ID {
+			latest = &m.notesList.Items[i]
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("no events to star")
+	}
+	if latest.Starred {
+		return fmt.Sprintf("Event %d is already starred", latest.ID), nil
+	}
+	if err := db.InsertNoteHighlight(m.db, latest.ID, "star"); err != nil {
+		return "", fmt.Errorf("failed to star event: %w", err)
+	}
+
+	category := latest.Category
+	if latest.Type == components.ItemTypeTackle {
+		category = "tackle"
+	}
+	m.notifyWebhooks(webhook.NoteStarred, latest.ID, category, db.NoteChildren{
+		Timings:    []db.NoteTiming{{Start: latest.TimestampSeconds, End: latest.TimestampSeconds}},
+		Videos:     []db.NoteVideo{{Path: m.videoPath}},
+		Highlights: []db.NoteHighlight{{Type: "star"}},
+	})
+	id := latest.ID
+	m.loadNotesAndTackles()
+	return fmt.Sprintf("Event %d starred", id), nil
+}
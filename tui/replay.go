@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+)
+
+// replayLeadIn is how far before the first event replay starts playback.
+const replayLeadIn = 5.0
+
+// replayState tracks session replay: the match plays while events re-surface in
+// the order and at the pace they were tagged, so live-tagging can be audited.
+type replayState struct {
+	Active bool
+	// Events are the notes being replayed, in tagging order
+	Events []db.ReplayEvent
+	// Next indexes the next event to surface
+	Next int
+	// Anchor is the video position the first event's tagging time is pinned to
+	Anchor float64
+	// TotalLag sums the lag of surfaced events, for the closing summary
+	TotalLag float64
+}
+
+// due returns the video position at which event i re-surfaces: the anchor plus
+// the wall-clock time between the first tag and this one.
+func (r replayState) due(i int) float64 {
+	return r.Anchor + r.Events[i].CreatedAt.Sub(r.Events[0].CreatedAt).Seconds()
+}
+
+// executeReplayCommand handles :replay [session-id|stop].
+func (m *Model) executeReplayCommand(args []string) (string, error) {
+	if len(args) > 0 && args[0] == "stop" || len(args) == 0 && m.replay.Active {
+		if !m.replay.Active {
+			return "Replay is not running", nil
+		}
+		m.replay = replayState{}
+		m.statusMsg = ""
+		return "Replay stopped", nil
+	}
+	if m.videoID <= 0 {
+		return "", fmt.Errorf("replay needs a registered video")
+	}
+	if m.client == nil || !m.client.IsConnected() {
+		return "", fmt.Errorf("mpv not connected")
+	}
+
+	var sessionID int64
+	if len(args) > 0 {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || id <= 0 {
+			return "", fmt.Errorf("invalid session ID: %s", args[0])
+		}
+		sessionID = id
+	}
+	events, err := db.SelectReplayEvents(m.db, m.videoID, sessionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load events: %w", err)
+	}
+	if len(events) == 0 {
+		return "No events to replay", nil
+	}
+
+	m.replay = replayState{Active: true, Events: events, Anchor: events[0].Time}
+	if err := m.client.Seek(math.Max(0, events[0].Time-replayLeadIn)); err != nil {
+		return "", err
+	}
+	if err := m.client.Play(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Replaying %d event(s) in tagging order (:replay to stop)", len(events)), nil
+}
+
+// advanceReplay surfaces events whose tagging time the playhead has reached.
+// Seeking back rewinds the replay. Returns true when the footer status changed.
+func (m *Model) advanceReplay() bool {
+	if !m.replay.Active {
+		return false
+	}
+	pos := m.statusBar.TimePos
+	due := 0
+	for due < len(m.replay.Events) && m.replay.due(due) <= pos {
+		due++
+	}
+	if due < m.replay.Next {
+		m.replay.Next = due
+		m.replay.TotalLag = 0
+		for i := 0; i < due; i++ {
+			m.replay.TotalLag += m.replay.due(i) - m.replay.Events[i].Time
+		}
+		return false
+	}
+	if due == m.replay.Next {
+		return false
+	}
+
+	// Several events can fall due in one tick; count them all but show the last
+	for i := m.replay.Next; i < due; i++ {
+		m.replay.TotalLag += m.replay.due(i) - m.replay.Events[i].Time
+	}
+	m.replay.Next = due
+	i := due - 1
+	ev := m.replay.Events[i]
+	for row, item := range m.notesList.Items {
+		if item.ID == ev.NoteID {
+			m.notesList.SelectedIndex = row
+			break
+		}
+	}
+	m.statusMsg = fmt.Sprintf("Replay %d/%d: %s @ %s, tagged %s",
+		due, len(m.replay.Events), ev.Category, timeutil.FormatTime(ev.Time), formatReplayLag(m.replay.due(i)-ev.Time))
+
+	if due == len(m.replay.Events) {
+		n := len(m.replay.Events)
+		m.statusMsg = fmt.Sprintf("Replay finished: %d event(s), average lag %s", n, formatReplayLag(m.replay.TotalLag/float64(n)))
+		m.replay = replayState{}
+	}
+	return true
+}
+
+// formatReplayLag formats the gap between when an event was tagged and where it
+// sits in the video, e.g. "+4.0s late" or "-1.5s early".
+func formatReplayLag(lag float64) string {
+	switch {
+	case lag >= 0.5:
+		return fmt.Sprintf("%+.1fs late", lag)
+	case lag <= -0.5:
+		return fmt.Sprintf("%+.1fs early", lag)
+	default:
+		return "on time"
+	}
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// scratchNote is a thinking-out-loud marker for a first pass through a video.
+// Scratch notes live in memory for the session and are never written to the
+// database unless promoted to a real note.
+type scratchNote struct {
+	// Seq numbers the note within the session; it is listed as ~Seq
+	Seq  int64
+	Time float64
+	Text string
+}
+
+// scratchItems returns the scratch notes as notes list rows. Their IDs are
+// -Seq, so they never match a note ID.
+func (m *Model) scratchItems() []components.ListItem {
+	items := make([]components.ListItem, len(m.scratch))
+	for i, s := range m.scratch {
+		items[i] = components.ListItem{
+			ID:               -s.Seq,
+			Type:             components.ItemTypeScratch,
+			TimestampSeconds: s.Time,
+			Category:         "scratch",
+			Text:             s.Text,
+		}
+	}
+	return items
+}
+
+// mergeByTime merges scratch rows into items, both in time order, keeping
+// each scratch row after events at the same time.
+func mergeByTime(items, scratch []components.ListItem) []components.ListItem {
+	if len(scratch) == 0 {
+		return items
+	}
+	out := make([]components.ListItem, 0, len(items)+len(scratch))
+	i := 0
+	for _, s := range scratch {
+		for i < len(items) && items[i].TimestampSeconds <= s.TimestampSeconds {
+			out = append(out, items[i])
+			i++
+		}
+		out = append(out, s)
+	}
+	return append(out, items[i:]...)
+}
+
+// addScratch adds a scratch note at the event timestamp.
+func (m *Model) addScratch(text string) (string, error) {
+	if m.client == nil || !m.client.IsConnected() {
+		return "", fmt.Errorf("mpv not connected")
+	}
+	timestamp, err := m.eventTimestamp()
+	if err != nil {
+		return "", fmt.Errorf("failed to get timestamp: %w", err)
+	}
+	m.scratchSeq++
+	s := scratchNote{Seq: m.scratchSeq, Time: timestamp, Text: text}
+
+	// Keep the slice in time order for mergeByTime
+	i := len(m.scratch)
+	for i > 0 && m.scratch[i-1].Time > s.Time {
+		i--
+	}
+	m.scratch = append(m.scratch[:i], append([]scratchNote{s}, m.scratch[i:]...)...)
+	m.loadNotesAndTackles()
+	return fmt.Sprintf("Scratch ~%d at %s (not saved: + in the list keeps it)", s.Seq, timeutil.FormatTime(timestamp)), nil
+}
+
+// scratchIndex returns the index in m.scratch of the scratch note shown as
+// list item id, or -1.
+func (m *Model) scratchIndex(id int64) int {
+	for i, s := range m.scratch {
+		if s.Seq == -id {
+			return i
+		}
+	}
+	return -1
+}
+
+// promoteScratch saves the scratch note at index i as a real note at its
+// time and drops it from the scratch list.
+func (m *Model) promoteScratch(i int) (int64, error) {
+	s := m.scratch[i]
+	if err := m.checkNoteRefs(s.Text); err != nil {
+		return 0, err
+	}
+	noteID, err := m.insertNote(s.Time, s.Text, "")
+	if err != nil {
+		return 0, err
+	}
+	m.scratch = append(m.scratch[:i], m.scratch[i+1:]...)
+	return noteID, nil
+}
+
+// handleScratchKey handles notes list keys on a selected scratch note: + keeps
+// it as a note, x discards it, and keys that change saved events explain why
+// they don't apply. It returns false for keys it leaves to handleNotesKeys.
+func (m *Model) handleScratchKey(key string) (bool, tea.Model, tea.Cmd) {
+	item := m.notesList.GetSelectedItem()
+	if item == nil || item.Type != components.ItemTypeScratch {
+		return false, m, nil
+	}
+	i := m.scratchIndex(item.ID)
+	if i < 0 {
+		return false, m, nil
+	}
+	switch key {
+	case "+":
+		noteID, err := m.promoteScratch(i)
+		if err != nil {
+			model, cmd := m.showStatus("Keep failed: " + err.Error())
+			return true, model, cmd
+		}
+		m.loadNotesAndTackles()
+		model, cmd := m.showStatus(fmt.Sprintf("Scratch ~%d kept as note %d", -item.ID, noteID))
+		return true, model, cmd
+	case "x", "X":
+		m.scratch = append(m.scratch[:i], m.scratch[i+1:]...)
+		m.loadNotesAndTackles()
+		model, cmd := m.showStatus(fmt.Sprintf("Scratch ~%d discarded", -item.ID))
+		return true, model, cmd
+	case "e", "E", "i", "p", "P", "ctrl+r":
+		model, cmd := m.showStatus("Scratch notes aren't saved: press + to keep it as a note first")
+		return true, model, cmd
+	}
+	return false, m, nil
+}
+
+// openScratchInput opens command mode with :scratch typed, capturing the
+// playback position now so the note lands where the thought came up.
+func (m *Model) openScratchInput() (tea.Model, tea.Cmd) {
+	m.commandInput.Active = true
+	m.commandInput.Input = "scratch "
+	m.commandInput.CursorPos = len(m.commandInput.Input)
+	m.commandInput.ClearResult()
+	m.captureCommandTime()
+	return m, nil
+}
+
+// executeScratchCommand handles :scratch [<text>|keep|clear]. keep and clear
+// only act on their own, so a note may start with either word.
+func (m *Model) executeScratchCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		if len(m.scratch) == 0 {
+			return "No scratch notes (add one with a, or :scratch <text>)", nil
+		}
+		return fmt.Sprintf("%d scratch note(s), not saved: + keeps one, :scratch keep keeps all", len(m.scratch)), nil
+	}
+	switch strings.Join(args, " ") {
+	case "keep":
+		kept := 0
+		for len(m.scratch) > 0 {
+			if _, err := m.promoteScratch(0); err != nil {
+				m.loadNotesAndTackles()
+				return "", fmt.Errorf("kept %d scratch note(s), then: %w", kept, err)
+			}
+			kept++
+		}
+		m.loadNotesAndTackles()
+		return fmt.Sprintf("Kept %d scratch note(s) as notes", kept), nil
+	case "clear":
+		n := len(m.scratch)
+		m.scratch = nil
+		m.loadNotesAndTackles()
+		return fmt.Sprintf("Discarded %d scratch note(s)", n), nil
+	}
+	return m.addScratch(strings.Join(args, " "))
+}
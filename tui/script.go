@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/script"
+	"github.com/user/tagging-rugby-cli/tui/components"
+	"github.com/user/tagging-rugby-cli/webhook"
+)
+
+// scriptHost exposes the model to Lua scripts.
+type scriptHost struct {
+	m *Model
+}
+
+func (h scriptHost) TimePos() (float64, error) {
+	if h.m.client == nil || !h.m.client.IsConnected() {
+		return 0, fmt.Errorf("not connected to mpv")
+	}
+	return h.m.client.GetTimePos()
+}
+
+func (h scriptHost) Seek(seconds float64) error {
+	if h.m.client == nil || !h.m.client.IsConnected() {
+		return fmt.Errorf("not connected to mpv")
+	}
+	return h.m.client.Seek(seconds)
+}
+
+func (h scriptHost) SeekRelative(seconds float64) error {
+	if h.m.client == nil || !h.m.client.IsConnected() {
+		return fmt.Errorf("not connected to mpv")
+	}
+	return h.m.client.SeekRelative(seconds)
+}
+
+func (h scriptHost) InsertNote(category, text string, at float64) (int64, error) {
+	duration, _ := h.m.client.GetDuration()
+	children := db.NoteChildren{
+		Timings: []db.NoteTiming{
+			{Start: at, End: at},
+		},
+		Videos: []db.NoteVideo{
+			newNoteVideo(h.m.videoPath, duration),
+		},
+	}
+	if text != "" {
+		children.Details = []db.NoteDetail{
+			{Type: "text", Note: text},
+		}
+	}
+	noteID, err := db.InsertNoteWithChildren(h.m.db, category, children)
+	if err != nil {
+		return 0, err
+	}
+	h.m.notifyWebhooks(webhook.NoteCreated, noteID, category, children)
+	h.m.loadNotesAndTackles()
+	return noteID, nil
+}
+
+func (h scriptHost) Stats() (script.Stats, error) {
+	stats := script.Stats{Players: make(map[string]script.PlayerStats)}
+	for _, item := range h.m.notesList.Items {
+		switch item.Type {
+		case components.ItemTypeNote:
+			stats.Notes++
+		case components.ItemTypeTackle:
+			stats.Tackles++
+		}
+	}
+	for _, ps := range h.m.statsView.Stats {
+		stats.Players[ps.Player] = script.PlayerStats{
+			Total:     ps.Total,
+			Completed: ps.Completed,
+			Missed:    ps.Missed,
+		}
+	}
+	return stats, nil
+}
+
+func (h scriptHost) Message(text string) {
+	h.m.statusMsg = text
+}
+
+// loadScripts creates the Lua engine and runs the user's scripts.
+// A script error is reported in the footer and leaves the remaining commands usable.
+func (m *Model) loadScripts() {
+	m.scripts = script.New(scriptHost{m: m})
+	dir, err := script.Dir()
+	if err != nil {
+		return
+	}
+	if err := m.scripts.LoadDir(dir); err != nil {
+		m.statusMsg = "Script error: " + err.Error()
+		m.notify(components.LevelError, m.statusMsg)
+	}
+}
+
+// runScriptKey runs a script key handler and shows its result or error.
+func (m *Model) runScriptKey(key string) (tea.Model, tea.Cmd) {
+	result, err := m.scripts.RunKey(key)
+	if err != nil {
+		m.statusMsg = "Script error: " + firstLine(err.Error())
+		m.notify(components.LevelError, err.Error())
+	} else if result != "" {
+		m.statusMsg = result
+	}
+	if m.statusMsg == "" {
+		return m, nil
+	}
+	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+		return clearStatusMsg{}
+	})
+}
+
+// firstLine trims Lua stack tracebacks to the error message.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,18 @@
+package tui
+
+import "github.com/user/tagging-rugby-cli/db"
+
+// startSession records the start of a tagging session in the session log and
+// returns a func that closes it. Sessions are best-effort: failures are ignored.
+func (m *Model) startSession() func() {
+	if m.db == nil || m.videoID <= 0 {
+		return func() {}
+	}
+	sessionID, err := db.StartSession(m.db, m.videoID)
+	if err != nil {
+		return func() {}
+	}
+	// The database the session started in, even if the tutorial is running
+	database := m.db
+	return func() { db.EndSession(database, sessionID) }
+}
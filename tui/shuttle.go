@@ -0,0 +1,96 @@
+package tui
+
+import "fmt"
+
+// shuttleSpeeds is the speed ladder stepped through by repeated j/l presses.
+var shuttleSpeeds = []float64{1, 2, 4, 8}
+
+// shuttleState tracks JKL shuttle mode: j plays backward, l forward, k pauses,
+// and repeated j/l presses climb the speed ladder.
+type shuttleState struct {
+	Active bool
+	// Direction is -1 (backward), 0 (paused) or 1 (forward)
+	Direction int
+	// Level indexes shuttleSpeeds
+	Level int
+}
+
+// indicator returns the status bar text for the current shuttle state.
+func (s shuttleState) indicator() string {
+	if !s.Active {
+		return ""
+	}
+	switch s.Direction {
+	case -1:
+		return fmt.Sprintf("JKL ◀◀ %gx", shuttleSpeeds[s.Level])
+	case 1:
+		return fmt.Sprintf("JKL ▶▶ %gx", shuttleSpeeds[s.Level])
+	default:
+		return "JKL ■"
+	}
+}
+
+// toggleShuttle turns shuttle mode on or off. Turning it off restores normal
+// forward playback at 1x, leaving the pause state as it is.
+func (m *Model) toggleShuttle() {
+	if m.shuttle.Active {
+		m.shuttle = shuttleState{}
+		if m.client != nil && m.client.IsConnected() {
+			_ = m.client.SetPlayDirection(false)
+			_ = m.client.SetSpeed(1)
+		}
+		m.statusMsg = "Shuttle mode off"
+	} else {
+		m.shuttle = shuttleState{Active: true}
+		m.statusMsg = "Shuttle mode: j back, k pause, l forward (Ctrl+S to exit)"
+	}
+	m.statusBar.Shuttle = m.shuttle.indicator()
+}
+
+// handleShuttleKey applies a shuttle key (j, k or l). Returns false for other keys.
+func (m *Model) handleShuttleKey(key string) bool {
+	var direction int
+	switch key {
+	case "j":
+		direction = -1
+	case "k":
+		direction = 0
+	case "l":
+		direction = 1
+	default:
+		return false
+	}
+
+	switch {
+	case direction == 0:
+		m.shuttle.Direction = 0
+		m.shuttle.Level = 0
+	case direction == m.shuttle.Direction:
+		// Same direction again: climb the ladder, wrapping back to 1x after the top
+		m.shuttle.Level = (m.shuttle.Level + 1) % len(shuttleSpeeds)
+	default:
+		m.shuttle.Direction = direction
+		m.shuttle.Level = 0
+	}
+	m.statusBar.Shuttle = m.shuttle.indicator()
+
+	m.applyShuttle(direction, shuttleSpeeds[m.shuttle.Level])
+	return true
+}
+
+// applyShuttle plays at speed in direction (-1 backward, 1 forward), or
+// pauses at 1x forward for direction 0.
+func (m *Model) applyShuttle(direction int, speed float64) {
+	if m.client == nil || !m.client.IsConnected() {
+		return
+	}
+	if direction == 0 {
+		_ = m.client.Pause()
+		_ = m.client.SetSpeed(1)
+		_ = m.client.SetPlayDirection(false)
+		return
+	}
+	_ = m.client.SetPlayDirection(direction < 0)
+	_ = m.client.SetSpeed(speed)
+	_ = m.client.Play()
+}
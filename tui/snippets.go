@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/pkg/snippet"
+	"github.com/user/tagging-rugby-cli/tui/forms"
+)
+
+// snippetNames returns the configured snippet names, sorted.
+func (m *Model) snippetNames() []string {
+	if m.config == nil {
+		return nil
+	}
+	names := make([]string, 0, len(m.config.Snippets))
+	for name := range m.config.Snippets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// snippetTemplates returns the snippet texts offered as note form completions.
+func (m *Model) snippetTemplates() []string {
+	var templates []string
+	for _, name := range m.snippetNames() {
+		templates = append(templates, m.config.Snippets[name])
+	}
+	return templates
+}
+
+// expandNoteSnippet fills {player}, {team} and {category} placeholders in the
+// note form text from the form's other fields. Other placeholders are kept.
+func expandNoteSnippet(result forms.NoteFormResult) string {
+	text, _ := snippet.Expand(result.Text, map[string]string{
+		"player":   result.Player,
+		"team":     result.Team,
+		"category": result.Category,
+	})
+	return text
+}
+
+// executeSnippetCommand handles :snippet [name [values...]]. With no name it
+// lists the snippets; otherwise the placeholders are filled from the values in
+// order and the note is added at the current position.
+func (m *Model) executeSnippetCommand(args []string) (string, error) {
+	names := m.snippetNames()
+	if len(args) == 0 {
+		if len(names) == 0 {
+			return "No snippets (add \"snippets\" to config.json)", nil
+		}
+		return "Snippets: " + strings.Join(names, ", "), nil
+	}
+	tmpl, ok := m.config.Snippets[args[0]]
+	if !ok {
+		return "", fmt.Errorf("unknown snippet: %s", args[0])
+	}
+	text, missing := snippet.Fill(tmpl, args[1:])
+	if len(missing) > 0 {
+		return "", fmt.Errorf("usage: snippet %s <%s>", args[0], strings.Join(snippet.Placeholders(tmpl), "> <"))
+	}
+	return m.addNote(text, "", "", "")
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/tui/components"
+	"github.com/user/tagging-rugby-cli/tui/layout"
+)
+
+// statsPanelVisible reports whether column 3 (the live stats panel) is on screen.
+func (m *Model) statsPanelVisible() bool {
+	_, _, _, _, _, showCol3, _ := layout.ComputeColumnWidths(m.width, false)
+	return showCol3
+}
+
+// handleStatsPanelKeys handles key events when the column 3 stats panel is focused.
+// o cycles the sort column, / filters the table by player, j/k move the selection
+// and Enter filters the notes list by the selected player (as moving does with
+// the cross-filter on).
+func (m *Model) handleStatsPanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.statsPanel.FilterMode {
+		return m.handleStatsPanelFilterInput(msg)
+	}
+
+	rows := m.statsPanel.Rows(m.statsView.Stats)
+	switch msg.String() {
+	case "j", "J", "up":
+		m.statsPanel.MoveUp()
+		m.followStatsSelection(m.statsPanel.Selected(m.statsView.Stats))
+	case "k", "K", "down":
+		m.statsPanel.MoveDown(len(rows))
+		m.followStatsSelection(m.statsPanel.Selected(m.statsView.Stats))
+	case "o", "O":
+		m.statsPanel.NextSort()
+	case "/":
+		m.statsPanel.FilterMode = true
+	case "enter":
+		player := m.statsPanel.Selected(m.statsView.Stats)
+		if player == "" {
+			return m, nil
+		}
+		if player == m.playerFilter {
+			return m.setPlayerFilter("")
+		}
+		return m.setPlayerFilter(player)
+	case "esc":
+		switch {
+		case m.statsPanel.FilterInput != "":
+			m.statsPanel.FilterInput = ""
+			m.statsPanel.SelectedIndex = 0
+		case m.playerFilter != "":
+			return m.setPlayerFilter("")
+		default:
+			m.focus = FocusNotes
+		}
+	}
+	return m, nil
+}
+
+// handleStatsPanelFilterInput handles typing the stats panel's player filter.
+// The table narrows as the filter is typed; Enter or Esc stops typing and keeps it.
+func (m *Model) handleStatsPanelFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc":
+		m.statsPanel.FilterMode = false
+	case "backspace":
+		if len(m.statsPanel.FilterInput) > 0 {
+			r := []rune(m.statsPanel.FilterInput)
+			m.statsPanel.FilterInput = string(r[:len(r)-1])
+		}
+	default:
+		if len(msg.String()) == 1 {
+			m.statsPanel.FilterInput += msg.String()
+		} else if msg.Type == tea.KeyRunes {
+			m.statsPanel.FilterInput += string(msg.Runes)
+		}
+	}
+	m.statsPanel.SelectedIndex = 0
+	return m, nil
+}
+
+// setPlayerFilter limits the notes list to events tagged with player ("" shows all).
+func (m *Model) setPlayerFilter(player string) (tea.Model, tea.Cmd) {
+	m.applyPlayerFilter(player)
+	if player == "" {
+		return m.showStatus("Notes filter cleared")
+	}
+	return m.showStatus("Notes filtered by " + player + " (Esc to clear)")
+}
+
+// filterItemsByPlayer returns the items tagged with player (case-insensitive).
+func filterItemsByPlayer(items []components.ListItem, player string) []components.ListItem {
+	var out []components.ListItem
+	for _, item := range items {
+		if strings.EqualFold(item.Player, player) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// trendMatches is how many earlier matches the stats panel's sparklines show
+// before the current one.
+const trendMatches = 5
+
+// trendState holds the players' completion % in earlier matches, read at
+// startup; the current match is added live from the panel's stats.
+type trendState struct {
+	// Matches is the number of earlier matches found (at most trendMatches)
+	Matches int
+	// Players maps lowercase player names to one completion % per earlier
+	// match, oldest first, -1 where the player has none
+	Players map[string][]float64
+}
+
+// loadTrends reads the players' tackle completion in the matches before this one.
+func (m *Model) loadTrends() {
+	m.trends = trendState{}
+	if m.db == nil {
+		return
+	}
+	rows, err := db.SelectPlayerTrends(m.db, m.videoID, trendMatches)
+	if err != nil {
+		return
+	}
+
+	// Rows come in match order: number the matches as they appear
+	match := make(map[int64]int)
+	for _, r := range rows {
+		if _, ok := match[r.VideoID]; !ok {
+			match[r.VideoID] = len(match)
+		}
+	}
+	players := make(map[string][]float64)
+	for _, r := range rows {
+		key := strings.ToLower(r.Player)
+		trend, ok := players[key]
+		if !ok {
+			trend = make([]float64, len(match))
+			for i := range trend {
+				trend[i] = -1
+			}
+			players[key] = trend
+		}
+		if r.Completed+r.Missed > 0 {
+			trend[match[r.VideoID]] = float64(r.Completed) / float64(r.Completed+r.Missed) * 100
+		}
+	}
+	m.trends = trendState{Matches: len(match), Players: players}
+}
+
+// playerTrends returns each player's completion % over the earlier matches
+// and this one, keyed by name as in the stats, for the stats panel sparklines. It is nil when there are no
+// earlier matches, so the panel leaves the trend column out.
+func (m *Model) playerTrends() map[string][]float64 {
+	if m.trends.Matches == 0 {
+		return nil
+	}
+	trends := make(map[string][]float64, len(m.statsView.Stats))
+	for _, p := range m.statsView.Stats {
+		trend := m.trends.Players[strings.ToLower(p.Player)]
+		if trend == nil {
+			trend = make([]float64, m.trends.Matches)
+			for i := range trend {
+				trend[i] = -1
+			}
+		}
+		current := -1.0
+		if p.Completed+p.Missed > 0 {
+			current = p.Percentage
+		}
+		trends[p.Player] = append(trend[:len(trend):len(trend)], current)
+	}
+	return trends
+}
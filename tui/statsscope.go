@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/season"
+)
+
+// statsScope is the set of videos the stats view counts, cycled with V.
+type statsScope int
+
+const (
+	scopeVideo statsScope = iota
+	// scopeMatch is every video linked to the current video's match
+	scopeMatch
+	// scopeSeason is every video whose match is in the current video's season
+	scopeSeason
+	scopeAll
+	numStatsScopes
+)
+
+// scopeFor returns the videos scope s covers for the current video and its
+// title label. ok is false when the scope doesn't apply: no match linked, or
+// no date to place the video in a season.
+func (m *Model) scopeFor(s statsScope) (scope db.VideoScope, label string, ok bool) {
+	switch s {
+	case scopeAll:
+		return db.VideoScope{}, "All Videos", true
+	case scopeVideo:
+		return db.VideoScope{VideoID: m.videoID}, "Current Video", m.videoID > 0
+	}
+	if m.videoID <= 0 {
+		return db.VideoScope{}, "", false
+	}
+	matchID, date, err := db.SelectVideoMatchDate(m.db, m.videoID)
+	if err != nil {
+		return db.VideoScope{}, "", false
+	}
+	if s == scopeMatch {
+		if matchID == 0 {
+			return db.VideoScope{}, "", false
+		}
+		match, err := db.SelectMatchByID(m.db, matchID)
+		if err != nil {
+			return db.VideoScope{}, "", false
+		}
+		return db.VideoScope{MatchID: matchID}, "Match " + match.Title(), true
+	}
+	startMonth := season.DefaultStartMonth
+	if m.config != nil && m.config.SeasonStart != 0 {
+		startMonth = m.config.SeasonStart
+	}
+	sn, err := season.Of(date, startMonth)
+	if err != nil {
+		return db.VideoScope{}, "", false
+	}
+	scope.From, scope.To = sn.Bounds()
+	return scope, "Season " + sn.Label(), true
+}
+
+// cycleStatsScope moves the stats view on to the next scope that applies:
+// current video, its match, its season, then all videos.
+func (m *Model) cycleStatsScope() {
+	for i := statsScope(1); i <= numStatsScopes; i++ {
+		next := (m.statsScope + i) % numStatsScopes
+		if _, _, ok := m.scopeFor(next); ok {
+			m.statsScope = next
+			break
+		}
+	}
+	m.loadTackleStats()
+}
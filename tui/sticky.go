@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/tui/forms"
+)
+
+// stickyTackle is the player and outcome carried over from the last tackle,
+// since consecutive events often involve the same player.
+type stickyTackle struct {
+	Player  string
+	Outcome string
+}
+
+// hasStickyTackle reports whether the next tackle form is pre-filled from the last tackle.
+func (m *Model) hasStickyTackle() bool {
+	return m.config != nil && m.config.StickyTackle && m.lastTackle.Player != ""
+}
+
+// tackleFormPrefill returns the values a new tackle form opens with: the
+// session defaults and configured event duration, plus the last player and
+// outcome when sticky_tackle is on.
+func (m *Model) tackleFormPrefill() forms.TackleFormResult {
+	prefill := m.defaults.tackleForm()
+	prefill.Duration = m.defaultEventDuration()
+	if m.hasStickyTackle() {
+		prefill.Player = m.lastTackle.Player
+		prefill.Outcome = m.lastTackle.Outcome
+	}
+	return prefill
+}
+
+// clearStickyTackle empties the carried-over player and outcome and reopens the
+// tackle form without them, keeping anything else already typed.
+func (m *Model) clearStickyTackle() tea.Cmd {
+	m.lastTackle = stickyTackle{}
+	m.tackleFormResult.Player = ""
+	m.tackleFormResult.Outcome = ""
+	m.tackleForm = forms.NewTackleForm(&m.tackleFormTimestamp, &m.tackleFormResult, false, m.tackleFormSuggestions())
+	return m.tackleForm.Init()
+}
+
+// toggleStickyTackle turns sticky tackle values on or off and saves the choice
+// to config.json.
+func (m *Model) toggleStickyTackle() (string, error) {
+	if m.config == nil {
+		m.config = &config.Config{}
+	}
+	m.config.StickyTackle = !m.config.StickyTackle
+
+	// Re-read before saving so edits made to config.json since startup are kept
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("sticky tackle not saved: %w", err)
+	}
+	cfg.StickyTackle = m.config.StickyTackle
+	if err := config.Save(cfg); err != nil {
+		return "", fmt.Errorf("sticky tackle not saved: %w", err)
+	}
+
+	if !m.config.StickyTackle {
+		return "Sticky tackle values off", nil
+	}
+	return "Sticky tackle values on: the tackle form keeps the last player and outcome", nil
+}
@@ -27,6 +27,8 @@ const (
 	Red = lipgloss.Color("#AC3835")
 	// Green is used for success messages (Ciapre ANSI 2)
 	Green = lipgloss.Color("#A6A75D")
+	// Yellow marks uncertain results such as possible tackles (Ciapre ANSI 11 bright yellow)
+	Yellow = lipgloss.Color("#DCDF7C")
 	// MatchBg is a subtle background for search-matched rows (slightly lighter than DeepPurple)
 	MatchBg = lipgloss.Color("#2A2D3A")
 )
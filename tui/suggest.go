@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/suggest"
+	"github.com/user/tagging-rugby-cli/tui/components"
+	"github.com/user/tagging-rugby-cli/webhook"
+)
+
+// suggestTimeout bounds how long a suggester process may run.
+const suggestTimeout = 30 * time.Minute
+
+// suggestionsMsg carries the result of a suggester run.
+type suggestionsMsg struct {
+	suggestions []suggest.Suggestion
+	err         error
+}
+
+// runSuggester starts the configured suggester in the background.
+// Returns nil when no suggester is configured.
+func (m *Model) runSuggester() tea.Cmd {
+	if m.suggester == nil {
+		return nil
+	}
+	m.suggestions.Loading = true
+	suggester := m.suggester
+	videoPath := m.videoPath
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), suggestTimeout)
+		defer cancel()
+		suggestions, err := suggester.Suggest(ctx, videoPath)
+		return suggestionsMsg{suggestions: suggestions, err: err}
+	}
+}
+
+// handleSuggestions fills the review queue with new suggestions, skipping any already tagged.
+func (m *Model) handleSuggestions(msg suggestionsMsg) (tea.Model, tea.Cmd) {
+	m.suggestions.Loading = false
+	if msg.err != nil {
+		m.statusMsg = "Suggester failed: " + msg.err.Error()
+		m.notify(components.LevelError, m.statusMsg)
+		return m, tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		})
+	}
+
+	items := make([]components.SuggestionItem, 0, len(msg.suggestions))
+	for _, s := range msg.suggestions {
+		if exists, err := db.NoteExistsAt(m.db, m.videoPath, s.Category, s.Time); err == nil && exists {
+			continue
+		}
+		items = append(items, components.SuggestionItem{
+			Time:       s.Time,
+			Label:      s.Label,
+			Category:   s.Category,
+			Confidence: s.Confidence,
+		})
+	}
+	m.suggestions.Items = items
+	m.suggestions.SelectedIndex = 0
+
+	m.statusMsg = fmt.Sprintf("%d suggested event(s) ready — :suggest to review", len(items))
+	return m, tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+		return clearStatusMsg{}
+	})
+}
+
+// handleSuggestionKeys handles key events while the review queue is displayed.
+func (m *Model) handleSuggestionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "backspace":
+		m.suggestions.Active = false
+	case "j", "J", "up":
+		m.suggestions.MoveUp()
+	case "k", "K", "down":
+		m.suggestions.MoveDown()
+	case " ":
+		if item := m.suggestions.Selected(); item != nil && m.client != nil && m.client.IsConnected() {
+			_ = m.client.Seek(item.Time)
+		}
+	case "enter", "a", "A":
+		return m.acceptSuggestion()
+	case "x", "X", "r", "R":
+		if m.suggestions.Selected() != nil {
+			m.suggestions.Remove()
+			m.suggestions.Rejected++
+		}
+	case "ctrl+c":
+		m.quitting = true
+		if timePos, tpErr := m.client.GetTimePos(); tpErr == nil && m.videoID > 0 {
+			_ = db.UpdateVideoTimingStopped(m.db, m.videoID, timePos)
+		}
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// acceptSuggestion inserts the highlighted suggestion as a note and removes it from the queue.
+func (m *Model) acceptSuggestion() (tea.Model, tea.Cmd) {
+	item := m.suggestions.Selected()
+	if item == nil {
+		return m, nil
+	}
+
+	duration, _ := m.client.GetDuration()
+	children := db.NoteChildren{
+		Timings: []db.NoteTiming{
+			{Start: item.Time, End: item.Time},
+		},
+		Videos: []db.NoteVideo{
+			newNoteVideo(m.videoPath, duration),
+		},
+		Details: []db.NoteDetail{
+			{Type: "source", Note: "suggester"},
+		},
+	}
+	if item.Label != "" && item.Label != item.Category {
+		children.Details = append(children.Details, db.NoteDetail{Type: "text", Note: item.Label})
+	}
+
+	noteID, err := db.InsertNoteWithChildren(m.db, item.Category, children)
+	if err != nil {
+		m.statusMsg = "Error: failed to insert note: " + err.Error()
+	} else {
+		m.notifyWebhooks(webhook.NoteCreated, noteID, item.Category, children)
+		m.statusMsg = fmt.Sprintf("Note %d added at %s", noteID, timeutil.FormatTime(item.Time))
+		m.suggestions.Remove()
+		m.suggestions.Accepted++
+		m.loadNotesAndTackles()
+	}
+	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+		return clearStatusMsg{}
+	})
+}
+
+// executeSuggestCommand handles :suggest (open the review queue) and :suggest run (re-run the suggester).
+func (m *Model) executeSuggestCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		return "OPEN_SUGGESTIONS", nil
+	}
+	switch args[0] {
+	case "run":
+		if m.suggester == nil {
+			return "", fmt.Errorf("no suggester configured (start with --suggest-cmd)")
+		}
+		if m.suggestions.Loading {
+			return "", fmt.Errorf("suggester is already running")
+		}
+		return "RUN_SUGGESTER", nil
+	case "clear":
+		n := len(m.suggestions.Items)
+		m.suggestions.Items = nil
+		m.suggestions.SelectedIndex = 0
+		return fmt.Sprintf("Discarded %d suggestion(s)", n), nil
+	default:
+		return "", fmt.Errorf("unknown suggest subcommand: %s (use: run, clear)", args[0])
+	}
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/tui/forms"
+)
+
+// formSuggestions returns the players, categories and zones entered before,
+// which the note and tackle forms offer as completions. It is loaded each time
+// a form opens, so names entered a moment ago are offered too.
+func (m *Model) formSuggestions() forms.Suggestions {
+	s := forms.Suggestions{Categories: m.categorySuggestions(), Events: len(m.events)}
+	if m.db != nil {
+		s.Players, _ = db.SelectPlayerNames(m.db, m.videoID)
+		s.Zones, _ = db.SelectZoneNames(m.db)
+	}
+	return s
+}
+
+// tackleFormSuggestions returns the form suggestions with the players most
+// recently tagged on this video, for the tackle form to number. They are kept
+// for the alt+1 onwards picks and the controls display.
+func (m *Model) tackleFormSuggestions() forms.Suggestions {
+	s := m.formSuggestions()
+	m.recentPlayers = nil
+	if m.db != nil && m.videoID > 0 {
+		m.recentPlayers, _ = db.SelectRecentPlayers(m.db, m.videoID, forms.MaxRecentPlayers)
+	}
+	s.RecentPlayers = m.recentPlayers
+	return s
+}
+
+// pickRecentPlayer handles alt+1 onwards while the new tackle form's player
+// field has focus: the form reopens with that recent player filled in, keeping
+// anything else typed, and moves on to the attempt. ok is false for other keys.
+func (m *Model) pickRecentPlayer(key string) (cmd tea.Cmd, ok bool) {
+	digit, found := strings.CutPrefix(key, "alt+")
+	if !found || len(digit) != 1 || digit[0] < '1' || digit[0] > '9' {
+		return nil, false
+	}
+	i := int(digit[0] - '1')
+	if i >= len(m.recentPlayers) || !forms.PlayerFocused(m.tackleForm) {
+		return nil, false
+	}
+	m.tackleFormResult.Player = m.recentPlayers[i]
+	m.tackleForm = forms.NewTackleForm(&m.tackleFormTimestamp, &m.tackleFormResult, m.hasStickyTackle(), m.tackleFormSuggestions())
+	return tea.Batch(m.tackleForm.Init(), m.tackleForm.NextField()), true
+}
+
+// spellNote respells the note form's category and player as entered before.
+func (m *Model) spellNote(r *forms.NoteFormResult) {
+	s := m.formSuggestions()
+	r.Category = forms.Spelled(r.Category, s.Categories)
+	r.Player = forms.Spelled(r.Player, s.Players)
+}
+
+// spellTackle respells the tackle form's player, followed and zone as entered
+// before.
+func (m *Model) spellTackle(r *forms.TackleFormResult) {
+	s := m.formSuggestions()
+	r.Player = forms.Spelled(r.Player, s.Players)
+	r.Followed = forms.Spelled(r.Followed, s.Players)
+	r.Zone = forms.Spelled(r.Zone, s.Zones)
+}
@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+	"github.com/user/tagging-rugby-cli/tui/forms"
+)
+
+// noteTemplates returns the note templates for the note form, by category
+// name: those from config.json, then the event types from the database not
+// overridden by one. Fields without a name are skipped.
+func (m *Model) noteTemplates() []forms.NoteTemplate {
+	var templates []forms.NoteTemplate
+	seen := make(map[string]bool)
+	if m.config != nil {
+		for category, fields := range m.config.NoteTemplates {
+			if t := formTemplate(category, fields); len(t.Fields) > 0 {
+				templates = append(templates, t)
+				seen[strings.ToLower(catpath.Normalize(category))] = true
+			}
+		}
+	}
+	if m.db != nil {
+		types, _ := db.SelectEventTypes(m.db)
+		for _, et := range types {
+			if t := eventTemplate(et); len(t.Fields) > 0 && !seen[strings.ToLower(catpath.Normalize(et.Name))] {
+				templates = append(templates, t)
+			}
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Category < templates[j].Category
+	})
+	return templates
+}
+
+// formTemplate converts a config.json template for the note form.
+func formTemplate(category string, fields []config.TemplateField) forms.NoteTemplate {
+	t := forms.NoteTemplate{Category: category}
+	for _, f := range fields {
+		if strings.TrimSpace(f.Name) == "" {
+			continue
+		}
+		t.Fields = append(t.Fields, forms.TemplateField{
+			Name:     f.Name,
+			Title:    f.Title,
+			Options:  f.Options,
+			Required: f.Required,
+		})
+	}
+	return t
+}
+
+// eventTemplate converts an event type for the note form.
+func eventTemplate(et db.EventType) forms.NoteTemplate {
+	t := forms.NoteTemplate{Category: et.Name}
+	for _, f := range et.Fields {
+		t.Fields = append(t.Fields, forms.TemplateField{
+			Name:     f.Name,
+			Title:    f.Title,
+			Type:     f.Type,
+			Options:  f.Options,
+			Required: f.Required,
+		})
+	}
+	return t
+}
+
+// noteTemplate returns the template for category, in any case: its
+// config.json template, else its event type.
+func (m *Model) noteTemplate(category string) (forms.NoteTemplate, bool) {
+	if m.config != nil {
+		if fields, ok := m.config.NoteTemplate(category); ok {
+			return formTemplate(category, fields), true
+		}
+	}
+	if m.db == nil || strings.TrimSpace(category) == "" {
+		return forms.NoteTemplate{}, false
+	}
+	et, err := db.SelectEventType(m.db, catpath.Normalize(category))
+	if err != nil {
+		return forms.NoteTemplate{}, false
+	}
+	return eventTemplate(et), true
+}
+
+// templateDetails returns the note details for the template fields filled in
+// on the note form, typed by field name.
+func (m *Model) templateDetails(result forms.NoteFormResult) []db.NoteDetail {
+	t, ok := m.noteTemplate(result.Category)
+	if !ok {
+		return nil
+	}
+	var details []db.NoteDetail
+	var players []string
+	for i, f := range t.Fields {
+		if i == forms.MaxTemplateFields {
+			break
+		}
+		v := strings.TrimSpace(result.Fields[i])
+		if v == "" {
+			continue
+		}
+		// Player fields are saved as tackle players are
+		if f.Type == "player" {
+			if players == nil {
+				players, _ = db.SelectPlayerNames(m.db, m.videoID)
+			}
+			v = forms.Spelled(v, players)
+			if name, err := db.ResolvePlayer(m.db, m.videoID, v); err == nil {
+				v = name
+			}
+		}
+		details = append(details, db.NoteDetail{Type: f.Name, Note: v})
+	}
+	return details
+}
+
+// templateSummary describes a note's template fields for its list row, e.g.
+// "thrower: Jones, outcome: won", or "" when the category has no template.
+func (m *Model) templateSummary(category string, details []db.NoteDetail) string {
+	t, ok := m.noteTemplate(category)
+	if !ok {
+		return ""
+	}
+	var parts []string
+	for _, f := range t.Fields {
+		for _, d := range details {
+			if d.Type == f.Name {
+				parts = append(parts, f.Name+": "+d.Note)
+				break
+			}
+		}
+	}
+	return strings.Join(parts, ", ")
+}
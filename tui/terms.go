@@ -0,0 +1,20 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkTerms checks note text against the club dictionary while it is typed and
+// returns a warning such as `Check: "rukc" → ruck`, or "" when the text is fine.
+func (m *Model) checkTerms(text string) string {
+	findings := m.dictionary.Check(text)
+	if len(findings) == 0 {
+		return ""
+	}
+	parts := make([]string, len(findings))
+	for i, f := range findings {
+		parts[i] = fmt.Sprintf("%q → %s", f.Word, f.Term)
+	}
+	return "Check: " + strings.Join(parts, ", ")
+}
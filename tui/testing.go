@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/mpv"
+)
+
+// NewTestModel builds a model for driving the TUI without a terminal or mpv:
+// player is usually a fake and database an in-memory one (db.OpenMemory).
+// The video is registered in the database and its notes, possession and
+// bookmarks are loaded as Run would, but the user's config.json, Lua scripts
+// and webhooks are not, so tests see the defaults.
+//
+// Feed the model key messages with Update and read the screen with View; see
+// the tuitest package for helpers.
+func NewTestModel(player mpv.Player, database *sql.DB, videoPath string) (*Model, error) {
+	videoID, err := db.EnsureVideo(database, videoPath, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("register video: %w", err)
+	}
+	m := NewModel(player, database, videoPath, videoID)
+	m.config = &config.Config{}
+	m.loadVideoState()
+	m.loadGameClock()
+	// Same initial state as Init, without starting the ticker
+	m.focus = FocusNotes
+	m.searchInput.Mode = "search"
+	m.width, m.height = 160, 48
+	return m, nil
+}
+
+// TickMsg returns the periodic refresh message the running TUI receives every
+// tick: it polls the player and reloads the notes list.
+func TickMsg() tea.Msg {
+	return tickMsg(time.Now())
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"reflect"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+const (
+	// pausedTickInterval is the refresh interval while the video is paused.
+	// Input still refreshes at once, so only changes made in the mpv window
+	// or by the clip worker wait for it.
+	pausedTickInterval = 500 * time.Millisecond
+	// defaultIdleAfter is how long the TUI waits with no input and the video
+	// paused before slowing its refresh further.
+	defaultIdleAfter = 5 * time.Minute
+	// idleTickInterval is the refresh interval while idle. It is short enough to
+	// notice playback resumed from the mpv window within a couple of seconds.
+	idleTickInterval = 2 * time.Second
+)
+
+// slowTickMsg is the refresh sent while paused or idle. gen identifies the
+// slow spell it belongs to, so ticks left over from an earlier one are dropped.
+type slowTickMsg struct {
+	gen int
+}
+
+// idleAfter returns how long input must be absent before going idle, or 0
+// when idle detection is disabled in config.json.
+func (m *Model) idleAfter() time.Duration {
+	if m.config == nil || m.config.IdleMinutes == 0 {
+		return defaultIdleAfter
+	}
+	if m.config.IdleMinutes < 0 {
+		return 0
+	}
+	return time.Duration(m.config.IdleMinutes * float64(time.Minute))
+}
+
+// nextTick schedules the next refresh: every tickInterval() while playing,
+// every pausedTickInterval while paused, and every idleTickInterval once the
+// video has been paused with no input for idleAfter.
+func (m *Model) nextTick() tea.Cmd {
+	if !m.statusBar.Paused {
+		m.slowTicking = false
+		return m.tickCmd()
+	}
+	interval := pausedTickInterval
+	if after := m.idleAfter(); after > 0 && time.Since(m.lastInput) >= after {
+		interval = idleTickInterval
+	}
+	m.slowTicking = true
+	m.tickGen++
+	gen := m.tickGen
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return slowTickMsg{gen: gen}
+	})
+}
+
+// trackInput records keyboard, mouse and controller input. While ticking
+// slowly it returns a command for an immediate refresh, so the result of the
+// input (e.g. playback starting) shows without waiting for the slow tick.
+func (m *Model) trackInput(msg tea.Msg) tea.Cmd {
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg, pedalMsg, midiMsg, remoteMsg:
+	default:
+		return nil
+	}
+	m.lastInput = time.Now()
+	if !m.slowTicking {
+		return nil
+	}
+	m.slowTicking = false
+	return m.tickCmd()
+}
+
+// refreshedState is the part of the model a tick refreshes. Comparing it
+// before and after a tick tells whether the screen needs redrawing.
+type refreshedState struct {
+	statusBar  components.StatusBarState
+	statusMsg  string
+	unread     int
+	items      []components.ListItem
+	selected   int
+	clipFlash  bool
+	stats      []components.PlayerStats
+	export     components.ExportIndicatorState
+	possession components.PossessionState
+	clips      components.ClipsViewState
+}
+
+// snapshotRefreshed captures the state a tick may change.
+func (m *Model) snapshotRefreshed() refreshedState {
+	s := refreshedState{
+		statusBar:  m.statusBar,
+		statusMsg:  m.statusMsg,
+		unread:     m.commandInput.Unread,
+		items:      m.notesList.Items,
+		selected:   m.notesList.SelectedIndex,
+		stats:      m.statsView.Stats,
+		export:     m.exportIndicator,
+		possession: m.possession,
+		clips:      m.clipsView,
+	}
+	now := time.Now()
+	for _, item := range m.notesList.Items {
+		if item.ClipJustFinished(now) {
+			s.clipFlash = true
+			break
+		}
+	}
+	return s
+}
+
+// differs reports whether anything shown on screen changed between s and before.
+func (s refreshedState) differs(before refreshedState) bool {
+	return !reflect.DeepEqual(s, before)
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/gameclock"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// timelineWindowSize is the length of the stats view's timeline chart windows.
+const timelineWindowSize = 10 * 60
+
+// loadTimelineChart counts the current video's events per 10 minutes of game
+// time for the stats view's timeline page, as 'stats timeline' does.
+func (m *Model) loadTimelineChart() {
+	m.statsView.TimelineWindows = nil
+	m.statsView.TimelineNote = "Game clock, 10-minute windows"
+	if !m.gameClock.HasPeriods() {
+		m.statsView.TimelineNote = "Video time (no match periods: run analyze halves)"
+	}
+	if m.db == nil {
+		return
+	}
+	notes, err := db.SelectNotesForExport(m.db, m.videoPath)
+	if err != nil || len(notes) == 0 {
+		return
+	}
+
+	end := 0.0
+	for _, n := range notes {
+		end = max(end, n.Start)
+	}
+	windows := m.gameClock.Windows(timelineWindowSize, end)
+	chart := make([]components.TimelineWindow, len(windows))
+	for i, w := range windows {
+		chart[i] = components.TimelineWindow{Period: w.PeriodName, Label: w.Label}
+	}
+	for _, n := range notes {
+		i := gameclock.WindowAt(windows, n.Start)
+		if i < 0 {
+			continue
+		}
+		chart[i].Events++
+		if n.Category == "tackle" {
+			chart[i].Tackles++
+			switch n.Outcome {
+			case "completed":
+				chart[i].Completed++
+			case "missed":
+				chart[i].Missed++
+			}
+		}
+	}
+	m.statsView.TimelineWindows = chart
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,27 +14,36 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/user/tagging-rugby-cli/clip"
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/controller"
 	"github.com/user/tagging-rugby-cli/db"
 	"github.com/user/tagging-rugby-cli/mpv"
+	"github.com/user/tagging-rugby-cli/pkg/catpath"
+	"github.com/user/tagging-rugby-cli/pkg/gameclock"
+	"github.com/user/tagging-rugby-cli/pkg/helpdoc"
+	"github.com/user/tagging-rugby-cli/pkg/lint"
 	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+	"github.com/user/tagging-rugby-cli/remote"
+	"github.com/user/tagging-rugby-cli/script"
+	"github.com/user/tagging-rugby-cli/suggest"
 	"github.com/user/tagging-rugby-cli/tui/components"
 	"github.com/user/tagging-rugby-cli/tui/forms"
+	"github.com/user/tagging-rugby-cli/tui/keymap"
 	"github.com/user/tagging-rugby-cli/tui/layout"
+	"github.com/user/tagging-rugby-cli/webhook"
 )
 
 const (
-	// tickInterval is the interval for polling mpv status.
-	tickInterval = 100 * time.Millisecond
+	// defaultTickInterval is the interval for polling mpv status while
+	// playing, unless "tick_ms" in config.json sets it.
+	defaultTickInterval = 100 * time.Millisecond
 	// defaultStepSize is the default seek step size in seconds.
 	defaultStepSize = 1.0
 	// resultDisplayDuration is how long to show command results.
 	resultDisplayDuration = 3 * time.Second
 )
 
-// stepSizes defines the available step sizes for seek operations.
-// Users can cycle through these with < and > keys.
-var stepSizes = []float64{0.1, 0.5, 1, 2, 5, 10, 30}
-
 // tickMsg is a message sent on every tick interval to update playback status.
 type tickMsg time.Time
 
@@ -47,7 +57,7 @@ type clearStatusMsg struct{}
 // It implements the tea.Model interface with Init, Update, and View methods.
 type Model struct {
 	// mpv client for controlling video playback
-	client *mpv.Client
+	client mpv.Player
 	// database connection for notes, clips, and tackles
 	db *sql.DB
 	// current video file path
@@ -64,6 +74,18 @@ type Model struct {
 	statusBar components.StatusBarState
 	// notes list state
 	notesList components.NotesListState
+	// events are the video's saved events by number (#17), including those
+	// the filters hide
+	events map[int]components.ListItem
+	// refNumbers are the current numbers of the events note texts refer to,
+	// by note_details ID and reference position (db.SelectNoteRefNumbers)
+	refNumbers map[int64]map[int]int
+	// eventTimes are the timestamps of the video's events, scratch notes
+	// included, ascending, for the time since the last event
+	eventTimes []float64
+	// gapWarned is set once the event gap warning has been shown, until an
+	// event closes the gap
+	gapWarned bool
 	// command input state
 	commandInput components.CommandInputState
 	// clip start timestamp (for clip start/end workflow)
@@ -72,6 +94,12 @@ type Model struct {
 	clipStartSet bool
 	// showHelp indicates if the help overlay is visible
 	showHelp bool
+	// showMessages indicates if the :messages history overlay is visible
+	showMessages bool
+	// messages is the notification history shown by :messages, oldest first
+	messages []components.Notification
+	// resultSetAt is when the footer result was last set
+	resultSetAt time.Time
 	// statsView holds the state for the stats view
 	statsView components.StatsViewState
 	// overlayEnabled indicates if the mpv overlay is enabled
@@ -82,12 +110,34 @@ type Model struct {
 	noteFormResult forms.NoteFormResult
 	// noteFormTimestamp is the timestamp captured when the note form was opened
 	noteFormTimestamp float64
+	// noteFormDuration is the video duration captured when the note form was
+	// opened, so the note still saves if mpv dies while the form is open
+	noteFormDuration float64
+	// noteFormEvent is the event type :event opened the note form for, whose
+	// category and duration it is pre-filled with (nil for a plain note)
+	noteFormEvent *db.EventType
+	// nextNoteEvent is the event type :event asks the next note form for
+	nextNoteEvent *db.EventType
 	// tackleForm is the huh form for tackle input (nil when inactive)
 	tackleForm *huh.Form
 	// tackleFormResult holds the bound values for the tackle form
 	tackleFormResult forms.TackleFormResult
 	// tackleFormTimestamp is the timestamp captured when the tackle form was opened
 	tackleFormTimestamp float64
+	// tackleFormDuration is the video duration captured when the tackle form was opened
+	tackleFormDuration float64
+	// commandTime is the playback position captured when command mode opened,
+	// used for events the command adds (nil outside command mode)
+	commandTime *float64
+	// defaults are the session's pre-filled form values, set with :default
+	defaults formDefaults
+	// recentPlayers are the players last tagged on the video, numbered in the
+	// open tackle form for picking with alt+1 onwards
+	recentPlayers []string
+
+	// lastTackle is the player and outcome of the last tackle added, pre-filled
+	// into the next tackle form when sticky_tackle is on
+	lastTackle stickyTackle
 	// confirmDiscardForm is shown when user presses Esc on a form with data (nil when inactive)
 	confirmDiscardForm *huh.Form
 	// confirmDiscard holds the confirm result (true = discard, false = go back)
@@ -112,6 +162,107 @@ type Model struct {
 	statusMsg string
 	// exportIndicator holds the current export progress state for Column 1
 	exportIndicator components.ExportIndicatorState
+	// possession holds the live possession/territory tracker state for Column 3
+	possession components.PossessionState
+	// suggester proposes candidate events for review (nil when not configured)
+	suggester suggest.Suggester
+	// pendingPlugin is the plugin :plugin started, until runPlugin runs it
+	pendingPlugin *pluginRun
+	// suggestions holds the review queue of suggested events
+	suggestions components.SuggestionQueueState
+	// clipsView holds the clip export view opened with Ctrl+E
+	clipsView components.ClipsViewState
+	// webhooks delivers note events to configured URLs (nil when none are configured)
+	webhooks *webhook.Notifier
+	// scripts holds user Lua commands and key handlers (nil when not loaded)
+	scripts *script.Engine
+	// config holds the settings loaded from config.json (nil until loaded)
+	config *config.Config
+	// keymap translates keys remapped in config.json (nil keeps the defaults)
+	keymap *keymap.Keymap
+	// commandAliases are config.json's command mode aliases, split into words
+	commandAliases map[string][]string
+	// dictionary holds the club terms note text is checked against as it is typed
+	dictionary *lint.Dictionary
+	// frameRamp accelerates Ctrl+H / Ctrl+L frame stepping while the key is held
+	frameRamp frameRamp
+	// shuttle holds the JKL shuttle mode state
+	shuttle shuttleState
+	// bookmarks are the current video's bookmarks, ordered by time
+	bookmarks []db.Bookmark
+	// bookmarkPending is true after ' while waiting for a bookmark number
+	bookmarkPending bool
+	// scratch are the session's unsaved scratch notes, in time order
+	scratch []scratchNote
+	// scratchSeq numbers scratch notes (~1, ~2, ...) for the session
+	scratchSeq int64
+	// lineup is the current video's lineup, by shirt number
+	lineup []db.LineupPlayer
+	// showLineup indicates if the lineup panel is visible (toggled with :lineup)
+	showLineup bool
+	// deletePending is the note x has asked to delete, waiting for a second x
+	deletePending int64
+	// commentaryForm is the huh form editing the match commentary (nil when inactive)
+	commentaryForm *huh.Form
+	// commentaryText is bound to the commentary form's text area
+	commentaryText string
+	// statsPanel holds the sort, filter and selection of the column 3 tackle table
+	statsPanel components.StatsPanelState
+	// trends holds the players' completion in earlier matches for the stats panel sparklines
+	trends trendState
+	// playerFilter limits the notes list to one player's events ("" shows all)
+	playerFilter string
+	// statsScope is the set of videos the stats view counts
+	statsScope statsScope
+	// categoryFilter limits the notes list to a category path and its
+	// subcategories ("" shows all)
+	categoryFilter string
+	// gameClock maps video time to match time from the video's periods
+	gameClock gameclock.Clock
+	// replay holds the session replay state (:replay)
+	replay replayState
+	// changes holds what changed since the previous session (:changes)
+	changes changesState
+	// tutorial holds the :tutorial walkthrough state
+	tutorial tutorialState
+	// pedal is the foot pedal or gamepad from config.json (nil when none)
+	pedal *controller.Device
+	// pedalButtons maps the pedal's button codes to actions
+	pedalButtons map[uint16]string
+	// midi is the MIDI controller from config.json (nil when none)
+	midi *controller.MIDIDevice
+	// remote is the HTTP action endpoint from config.json (nil when not running)
+	remote *remote.Server
+	// angles are the secondary camera angles linked to the video
+	angles []db.VideoAngle
+	// angleIndex is the angle playing: 0 for the main video, n for angles[n-1]
+	angleIndex int
+	// basePlayer is the unwrapped player, set once an angle switch has happened
+	basePlayer mpv.Player
+	// waveform holds the audio peaks drawn under the timeline (empty when not computed)
+	waveform db.Waveform
+	// showWaveform toggles the waveform strip (:waveform)
+	showWaveform bool
+	// chapters are the match periods and tries, pushed to mpv as chapters
+	chapters []mpv.Chapter
+	// chaptersPushed is false until chapters have been set on the playing file
+	chaptersPushed bool
+	// lastInput is when a key, mouse or controller input last arrived
+	lastInput time.Time
+	// slowTicking is true while the refresh is slowed because the video is paused
+	slowTicking bool
+	// tickGen counts slow spells, to drop slow ticks from an earlier one
+	tickGen int
+	// dirty is true when the model may have changed since View last rendered
+	dirty bool
+	// rendered is the last View output, reused while nothing has changed
+	rendered string
+	// crashLog holds the recent messages for a crash dump
+	crashLog crashLog
+	// crashed is set once a panic has been recovered; the TUI then quits
+	crashed *crashReport
+	// program is the running Bubble Tea program (nil in tests)
+	program *tea.Program
 }
 
 // newNoteVideo builds a NoteVideo with filesize and format populated from the filesystem.
@@ -120,7 +271,7 @@ func newNoteVideo(path string, duration float64) db.NoteVideo {
 	v := db.NoteVideo{
 		Path:     path,
 		Duration: duration,
-		Format:   strings.TrimPrefix(filepath.Ext(path), "."),
+		Format:   videosrc.Ext(path),
 	}
 	if info, err := os.Stat(path); err == nil {
 		v.Size = info.Size()
@@ -128,8 +279,17 @@ func newNoteVideo(path string, duration float64) db.NoteVideo {
 	return v
 }
 
+// captureDuration returns the video duration for a form being opened, falling
+// back to the last duration read by the status bar.
+func (m *Model) captureDuration() float64 {
+	if duration, err := m.client.GetDuration(); err == nil && duration > 0 {
+		return duration
+	}
+	return m.statusBar.Duration
+}
+
 // NewModel creates a new TUI model with the given mpv client, database connection, video path, and video ID.
-func NewModel(client *mpv.Client, db *sql.DB, videoPath string, videoID int64) *Model {
+func NewModel(client mpv.Player, db *sql.DB, videoPath string, videoID int64) *Model {
 	return &Model{
 		client:    client,
 		db:        db,
@@ -138,6 +298,9 @@ func NewModel(client *mpv.Client, db *sql.DB, videoPath string, videoID int64) *
 		statusBar: components.StatusBarState{
 			StepSize: defaultStepSize,
 		},
+		frameRamp: newFrameRamp(nil),
+		lastInput: time.Now(),
+		dirty:     true,
 	}
 }
 
@@ -145,21 +308,80 @@ func NewModel(client *mpv.Client, db *sql.DB, videoPath string, videoID int64) *
 func (m *Model) Init() tea.Cmd {
 	m.focus = FocusNotes
 	m.searchInput.Mode = "search"
-	// Start the ticker for polling mpv status
-	return tickCmd()
+	// Start the ticker for polling mpv status, and the suggester and controllers if configured
+	return tea.Batch(m.tickCmd(), m.runSuggester(), m.waitForPedal(), m.waitForMIDI(), m.waitForRemote())
 }
 
 // tickCmd returns a command that sends a tickMsg after the tick interval.
-func tickCmd() tea.Cmd {
-	return tea.Tick(tickInterval, func(t time.Time) tea.Msg {
+func (m *Model) tickCmd() tea.Cmd {
+	return tea.Tick(m.tickInterval(), func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
-// Update handles messages and updates the model state.
-func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// tickInterval returns the interval for polling mpv status while playing.
+func (m *Model) tickInterval() time.Duration {
+	if m.config == nil || m.config.TickMS <= 0 {
+		return defaultTickInterval
+	}
+	return time.Duration(m.config.TickMS) * time.Millisecond
+}
+
+// Update handles messages and updates the model state. A panic is recovered
+// into a crash dump and the TUI quits cleanly.
+func (m *Model) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	if m.crashed != nil {
+		return m, tea.Quit
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			m.crash(r)
+			model, cmd = m, tea.Quit
+		}
+	}()
+	m.crashLog.record(msg)
+	return m.update(msg)
+}
+
+// update handles a message; see Update.
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Input restores the fast refresh before being handled as usual
+	if wake := m.trackInput(msg); wake != nil {
+		model, cmd := m.update(msg)
+		return model, tea.Batch(wake, cmd)
+	}
+	// Slow ticks refresh like normal ticks, open form or not
+	if msg, ok := msg.(slowTickMsg); ok {
+		if !m.slowTicking || msg.gen != m.tickGen {
+			return m, nil
+		}
+		return m.update(tickMsg(time.Now()))
+	}
+	// Ticks re-render only when the refresh changes something; anything else may
+	if _, isTick := msg.(tickMsg); !isTick {
+		m.dirty = true
+	}
+	// Suggester results arrive asynchronously and must not be swallowed by an open form
+	if msg, ok := msg.(suggestionsMsg); ok {
+		return m.handleSuggestions(msg)
+	}
+	// Plugin results likewise arrive while a form may be open
+	if msg, ok := msg.(pluginMsg); ok {
+		return m.handlePlugin(msg)
+	}
+	// Pedal, MIDI and remote actions drive playback even while a form is open
+	if msg, ok := msg.(pedalMsg); ok {
+		return m.handlePedal(msg)
+	}
+	if msg, ok := msg.(midiMsg); ok {
+		return m.handleMIDI(msg)
+	}
+	if msg, ok := msg.(remoteMsg); ok {
+		return m.handleRemote(msg)
+	}
+
 	// Delegate all messages to active huh form (it needs non-key messages too)
-	if m.confirmDiscardForm != nil || m.noteForm != nil || m.tackleForm != nil {
+	if m.confirmDiscardForm != nil || m.noteForm != nil || m.tackleForm != nil || m.commentaryForm != nil {
 		if _, isKey := msg.(tea.KeyMsg); !isKey {
 			if _, isTick := msg.(tickMsg); !isTick {
 				if _, isClear := msg.(clearResultMsg); !isClear {
@@ -171,6 +393,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							if m.noteForm != nil {
 								return m.handleNoteFormUpdate(msg)
 							}
+							if m.commentaryForm != nil {
+								return m.handleCommentaryFormUpdate(msg)
+							}
 							return m.handleTackleFormUpdate(msg)
 						}
 					}
@@ -183,27 +408,48 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		if m.focus == FocusStats && !m.statsPanelVisible() {
+			m.focus = FocusNotes
+		}
 		return m, nil
 
 	case tickMsg:
+		before := m.snapshotRefreshed()
 		// Update status bar from mpv
 		m.updateStatusFromMpv()
+		m.statusBar.Chapter = m.currentChapter(m.statusBar.TimePos)
 		// Update overlay if enabled
 		if m.overlayEnabled {
 			m.updateOverlay()
 		}
 		// Refresh stats for column 3 periodically (every tick is fine, query is fast)
 		m.loadTackleStatsForPanel()
-		// Refresh export progress for the indicator in column 1
+		// Refresh export progress for the indicator in column 1 and the clip export view
 		m.refreshExportProgress()
+		m.refreshClipsView()
+		// Refresh possession percentages (open interval grows with playback)
+		m.refreshPossession()
 		// Refresh notes list to pick up clip status changes from background worker
 		m.loadNotesAndTackles()
-		// Continue ticking
-		return m, tickCmd()
+		m.updateSinceEvent()
+		// Re-surface replayed events; the closing summary clears like other statuses
+		replayFinished := m.advanceReplay() && !m.replay.Active
+		// Surface failed webhook deliveries
+		webhookFailed := !replayFinished && m.refreshWebhookErrors()
+		if m.snapshotRefreshed().differs(before) {
+			m.dirty = true
+		}
+		if replayFinished || webhookFailed {
+			return m, tea.Batch(m.nextTick(), tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+				return clearStatusMsg{}
+			}))
+		}
+		// Continue ticking, more slowly while paused
+		return m, m.nextTick()
 
 	case clearResultMsg:
 		// Clear the command result message
-		m.commandInput.ClearResult()
+		m.clearResult()
 		return m, nil
 
 	case clearStatusMsg:
@@ -211,6 +457,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// A delete waiting for confirmation is cancelled by any other key
+		if m.deletePending != 0 {
+			if key, _ := m.keymap.Translate(m.keyScope(), msg.String()); key != "x" && key != "X" {
+				m.deletePending = 0
+			}
+		}
+
+		// Inline note editor takes all keys while open
+		if m.notesList.Edit.Active {
+			return m.handleInlineEditKeys(msg)
+		}
+
 		// Unified Esc handler — covers all overlay/form/search dismissal in priority order
 		if msg.String() == "esc" {
 			if m.confirmDiscardForm != nil {
@@ -222,19 +480,43 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.tackleForm != nil {
 				return m.handleTackleFormUpdate(msg)
 			}
+			if m.commentaryForm != nil {
+				return m.handleCommentaryFormUpdate(msg)
+			}
 			if m.showHelp {
 				m.showHelp = false
 				return m, nil
 			}
+			if m.showMessages {
+				m.showMessages = false
+				return m, nil
+			}
 			if m.statsView.Active {
 				m.statsView.Active = false
 				return m, nil
 			}
+			if m.suggestions.Active {
+				m.suggestions.Active = false
+				return m, nil
+			}
+			if m.clipsView.Active {
+				m.clipsView.Active = false
+				return m, nil
+			}
 			if m.focus == FocusSearch {
 				m.searchInput.Clear()
 				m.focus = FocusNotes
 				return m, nil
 			}
+			if m.focus == FocusStats {
+				return m.handleStatsPanelKeys(msg)
+			}
+			if m.playerFilter != "" {
+				return m.setPlayerFilter("")
+			}
+			if m.categoryFilter != "" {
+				return m.clearCategoryFilter()
+			}
 		}
 
 		// Handle stats view input
@@ -242,6 +524,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleStatsViewInput(msg)
 		}
 
+		// Handle suggestion review queue input
+		if m.suggestions.Active && m.noteForm == nil && m.tackleForm == nil && m.confirmDiscardForm == nil {
+			return m.handleSuggestionKeys(msg)
+		}
+
+		// Handle clip export view input
+		if m.clipsView.Active && m.noteForm == nil && m.tackleForm == nil && m.confirmDiscardForm == nil {
+			return m.handleClipsViewKeys(msg)
+		}
+
 		// Handle confirm discard dialog (huh form)
 		if m.confirmDiscardForm != nil {
 			return m.handleConfirmDiscardUpdate(msg)
@@ -257,11 +549,32 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleTackleFormUpdate(msg)
 		}
 
+		// Handle commentary editor (huh form)
+		if m.commentaryForm != nil {
+			return m.handleCommentaryFormUpdate(msg)
+		}
+
 		// Handle command mode input
 		if m.commandInput.Active {
 			return m.handleCommandInput(msg)
 		}
 
+		// Stats panel player filter takes typed keys before global keys
+		if m.focus == FocusStats && m.statsPanel.FilterMode {
+			return m.handleStatsPanelFilterInput(msg)
+		}
+
+		// Keys remapped in config.json act as the default keys of their actions
+		if m.focus != FocusSearch {
+			key, ok := m.keymap.Translate(m.keyScope(), msg.String())
+			if !ok {
+				return m, nil
+			}
+			if key != msg.String() {
+				msg = keymap.KeyMsg(key)
+			}
+		}
+
 		// Tab / Shift+Tab: cycle matches when in search with matches, else cycle focus
 		switch msg.String() {
 		case "tab":
@@ -301,8 +614,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "s", "S":
 			if m.focus != FocusSearch && m.width >= 61 {
 				m.loadTackleStats()
+				if m.statsView.Timeline {
+					m.loadTimelineChart()
+				}
 				m.statsView.Active = true
-				return m, nil
+				m.numberBuffer = ""
+				m.lastKeyG = false
+				return m, m.advanceTutorial(tutorialStats, 0)
 			}
 		case "n", "N":
 			if m.focus != FocusSearch {
@@ -312,6 +630,72 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.focus != FocusSearch {
 				return m.openTackleInput()
 			}
+		case "c", "C":
+			if m.focus != FocusSearch {
+				return m.openCommentary()
+			}
+		case "w":
+			if m.focus != FocusSearch {
+				return m.openScratchInput()
+			}
+		case "ctrl+e":
+			if m.focus != FocusSearch {
+				return m.openClipExport()
+			}
+		}
+
+		// Bookmarks: b drops one, B jumps to the next, ' + digit jumps to one by number
+		if m.focus != FocusSearch {
+			key := msg.String()
+			if m.bookmarkPending {
+				m.bookmarkPending = false
+				if len(key) == 1 && key >= "1" && key <= "9" {
+					return m.bookmarkResult(m.jumpToBookmark(int(key[0] - '0')))
+				}
+				if key == "esc" {
+					return m, nil
+				}
+			}
+			switch key {
+			case "b":
+				return m.bookmarkResult(m.addBookmark())
+			case "B":
+				return m.bookmarkResult(m.jumpToNextBookmark())
+			case "'":
+				m.bookmarkPending = true
+				return m, nil
+			}
+		}
+
+		// Shuttle mode: Ctrl+S toggles; j/k/l shuttle instead of their usual actions
+		if m.focus != FocusSearch {
+			if msg.String() == "ctrl+s" {
+				m.toggleShuttle()
+				return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+					return clearStatusMsg{}
+				})
+			}
+			if m.shuttle.Active && m.handleShuttleKey(msg.String()) {
+				return m, nil
+			}
+		}
+
+		// Script key handlers (outside text input)
+		if m.focus != FocusSearch && m.scripts != nil && m.scripts.HasKey(msg.String()) {
+			return m.runScriptKey(msg.String())
+		}
+
+		// Player hotkeys: F1-F12 log a completed tackle, Shift+F1-F12 a missed one;
+		// a script binding the same key wins
+		if m.focus != FocusSearch {
+			if number, outcome, ok := playerHotkey(msg.String()); ok {
+				return m.logHotkeyTackle(number, outcome)
+			}
+		}
+
+		// Camera angles: a cycles through the linked angles
+		if m.focus != FocusSearch && msg.String() == "a" && len(m.angles) > 0 {
+			return m.showStatus(m.nextAngle())
 		}
 
 		// Focus-specific key routing
@@ -322,6 +706,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleVideoKeys(msg)
 		case FocusNotes:
 			return m.handleNotesKeys(msg)
+		case FocusStats:
+			return m.handleStatsPanelKeys(msg)
 		}
 	}
 
@@ -358,11 +744,13 @@ func (m *Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "esc":
 		m.searchInput.Clear()
 		m.focus = FocusNotes
+		m.commandTime = nil
 		return m, nil
 	case "backspace":
 		if m.searchInput.Mode == "command" && m.searchInput.Input == "" {
 			// Backspace on empty command input switches back to search mode
 			m.searchInput.Mode = "search"
+			m.commandTime = nil
 			return m, nil
 		}
 		m.searchInput.Backspace()
@@ -378,6 +766,8 @@ func (m *Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "enter":
 		if m.searchInput.Mode == "command" {
+			defer func() { m.commandTime = nil }()
+
 			// Execute command
 			cmd := m.searchInput.Input
 			m.searchInput.Clear()
@@ -385,7 +775,7 @@ func (m *Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if cmd != "" {
 				result, err := m.executeCommand(cmd)
 				if err != nil {
-					m.commandInput.SetResult("Error: "+err.Error(), true)
+					m.setResult("Error: "+err.Error(), true)
 					return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 						return clearResultMsg{}
 					})
@@ -396,7 +786,13 @@ func (m *Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if result == "OPEN_TACKLE_INPUT" {
 					return m.openTackleInput()
 				}
-				m.commandInput.SetResult(result, false)
+				if result == "OPEN_COMMENTARY" {
+					return m.openCommentary()
+				}
+				if result == "RUN_PLUGIN" {
+					return m, m.runPlugin()
+				}
+				m.setResult(result, false)
 				return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 					return clearResultMsg{}
 				})
@@ -407,6 +803,7 @@ func (m *Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Check for : to switch to command mode when input is empty
 		if msg.String() == ":" && m.searchInput.Input == "" && m.searchInput.Mode == "search" {
 			m.searchInput.Mode = "command"
+			m.captureCommandTime()
 			return m, nil
 		}
 		// Insert printable characters
@@ -424,13 +821,7 @@ func (m *Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) handleVideoKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case " ":
-		if m.client != nil && m.client.IsConnected() {
-			if err := m.client.TogglePause(); err == nil {
-				if timePos, tpErr := m.client.GetTimePos(); tpErr == nil && m.videoID > 0 {
-					_ = db.UpdateVideoTimingStopped(m.db, m.videoID, timePos)
-				}
-			}
-		}
+		m.togglePause()
 		return m, nil
 	case "m", "M":
 		if m.client != nil && m.client.IsConnected() {
@@ -441,24 +832,16 @@ func (m *Model) handleVideoKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case "ctrl+h":
-		if m.client != nil && m.client.IsConnected() {
-			_ = m.client.FrameBackStep()
-		}
+		m.frameStep(msg.String(), -1)
 		return m, nil
 	case "ctrl+l":
-		if m.client != nil && m.client.IsConnected() {
-			_ = m.client.FrameStep()
-		}
+		m.frameStep(msg.String(), 1)
 		return m, nil
 	case "h", "H":
-		if m.client != nil && m.client.IsConnected() {
-			_ = m.client.SeekRelative(-m.statusBar.StepSize)
-		}
+		m.seekStep(-1)
 		return m, nil
 	case "l", "L":
-		if m.client != nil && m.client.IsConnected() {
-			_ = m.client.SeekRelative(m.statusBar.StepSize)
-		}
+		m.seekStep(1)
 		return m, nil
 	case "<", ",":
 		m.decreaseStepSize()
@@ -466,6 +849,12 @@ func (m *Model) handleVideoKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case ">", ".":
 		m.increaseStepSize()
 		return m, nil
+	case "p":
+		return m.possessionKeyResult(m.togglePossession)
+	case "P":
+		return m.possessionKeyResult(m.endPossession)
+	case "y", "Y":
+		return m.possessionKeyResult(m.flipTerritory)
 	case "o", "O":
 		m.overlayEnabled = !m.overlayEnabled
 		m.statusBar.OverlayEnabled = m.overlayEnabled
@@ -496,6 +885,13 @@ func (m *Model) handleNotesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Scratch notes aren't saved, so keys acting on saved events don't apply
+	if handled, model, cmd := m.handleScratchKey(key); handled {
+		m.numberBuffer = ""
+		m.lastKeyG = false
+		return model, cmd
+	}
+
 	switch key {
 	case "g":
 		if m.lastKeyG {
@@ -544,10 +940,26 @@ func (m *Model) handleNotesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.numberBuffer = ""
 		m.lastKeyG = false
 		return m.openEditTackleInput()
+	case "i":
+		m.numberBuffer = ""
+		m.lastKeyG = false
+		return m.openInlineEdit()
 	case "x", "X":
 		m.numberBuffer = ""
 		m.lastKeyG = false
 		return m.deleteSelectedItem()
+	case "p":
+		m.numberBuffer = ""
+		m.lastKeyG = false
+		return m.pinSelectedItem(true)
+	case "P":
+		m.numberBuffer = ""
+		m.lastKeyG = false
+		return m.pinSelectedItem(false)
+	case "f":
+		m.numberBuffer = ""
+		m.lastKeyG = false
+		return m.filterBySelectedPlayer()
 	case ":":
 		m.numberBuffer = ""
 		m.lastKeyG = false
@@ -555,11 +967,21 @@ func (m *Model) handleNotesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.commandInput.Input = ""
 		m.commandInput.CursorPos = 0
 		m.commandInput.ClearResult()
+		m.captureCommandTime()
 		return m, nil
 	case "ctrl+r":
 		m.numberBuffer = ""
 		m.lastKeyG = false
 		return m.startRegenerateClip()
+	case "r":
+		// nr: follow the selected event's nth reference
+		n, err := strconv.Atoi(m.numberBuffer)
+		if err != nil || n < 1 {
+			n = 1
+		}
+		m.numberBuffer = ""
+		m.lastKeyG = false
+		return m.followRef(n)
 	case "esc":
 		m.numberBuffer = ""
 		m.lastKeyG = false
@@ -591,15 +1013,19 @@ func (m *Model) handleCommandInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "esc":
 		// Cancel command mode
 		m.commandInput.Clear()
+		m.commandTime = nil
 		return m, nil
 
 	case "enter":
+		// Events added by the command are stamped with the time captured at ':'
+		defer func() { m.commandTime = nil }()
+
 		// Execute command
 		cmd := m.commandInput.GetCommand()
 		if cmd != "" {
 			result, err := m.executeCommand(cmd)
 			if err != nil {
-				m.commandInput.SetResult("Error: "+err.Error(), true)
+				m.setResult("Error: "+err.Error(), true)
 				return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 					return clearResultMsg{}
 				})
@@ -613,7 +1039,25 @@ func (m *Model) handleCommandInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.commandInput.Clear()
 				return m.openTackleInput()
 			}
-			m.commandInput.SetResult(result, false)
+			if result == "OPEN_COMMENTARY" {
+				m.commandInput.Clear()
+				return m.openCommentary()
+			}
+			if result == "OPEN_SUGGESTIONS" {
+				m.commandInput.Clear()
+				m.suggestions.Active = true
+				return m, nil
+			}
+			if result == "RUN_SUGGESTER" {
+				m.commandInput.Clear()
+				m.suggestions.Active = true
+				return m, m.runSuggester()
+			}
+			if result == "RUN_PLUGIN" {
+				m.commandInput.Clear()
+				return m, m.runPlugin()
+			}
+			m.setResult(result, false)
 			// Schedule clearing the result message
 			return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 				return clearResultMsg{}
@@ -652,20 +1096,23 @@ func (m *Model) handleCommandInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // openNoteInput opens the huh note form.
 func (m *Model) openNoteInput() (tea.Model, tea.Cmd) {
+	// Only the form :event opens is for an event type
+	event := m.nextNoteEvent
+	m.nextNoteEvent = nil
 	if m.width < 61 {
 		return m, nil
 	}
 	if m.client == nil || !m.client.IsConnected() {
-		m.commandInput.SetResult("Not connected to mpv", true)
+		m.setResult("Not connected to mpv", true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
 	}
 
-	// Get current timestamp from mpv
-	timestamp, err := m.client.GetTimePos()
+	// Timestamp from the keypress or command that opened the form
+	timestamp, err := m.eventTimestamp()
 	if err != nil {
-		m.commandInput.SetResult("Failed to get timestamp: "+err.Error(), true)
+		m.setResult("Failed to get timestamp: "+err.Error(), true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
@@ -675,15 +1122,21 @@ func (m *Model) openNoteInput() (tea.Model, tea.Cmd) {
 	}
 
 	// Initialize huh note form
-	m.noteFormResult = forms.NoteFormResult{}
+	m.noteFormEvent = event
+	m.noteFormResult = m.noteFormPrefill()
 	m.noteFormTimestamp = timestamp
-	m.noteForm = forms.NewNoteForm(timestamp, &m.noteFormResult)
+	m.noteFormDuration = m.captureDuration()
+	m.noteForm = forms.NewNoteForm(&m.noteFormTimestamp, &m.noteFormResult, m.snippetTemplates(), m.checkTerms, m.noteTemplates(), m.formSuggestions())
 
 	return m, m.noteForm.Init()
 }
 
 // handleNoteFormUpdate delegates messages to the huh note form and handles completion.
 func (m *Model) handleNoteFormUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+t" {
+		m.recaptureFormTime(&m.noteFormTimestamp)
+	}
+
 	form, cmd := m.noteForm.Update(msg)
 	if f, ok := form.(*huh.Form); ok {
 		m.noteForm = f
@@ -695,7 +1148,7 @@ func (m *Model) handleNoteFormUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 	if m.noteForm.State == huh.StateAborted {
 		// If form has data, show confirm discard dialog
-		if m.noteFormResult.HasData() {
+		if m.noteFormResult != m.noteFormPrefill() {
 			return m.openConfirmDiscard("note")
 		}
 		m.noteForm = nil
@@ -708,26 +1161,31 @@ func (m *Model) handleNoteFormUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 // saveNoteFromForm saves the note data from the completed huh form.
 func (m *Model) saveNoteFromForm() (tea.Model, tea.Cmd) {
 	result := m.noteFormResult
+	m.spellNote(&result)
 	timestamp := m.noteFormTimestamp
-
-	// Get video duration for video child record
-	duration, _ := m.client.GetDuration()
+	// Captured at form open: mpv may have gone away since
+	duration := m.noteFormDuration
+	length, _ := forms.ParseDuration(result.Duration)
 
 	// Build children
 	children := db.NoteChildren{
 		Timings: []db.NoteTiming{
-			{Start: timestamp, End: timestamp},
+			{Start: timestamp, End: timestamp + length},
 		},
 		Videos: []db.NoteVideo{
 			newNoteVideo(m.videoPath, duration),
 		},
-		Details: []db.NoteDetail{
-			{Type: "text", Note: result.Text},
-		},
 	}
+	// Text is optional for events tagged by their fields
+	text := expandNoteSnippet(result)
+	if text != "" {
+		children.Details = append(children.Details, db.NoteDetail{Type: "text", Note: text})
+	}
+	targets := m.noteRefTargets(text)
+	children.Details = append(children.Details, m.templateDetails(result)...)
 
 	// Use category from input, default to "note"
-	category := result.Category
+	category := catpath.Normalize(result.Category)
 	if category == "" {
 		category = "note"
 	}
@@ -737,18 +1195,21 @@ func (m *Model) saveNoteFromForm() (tea.Model, tea.Cmd) {
 	m.noteForm = nil
 
 	if err != nil {
-		m.commandInput.SetResult("Error: "+err.Error(), true)
+		m.setResult("Error: "+err.Error(), true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
 	}
 
+	m.saveNoteRefs(noteID, text, targets)
+	m.notifyWebhooks(webhook.NoteCreated, noteID, category, children)
+
 	// Reload list and show confirmation
 	m.loadNotesAndTackles()
-	m.commandInput.SetResult(fmt.Sprintf("Note %d added at %s", noteID, timeutil.FormatTime(timestamp)), false)
-	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+	m.setResult(fmt.Sprintf("Note %d added at %s", noteID, timeutil.FormatTime(timestamp)), false)
+	return m, tea.Batch(m.advanceTutorial(tutorialNote, noteID), tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 		return clearResultMsg{}
-	})
+	}))
 }
 
 // openTackleInput opens the huh tackle wizard form.
@@ -757,16 +1218,16 @@ func (m *Model) openTackleInput() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	if m.client == nil || !m.client.IsConnected() {
-		m.commandInput.SetResult("Not connected to mpv", true)
+		m.setResult("Not connected to mpv", true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
 	}
 
-	// Get current timestamp from mpv
-	timestamp, err := m.client.GetTimePos()
+	// Timestamp from the keypress or command that opened the form
+	timestamp, err := m.eventTimestamp()
 	if err != nil {
-		m.commandInput.SetResult("Failed to get timestamp: "+err.Error(), true)
+		m.setResult("Failed to get timestamp: "+err.Error(), true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
@@ -776,9 +1237,10 @@ func (m *Model) openTackleInput() (tea.Model, tea.Cmd) {
 	}
 
 	// Initialize huh tackle form
-	m.tackleFormResult = forms.TackleFormResult{}
+	m.tackleFormResult = m.tackleFormPrefill()
 	m.tackleFormTimestamp = timestamp
-	m.tackleForm = forms.NewTackleForm(timestamp, &m.tackleFormResult)
+	m.tackleFormDuration = m.captureDuration()
+	m.tackleForm = forms.NewTackleForm(&m.tackleFormTimestamp, &m.tackleFormResult, m.hasStickyTackle(), m.tackleFormSuggestions())
 
 	return m, m.tackleForm.Init()
 }
@@ -787,7 +1249,7 @@ func (m *Model) openTackleInput() (tea.Model, tea.Cmd) {
 func (m *Model) openEditTackleInput() (tea.Model, tea.Cmd) {
 	item := m.notesList.GetSelectedItem()
 	if item == nil {
-		m.commandInput.SetResult("No item selected", true)
+		m.setResult("No item selected", true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
@@ -795,7 +1257,7 @@ func (m *Model) openEditTackleInput() (tea.Model, tea.Cmd) {
 
 	// Only tackles can be edited
 	if item.Type != components.ItemTypeTackle {
-		m.commandInput.SetResult("Edit not supported for notes", true)
+		m.setResult("Edit not supported for notes", true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
@@ -804,12 +1266,21 @@ func (m *Model) openEditTackleInput() (tea.Model, tea.Cmd) {
 	// Load existing data from database
 	data, err := db.LoadNoteForEdit(m.db, item.ID)
 	if err != nil {
-		m.commandInput.SetResult("Error: "+err.Error(), true)
+		m.setResult("Error: "+err.Error(), true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
 	}
 
+	// References show the events' current numbers, resolved again on save
+	if details, err := db.SelectNoteDetailsByNote(m.db, item.ID); err == nil {
+		for _, d := range details {
+			if d.Type == "notes" {
+				data.Notes = m.detailText(d)
+			}
+		}
+	}
+
 	// Map db.EditTackleData to forms.EditTackleFormResult
 	m.editTackleFormResult = forms.EditTackleFormResult{
 		TackleFormResult: forms.TackleFormResult{
@@ -827,13 +1298,25 @@ func (m *Model) openEditTackleInput() (tea.Model, tea.Cmd) {
 
 	m.editingNoteID = item.ID
 	m.tackleFormTimestamp = data.Timestamp
-	m.tackleForm = forms.NewEditTackleForm(data.Timestamp, data.EndSeconds, &m.editTackleFormResult)
+	m.tackleForm = forms.NewEditTackleForm(data.Timestamp, data.EndSeconds, &m.editTackleFormResult, m.formSuggestions())
 
 	return m, m.tackleForm.Init()
 }
 
 // handleTackleFormUpdate delegates messages to the huh tackle form and handles completion.
 func (m *Model) handleTackleFormUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+x" && m.editingNoteID == 0 && m.hasStickyTackle() {
+		return m, m.clearStickyTackle()
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+t" && m.editingNoteID == 0 {
+		m.recaptureFormTime(&m.tackleFormTimestamp)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.editingNoteID == 0 {
+		if cmd, picked := m.pickRecentPlayer(keyMsg.String()); picked {
+			return m, cmd
+		}
+	}
+
 	form, cmd := m.tackleForm.Update(msg)
 	if f, ok := form.(*huh.Form); ok {
 		m.tackleForm = f
@@ -852,7 +1335,7 @@ func (m *Model) handleTackleFormUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.editingNoteID > 0 {
 			hasData = m.editTackleFormResult.HasData()
 		} else {
-			hasData = m.tackleFormResult.HasData()
+			hasData = m.tackleFormResult.ChangedFrom(m.tackleFormPrefill())
 		}
 		if hasData {
 			return m.openConfirmDiscard("tackle")
@@ -895,7 +1378,7 @@ func (m *Model) handleConfirmDiscardUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// User chose to go back — reopen the form from saved state
 		if m.confirmDiscardTarget == "note" {
-			m.noteForm = forms.NewNoteForm(m.noteFormTimestamp, &m.noteFormResult)
+			m.noteForm = forms.NewNoteForm(&m.noteFormTimestamp, &m.noteFormResult, m.snippetTemplates(), m.checkTerms, m.noteTemplates(), m.formSuggestions())
 			return m, m.noteForm.Init()
 		}
 		return m, m.reopenTackleForm()
@@ -905,7 +1388,7 @@ func (m *Model) handleConfirmDiscardUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Esc on confirm dialog — treat as "go back" to form
 		m.confirmDiscardForm = nil
 		if m.confirmDiscardTarget == "note" {
-			m.noteForm = forms.NewNoteForm(m.noteFormTimestamp, &m.noteFormResult)
+			m.noteForm = forms.NewNoteForm(&m.noteFormTimestamp, &m.noteFormResult, m.snippetTemplates(), m.checkTerms, m.noteTemplates(), m.formSuggestions())
 			return m, m.noteForm.Init()
 		}
 		return m, m.reopenTackleForm()
@@ -920,12 +1403,12 @@ func (m *Model) reopenTackleForm() tea.Cmd {
 		// Save current user-edited values before NewEditTackleForm overwrites them
 		savedTimestamp := m.editTackleFormResult.Timestamp
 		savedEndSeconds := m.editTackleFormResult.EndSeconds
-		m.tackleForm = forms.NewEditTackleForm(m.tackleFormTimestamp, 0, &m.editTackleFormResult)
+		m.tackleForm = forms.NewEditTackleForm(m.tackleFormTimestamp, 0, &m.editTackleFormResult, m.formSuggestions())
 		// Restore user's values
 		m.editTackleFormResult.Timestamp = savedTimestamp
 		m.editTackleFormResult.EndSeconds = savedEndSeconds
 	} else {
-		m.tackleForm = forms.NewTackleForm(m.tackleFormTimestamp, &m.tackleFormResult)
+		m.tackleForm = forms.NewTackleForm(&m.tackleFormTimestamp, &m.tackleFormResult, m.hasStickyTackle(), m.tackleFormSuggestions())
 	}
 	return m.tackleForm.Init()
 }
@@ -934,18 +1417,21 @@ func (m *Model) reopenTackleForm() tea.Cmd {
 func (m *Model) saveTackleFromForm() (tea.Model, tea.Cmd) {
 	result := m.tackleFormResult
 	timestamp := m.tackleFormTimestamp
+	m.spellTackle(&result)
+	result.Player = m.resolvePlayer(result.Player)
 
 	// Parse attempt as integer
 	var attempt int
 	fmt.Sscanf(result.Attempt, "%d", &attempt)
 
-	// Get video duration for video child record
-	duration, _ := m.client.GetDuration()
+	// Captured at form open: mpv may have gone away since
+	duration := m.tackleFormDuration
+	length, _ := forms.ParseDuration(result.Duration)
 
 	// Build children
 	children := db.NoteChildren{
 		Timings: []db.NoteTiming{
-			{Start: timestamp, End: timestamp},
+			{Start: timestamp, End: timestamp + length},
 		},
 		Videos: []db.NoteVideo{
 			newNoteVideo(m.videoPath, duration),
@@ -984,39 +1470,46 @@ func (m *Model) saveTackleFromForm() (tea.Model, tea.Cmd) {
 	}
 
 	// Category is always "tackle" — auto-set, not a form field
+	targets := m.noteRefTargets(result.Notes)
 	noteID, err := db.InsertNoteWithChildren(m.db, "tackle", children)
 	m.tackleForm = nil
 
 	if err != nil {
-		m.commandInput.SetResult("Error: "+err.Error(), true)
+		m.setResult("Error: "+err.Error(), true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
 	}
 
+	m.saveNoteRefs(noteID, result.Notes, targets)
+	m.notifyWebhooks(webhook.TackleCreated, noteID, "tackle", children)
+	m.lastTackle = stickyTackle{Player: result.Player, Outcome: result.Outcome}
+
 	// Reload list and show confirmation
 	m.loadNotesAndTackles()
 	starSymbol := ""
 	if result.Star {
 		starSymbol = " ★"
 	}
-	m.commandInput.SetResult(fmt.Sprintf("Tackle %d recorded: %s %s%s", noteID, result.Player, result.Outcome, starSymbol), false)
-	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+	m.setResult(fmt.Sprintf("Tackle %d recorded: %s %s%s", noteID, result.Player, result.Outcome, starSymbol), false)
+	return m, tea.Batch(m.advanceTutorial(tutorialTackle, noteID), tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 		return clearResultMsg{}
-	})
+	}))
 }
 
 // saveEditTackleFromForm saves the edited tackle data from the completed edit form.
 func (m *Model) saveEditTackleFromForm() (tea.Model, tea.Cmd) {
 	result := m.editTackleFormResult
 	noteID := m.editingNoteID
+	m.spellTackle(&result.TackleFormResult)
+	result.Player = m.resolvePlayer(result.Player)
 
 	// Parse timestamp from the form
 	timestamp, err := timeutil.ParseTimeToSeconds(result.Timestamp)
 	if err != nil {
 		m.tackleForm = nil
 		m.editingNoteID = 0
-		m.commandInput.SetResult("Error: invalid timestamp", true)
+		m.setResult("Error: invalid timestamp", true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
@@ -1066,12 +1559,17 @@ func (m *Model) saveEditTackleFromForm() (tea.Model, tea.Cmd) {
 			{Type: "star"},
 		}
 	}
+	// Keep the pin, which the form doesn't edit
+	if m.isPinned(noteID) {
+		children.Highlights = append(children.Highlights, db.NoteHighlight{Type: pinHighlight})
+	}
 
 	// Update children in database
+	targets := m.noteRefTargets(result.Notes)
 	if err := db.UpdateNoteWithChildren(m.db, noteID, children); err != nil {
 		m.tackleForm = nil
 		m.editingNoteID = 0
-		m.commandInput.SetResult("Error: "+err.Error(), true)
+		m.setResult("Error: "+err.Error(), true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
@@ -1081,20 +1579,37 @@ func (m *Model) saveEditTackleFromForm() (tea.Model, tea.Cmd) {
 	if err := db.UpdateNoteTiming(m.db, noteID, timestamp, timestamp+endSeconds); err != nil {
 		m.tackleForm = nil
 		m.editingNoteID = 0
-		m.commandInput.SetResult("Error: "+err.Error(), true)
+		m.setResult("Error: "+err.Error(), true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
 	}
+	m.saveNoteRefs(noteID, result.Notes, targets)
 
 	m.tackleForm = nil
 	m.editingNoteID = 0
 
+	// Notify webhooks when the edit newly stars the tackle
+	if result.Star {
+		wasStarred := false
+		for _, item := range m.notesList.Items {
+			if item.ID == noteID {
+				wasStarred = item.Starred
+				break
+			}
+		}
+		if !wasStarred {
+			children.Timings = []db.NoteTiming{{Start: timestamp, End: timestamp + endSeconds}}
+			children.Videos = []db.NoteVideo{{Path: m.videoPath}}
+			m.notifyWebhooks(webhook.NoteStarred, noteID, "tackle", children)
+		}
+	}
+
 	// Reload list and stats
 	m.loadNotesAndTackles()
 	m.loadTackleStatsForPanel()
 
-	m.commandInput.SetResult(fmt.Sprintf("Updated tackle %d", noteID), false)
+	m.setResult(fmt.Sprintf("Updated tackle %d", noteID), false)
 	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 		return clearResultMsg{}
 	})
@@ -1109,6 +1624,11 @@ func (m *Model) executeCommand(cmdStr string) (string, error) {
 		return "", nil
 	}
 
+	// Aliases from config.json stand for a command and its leading arguments
+	if words, ok := m.commandAliases[parts[0]]; ok {
+		parts = append(append([]string{}, words...), parts[1:]...)
+	}
+
 	cmd := parts[0]
 	args := parts[1:]
 
@@ -1119,6 +1639,12 @@ func (m *Model) executeCommand(cmdStr string) (string, error) {
 		return m.executeClipCommand(args)
 	case "tackle":
 		return m.executeTackleCommand(args)
+	case "possession", "territory":
+		return m.executePossessionCommand(cmd, args)
+	case "suggest":
+		return m.executeSuggestCommand(args)
+	case "plugin":
+		return m.executePluginCommand(args)
 	// Shorthand commands
 	case "nn":
 		return m.executeShorthandNoteCommand(args)
@@ -1154,6 +1680,11 @@ func (m *Model) executeCommand(cmdStr string) (string, error) {
 			return "Muted", nil
 		}
 		return "Unmuted", nil
+	case "goto":
+		if len(args) < 1 {
+			return "", fmt.Errorf("goto requires an event number or note ID (e.g., goto #17)")
+		}
+		return m.gotoNote(args[0])
 	case "seek":
 		if len(args) < 1 {
 			return "", fmt.Errorf("seek requires a time argument (e.g., seek 1:11:22 or seek 1:30 or seek 90)")
@@ -1182,12 +1713,61 @@ func (m *Model) executeCommand(cmdStr string) (string, error) {
 			return "", err
 		}
 		return fmt.Sprintf("Speed set to %.1fx", speed), nil
+	case "commentary":
+		return "OPEN_COMMENTARY", nil
+	case "bookmark", "bm":
+		return m.executeBookmarkCommand(args)
+	case "shuttle":
+		m.toggleShuttle()
+		return m.statusMsg, nil
+	case "gameclock", "gc":
+		return m.toggleGameClock()
+	case "replay":
+		return m.executeReplayCommand(args)
+	case "changes":
+		return m.executeChangesCommand()
+	case "tutorial":
+		return m.executeTutorialCommand(args)
+	case "lineup":
+		return m.executeLineupCommand(args)
+	case "waveform", "wf":
+		return m.toggleWaveform()
+	case "sticky":
+		return m.toggleStickyTackle()
+	case "scratch", "sc":
+		return m.executeScratchCommand(args)
+	case "crossfilter", "xf":
+		return m.toggleCrossFilter()
+	case "category", "cat":
+		return m.executeCategoryCommand(args)
+	case "event", "ev":
+		return m.executeEventCommand(args)
+	case "default", "def":
+		return m.executeDefaultCommand(args)
+	case "messages", "msg":
+		return m.toggleMessages()
+	case "snippet", "sn":
+		return m.executeSnippetCommand(args)
 	case "q", "quit":
 		m.quitting = true
 		return "", nil
 	case "help", "h":
-		return "Commands: note add/list/goto, clip start/end/list/play/stop, tackle add/list, pause, play, mute, seek, speed, quit", nil
+		help := "Commands: " + strings.Join(helpdoc.CommandNames(), ", ")
+		if m.scripts != nil && len(m.scripts.Commands()) > 0 {
+			help += " | Scripts: " + strings.Join(m.scripts.Commands(), ", ")
+		}
+		return help, nil
 	default:
+		if m.scripts != nil && m.scripts.HasCommand(cmd) {
+			result, err := m.scripts.RunCommand(cmd, args)
+			if err != nil {
+				return "", fmt.Errorf("%s", firstLine(err.Error()))
+			}
+			if result == "" {
+				result = cmd + " done"
+			}
+			return result, nil
+		}
 		return "", fmt.Errorf("unknown command: %s", cmd)
 	}
 }
@@ -1220,13 +1800,9 @@ func (m *Model) executeNoteCommand(args []string) (string, error) {
 
 	case "goto":
 		if len(subargs) == 0 {
-			return "", fmt.Errorf("note goto requires note ID")
-		}
-		var noteID int64
-		if _, err := fmt.Sscanf(subargs[0], "%d", &noteID); err != nil {
-			return "", fmt.Errorf("invalid note ID: %s", subargs[0])
+			return "", fmt.Errorf("note goto requires note ID or #event number")
 		}
-		return m.gotoNote(noteID)
+		return m.gotoNote(subargs[0])
 
 	default:
 		return "", fmt.Errorf("unknown note subcommand: %s", subcmd)
@@ -1349,6 +1925,9 @@ func (m *Model) executeTackleCommand(args []string) (string, error) {
 		if player == "" {
 			return "", fmt.Errorf("tackle add requires --player")
 		}
+		if team == "" {
+			team = m.defaults.Team
+		}
 		if team == "" {
 			return "", fmt.Errorf("tackle add requires --team")
 		}
@@ -1391,12 +1970,16 @@ func (m *Model) executeShorthandNoteCommand(args []string) (string, error) {
 // executeShorthandTackleCommand handles the :nt shorthand command.
 // With no args, it opens the quick tackle input prompt (same as T key).
 // With 4 positional args, it adds a tackle: :nt <player> <team> <attempt> <outcome>
+// The team can be left out when a default team is set with :default.
 // With partial args, it shows a usage hint.
 func (m *Model) executeShorthandTackleCommand(args []string) (string, error) {
 	if len(args) == 0 {
 		// No args - open quick tackle input prompt
 		return "OPEN_TACKLE_INPUT", nil
 	}
+	if len(args) == 3 && m.defaults.Team != "" {
+		args = []string{args[0], m.defaults.Team, args[1], args[2]}
+	}
 	if len(args) != 4 {
 		return "", fmt.Errorf("usage: :nt <player> <team> <attempt> <outcome>")
 	}
@@ -1413,12 +1996,32 @@ func (m *Model) executeShorthandTackleCommand(args []string) (string, error) {
 
 // addNote adds a note at the current timestamp.
 func (m *Model) addNote(text, category, _, _ string) (string, error) {
-	timestamp, err := m.client.GetTimePos()
+	if err := m.checkNoteRefs(text); err != nil {
+		return "", err
+	}
+	timestamp, err := m.eventTimestamp()
 	if err != nil {
 		return "", fmt.Errorf("failed to get timestamp: %w", err)
 	}
 
-	duration, _ := m.client.GetDuration()
+	noteID, err := m.insertNote(timestamp, text, category)
+	if err != nil {
+		return "", err
+	}
+
+	// Reload notes list
+	m.loadNotesAndTackles()
+
+	return fmt.Sprintf("Note %d added at %s", noteID, timeutil.FormatTime(timestamp)), nil
+}
+
+// insertNote saves a note with optional text at timestamp, category "note"
+// when none is given, and notifies webhooks. The caller reloads the list.
+func (m *Model) insertNote(timestamp float64, text, category string) (int64, error) {
+	var duration float64
+	if m.client != nil {
+		duration, _ = m.client.GetDuration()
+	}
 
 	children := db.NoteChildren{
 		Timings: []db.NoteTiming{
@@ -1439,15 +2042,14 @@ func (m *Model) addNote(text, category, _, _ string) (string, error) {
 		category = "note"
 	}
 
+	targets := m.noteRefTargets(text)
 	noteID, err := db.InsertNoteWithChildren(m.db, category, children)
 	if err != nil {
-		return "", fmt.Errorf("failed to insert note: %w", err)
+		return 0, fmt.Errorf("failed to insert note: %w", err)
 	}
-
-	// Reload notes list
-	m.loadNotesAndTackles()
-
-	return fmt.Sprintf("Note %d added at %s", noteID, timeutil.FormatTime(timestamp)), nil
+	m.saveNoteRefs(noteID, text, targets)
+	m.notifyWebhooks(webhook.NoteCreated, noteID, category, children)
+	return noteID, nil
 }
 
 // countNotes counts notes for the current video.
@@ -1464,8 +2066,39 @@ func (m *Model) countNotes() (int, error) {
 	return count, rows.Err()
 }
 
-// gotoNote seeks to a note's timestamp.
-func (m *Model) gotoNote(noteID int64) (string, error) {
+// resolveNoteRef returns the note ID a command refers to: "#17" is the
+// video's 17th event in time order, a plain number a note ID.
+func (m *Model) resolveNoteRef(ref string) (int64, error) {
+	if num, ok := strings.CutPrefix(ref, "#"); ok {
+		n, err := strconv.Atoi(num)
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid event number: %s", ref)
+		}
+		noteID, err := db.SelectNoteIDByNumber(m.db, m.videoPath, n)
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("no event #%d in this video", n)
+		}
+		return noteID, err
+	}
+	var noteID int64
+	if _, err := fmt.Sscanf(ref, "%d", &noteID); err != nil {
+		return 0, fmt.Errorf("invalid note ID: %s", ref)
+	}
+	return noteID, nil
+}
+
+// gotoNote seeks to the timestamp of the note ref refers to, by event number
+// or note ID.
+func (m *Model) gotoNote(ref string) (string, error) {
+	noteID, err := m.resolveNoteRef(ref)
+	if err != nil {
+		return "", err
+	}
+	label := fmt.Sprintf("note %d", noteID)
+	if strings.HasPrefix(ref, "#") {
+		label = fmt.Sprintf("%s (note %d)", ref, noteID)
+	}
+
 	// Check note exists
 	note, err := db.SelectNoteByID(m.db, noteID)
 	if err == sql.ErrNoRows {
@@ -1496,7 +2129,7 @@ func (m *Model) gotoNote(noteID int64) (string, error) {
 		}
 	}
 
-	return fmt.Sprintf("Jumped to note %d [%s]: %s", note.ID, note.Category, textStr), nil
+	return fmt.Sprintf("Jumped to %s [%s]: %s", label, note.Category, textStr), nil
 }
 
 // addClip adds a clip to the database.
@@ -1572,12 +2205,13 @@ func (m *Model) addTackle(player, _ string, attempt int, outcome string) (string
 		return "", fmt.Errorf("invalid outcome '%s': must be missed, completed, possible, or other", outcome)
 	}
 
-	timestamp, err := m.client.GetTimePos()
+	timestamp, err := m.eventTimestamp()
 	if err != nil {
 		return "", fmt.Errorf("failed to get timestamp: %w", err)
 	}
 
 	duration, _ := m.client.GetDuration()
+	player = m.resolvePlayer(player)
 
 	children := db.NoteChildren{
 		Timings: []db.NoteTiming{
@@ -1595,6 +2229,8 @@ func (m *Model) addTackle(player, _ string, attempt int, outcome string) (string
 	if err != nil {
 		return "", fmt.Errorf("failed to insert tackle: %w", err)
 	}
+	m.notifyWebhooks(webhook.TackleCreated, noteID, "tackle", children)
+	m.lastTackle = stickyTackle{Player: player, Outcome: outcome}
 
 	// Reload notes list
 	m.loadNotesAndTackles()
@@ -1619,19 +2255,47 @@ func (m *Model) countTackles() (int, error) {
 	return count, rows.Err()
 }
 
-// deleteSelectedItem deletes the currently selected item from the database and refreshes the list.
+// deleteSelectedItem deletes the currently selected item from the database and
+// refreshes the list. The first press shows what will be deleted (child
+// records) and the exported clip files kept on disk; pressing x again
+// deletes it.
 func (m *Model) deleteSelectedItem() (tea.Model, tea.Cmd) {
 	item := m.notesList.GetSelectedItem()
 	if item == nil {
-		m.commandInput.SetResult("No item selected", true)
+		m.setResult("No item selected", true)
+		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+			return clearResultMsg{}
+		})
+	}
+	kind := "note"
+	if item.Type == components.ItemTypeTackle {
+		kind = "tackle"
+	}
+
+	preview, err := db.PreviewNoteDelete(m.db, item.ID)
+	if err != nil {
+		m.deletePending = 0
+		m.setResult("Error: "+err.Error(), true)
+		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+			return clearResultMsg{}
+		})
+	}
+	if m.deletePending != item.ID {
+		m.deletePending = item.ID
+		summary := preview.Summary()
+		if n := len(preview.ClipFiles); n > 0 {
+			summary += fmt.Sprintf(" (keeps %d clip file(s) on disk)", n)
+		}
+		m.setResult(fmt.Sprintf("Delete %s %d with %s? Press x again to confirm", kind, item.ID, summary), false)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
 	}
+	m.deletePending = 0
 
-	// Delete from database (cascade handles child tables)
+	// Delete from database (cascade handles child tables); exported clips stay on disk
 	if err := db.DeleteNote(m.db, item.ID); err != nil {
-		m.commandInput.SetResult("Error: "+err.Error(), true)
+		m.setResult("Error: "+err.Error(), true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
@@ -1650,7 +2314,7 @@ func (m *Model) deleteSelectedItem() (tea.Model, tea.Cmd) {
 		m.notesList.SelectedIndex = len(m.notesList.Items) - 1
 	}
 
-	m.commandInput.SetResult(fmt.Sprintf("Deleted tackle %d", deletedID), false)
+	m.setResult(fmt.Sprintf("Deleted %s %d", kind, deletedID), false)
 	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 		return clearResultMsg{}
 	})
@@ -1660,14 +2324,14 @@ func (m *Model) deleteSelectedItem() (tea.Model, tea.Cmd) {
 func (m *Model) jumpToSelectedItem() (tea.Model, tea.Cmd) {
 	item := m.notesList.GetSelectedItem()
 	if item == nil {
-		m.commandInput.SetResult("No item selected", true)
+		m.setResult("No item selected", true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
 	}
 
 	if m.client == nil || !m.client.IsConnected() {
-		m.commandInput.SetResult("Not connected to mpv", true)
+		m.setResult("Not connected to mpv", true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
@@ -1675,7 +2339,7 @@ func (m *Model) jumpToSelectedItem() (tea.Model, tea.Cmd) {
 
 	// Seek to the item's timestamp
 	if err := m.client.Seek(item.TimestampSeconds); err != nil {
-		m.commandInput.SetResult("Error: "+err.Error(), true)
+		m.setResult("Error: "+err.Error(), true)
 		return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 			return clearResultMsg{}
 		})
@@ -1718,14 +2382,24 @@ func (m *Model) jumpToSelectedItem() (tea.Model, tea.Cmd) {
 	}
 
 	result := fmt.Sprintf("Jumped to %s %d%s: %s", typeStr, item.ID, starStr, info)
-	m.commandInput.SetResult(result, false)
-	return m, tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+	m.setResult(result, false)
+	return m, tea.Batch(m.advanceTutorial(tutorialJump, item.ID), tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
 		return clearResultMsg{}
-	})
+	}))
+}
+
+// stepSizes returns the step sizes < and > cycle through, ascending: those in
+// config.json, or config.DefaultStepSizes.
+func (m *Model) stepSizes() []float64 {
+	if m.config == nil || len(m.config.StepSizes) == 0 {
+		return config.DefaultStepSizes
+	}
+	return m.config.StepSizes
 }
 
 // decreaseStepSize cycles to the previous (smaller) step size.
 func (m *Model) decreaseStepSize() {
+	stepSizes := m.stepSizes()
 	currentIndex := m.findStepSizeIndex()
 	if currentIndex > 0 {
 		m.statusBar.StepSize = stepSizes[currentIndex-1]
@@ -1734,15 +2408,17 @@ func (m *Model) decreaseStepSize() {
 
 // increaseStepSize cycles to the next (larger) step size.
 func (m *Model) increaseStepSize() {
+	stepSizes := m.stepSizes()
 	currentIndex := m.findStepSizeIndex()
 	if currentIndex < len(stepSizes)-1 {
 		m.statusBar.StepSize = stepSizes[currentIndex+1]
 	}
 }
 
-// findStepSizeIndex finds the index of the current step size in the stepSizes array.
+// findStepSizeIndex finds the index of the current step size in the step sizes.
 // If the current step size is not in the array, it returns the index of the closest value.
 func (m *Model) findStepSizeIndex() int {
+	stepSizes := m.stepSizes()
 	for i, size := range stepSizes {
 		if m.statusBar.StepSize == size {
 			return i
@@ -1760,8 +2436,17 @@ func (m *Model) findStepSizeIndex() int {
 	return len(stepSizes) - 1
 }
 
-// overlayProximitySeconds is how close (in seconds) a note must be to current timestamp to display.
-const overlayProximitySeconds = 2.0
+// defaultOverlayProximity is how long (in seconds) after its timestamp a note
+// is displayed, unless "overlay_proximity" in config.json sets it.
+const defaultOverlayProximity = 2.0
+
+// overlayProximity returns how long after its timestamp a note is displayed.
+func (m *Model) overlayProximity() float64 {
+	if m.config == nil || m.config.OverlayProximity <= 0 {
+		return defaultOverlayProximity
+	}
+	return m.config.OverlayProximity
+}
 
 // overlayID is the ID used for the notes overlay in mpv.
 const overlayID = 1
@@ -1776,6 +2461,7 @@ func (m *Model) updateOverlay() {
 	timePos := m.statusBar.TimePos
 
 	// Find notes within proximity of current timestamp
+	proximity := m.overlayProximity()
 	var nearbyNotes []components.ListItem
 	for _, item := range m.notesList.Items {
 		// Only show notes (not tackles) in overlay
@@ -1784,7 +2470,7 @@ func (m *Model) updateOverlay() {
 		}
 		// Check if note is within proximity
 		diff := timePos - item.TimestampSeconds
-		if diff >= 0 && diff <= overlayProximitySeconds {
+		if diff >= 0 && diff <= proximity {
 			nearbyNotes = append(nearbyNotes, item)
 		}
 	}
@@ -1845,6 +2531,10 @@ func (m *Model) updateOverlay() {
 // updateStatusFromMpv polls mpv for current playback status and updates the status bar.
 func (m *Model) updateStatusFromMpv() {
 	if m.client == nil || !m.client.IsConnected() {
+		if m.statusBar.VideoOpen && (m.noteForm != nil || m.tackleForm != nil) {
+			m.statusMsg = "mpv disconnected: finish the form and it will still be saved"
+			m.notify(components.LevelWarning, m.statusMsg)
+		}
 		m.statusBar.VideoOpen = false
 		return
 	}
@@ -1875,7 +2565,6 @@ func (m *Model) updateStatusFromMpv() {
 	}
 }
 
-
 // startRegenerateClip queues a clip regeneration for the selected note.
 func (m *Model) startRegenerateClip() (tea.Model, tea.Cmd) {
 	item := m.notesList.GetSelectedItem()
@@ -1889,6 +2578,12 @@ func (m *Model) startRegenerateClip() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	videoPath := videos[0].Path
+	if videosrc.IsURL(videoPath) {
+		m.statusMsg = "Clip export unavailable for streamed video (use 'video relink')"
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		})
+	}
 
 	// Load timing and tackle data
 	timings, err := db.SelectNoteTimingByNote(m.db, item.ID)
@@ -1922,8 +2617,32 @@ func (m *Model) startRegenerateClip() (tea.Model, tea.Cmd) {
 	})
 }
 
-// View renders the current state of the model as a string.
-func (m *Model) View() string {
+// View renders the current state of the model as a string. The last render is
+// reused until an update marks the model dirty.
+func (m *Model) View() (view string) {
+	if m.crashed != nil {
+		return ""
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			m.crash(r)
+			// View can't return a command; quit from outside the event loop
+			if m.program != nil {
+				go m.program.Quit()
+			}
+			view = ""
+		}
+	}()
+	if !m.dirty && m.rendered != "" {
+		return m.rendered
+	}
+	m.rendered = m.render()
+	m.dirty = false
+	return m.rendered
+}
+
+// render draws the whole screen.
+func (m *Model) render() string {
 	if m.quitting {
 		return "Goodbye!\n"
 	}
@@ -1933,15 +2652,33 @@ func (m *Model) View() string {
 	}
 
 	// --- Responsive multi-column layout ---
-	// Available height for columns: total height minus timeline (2 lines) and command input (1 line)
+	// Available height for columns: total height minus timeline (2 lines, plus the
+	// waveform strip when shown) and command input (1 line)
+	overlayActive := m.noteForm != nil || m.tackleForm != nil || m.confirmDiscardForm != nil || m.commentaryForm != nil || m.showHelp || m.showMessages || m.statsView.Active || m.suggestions.Active || m.clipsView.Active
+	col1Width, col2Width, col3Width, col4Width, showCol2, showCol3, showCol4 := layout.ComputeColumnWidths(m.width, overlayActive)
+
+	// Without column 4 the valid keys go in a one-line bar above the command input
+	var controls string
+	if !showCol4 && showCol2 {
+		controls = components.ControlsDisplay(m.controlGroups(), m.width)
+	}
+
+	waveform := m.renderWaveform()
+	tutorial := m.renderTutorial()
 	colHeight := m.height - 3
+	if waveform != "" {
+		colHeight--
+	}
+	if tutorial != "" {
+		colHeight--
+	}
+	if controls != "" {
+		colHeight--
+	}
 	if colHeight < 5 {
 		colHeight = 5
 	}
 
-	overlayActive := m.noteForm != nil || m.tackleForm != nil || m.confirmDiscardForm != nil || m.showHelp || m.statsView.Active
-	col1Width, col2Width, col3Width, col4Width, showCol2, showCol3, showCol4 := layout.ComputeColumnWidths(m.width, overlayActive)
-
 	var columnsView string
 	if showCol4 && showCol3 {
 		columns := []string{
@@ -1976,15 +2713,21 @@ func (m *Model) View() string {
 		widths := []int{col1Width, col2Width}
 		columnsView = layout.JoinColumns(columns, widths, colHeight)
 	} else {
-		columns := []string{
-			m.renderColumn1(col1Width, colHeight),
-		}
-		widths := []int{col1Width}
-		columnsView = layout.JoinColumns(columns, widths, colHeight)
+		// Too narrow for the notes column: mini player + notes list at full width
+		columnsView = m.renderMiniPlayer(m.width, colHeight)
 	}
 
 	// Render timeline progress bar below columns (full width)
 	timeline := components.Timeline(m.statusBar.TimePos, m.statusBar.Duration, m.notesList.Items, m.width)
+	if waveform != "" {
+		timeline += "\n" + waveform
+	}
+	if controls != "" {
+		timeline += "\n" + controls
+	}
+	if tutorial != "" {
+		timeline += "\n" + tutorial
+	}
 
 	// Render command input or status message at bottom (full width)
 	var footer string
@@ -1997,8 +2740,6 @@ func (m *Model) View() string {
 	return columnsView + "\n" + timeline + "\n" + footer
 }
 
-
-
 // truncateViewToWidth truncates each line of a multi-line view to fit within the given width.
 func truncateViewToWidth(view string, width int) string {
 	if width <= 0 {
@@ -2013,15 +2754,56 @@ func truncateViewToWidth(view string, width int) string {
 
 // Run starts the Bubbletea program with the given model.
 // It returns an error if the program fails to start or run.
-func Run(client *mpv.Client, db *sql.DB, videoPath string, videoID int64) error {
+// suggester may be nil; when set it runs in the background and fills the review queue.
+func Run(client mpv.Player, db *sql.DB, videoPath string, videoID int64, suggester suggest.Suggester) error {
 	model := NewModel(client, db, videoPath, videoID)
-	// Load notes and tackles for the current video
-	model.loadNotesAndTackles()
+	model.suggester = suggester
+	model.loadVideoState()
+	// Load user Lua scripts (custom commands and key handlers)
+	model.loadScripts()
+	defer model.scripts.Close()
+	// Settings from config.json, then webhooks for note events
+	model.loadConfig()
+	model.loadPedal()
+	defer model.closePedal()
+	model.loadMIDI()
+	defer model.closeMIDI()
+	model.loadRemote()
+	defer model.closeRemote()
+	model.loadWebhooks()
+	model.loadGameClock()
+	// Summarise what changed since the last session, before this one starts
+	model.loadChanges()
+	// Record a tagging session for the session log
+	endSession := model.startSession()
+	defer endSession()
 	p := tea.NewProgram(model, tea.WithAltScreen())
+	model.program = p
 	_, err := p.Run()
+	// Let in-flight webhook deliveries finish before exiting
+	if model.webhooks != nil {
+		model.webhooks.Wait()
+	}
+	if model.crashed != nil {
+		return model.crashed.error()
+	}
 	return err
 }
 
+// loadVideoState loads the current video's notes, possession, bookmarks, lineup
+// and player trends.
+func (m *Model) loadVideoState() {
+	// Load notes and tackles for the current video
+	m.loadNotesAndTackles()
+	// Resume any possession interval left open by a previous session
+	m.loadPossessionState()
+	m.loadBookmarks()
+	m.loadLineup()
+	m.loadTrends()
+	m.loadAngles()
+	m.loadWaveform()
+}
+
 // loadNotesAndTackles loads notes and tackles from the database for the current video.
 // Uses the normalized schema: queries notes joined with note_videos, note_timing, note_details, note_tackles, note_highlights.
 func (m *Model) loadNotesAndTackles() {
@@ -2031,6 +2813,9 @@ func (m *Model) loadNotesAndTackles() {
 
 	var items []components.ListItem
 
+	// References in note texts show the events' current numbers
+	m.refNumbers, _ = db.SelectNoteRefNumbers(m.db, m.videoPath)
+
 	// Query all notes for this video with timing info and clip status
 	rows, err := m.db.Query(`
 		SELECT n.id, n.category, COALESCE(nt.start, 0), COALESCE(nc.status, ''), nc.finished_at
@@ -2039,12 +2824,15 @@ func (m *Model) loadNotesAndTackles() {
 		LEFT JOIN note_timing nt ON nt.note_id = n.id
 		LEFT JOIN note_clips nc ON nc.note_id = n.id
 		WHERE v.path = ?
-		ORDER BY nt.start ASC`, m.videoPath)
+		ORDER BY COALESCE(nt.start, 0) ASC, n.id ASC`, m.videoPath)
 	if err != nil {
 		return
 	}
 	defer rows.Close()
 
+	// Events are numbered 1..N in time order, the same order
+	// db.SelectNoteIDByNumber counts them in
+	number := 0
 	for rows.Next() {
 		var noteID int64
 		var category string
@@ -2055,8 +2843,10 @@ func (m *Model) loadNotesAndTackles() {
 			continue
 		}
 
+		number++
 		item := components.ListItem{
 			ID:               noteID,
+			Number:           number,
 			TimestampSeconds: timestamp,
 			Category:         category,
 			ClipStatus:       clipStatus,
@@ -2074,6 +2864,7 @@ func (m *Model) loadNotesAndTackles() {
 			if err == nil && len(tackles) > 0 {
 				t := tackles[0]
 				item.Player = t.Player
+				item.Outcome = t.Outcome
 				item.Text = t.Player
 				if t.Outcome != "" {
 					item.Text += " - " + t.Outcome
@@ -2085,6 +2876,9 @@ func (m *Model) loadNotesAndTackles() {
 
 		// Load detail text
 		details, err := db.SelectNoteDetailsByNote(m.db, noteID)
+		for i := range details {
+			details[i].Note = m.detailText(details[i])
+		}
 		if err == nil && len(details) > 0 {
 			if item.Type == components.ItemTypeTackle && item.Text != "" {
 				// Append detail text to tackle display
@@ -2092,15 +2886,25 @@ func (m *Model) loadNotesAndTackles() {
 			} else {
 				item.Text = details[0].Note
 			}
+			if summary := m.templateSummary(category, details); summary != "" && item.Type == components.ItemTypeNote {
+				if t := details[0].Type; t == "text" || t == "notes" {
+					item.Text += " (" + summary + ")"
+				} else {
+					// An event tagged without text ('event add')
+					item.Text = summary
+				}
+			}
 		}
 
-		// Check for star highlights
+		// Check for star and pin highlights
 		highlights, err := db.SelectNoteHighlightsByNote(m.db, noteID)
 		if err == nil {
 			for _, h := range highlights {
-				if h.Type == "star" {
+				switch h.Type {
+				case "star":
 					item.Starred = true
-					break
+				case pinHighlight:
+					item.Pinned = true
 				}
 			}
 		}
@@ -2108,6 +2912,39 @@ func (m *Model) loadNotesAndTackles() {
 		items = append(items, item)
 	}
 
+	if m.showGameClock() {
+		for i := range items {
+			items[i].GameClock = m.gameClock.Format(items[i].TimestampSeconds)
+		}
+	}
+	m.notesList.ShowGameClock = m.showGameClock()
+
+	m.events = make(map[int]components.ListItem, len(items))
+	for _, item := range items {
+		m.events[item.Number] = item
+	}
+
+	// Tally counts the whole video, even while the list is filtered by player
+	m.statusBar.Tally = components.Tally(items)
+	m.syncChapters(items)
+	items = mergeByTime(items, m.scratchItems())
+	m.eventTimes = m.eventTimes[:0]
+	for _, item := range items {
+		m.eventTimes = append(m.eventTimes, item.TimestampSeconds)
+	}
+	sort.Float64s(m.eventTimes)
+	m.notesList.Total = len(items)
+	m.notesList.Filters = nil
+	if m.playerFilter != "" {
+		items = filterItemsByPlayer(items, m.playerFilter)
+		m.notesList.Filters = append(m.notesList.Filters, "player="+m.playerFilter)
+	}
+	if m.categoryFilter != "" {
+		items = filterItemsByCategory(items, m.categoryFilter)
+		m.notesList.Filters = append(m.notesList.Filters, "category="+m.categoryFilter)
+	}
+	items = pinnedFirst(items)
+
 	prevSelected := m.notesList.SelectedIndex
 	prevScroll := m.notesList.ScrollOffset
 	m.notesList.Items = items
@@ -2128,7 +2965,51 @@ func (m *Model) handleStatsViewInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleStatsFilterInput(msg)
 	}
 
-	switch msg.String() {
+	// Digit keys: accumulate into numberBuffer, as in the notes list
+	key := msg.String()
+	if len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+		m.lastKeyG = false
+		if key == "0" && m.numberBuffer == "" {
+			m.statsView.JumpTo(0)
+			m.followStatsSelection(m.statsView.Selected())
+			return m, nil
+		}
+		m.numberBuffer += key
+		return m, nil
+	}
+
+	switch key {
+	case "g":
+		if m.lastKeyG {
+			// gg: jump to first row
+			m.statsView.JumpTo(0)
+			m.followStatsSelection(m.statsView.Selected())
+			m.lastKeyG = false
+			m.numberBuffer = ""
+			return m, nil
+		}
+		m.lastKeyG = true
+		return m, nil
+	case "G":
+		if m.numberBuffer != "" {
+			// nG: jump to row n (1-indexed), the # column
+			n, err := strconv.Atoi(m.numberBuffer)
+			if err == nil {
+				m.statsView.JumpTo(n - 1)
+			}
+			m.numberBuffer = ""
+		} else {
+			// G: jump to last row
+			m.statsView.JumpTo(len(m.statsView.Stats) - 1)
+		}
+		m.followStatsSelection(m.statsView.Selected())
+		m.lastKeyG = false
+		return m, nil
+	}
+	m.numberBuffer = ""
+	m.lastKeyG = false
+
+	switch key {
 	case "backspace":
 		// Return to main view
 		m.statsView.Active = false
@@ -2137,19 +3018,58 @@ func (m *Model) handleStatsViewInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Cycle sort column
 		m.statsView.NextSortColumn()
 		return m, nil
-	case "v", "V":
-		// Toggle between current video / all videos
-		m.statsView.AllVideos = !m.statsView.AllVideos
+	case "z", "Z":
+		// Cycle the zone filter through the zones tackles were recorded in
+		if m.db == nil {
+			return m, nil
+		}
+		scope, _, _ := m.scopeFor(m.statsScope)
+		zones, err := db.SelectTackleZones(m.db, scope)
+		if err != nil {
+			return m, nil
+		}
+		if len(zones) == 0 {
+			return m.showStatus("No tackles with a zone")
+		}
+		m.statsView.NextZone(zones)
+		m.loadTackleStats()
+		return m, nil
+	case "f", "F":
+		// Cycle the followed-up filter
+		m.statsView.NextFollowed()
 		m.loadTackleStats()
 		return m, nil
+	case "t", "T":
+		// Toggle between the player table and the timeline chart
+		m.statsView.Timeline = !m.statsView.Timeline
+		if m.statsView.Timeline {
+			m.loadTimelineChart()
+		}
+		return m, nil
+	case "v", "V":
+		// Cycle current video / match / season / all videos
+		m.cycleStatsScope()
+		return m, nil
 	case "j", "J":
 		// Move selection up
 		m.statsView.MoveUp()
+		m.followStatsSelection(m.statsView.Selected())
 		return m, nil
 	case "k", "K":
 		// Move selection down
 		m.statsView.MoveDown()
+		m.followStatsSelection(m.statsView.Selected())
 		return m, nil
+	case "enter":
+		// Filter the notes list by the selected player (again to clear)
+		player := m.statsView.Selected()
+		if player == "" {
+			return m, nil
+		}
+		if player == m.playerFilter {
+			return m.setPlayerFilter("")
+		}
+		return m.setPlayerFilter(player)
 	case "ctrl+c":
 		m.quitting = true
 		if timePos, tpErr := m.client.GetTimePos(); tpErr == nil && m.videoID > 0 {
@@ -2180,9 +3100,11 @@ func (m *Model) handleStatsFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.statsView.FilterInput = ""
 		return m, nil
 	case "enter":
-		// Apply filter and exit filter mode
+		// Apply filter and exit filter mode, staying on the player found
 		if m.statsView.FilterInput != "" {
+			player := m.statsView.Selected()
 			m.statsView.ToggleFilter(m.statsView.FilterInput)
+			m.statsView.Select(player)
 		}
 		m.statsView.FilterMode = false
 		m.statsView.FilterInput = ""
@@ -2192,6 +3114,7 @@ func (m *Model) handleStatsFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if len(m.statsView.FilterInput) > 0 {
 			m.statsView.FilterInput = m.statsView.FilterInput[:len(m.statsView.FilterInput)-1]
 		}
+		m.searchStatsView()
 		return m, nil
 	default:
 		// Add character to filter input
@@ -2202,43 +3125,18 @@ func (m *Model) handleStatsFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.statsView.FilterInput += string(r)
 			}
 		}
+		m.searchStatsView()
 		return m, nil
 	}
 }
 
-// tackleStatsAllVideosQuery aggregates tackle stats across all videos.
-const tackleStatsAllVideosQuery = `
-SELECT
-    ntk.player,
-    COUNT(*) AS total,
-    SUM(CASE WHEN ntk.outcome = 'completed' THEN 1 ELSE 0 END) AS completed,
-    SUM(CASE WHEN ntk.outcome = 'missed' THEN 1 ELSE 0 END) AS missed,
-    SUM(CASE WHEN ntk.outcome = 'possible' THEN 1 ELSE 0 END) AS possible,
-    SUM(CASE WHEN ntk.outcome = 'other' THEN 1 ELSE 0 END) AS other,
-    SUM(CASE WHEN nh.type = 'star' THEN 1 ELSE 0 END) AS starred
-FROM note_tackles ntk
-INNER JOIN notes n ON n.id = ntk.note_id
-LEFT JOIN note_highlights nh ON nh.note_id = n.id AND nh.type = 'star'
-GROUP BY ntk.player
-ORDER BY total DESC`
-
-// tackleStatsByVideoQuery aggregates tackle stats for a specific video.
-const tackleStatsByVideoQuery = `
-SELECT
-    ntk.player,
-    COUNT(*) AS total,
-    SUM(CASE WHEN ntk.outcome = 'completed' THEN 1 ELSE 0 END) AS completed,
-    SUM(CASE WHEN ntk.outcome = 'missed' THEN 1 ELSE 0 END) AS missed,
-    SUM(CASE WHEN ntk.outcome = 'possible' THEN 1 ELSE 0 END) AS possible,
-    SUM(CASE WHEN ntk.outcome = 'other' THEN 1 ELSE 0 END) AS other,
-    SUM(CASE WHEN nh.type = 'star' THEN 1 ELSE 0 END) AS starred
-FROM note_tackles ntk
-INNER JOIN notes n ON n.id = ntk.note_id
-INNER JOIN videos v ON v.id = n.video_id
-LEFT JOIN note_highlights nh ON nh.note_id = n.id AND nh.type = 'star'
-WHERE v.path = ?
-GROUP BY ntk.player
-ORDER BY total DESC`
+// searchStatsView moves the stats view's selection to the first player
+// matching the filter input as it is typed.
+func (m *Model) searchStatsView() {
+	if m.statsView.Search(m.statsView.FilterInput) {
+		m.followStatsSelection(m.statsView.Selected())
+	}
+}
 
 // loadTackleStats loads tackle statistics from the database.
 func (m *Model) loadTackleStats() {
@@ -2246,40 +3144,21 @@ func (m *Model) loadTackleStats() {
 		return
 	}
 
-	var query string
-	var args []interface{}
-
-	if m.statsView.AllVideos {
-		query = tackleStatsAllVideosQuery
-	} else {
-		query = tackleStatsByVideoQuery
-		args = append(args, m.videoPath)
+	filter := db.TackleFilter{Zone: m.statsView.ZoneFilter, Followed: m.statsView.FollowedFilter}
+	scope, label, ok := m.scopeFor(m.statsScope)
+	if !ok && m.statsScope != scopeVideo {
+		// The match or season no longer applies, e.g. the video was unlinked
+		m.statsScope = scopeVideo
+		scope, label, ok = m.scopeFor(m.statsScope)
 	}
-
-	rows, err := m.db.Query(query, args...)
-	if err != nil {
+	if !ok {
 		return
 	}
-	defer rows.Close()
-
-	var stats []components.PlayerStats
-	for rows.Next() {
-		var stat components.PlayerStats
-		err := rows.Scan(
-			&stat.Player,
-			&stat.Total,
-			&stat.Completed,
-			&stat.Missed,
-			&stat.Possible,
-			&stat.Other,
-			&stat.Starred,
-		)
-		if err == nil {
-			if stat.Completed+stat.Missed > 0 {
-				stat.Percentage = float64(stat.Completed) / float64(stat.Completed+stat.Missed) * 100
-			}
-			stats = append(stats, stat)
-		}
+	filter.VideoScope = scope
+	m.statsView.Scope = label
+	stats, err := m.tackleStats(filter)
+	if err != nil {
+		return
 	}
 
 	m.statsView.Stats = stats
@@ -2288,6 +3167,31 @@ func (m *Model) loadTackleStats() {
 	m.statsView.SortStats()
 }
 
+// tackleStats returns the per-player tackle stats matching filter.
+func (m *Model) tackleStats(filter db.TackleFilter) ([]components.PlayerStats, error) {
+	rows, err := db.SelectTackleBreakdown(m.db, filter)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]components.PlayerStats, len(rows))
+	for i, r := range rows {
+		stats[i] = components.PlayerStats{
+			Player:    r.Group,
+			Total:     r.Total,
+			Completed: r.Completed,
+			Missed:    r.Missed,
+			Possible:  r.Possible,
+			Other:     r.Other,
+			Starred:   r.Starred,
+			Covered:   r.Covered,
+		}
+		if r.Completed+r.Missed > 0 {
+			stats[i].Percentage = float64(r.Completed) / float64(r.Completed+r.Missed) * 100
+		}
+	}
+	return stats, nil
+}
+
 // loadTackleStatsForPanel refreshes tackle stats for the live stats panel (column 3).
 // Unlike loadTackleStats, this does not reset selection/scroll state.
 func (m *Model) loadTackleStatsForPanel() {
@@ -2295,30 +3199,12 @@ func (m *Model) loadTackleStatsForPanel() {
 		return
 	}
 
-	rows, err := m.db.Query(tackleStatsByVideoQuery, m.videoPath)
-	if err != nil {
+	if m.videoID <= 0 {
 		return
 	}
-	defer rows.Close()
-
-	var stats []components.PlayerStats
-	for rows.Next() {
-		var stat components.PlayerStats
-		err := rows.Scan(
-			&stat.Player,
-			&stat.Total,
-			&stat.Completed,
-			&stat.Missed,
-			&stat.Possible,
-			&stat.Other,
-			&stat.Starred,
-		)
-		if err == nil {
-			if stat.Completed+stat.Missed > 0 {
-				stat.Percentage = float64(stat.Completed) / float64(stat.Completed+stat.Missed) * 100
-			}
-			stats = append(stats, stat)
-		}
+	stats, err := m.tackleStats(db.TackleFilter{VideoScope: db.VideoScope{VideoID: m.videoID}})
+	if err != nil {
+		return
 	}
 
 	// Only update stats if the stats view is not actively being used (to avoid interfering)
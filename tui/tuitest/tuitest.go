@@ -0,0 +1,227 @@
+// Package tuitest drives the tagging TUI programmatically, without a terminal
+// or mpv: a Harness wires an mpv.Fake player and an in-memory database into a TUI
+// model and offers helpers to press keys, type text, run commands and read the
+// rendered screen.
+//
+//	h, err := tuitest.New("match.mp4", 80*60)
+//	if err != nil { ... }
+//	defer h.Close()
+//	h.Player.TimePos = 125
+//	h.Command("nn Great line speed")
+//	if !strings.Contains(h.View(), "Great line speed") { ... }
+//
+// Messages are processed synchronously, and the commands the model returns are
+// run and their messages delivered in turn, so forms advance and submit.
+// Commands still running after CmdTimeout — timers such as the periodic
+// refresh, message expiry and cursor blink — are dropped; call Tick to apply a
+// periodic refresh.
+package tuitest
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/mpv"
+	"github.com/user/tagging-rugby-cli/tui"
+)
+
+// DefaultCmdTimeout is how long a command may run before the harness drops it
+// as a timer.
+const DefaultCmdTimeout = 50 * time.Millisecond
+
+// maxMessages bounds the messages one Send delivers, so commands that keep
+// returning commands can't loop forever.
+const maxMessages = 1000
+
+// Harness holds a TUI model with its fake player and database.
+type Harness struct {
+	Model  *tui.Model
+	Player *mpv.Fake
+	DB     *sql.DB
+	// CmdTimeout is how long a command returned by the model may run before
+	// it is dropped; DefaultCmdTimeout unless changed
+	CmdTimeout time.Duration
+}
+
+// New returns a harness for videoPath backed by a new in-memory database and a
+// paused mpv.Fake with a video of duration seconds.
+func New(videoPath string, duration float64) (*Harness, error) {
+	database, err := db.OpenMemory()
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	player := mpv.NewFake(duration)
+	model, err := tui.NewTestModel(player, database, videoPath)
+	if err != nil {
+		database.Close()
+		return nil, err
+	}
+	h := &Harness{Model: model, Player: player, DB: database, CmdTimeout: DefaultCmdTimeout}
+	h.Tick()
+	return h, nil
+}
+
+// Close releases the database.
+func (h *Harness) Close() error {
+	return h.DB.Close()
+}
+
+// Send delivers a message to the model, then runs the commands it returns and
+// delivers their messages, until none are left.
+func (h *Harness) Send(msg tea.Msg) {
+	queue := []tea.Msg{msg}
+	for n := 0; len(queue) > 0 && n < maxMessages; n++ {
+		msg, queue = queue[0], queue[1:]
+		_, cmd := h.Model.Update(msg)
+		queue = append(queue, h.run(cmd)...)
+	}
+}
+
+// cmdType is the type of tea.Cmd, the element type of tea.Sequence's message.
+var cmdType = reflect.TypeOf((tea.Cmd)(nil))
+
+// run runs cmds at once and returns their messages in order, dropping those
+// still running after CmdTimeout. Batches and sequences are run in turn.
+func (h *Harness) run(cmds ...tea.Cmd) []tea.Msg {
+	results := make([]chan tea.Msg, len(cmds))
+	for i, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+		results[i] = make(chan tea.Msg, 1)
+		go func(cmd tea.Cmd, result chan<- tea.Msg) {
+			result <- cmd()
+		}(cmd, results[i])
+	}
+
+	deadline := time.After(h.CmdTimeout)
+	expired := false
+	var msgs []tea.Msg
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		var msg tea.Msg
+		if expired {
+			select {
+			case msg = <-result:
+			default:
+				continue
+			}
+		} else {
+			select {
+			case msg = <-result:
+			case <-deadline:
+				expired = true
+				continue
+			}
+		}
+		switch m := msg.(type) {
+		case nil, tea.QuitMsg:
+		case tea.BatchMsg:
+			msgs = append(msgs, h.run(m...)...)
+		default:
+			// tea.Sequence's message is an unexported []tea.Cmd
+			if v := reflect.ValueOf(msg); v.Kind() == reflect.Slice && v.Type().Elem() == cmdType {
+				for i := 0; i < v.Len(); i++ {
+					msgs = append(msgs, h.run(v.Index(i).Interface().(tea.Cmd))...)
+				}
+				continue
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// Tick applies one periodic refresh: the status bar is read from the player
+// and the notes list is reloaded from the database.
+func (h *Harness) Tick() {
+	h.Send(tui.TickMsg())
+}
+
+// Resize sets the terminal size.
+func (h *Harness) Resize(width, height int) {
+	h.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Press sends each key in turn. Keys use Bubble Tea names: "enter", "esc",
+// "tab", "shift+tab", "space", "up", "ctrl+s", or a single character.
+func (h *Harness) Press(keys ...string) {
+	for _, k := range keys {
+		h.Send(Key(k))
+	}
+}
+
+// Type sends text one character at a time.
+func (h *Harness) Type(text string) {
+	for _, r := range text {
+		h.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// Command runs a command-mode command, e.g. Command("bookmark").
+func (h *Harness) Command(command string) {
+	h.Press(":")
+	h.Type(command)
+	h.Press("enter")
+}
+
+// View returns the rendered screen.
+func (h *Harness) View() string {
+	return h.Model.View()
+}
+
+// PlainView returns the rendered screen with styling removed and trailing
+// spaces trimmed, for comparing against expected text.
+func (h *Harness) PlainView() string {
+	lines := strings.Split(ansi.Strip(h.View()), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// namedKeys maps Bubble Tea key names to key types.
+var namedKeys = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEsc,
+	"tab":       tea.KeyTab,
+	"shift+tab": tea.KeyShiftTab,
+	"backspace": tea.KeyBackspace,
+	"delete":    tea.KeyDelete,
+	"space":     tea.KeySpace,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+}
+
+// Key returns the key message for a Bubble Tea key name (see Press).
+func Key(name string) tea.KeyMsg {
+	if t, ok := namedKeys[name]; ok {
+		if t == tea.KeySpace {
+			return tea.KeyMsg{Type: t, Runes: []rune{' '}}
+		}
+		return tea.KeyMsg{Type: t}
+	}
+	if letter, ok := strings.CutPrefix(name, "ctrl+"); ok && len(letter) == 1 && letter[0] >= 'a' && letter[0] <= 'z' {
+		return tea.KeyMsg{Type: tea.KeyCtrlA + tea.KeyType(letter[0]-'a')}
+	}
+	if letter, ok := strings.CutPrefix(name, "alt+"); ok {
+		msg := Key(letter)
+		msg.Alt = true
+		return msg
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
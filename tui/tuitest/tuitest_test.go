@@ -0,0 +1,57 @@
+package tuitest
+
+import (
+	"strings"
+	"testing"
+)
+
+// countNotes returns the number of notes in the harness database.
+func countNotes(t *testing.T, h *Harness) int {
+	t.Helper()
+	var n int
+	if err := h.DB.QueryRow("SELECT COUNT(*) FROM notes").Scan(&n); err != nil {
+		t.Fatalf("count notes: %v", err)
+	}
+	return n
+}
+
+func TestNoteForm(t *testing.T) {
+	h, err := New("match.mp4", 80*60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.Resize(160, 40)
+	h.Player.TimePos = 125
+
+	h.Press("n")
+	h.Type("Great line speed")
+	for i := 0; i < 12 && countNotes(t, h) == 0; i++ {
+		h.Press("enter")
+	}
+	if n := countNotes(t, h); n != 1 {
+		t.Fatalf("notes after submitting the form = %d, want 1", n)
+	}
+	h.Tick()
+	if view := h.PlainView(); !strings.Contains(view, "Great line speed") {
+		t.Errorf("notes list doesn't show the note:\n%s", view)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	h, err := New("match.mp4", 80*60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.Resize(160, 40)
+	h.Player.TimePos = 300
+
+	h.Command("nn Kick chase")
+	if n := countNotes(t, h); n != 1 {
+		t.Fatalf("notes after :nn = %d, want 1", n)
+	}
+	if view := h.PlainView(); !strings.Contains(view, "Kick chase") {
+		t.Errorf("notes list doesn't show the note:\n%s", view)
+	}
+}
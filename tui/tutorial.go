@@ -0,0 +1,212 @@
+package tui
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// Tutorial actions, taken by the analyst to complete a step.
+const (
+	tutorialNote   = "note"
+	tutorialTackle = "tackle"
+	tutorialJump   = "jump"
+	tutorialStats  = "stats"
+)
+
+// tutorialStep is one prompt of the :tutorial walkthrough, done once its
+// action has been taken.
+type tutorialStep struct {
+	Action string
+	Title  string
+	Prompt string
+}
+
+// tutorialSteps walk a new analyst through the core loop: tag an event, tag a
+// tackle, find it again and see it counted.
+var tutorialSteps = []tutorialStep{
+	{tutorialNote, "Open a note", "Press n for the note form, type what happened and press Enter through the fields to save it"},
+	{tutorialTackle, "Tag a tackle", "Press t for the tackle wizard, enter a player and an outcome, and save it"},
+	{tutorialJump, "Jump to it", "Select your tackle, #%d in the notes list, with j/k and press Enter to seek the video to it"},
+	{tutorialStats, "View stats", "Press s for the stats view, where the tackle counts towards the player's completion"},
+}
+
+// tutorialState tracks the :tutorial walkthrough.
+type tutorialState struct {
+	Active bool
+	// Step indexes the step being prompted
+	Step int
+	// TackleID is the tackle tagged during the tutorial, the one to jump to
+	TackleID int64
+
+	// The analyst's database, video and scratch notes, put aside while the
+	// tutorial runs against its demo database
+	realDB      *sql.DB
+	realVideoID int64
+	realScratch []scratchNote
+}
+
+// tutorialDemo is the demo match the tutorial starts from, so the notes list
+// and stats view have something in them. At places each event as a fraction
+// of the video.
+var tutorialDemo = []struct {
+	At              float64
+	Category, Text  string
+	Player, Outcome string
+}{
+	{0.02, "kickoff", "Home kick off, long to the left", "", ""},
+	{0.08, "tackle", "", "Tane", "completed"},
+	{0.15, "set-piece/lineout", "Five-man lineout, won clean", "", ""},
+	{0.22, "tackle", "", "Ruiz", "missed"},
+	{0.30, "tackle", "", "Tane", "completed"},
+	{0.38, "penalty", "Offside at the ruck", "", ""},
+	{0.45, "tackle", "", "Okafor", "completed"},
+}
+
+// tutorialSpan is the seconds the demo events are spread over when the
+// video's duration isn't known.
+const tutorialSpan = 600.0
+
+// openTutorialDB returns an in-memory database holding the demo match on the
+// open video, and the video's ID in it.
+func (m *Model) openTutorialDB() (*sql.DB, int64, error) {
+	demo, err := db.OpenMemory()
+	if err != nil {
+		return nil, 0, fmt.Errorf("open demo database: %w", err)
+	}
+	videoID, err := db.EnsureVideo(demo, m.videoPath, 0, "")
+	if err != nil {
+		demo.Close()
+		return nil, 0, fmt.Errorf("add demo video: %w", err)
+	}
+	span := m.statusBar.Duration
+	if span <= 0 {
+		span = tutorialSpan
+	}
+	for _, e := range tutorialDemo {
+		at := e.At * span
+		children := db.NoteChildren{
+			Timings: []db.NoteTiming{{Start: at, End: at}},
+			Videos:  []db.NoteVideo{newNoteVideo(m.videoPath, m.statusBar.Duration)},
+		}
+		if e.Text != "" {
+			children.Details = []db.NoteDetail{{Type: "text", Note: e.Text}}
+		}
+		if e.Player != "" {
+			children.Tackles = []db.NoteTackle{{Player: e.Player, Attempt: 1, Outcome: e.Outcome}}
+		}
+		if _, err := db.InsertNoteWithChildren(demo, e.Category, children); err != nil {
+			demo.Close()
+			return nil, 0, fmt.Errorf("add demo event: %w", err)
+		}
+	}
+	return demo, videoID, nil
+}
+
+// executeTutorialCommand handles :tutorial [stop]; :tutorial while running
+// also stops it.
+func (m *Model) executeTutorialCommand(args []string) (string, error) {
+	if len(args) > 0 && args[0] == "stop" || len(args) == 0 && m.tutorial.Active {
+		if !m.tutorial.Active {
+			return "Tutorial is not running", nil
+		}
+		m.endTutorial()
+		return "Tutorial stopped: back to your notes", nil
+	}
+	if len(args) > 0 {
+		return "", fmt.Errorf("usage: tutorial [stop]")
+	}
+	demo, videoID, err := m.openTutorialDB()
+	if err != nil {
+		return "", err
+	}
+	m.tutorial = tutorialState{Active: true, realDB: m.db, realVideoID: m.videoID, realScratch: m.scratch}
+	m.switchVideoState(demo, videoID, nil)
+	m.focus = FocusNotes
+	return fmt.Sprintf("Tutorial started on a demo match (nothing is saved): %d steps, prompted above the timeline (:tutorial stop to quit)", len(tutorialSteps)), nil
+}
+
+// endTutorial stops the tutorial, dropping its demo database and bringing
+// back the analyst's notes.
+func (m *Model) endTutorial() {
+	t := m.tutorial
+	m.tutorial = tutorialState{}
+	if t.realDB == nil {
+		return
+	}
+	demo := m.db
+	m.switchVideoState(t.realDB, t.realVideoID, t.realScratch)
+	demo.Close()
+}
+
+// switchVideoState moves the model onto database, reloading what it shows of
+// the video from there.
+func (m *Model) switchVideoState(database *sql.DB, videoID int64, scratch []scratchNote) {
+	m.db = database
+	m.videoID = videoID
+	m.scratch = scratch
+	m.deletePending = 0
+	m.possession = components.PossessionState{}
+	m.loadVideoState()
+	m.loadTackleStatsForPanel()
+	if len(m.notesList.Items) == 0 {
+		m.notesList.SelectedIndex = 0
+	} else if m.notesList.SelectedIndex >= len(m.notesList.Items) {
+		m.notesList.SelectedIndex = len(m.notesList.Items) - 1
+	}
+}
+
+// advanceTutorial moves the tutorial on when action completes the current
+// step; noteID is the note the action saved or jumped to. Returns a command
+// clearing the closing message once the last step is done.
+func (m *Model) advanceTutorial(action string, noteID int64) tea.Cmd {
+	if !m.tutorial.Active || tutorialSteps[m.tutorial.Step].Action != action {
+		return nil
+	}
+	switch action {
+	case tutorialTackle:
+		m.tutorial.TackleID = noteID
+	case tutorialJump:
+		if noteID != m.tutorial.TackleID {
+			return nil
+		}
+	}
+	m.tutorial.Step++
+	if m.tutorial.Step < len(tutorialSteps) {
+		return nil
+	}
+	m.endTutorial()
+	m.setResult("Tutorial complete, back to your notes: ? lists every key and :help every command", false)
+	return tea.Tick(resultDisplayDuration, func(t time.Time) tea.Msg {
+		return clearResultMsg{}
+	})
+}
+
+// renderTutorial returns the current step's prompt bar, or "" when the
+// tutorial isn't running.
+func (m *Model) renderTutorial() string {
+	if !m.tutorial.Active {
+		return ""
+	}
+	step := tutorialSteps[m.tutorial.Step]
+	prompt := step.Prompt
+	if step.Action == tutorialJump {
+		prompt = fmt.Sprintf(prompt, m.tutorialTackleNumber())
+	}
+	return components.TutorialPrompt(m.tutorial.Step+1, len(tutorialSteps), step.Title, prompt, m.width)
+}
+
+// tutorialTackleNumber returns the event number of the tackle tagged during
+// the tutorial, as the notes list's # column shows it.
+func (m *Model) tutorialTackleNumber() int {
+	for number, item := range m.events {
+		if item.ID == m.tutorial.TackleID {
+			return number
+		}
+	}
+	return 0
+}
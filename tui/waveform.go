@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/tui/components"
+)
+
+// loadWaveform loads the audio peaks computed by 'analyze waveform'. The strip
+// is shown under the timeline whenever peaks exist, until :waveform hides it.
+func (m *Model) loadWaveform() {
+	m.waveform = db.Waveform{}
+	if m.db == nil || m.videoID <= 0 {
+		return
+	}
+	w, err := db.SelectWaveform(m.db, m.videoID)
+	if err != nil {
+		return
+	}
+	m.waveform = w
+	m.showWaveform = true
+}
+
+// toggleWaveform shows or hides the waveform strip.
+func (m *Model) toggleWaveform() (string, error) {
+	if len(m.waveform.Peaks) == 0 {
+		return "No waveform for this video: run 'tagging-rugby-cli analyze waveform <video>'", nil
+	}
+	m.showWaveform = !m.showWaveform
+	if m.showWaveform {
+		return "Waveform shown", nil
+	}
+	return "Waveform hidden", nil
+}
+
+// renderWaveform returns the waveform strip, or "" when there is none to show.
+func (m *Model) renderWaveform() string {
+	if !m.showWaveform {
+		return ""
+	}
+	return components.Waveform(m.waveform.Peaks, m.waveform.SecondsPerPeak, m.statusBar.TimePos, m.statusBar.Duration, m.width)
+}
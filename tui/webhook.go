@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/tui/components"
+	"github.com/user/tagging-rugby-cli/webhook"
+)
+
+// loadWebhooks creates the webhook notifier from config.json.
+func (m *Model) loadWebhooks() {
+	if m.config != nil && len(m.config.Webhooks) > 0 {
+		m.webhooks = webhook.New(m.config.Webhooks)
+	}
+}
+
+// notifyWebhooks sends a note event to the configured webhooks in the background.
+// Starred notes additionally send note.starred.
+func (m *Model) notifyWebhooks(event string, noteID int64, category string, children db.NoteChildren) {
+	// The tutorial's demo events stay out of the analyst's integrations
+	if m.webhooks == nil || m.tutorial.Active {
+		return
+	}
+	payload := webhook.NewPayload(event, noteID, category, children)
+	m.webhooks.Notify(payload)
+	if payload.Starred && event != webhook.NoteStarred {
+		payload.Event = webhook.NoteStarred
+		m.webhooks.Notify(payload)
+	}
+}
+
+// refreshWebhookErrors surfaces failed deliveries in the footer.
+// Returns true when a new error was shown.
+func (m *Model) refreshWebhookErrors() bool {
+	if m.webhooks == nil {
+		return false
+	}
+	errs := m.webhooks.TakeErrors()
+	if len(errs) == 0 {
+		return false
+	}
+	for _, err := range errs {
+		m.notify(components.LevelWarning, "Webhook failed: "+err.Error())
+	}
+	m.statusMsg = "Webhook failed: " + errs[len(errs)-1].Error()
+	return true
+}
@@ -0,0 +1,98 @@
+package upload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// dropboxChunkSize is the upload session chunk size; single requests are limited to 150 MB.
+const dropboxChunkSize = 64 << 20
+
+// dropbox uploads files into a Dropbox folder using an upload session.
+type dropbox struct {
+	folder string
+}
+
+func (d dropbox) Upload(ctx context.Context, client *http.Client, filePath, title string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	folder := "/" + strings.Trim(d.folder, "/")
+	dest := path.Join(folder, title+filepath.Ext(filePath))
+
+	// Start the session with the first chunk, append the rest, then commit
+	var start struct {
+		SessionID string `json:"session_id"`
+	}
+	first := io.LimitReader(f, dropboxChunkSize)
+	firstLen := min(info.Size(), dropboxChunkSize)
+	if err := dropboxCall(ctx, client, "upload_session/start", map[string]interface{}{"close": false}, first, firstLen, &start); err != nil {
+		return "", fmt.Errorf("dropbox upload: %w", err)
+	}
+
+	offset := firstLen
+	for info.Size()-offset > 0 {
+		n := min(info.Size()-offset, dropboxChunkSize)
+		arg := map[string]interface{}{
+			"cursor": map[string]interface{}{"session_id": start.SessionID, "offset": offset},
+			"close":  false,
+		}
+		if err := dropboxCall(ctx, client, "upload_session/append_v2", arg, io.LimitReader(f, n), n, nil); err != nil {
+			return "", fmt.Errorf("dropbox upload: %w", err)
+		}
+		offset += n
+	}
+
+	var meta struct {
+		PathDisplay string `json:"path_display"`
+	}
+	arg := map[string]interface{}{
+		"cursor": map[string]interface{}{"session_id": start.SessionID, "offset": offset},
+		"commit": map[string]interface{}{"path": dest, "mode": "add", "autorename": true},
+	}
+	if err := dropboxCall(ctx, client, "upload_session/finish", arg, strings.NewReader(""), 0, &meta); err != nil {
+		return "", fmt.Errorf("dropbox upload: %w", err)
+	}
+	return "dropbox:" + meta.PathDisplay, nil
+}
+
+// dropboxCall calls a Dropbox content endpoint with arg in the Dropbox-API-Arg header.
+func dropboxCall(ctx context.Context, client *http.Client, endpoint string, arg interface{}, body io.Reader, length int64, result interface{}) error {
+	argJSON, err := json.Marshal(arg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://content.dropboxapi.com/2/files/"+endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = length
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Dropbox-API-Arg", string(argJSON))
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return apiError(endpoint, resp)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
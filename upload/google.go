@@ -0,0 +1,109 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// youTube uploads videos as unlisted YouTube videos.
+type youTube struct{}
+
+func (youTube) Upload(ctx context.Context, client *http.Client, path, title string) (string, error) {
+	meta := map[string]interface{}{
+		"snippet": map[string]interface{}{
+			"title":       title,
+			"description": "Uploaded by tagging-rugby-cli",
+		},
+		"status": map[string]interface{}{
+			"privacyStatus": "unlisted",
+		},
+	}
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := resumableUpload(ctx, client, "https://www.googleapis.com/upload/youtube/v3/videos?uploadType=resumable&part=snippet,status", meta, path, &result); err != nil {
+		return "", fmt.Errorf("youtube upload: %w", err)
+	}
+	return "https://youtu.be/" + result.ID, nil
+}
+
+// drive uploads files to Google Drive, optionally into a folder.
+type drive struct {
+	folderID string
+}
+
+func (d drive) Upload(ctx context.Context, client *http.Client, path, title string) (string, error) {
+	meta := map[string]interface{}{
+		"name": title + filepath.Ext(path),
+	}
+	if d.folderID != "" {
+		meta["parents"] = []string{d.folderID}
+	}
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := resumableUpload(ctx, client, "https://www.googleapis.com/upload/drive/v3/files?uploadType=resumable", meta, path, &result); err != nil {
+		return "", fmt.Errorf("drive upload: %w", err)
+	}
+	return "https://drive.google.com/file/d/" + result.ID + "/view", nil
+}
+
+// resumableUpload runs Google's resumable upload protocol: a metadata request returns an
+// upload session URL, then the file is sent in a single PUT. The final JSON response is
+// decoded into result.
+func resumableUpload(ctx context.Context, client *http.Client, initURL string, meta interface{}, path string, result interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, initURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Upload-Content-Length", fmt.Sprintf("%d", info.Size()))
+	req.Header.Set("X-Upload-Content-Type", "video/*")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return apiError("start upload session", resp)
+	}
+	sessionURL := resp.Header.Get("Location")
+	if sessionURL == "" {
+		return fmt.Errorf("start upload session: no session URL returned")
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPut, sessionURL, f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "video/*")
+	resp, err = client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return apiError("upload", resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
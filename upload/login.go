@@ -0,0 +1,142 @@
+package upload
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/config"
+	"golang.org/x/oauth2"
+)
+
+// Login runs the OAuth flow for a target and stores the resulting token.
+// If app has a client ID it is saved to config.json first, so later logins and
+// uploads only need the target name.
+func Login(ctx context.Context, name string, app config.UploadTarget, in io.Reader, out io.Writer) error {
+	svc, ok := services[name]
+	if !ok {
+		return fmt.Errorf("unknown upload target '%s': must be one of %s", name, strings.Join(Names(), ", "))
+	}
+
+	if app.ClientID != "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		if cfg.Uploads == nil {
+			cfg.Uploads = make(map[string]config.UploadTarget)
+		}
+		if app.Folder == "" {
+			app.Folder = cfg.Uploads[name].Folder
+		}
+		cfg.Uploads[name] = app
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+	}
+	svc, app, err := lookup(name)
+	if err != nil {
+		return err
+	}
+
+	var tok *oauth2.Token
+	if svc.loopback {
+		tok, err = loopbackLogin(ctx, svc, app, out)
+	} else {
+		tok, err = pasteLogin(ctx, svc, app, in, out)
+	}
+	if err != nil {
+		return err
+	}
+	return saveToken(name, tok)
+}
+
+// loopbackLogin receives the authorization code on a temporary local HTTP listener.
+func loopbackLogin(ctx context.Context, svc service, app config.UploadTarget, out io.Writer) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("start callback listener: %w", err)
+	}
+	defer listener.Close()
+
+	redirectURL := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+	conf := oauthConfig(svc, app, redirectURL)
+	state := randomState()
+	verifier := oauth2.GenerateVerifier()
+
+	opts := append([]oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)}, svc.authParams...)
+	fmt.Fprintf(out, "Open this URL in your browser to authorize:\n\n  %s\n\nWaiting for authorization...\n", conf.AuthCodeURL(state, opts...))
+
+	codes := make(chan string, 1)
+	errs := make(chan error, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/callback" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		switch {
+		case q.Get("state") != state:
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			errs <- errors.New("authorization failed: state mismatch")
+		case q.Get("error") != "":
+			fmt.Fprintln(w, "Authorization denied. You can close this window.")
+			errs <- fmt.Errorf("authorization denied: %s", q.Get("error"))
+		default:
+			fmt.Fprintln(w, "Authorized. You can close this window and return to the terminal.")
+			codes <- q.Get("code")
+		}
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	select {
+	case code := <-codes:
+		tok, err := conf.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+		if err != nil {
+			return nil, fmt.Errorf("exchange code: %w", err)
+		}
+		return tok, nil
+	case err := <-errs:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// pasteLogin asks the user to paste the authorization code shown by the service.
+func pasteLogin(ctx context.Context, svc service, app config.UploadTarget, in io.Reader, out io.Writer) (*oauth2.Token, error) {
+	conf := oauthConfig(svc, app, "")
+	verifier := oauth2.GenerateVerifier()
+
+	opts := append([]oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)}, svc.authParams...)
+	fmt.Fprintf(out, "Open this URL in your browser to authorize:\n\n  %s\n\nPaste the authorization code: ", conf.AuthCodeURL("", opts...))
+
+	code, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && code == "" {
+		return nil, fmt.Errorf("read code: %w", err)
+	}
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, errors.New("no authorization code entered")
+	}
+	tok, err := conf.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange code: %w", err)
+	}
+	return tok, nil
+}
+
+// randomState returns an unguessable OAuth state value.
+func randomState() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,215 @@
+// Package upload sends exported clips to cloud services (YouTube, Google Drive,
+// Dropbox). Each service uses the user's own OAuth app; tokens are stored in
+// ~/.config/tagging-rugby/tokens.
+package upload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/user/tagging-rugby-cli/config"
+	"golang.org/x/oauth2"
+)
+
+// Target is an upload destination.
+type Target interface {
+	// Upload sends the file at path and returns a link (or path) to the uploaded file.
+	Upload(ctx context.Context, client *http.Client, path, title string) (string, error)
+}
+
+// service describes an upload service: its OAuth endpoints and how to log in.
+type service struct {
+	endpoint oauth2.Endpoint
+	scopes   []string
+	// loopback services redirect to a local listener; others ask the user to paste the code
+	loopback bool
+	// authParams are extra authorization URL parameters
+	authParams []oauth2.AuthCodeOption
+	newTarget  func(app config.UploadTarget) Target
+}
+
+var googleEndpoint = oauth2.Endpoint{
+	AuthURL:  "https://accounts.google.com/o/oauth2/auth",
+	TokenURL: "https://oauth2.googleapis.com/token",
+}
+
+var services = map[string]service{
+	"youtube": {
+		endpoint:   googleEndpoint,
+		scopes:     []string{"https://www.googleapis.com/auth/youtube.upload"},
+		loopback:   true,
+		authParams: []oauth2.AuthCodeOption{oauth2.AccessTypeOffline, oauth2.SetAuthURLParam("prompt", "consent")},
+		newTarget:  func(app config.UploadTarget) Target { return youTube{} },
+	},
+	"drive": {
+		endpoint:   googleEndpoint,
+		scopes:     []string{"https://www.googleapis.com/auth/drive.file"},
+		loopback:   true,
+		authParams: []oauth2.AuthCodeOption{oauth2.AccessTypeOffline, oauth2.SetAuthURLParam("prompt", "consent")},
+		newTarget:  func(app config.UploadTarget) Target { return drive{folderID: app.Folder} },
+	},
+	"dropbox": {
+		endpoint: oauth2.Endpoint{
+			AuthURL:  "https://www.dropbox.com/oauth2/authorize",
+			TokenURL: "https://api.dropboxapi.com/oauth2/token",
+		},
+		authParams: []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("token_access_type", "offline")},
+		newTarget:  func(app config.UploadTarget) Target { return dropbox{folder: app.Folder} },
+	},
+}
+
+// Names returns the supported target names, sorted.
+func Names() []string {
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup returns the service and its configured app credentials.
+func lookup(name string) (service, config.UploadTarget, error) {
+	svc, ok := services[name]
+	if !ok {
+		return service{}, config.UploadTarget{}, fmt.Errorf("unknown upload target '%s': must be one of %s", name, strings.Join(Names(), ", "))
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return service{}, config.UploadTarget{}, err
+	}
+	app, ok := cfg.Uploads[name]
+	if !ok || app.ClientID == "" {
+		return service{}, config.UploadTarget{}, fmt.Errorf("%s is not configured: run 'upload login %s --client-id ... --client-secret ...'", name, name)
+	}
+	return svc, app, nil
+}
+
+// oauthConfig builds the OAuth config for a service.
+func oauthConfig(svc service, app config.UploadTarget, redirectURL string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     app.ClientID,
+		ClientSecret: app.ClientSecret,
+		Endpoint:     svc.endpoint,
+		Scopes:       svc.scopes,
+		RedirectURL:  redirectURL,
+	}
+}
+
+// tokenPath returns the token file for a target.
+func tokenPath(name string) (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tokens", name+".json"), nil
+}
+
+// loadToken reads the stored token for a target.
+func loadToken(name string) (*oauth2.Token, error) {
+	path, err := tokenPath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("not logged in to %s: run 'upload login %s'", name, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read token: %w", err)
+	}
+	var tok oauth2.Token
+	if err := json.Unmarshal(data, &tok); err != nil {
+		return nil, fmt.Errorf("parse token: %w", err)
+	}
+	return &tok, nil
+}
+
+// saveToken stores a token with owner-only permissions.
+func saveToken(name string, tok *oauth2.Token) error {
+	path, err := tokenPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create token dir: %w", err)
+	}
+	data, err := json.Marshal(tok)
+	if err != nil {
+		return fmt.Errorf("encode token: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("write token: %w", err)
+	}
+	return nil
+}
+
+// Logout deletes the stored token for a target.
+func Logout(name string) error {
+	if _, ok := services[name]; !ok {
+		return fmt.Errorf("unknown upload target '%s': must be one of %s", name, strings.Join(Names(), ", "))
+	}
+	path, err := tokenPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove token: %w", err)
+	}
+	return nil
+}
+
+// persistingSource saves refreshed tokens so the refresh token keeps working across runs.
+type persistingSource struct {
+	name string
+	src  oauth2.TokenSource
+	last string
+}
+
+func (p *persistingSource) Token() (*oauth2.Token, error) {
+	tok, err := p.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok.AccessToken != p.last {
+		p.last = tok.AccessToken
+		_ = saveToken(p.name, tok)
+	}
+	return tok, nil
+}
+
+// File uploads path to the named target and returns a link to the uploaded file.
+func File(ctx context.Context, name, path, title string) (string, error) {
+	svc, app, err := lookup(name)
+	if err != nil {
+		return "", err
+	}
+	tok, err := loadToken(name)
+	if err != nil {
+		return "", err
+	}
+	src := &persistingSource{
+		name: name,
+		src:  oauthConfig(svc, app, "").TokenSource(ctx, tok),
+		last: tok.AccessToken,
+	}
+	client := oauth2.NewClient(ctx, src)
+
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return svc.newTarget(app).Upload(ctx, client, path, title)
+}
+
+// apiError turns a non-2xx response into an error including the response body.
+func apiError(what string, resp *http.Response) error {
+	var body [512]byte
+	n, _ := resp.Body.Read(body[:])
+	return fmt.Errorf("%s: %s: %s", what, resp.Status, strings.TrimSpace(string(body[:n])))
+}
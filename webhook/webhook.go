@@ -0,0 +1,182 @@
+// Package webhook POSTs JSON payloads to user-configured URLs when notes are
+// created or starred, e.g. into Slack or Discord channels.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/user/tagging-rugby-cli/config"
+	"github.com/user/tagging-rugby-cli/db"
+	"github.com/user/tagging-rugby-cli/pkg/timeutil"
+	"github.com/user/tagging-rugby-cli/pkg/videosrc"
+)
+
+// Event names.
+const (
+	NoteCreated   = "note.created"
+	TackleCreated = "tackle.created"
+	NoteStarred   = "note.starred"
+)
+
+// Events lists every event name, for validation and help text.
+var Events = []string{NoteCreated, TackleCreated, NoteStarred}
+
+// deliveryTimeout bounds each POST.
+const deliveryTimeout = 10 * time.Second
+
+// Payload is the JSON body sent to each webhook.
+type Payload struct {
+	Event     string  `json:"event"`
+	NoteID    int64   `json:"note_id"`
+	Category  string  `json:"category"`
+	Video     string  `json:"video"`
+	Timestamp float64 `json:"timestamp"`
+	Time      string  `json:"time"`
+	Player    string  `json:"player,omitempty"`
+	Team      string  `json:"team,omitempty"`
+	Outcome   string  `json:"outcome,omitempty"`
+	Note      string  `json:"note,omitempty"`
+	Starred   bool    `json:"starred"`
+	// Text (Slack) and Content (Discord) carry a one-line summary so incoming
+	// webhooks of both services render the event without a custom integration
+	Text    string `json:"text"`
+	Content string `json:"content"`
+}
+
+// NewPayload builds the payload for a note from the children it was saved with.
+func NewPayload(event string, noteID int64, category string, children db.NoteChildren) Payload {
+	p := Payload{Event: event, NoteID: noteID, Category: category}
+	if len(children.Timings) > 0 {
+		p.Timestamp = children.Timings[0].Start
+	}
+	p.Time = timeutil.FormatTime(p.Timestamp)
+	if len(children.Videos) > 0 {
+		p.Video = children.Videos[0].Path
+	}
+	if len(children.Tackles) > 0 {
+		p.Player = children.Tackles[0].Player
+		p.Outcome = children.Tackles[0].Outcome
+	}
+	for _, d := range children.Details {
+		switch d.Type {
+		case "text", "notes":
+			p.Note = d.Note
+		case "player":
+			p.Player = d.Note
+		case "team":
+			p.Team = d.Note
+		}
+	}
+	for _, h := range children.Highlights {
+		if h.Type == "star" {
+			p.Starred = true
+		}
+	}
+	p.Text = p.summary()
+	p.Content = p.Text
+	return p
+}
+
+// summary renders a one-line description such as "★ tackle #12 @ 0:12:34 — Smith completed (match.mp4)".
+func (p Payload) summary() string {
+	var b strings.Builder
+	if p.Starred {
+		b.WriteString("★ ")
+	}
+	fmt.Fprintf(&b, "%s #%d @ %s", p.Category, p.NoteID, p.Time)
+	var parts []string
+	for _, s := range []string{p.Player, p.Team, p.Outcome, p.Note} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) > 0 {
+		b.WriteString(" — " + strings.Join(parts, " "))
+	}
+	if p.Video != "" {
+		name := p.Video
+		if !videosrc.IsURL(name) {
+			name = filepath.Base(name)
+		}
+		fmt.Fprintf(&b, " (%s)", name)
+	}
+	return b.String()
+}
+
+// Notifier delivers payloads in the background and collects failures.
+type Notifier struct {
+	hooks  []config.Webhook
+	client *http.Client
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	errs   []error
+}
+
+// New returns a notifier for the given webhooks.
+func New(hooks []config.Webhook) *Notifier {
+	return &Notifier{
+		hooks:  hooks,
+		client: &http.Client{Timeout: deliveryTimeout},
+	}
+}
+
+// Notify sends p to every webhook subscribed to its event without blocking.
+func (n *Notifier) Notify(p Payload) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		n.addError(fmt.Errorf("encode webhook payload: %w", err))
+		return
+	}
+	for _, hook := range n.hooks {
+		if !hook.Wants(p.Event) {
+			continue
+		}
+		n.wg.Add(1)
+		go func(url string) {
+			defer n.wg.Done()
+			if err := n.post(url, body); err != nil {
+				n.addError(err)
+			}
+		}(hook.URL)
+	}
+}
+
+// Wait blocks until all pending deliveries have finished.
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+// TakeErrors returns and clears the delivery failures collected so far.
+func (n *Notifier) TakeErrors() []error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	errs := n.errs
+	n.errs = nil
+	return errs
+}
+
+func (n *Notifier) addError(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.errs = append(n.errs, err)
+}
+
+// post sends body to url and treats any non-2xx status as a failure.
+func (n *Notifier) post(url string, body []byte) error {
+	resp, err := n.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s: %s", url, resp.Status)
+	}
+	return nil
+}